@@ -136,23 +136,26 @@ func dumpState(ctx *cli.Context) error {
 		return err
 	}
 	lastConsolidatedBatchNum := lastConsolidatedBatch.BatchNumber
-	for i := uint64(0); i <= lastBatchNum; i++ {
-		b, err := stateDB.GetBatchByNumber(dbCtx, i, nil)
-		if err != nil {
-			return err
+	const batchesPerQuery = 1000
+	for first := uint64(0); first <= lastBatchNum; first += batchesPerQuery {
+		batchNumbers := make([]uint64, 0, batchesPerQuery)
+		for i := first; i <= lastBatchNum && i < first+batchesPerQuery; i++ {
+			batchNumbers = append(batchNumbers, i)
 		}
-		isClosed, err := stateDB.IsBatchClosed(dbCtx, i, nil)
+		batches, err := stateDB.GetBatchesByNumbers(dbCtx, batchNumbers, nil)
 		if err != nil {
 			return err
 		}
-		if !isClosed {
-			continue
+		for _, b := range batches {
+			if b.WIP {
+				continue
+			}
+			dump.Batches = append(dump.Batches, batchMeta{
+				Virtualized:  b.BatchNumber <= lastVirtualBatchNum,
+				Consolidated: b.BatchNumber <= lastConsolidatedBatchNum,
+				Batch:        batch(*b),
+			})
 		}
-		dump.Batches = append(dump.Batches, batchMeta{
-			Virtualized:  i <= lastVirtualBatchNum,
-			Consolidated: i <= lastConsolidatedBatchNum,
-			Batch:        batch(*b),
-		})
 	}
 
 	// Dump JSON
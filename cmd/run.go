@@ -18,6 +18,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/awskms"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
@@ -25,6 +26,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/gasprice"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
@@ -37,6 +39,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/0xPolygonHermez/zkevm-node/synchronizer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
@@ -85,6 +88,15 @@ func start(cliCtx *cli.Context) error {
 			needsExecutor = true
 			needsStateTree = true
 		}
+		if component == RPC {
+			if err := c.RPC.Validate(); err != nil {
+				log.Fatal(err)
+			}
+			// the RPC advertises the data stream URI built from the stream server config
+			if err := c.Sequencer.StreamServer.Validate(); err != nil {
+				log.Fatal(err)
+			}
+		}
 	}
 
 	if c.EventLog.DB.Name != "" {
@@ -146,7 +158,12 @@ func start(cliCtx *cli.Context) error {
 		EventID:    event.EventID_NodeComponentStarted,
 	}
 
-	var poolInstance *pool.Pool
+	var (
+		poolInstance *pool.Pool
+		seq          *sequencer.Sequencer
+		runRPC       bool
+		apis         = map[string]bool{}
+	)
 
 	if c.Metrics.ProfilingEnabled {
 		go startProfilingHttpServer(c.Metrics)
@@ -176,7 +193,7 @@ func start(cliCtx *cli.Context) error {
 			if poolInstance == nil {
 				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
-			seq := createSequencer(*c, poolInstance, st, eventLog)
+			seq = createSequencer(*c, poolInstance, st, eventLog)
 			go seq.Start(cliCtx.Context)
 		case SEQUENCE_SENDER:
 			ev.Component = event.Component_Sequence_Sender
@@ -205,11 +222,10 @@ func start(cliCtx *cli.Context) error {
 				poolInstance.StartPollingMinSuggestedGasPrice(cliCtx.Context)
 			}
 			poolInstance.StartRefreshingBlockedAddressesPeriodically()
-			apis := map[string]bool{}
 			for _, a := range cliCtx.StringSlice(config.FlagHTTPAPI) {
 				apis[a] = true
 			}
-			go runJSONRPCServer(*c, etherman, l2ChainID, poolInstance, st, apis)
+			runRPC = true
 		case SYNCHRONIZER:
 			ev.Component = event.Component_Synchronizer
 			ev.Description = "Running synchronizer"
@@ -244,8 +260,14 @@ func start(cliCtx *cli.Context) error {
 		}
 	}
 
+	// The JSON-RPC server is started after all the components to be able to link the admin endpoints
+	// with the sequencer when both are running in the same instance
+	if runRPC {
+		go runJSONRPCServer(*c, etherman, l2ChainID, poolInstance, st, seq, apis)
+	}
+
 	if c.Metrics.Enabled {
-		go startMetricsHttpServer(c.Metrics)
+		go startMetricsHttpServer(c.Metrics, seq)
 	}
 
 	waitSignal(cancelFuncs)
@@ -325,11 +347,17 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 	}
 }
 
-func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
+func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, seq *sequencer.Sequencer, apis map[string]bool) {
 	var err error
 	storage := jsonrpc.NewStorage()
+	if c.RPC.FilterTTL.Duration > 0 {
+		go storage.StartToExpireFilters(c.RPC.FilterTTL.Duration)
+	}
 	c.RPC.MaxCumulativeGasUsed = c.State.Batch.Constraints.MaxCumulativeGasUsed
 	c.RPC.L2Coinbase = c.SequenceSender.L2Coinbase
+	if c.Sequencer.StreamServer.Enabled {
+		c.RPC.BroadcastURI = "tcp://" + net.JoinHostPort(c.Sequencer.StreamServer.Host, fmt.Sprint(c.Sequencer.StreamServer.Port))
+	}
 	if !c.IsTrustedSequencer {
 		if c.RPC.SequencerNodeURI == "" {
 			log.Debug("getting trusted sequencer URL from smc")
@@ -341,11 +369,16 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		log.Debug("SequencerNodeURI ", c.RPC.SequencerNodeURI)
 	}
 
+	var sequencerI jsonrpcTypes.SequencerInterface
+	if seq != nil {
+		sequencerI = seq
+	}
+
 	services := []jsonrpc.Service{}
 	if _, ok := apis[jsonrpc.APIEth]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIEth,
-			Service: jsonrpc.NewEthEndpoints(c.RPC, chainID, pool, st, etherman, storage),
+			Service: jsonrpc.NewEthEndpoints(c.RPC, chainID, pool, st, etherman, sequencerI, storage),
 		})
 	}
 
@@ -359,14 +392,14 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APIZKEVM]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIZKEVM,
-			Service: jsonrpc.NewZKEVMEndpoints(c.RPC, st, etherman),
+			Service: jsonrpc.NewZKEVMEndpoints(c.RPC, pool, st, etherman, c.L2GasPriceSuggester.Type.Source()),
 		})
 	}
 
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(sequencerI),
 		})
 	}
 
@@ -377,6 +410,13 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APITrace]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APITrace,
+			Service: jsonrpc.NewTraceEndpoints(c.RPC, st, etherman),
+		})
+	}
+
 	if _, ok := apis[jsonrpc.APIWeb3]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIWeb3,
@@ -384,6 +424,13 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APIAdmin]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIAdmin,
+			Service: jsonrpc.NewAdminEndpoints(c.RPC, st, sequencerI),
+		})
+	}
+
 	if err := jsonrpc.NewServer(c.RPC, chainID, pool, st, storage, services).Start(); err != nil {
 		log.Fatal(err)
 	}
@@ -408,7 +455,7 @@ func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxm
 		log.Fatal(err)
 	}
 
-	auth, err := etherman.LoadAuthFromKeyStore(cfg.SequenceSender.PrivateKey.Path, cfg.SequenceSender.PrivateKey.Password)
+	auth, err := loadSequenceSenderAuth(cfg.SequenceSender, etherman)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -462,6 +509,9 @@ func waitSignal(cancelFuncs []context.CancelFunc) {
 }
 
 func newState(ctx context.Context, c *config.Config, l2ChainID uint64, forkIDIntervals []state.ForkIDInterval, sqlDB *pgxpool.Pool, eventLog *event.EventLog, needsExecutor, needsStateTree bool) *state.State {
+	if !state.IsValidBatchDataCompression(c.State.BatchDataCompression) {
+		log.Fatalf("unsupported State.BatchDataCompression %s", c.State.BatchDataCompression)
+	}
 	stateDb := pgstatestorage.NewPostgresStorage(c.State, sqlDB)
 
 	// Executor
@@ -488,6 +538,7 @@ func newState(ctx context.Context, c *config.Config, l2ChainID uint64, forkIDInt
 		MaxLogsCount:                 c.RPC.MaxLogsCount,
 		MaxLogsBlockRange:            c.RPC.MaxLogsBlockRange,
 		MaxNativeBlockHashBlockRange: c.RPC.MaxNativeBlockHashBlockRange,
+		GasEstimationTolerance:       c.RPC.GasEstimationTolerance,
 	}
 	allLeaves, err := stateDb.GetAllL1InfoRootEntries(ctx, nil)
 	if err != nil {
@@ -503,6 +554,14 @@ func newState(ctx context.Context, c *config.Config, l2ChainID uint64, forkIDInt
 	}
 
 	st := state.NewState(stateCfg, stateDb, executorClient, stateTree, eventLog, mt)
+	if c.State.AuditLog {
+		auditLog, err := state.NewAuditLog(c.State.AuditLogFile, []byte(c.State.AuditLogHMACKey))
+		if err != nil {
+			log.Fatalf("failed to open the state audit log: %v", err)
+		}
+		st.SetAuditLog(auditLog)
+	}
+	go st.StartToRefreshBatchNumCache(ctx)
 	return st
 }
 
@@ -516,6 +575,19 @@ func createPool(cfgPool pool.Config, constraintsCfg state.BatchConstraintsCfg, l
 	return poolInstance
 }
 
+// loadSequenceSenderAuth loads the authorization used to sign the sequences, from
+// the AWS KMS key when HSMKeyARN is set and from the key store file otherwise
+func loadSequenceSenderAuth(cfg sequencesender.Config, ethMan *etherman.Client) (*bind.TransactOpts, error) {
+	if cfg.HSMKeyARN != "" {
+		hsm, err := awskms.NewClient(context.Background(), cfg.HSMKeyARN)
+		if err != nil {
+			return nil, err
+		}
+		return ethMan.LoadAuthFromHSM(context.Background(), hsm)
+	}
+	return ethMan.LoadAuthFromKeyStore(cfg.PrivateKey.Path, cfg.PrivateKey.Password)
+}
+
 func createEthTxManager(cfg config.Config, etmStorage *ethtxmanager.PostgresStorage, st *state.State) *ethtxmanager.Client {
 	etherman, err := newEtherman(cfg)
 	if err != nil {
@@ -528,6 +600,13 @@ func createEthTxManager(cfg config.Config, etmStorage *ethtxmanager.PostgresStor
 			log.Fatal(err)
 		}
 	}
+	// The sequences signed by the HSM are sent by the eth tx manager too
+	if cfg.SequenceSender.HSMKeyARN != "" {
+		_, err := loadSequenceSenderAuth(cfg.SequenceSender, etherman)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 	etm := ethtxmanager.New(cfg.EthTxManager, etherman, etmStorage, st)
 	return etm
 }
@@ -562,7 +641,7 @@ func startProfilingHttpServer(c metrics.Config) {
 	}
 }
 
-func startMetricsHttpServer(c metrics.Config) {
+func startMetricsHttpServer(c metrics.Config, seq *sequencer.Sequencer) {
 	const ten = 10
 	mux := http.NewServeMux()
 	address := fmt.Sprintf("%s:%d", c.Host, c.Port)
@@ -572,6 +651,8 @@ func startMetricsHttpServer(c metrics.Config) {
 		return
 	}
 	mux.Handle(metrics.Endpoint, promhttp.Handler())
+	mux.HandleFunc(metrics.ReadinessEndpoint, readinessHandler(seq))
+	mux.HandleFunc(metrics.LivenessEndpoint, livenessHandler(seq))
 
 	metricsServer := &http.Server{
 		Handler:           mux,
@@ -589,6 +670,37 @@ func startMetricsHttpServer(c metrics.Config) {
 	}
 }
 
+// readinessHandler responds 503 while the sequencer running in the instance, if any, doesn't
+// have its finalizer running, including when the finalizer is halted
+func readinessHandler(seq *sequencer.Sequencer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if seq != nil && !seq.Running() {
+			http.Error(w, "the sequencer finalizer is not running", http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte("ok"))
+		if err != nil {
+			log.Errorf("failed to write the readiness response: %v", err)
+		}
+	}
+}
+
+// livenessHandler responds 503 when the sequencer running in the instance, if any, has its
+// finalizer halted due to a fatal error or its main loop stopped sending heartbeats for more than
+// Sequencer.Finalizer.LivenessTimeout, so the instance can be restarted
+func livenessHandler(seq *sequencer.Sequencer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if seq != nil && !seq.Alive() {
+			http.Error(w, "the sequencer finalizer is halted or stuck", http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte("ok"))
+		if err != nil {
+			log.Errorf("failed to write the liveness response: %v", err)
+		}
+	}
+}
+
 func logVersion() {
 	log.Infow("Starting application",
 		// node version is already logged by default
@@ -177,6 +177,11 @@ func Load(ctx *cli.Context, loadNetworkConfig bool) (*Config, error) {
 		return nil, err
 	}
 
+	err = cfg.L2GasPriceSuggester.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	if loadNetworkConfig {
 		// Load genesis parameters
 		cfg.loadNetworkConfig(ctx)
@@ -72,6 +72,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.MaxTxLifetime",
 			expectedValue: types.NewDuration(3 * time.Hour),
 		},
+		{
+			path:          "Sequencer.MaxTxsPerAddress",
+			expectedValue: uint(0),
+		},
+		{
+			path:          "Sequencer.DynamicConfigFile",
+			expectedValue: "",
+		},
 		{
 			path:          "Sequencer.Finalizer.GERDeadlineTimeout",
 			expectedValue: types.NewDuration(5 * time.Second),
@@ -80,10 +88,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.ForcedBatchDeadlineTimeout",
 			expectedValue: types.NewDuration(60 * time.Second),
 		},
+		{
+			path:          "Sequencer.Finalizer.MinForcedBatchProcessingInterval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
 		{
 			path:          "Sequencer.Finalizer.SleepDuration",
 			expectedValue: types.NewDuration(100 * time.Millisecond),
 		},
+		{
+			path:          "Sequencer.Finalizer.LivenessTimeout",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
 		{
 			path:          "Sequencer.Finalizer.ResourcePercentageToCloseBatch",
 			expectedValue: uint32(10),
@@ -96,6 +112,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.ForcedBatchesFinalityNumberOfBlocks",
 			expectedValue: uint64(64),
 		},
+		{
+			path:          "Sequencer.Finalizer.ForcedBatchConfirmations",
+			expectedValue: uint64(12),
+		},
 		{
 			path:          "Sequencer.Finalizer.L1InfoRootFinalityNumberOfBlocks",
 			expectedValue: uint64(64),
@@ -136,6 +156,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.DBManager.L2ReorgRetrievalInterval",
 			expectedValue: types.NewDuration(5 * time.Second),
 		},
+		{
+			path:          "Sequencer.StreamServer.Host",
+			expectedValue: "",
+		},
 		{
 			path:          "Sequencer.StreamServer.Port",
 			expectedValue: uint16(0),
@@ -160,6 +184,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "SequenceSender.MaxTxSizeForL1",
 			expectedValue: uint64(131072),
 		},
+		{
+			path:          "SequenceSender.HSMKeyARN",
+			expectedValue: "",
+		},
 		{
 			path:          "SequenceSender.GasOffset",
 			expectedValue: uint64(80000),
@@ -188,6 +216,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Etherman.MultiGasProvider",
 			expectedValue: false,
 		},
+		{
+			path:          "SequenceSender.MinTxCountForL1Submission",
+			expectedValue: int(1),
+		},
+		{
+			path:          "SequenceSender.MaxWaitForL1Submission",
+			expectedValue: types.NewDuration(10 * time.Minute),
+		},
+		{
+			path:          "SequenceSender.MaxL1GasPriceWei",
+			expectedValue: uint64(0),
+		},
 		{
 			path:          "EthTxManager.FrequencyToMonitorTxs",
 			expectedValue: types.NewDuration(1 * time.Second),
@@ -212,6 +252,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "EthTxManager.MaxGasPriceLimit",
 			expectedValue: uint64(0),
 		},
+		{
+			path:          "EthTxManager.L1TxTimeout",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
+		{
+			path:          "EthTxManager.L1GasBumpPercent",
+			expectedValue: uint64(10),
+		},
 		{
 			path:          "L2GasPriceSuggester.DefaultGasPriceWei",
 			expectedValue: uint64(2000000000),
@@ -220,10 +268,30 @@ func Test_Defaults(t *testing.T) {
 			path:          "L2GasPriceSuggester.MaxGasPriceWei",
 			expectedValue: uint64(0),
 		},
+		{
+			path:          "L2GasPriceSuggester.GasPriceDropRateLimit",
+			expectedValue: float64(0),
+		},
 		{
 			path:          "MTClient.URI",
 			expectedValue: "zkevm-prover:50061",
 		},
+		{
+			path:          "State.BatchDataCompression",
+			expectedValue: "none",
+		},
+		{
+			path:          "State.AuditLog",
+			expectedValue: false,
+		},
+		{
+			path:          "State.AuditLogFile",
+			expectedValue: "",
+		},
+		{
+			path:          "State.AuditLogHMACKey",
+			expectedValue: "",
+		},
 		{
 			path:          "State.DB.User",
 			expectedValue: "state_user",
@@ -351,11 +419,15 @@ func Test_Defaults(t *testing.T) {
 		},
 		{
 			path:          "RPC.ReadTimeout",
-			expectedValue: types.NewDuration(60 * time.Second),
+			expectedValue: types.NewDuration(5 * time.Second),
 		},
 		{
 			path:          "RPC.WriteTimeout",
-			expectedValue: types.NewDuration(60 * time.Second),
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "RPC.IdleTimeout",
+			expectedValue: types.NewDuration(120 * time.Second),
 		},
 		{
 			path:          "RPC.SequencerNodeURI",
@@ -365,6 +437,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxRequestsPerIPAndSecond",
 			expectedValue: float64(500),
 		},
+		{
+			path:          "RPC.MaxConnections",
+			expectedValue: 0,
+		},
 		{
 			path:          "RPC.EnableL2SuggestedGasPricePolling",
 			expectedValue: true,
@@ -389,6 +465,66 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
 		},
+		{
+			path:          "RPC.MaxCallGas",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "RPC.MaxCallGasExecution",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "RPC.GasEstimationTolerance",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.ConsolidatedBlockNumberCacheTTL",
+			expectedValue: types.NewDuration(1 * time.Second),
+		},
+		{
+			path:          "RPC.L2BridgeAddress",
+			expectedValue: common.Address{},
+		},
+		{
+			path:          "RPC.BlockCacheSize",
+			expectedValue: 128,
+		},
+		{
+			path:          "RPC.AdminAllowedIPs",
+			expectedValue: []string{},
+		},
+		{
+			path:          "RPC.EnableAdminForceBatchProcessing",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.EnableAdminPruneBatches",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.EnableAdminPauseForcedBatchProcessing",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.EnableAdminSetDynamicConfig",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.PruneBatchesChunkSize",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.ProtocolVersion",
+			expectedValue: "0x41",
+		},
+		{
+			path:          "RPC.IdempotencyKeyTTL",
+			expectedValue: types.NewDuration(1 * time.Minute),
+		},
+		{
+			path:          "RPC.FilterTTL",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
 		{
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
@@ -425,6 +561,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Executor.MaxGRPCMessageSize",
 			expectedValue: int(100000000),
 		},
+		{
+			path:          "Executor.ExecutorGRPCKeepaliveTime",
+			expectedValue: types.NewDuration(0),
+		},
+		{
+			path:          "Executor.ExecutorGRPCKeepaliveTimeout",
+			expectedValue: types.NewDuration(20 * time.Second),
+		},
+		{
+			path:          "Executor.ExecutorGRPCMaxRetries",
+			expectedValue: int(5),
+		},
 		{
 			path:          "Metrics.Host",
 			expectedValue: "0.0.0.0",
@@ -576,3 +724,23 @@ func TestEnvVarArrayDecoding(t *testing.T) {
 	assert.Equal(t, "b", cfg.Log.Outputs[1])
 	assert.Equal(t, "c", cfg.Log.Outputs[2])
 }
+
+func TestInvalidGasPriceDropRateLimit(t *testing.T) {
+	file, err := os.CreateTemp("", "genesisConfig")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.Remove(file.Name()))
+	}()
+	require.NoError(t, os.WriteFile(file.Name(), []byte("{}"), 0600))
+	flagSet := flag.NewFlagSet("", flag.PanicOnError)
+	flagSet.String(config.FlagNetwork, "testnet", "")
+	ctx := cli.NewContext(cli.NewApp(), flagSet, nil)
+
+	os.Setenv("ZKEVM_NODE_L2GASPRICESUGGESTER_GASPRICEDROPRATELIMIT", "1")
+	defer func() {
+		os.Unsetenv("ZKEVM_NODE_L2GASPRICESUGGESTER_GASPRICEDROPRATELIMIT")
+	}()
+
+	_, err = config.Load(ctx, true)
+	assert.ErrorContains(t, err, "GasPriceDropRateLimit")
+}
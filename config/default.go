@@ -12,6 +12,10 @@ Level = "info"
 Outputs = ["stderr"]
 
 [State]
+BatchDataCompression = "none"
+AuditLog = false
+AuditLogFile = ""
+AuditLogHMACKey = ""
 	[State.DB]
 	User = "state_user"
 	Password = "state_password"
@@ -75,13 +79,17 @@ WaitTxToBeMined = "2m"
 ForcedGas = 0
 GasPriceMarginFactor = 1
 MaxGasPriceLimit = 0
+L1TxTimeout = "5m"
+L1GasBumpPercent = 10
 
 [RPC]
 Host = "0.0.0.0"
 Port = 8545
-ReadTimeout = "60s"
-WriteTimeout = "60s"
+ReadTimeout = "5s"
+WriteTimeout = "30s"
+IdleTimeout = "120s"
 MaxRequestsPerIPAndSecond = 500
+MaxConnections = 0
 SequencerNodeURI = ""
 EnableL2SuggestedGasPricePolling = true
 BatchRequestsEnabled = false
@@ -89,6 +97,21 @@ BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
 MaxNativeBlockHashBlockRange = 60000
+MaxCallGas = 0
+MaxCallGasExecution = 0
+GasEstimationTolerance = 100
+ConsolidatedBlockNumberCacheTTL = "1s"
+L2BridgeAddress = "0x0000000000000000000000000000000000000000"
+BlockCacheSize = 128
+AdminAllowedIPs = []
+EnableAdminForceBatchProcessing = false
+EnableAdminPruneBatches = false
+EnableAdminPauseForcedBatchProcessing = false
+EnableAdminSetDynamicConfig = false
+PruneBatchesChunkSize = 100
+ProtocolVersion = "0x41"
+IdempotencyKeyTTL = "1m"
+FilterTTL = "5m"
 EnableHttpLog = true
 	[RPC.WebSockets]
 		Enabled = true
@@ -121,13 +144,18 @@ BlocksAmountForTxsToBeDeleted = 100
 FrequencyToCheckTxsForDelete = "12h"
 TxLifetimeCheckTimeout = "10m"
 MaxTxLifetime = "3h"
+MaxTxsPerAddress = 0
+DynamicConfigFile = ""
 	[Sequencer.Finalizer]
 		GERDeadlineTimeout = "5s"
 		ForcedBatchDeadlineTimeout = "60s"
+		MinForcedBatchProcessingInterval = "10s"
 		SleepDuration = "100ms"
+		LivenessTimeout = "5m"
 		ResourcePercentageToCloseBatch = 10
 		GERFinalityNumberOfBlocks = 64
 		ForcedBatchesFinalityNumberOfBlocks = 64
+		ForcedBatchConfirmations = 12
 		L1InfoRootFinalityNumberOfBlocks = 64
 		ClosingSignalsManagerWaitForCheckingL1Timeout = "10s"
 		ClosingSignalsManagerWaitForCheckingGER = "10s"
@@ -141,6 +169,7 @@ MaxTxLifetime = "3h"
 		PoolRetrievalInterval = "500ms"
 		L2ReorgRetrievalInterval = "5s"
 	[Sequencer.StreamServer]
+		Host = ""
 		Port = 0
 		Filename = ""
 		Enabled = false
@@ -151,7 +180,11 @@ LastBatchVirtualizationTimeMaxWaitPeriod = "5s"
 MaxTxSizeForL1 = 131072
 L2Coinbase = "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
 PrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
+HSMKeyARN = ""
 GasOffset = 80000
+MinTxCountForL1Submission = 1
+MaxWaitForL1Submission = "10m"
+MaxL1GasPriceWei = 0
 
 [Aggregator]
 Host = "0.0.0.0"
@@ -171,6 +204,7 @@ UpdatePeriod = "10s"
 Factor = 0.15
 DefaultGasPriceWei = 2000000000
 MaxGasPriceWei = 0
+GasPriceDropRateLimit = 0
 CleanHistoryPeriod = "1h"
 CleanHistoryTimeRetention = "5m"
 
@@ -182,6 +216,9 @@ URI = "zkevm-prover:50071"
 MaxResourceExhaustedAttempts = 3
 WaitOnResourceExhaustion = "1s"
 MaxGRPCMessageSize = 100000000
+ExecutorGRPCKeepaliveTime = "0s"
+ExecutorGRPCKeepaliveTimeout = "20s"
+ExecutorGRPCMaxRetries = 5
 
 [Metrics]
 Host = "0.0.0.0"
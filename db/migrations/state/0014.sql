@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE state.forced_tx
+(
+    forced_batch_num BIGINT NOT NULL REFERENCES state.forced_batch (forced_batch_num) ON DELETE CASCADE,
+    tx_hash          VARCHAR NOT NULL,
+    from_address     VARCHAR NOT NULL,
+    PRIMARY KEY (forced_batch_num, tx_hash)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.forced_tx;
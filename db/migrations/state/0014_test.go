@@ -0,0 +1,63 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the table of the forced txs of the forced batches being processed
+type migrationTest0014 struct{}
+
+func (m migrationTest0014) InsertData(db *sql.DB) error {
+	const addBlock = "INSERT INTO state.block (block_num, received_at, block_hash) VALUES ($1, $2, $3)"
+	if _, err := db.Exec(addBlock, 1, time.Now(), blockHashValue); err != nil {
+		return err
+	}
+	const addForcedBatch = "INSERT INTO state.forced_batch (forced_batch_num, global_exit_root, timestamp, raw_txs_data, coinbase, block_num) VALUES ($1, $2, $3, $4, $5, $6)"
+	if _, err := db.Exec(addForcedBatch, 1, globalExitRootValue, time.Now(), "0x", "0x0000", 1); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const addForcedTx = "INSERT INTO state.forced_tx (forced_batch_num, tx_hash, from_address) VALUES ($1, $2, $3)"
+	_, err := db.Exec(addForcedTx, 1, "0x0001", "0x0002")
+	assert.NoError(t, err)
+	// the same tx can't be stored twice for a forced batch
+	_, err = db.Exec(addForcedTx, 1, "0x0001", "0x0002")
+	assert.Error(t, err)
+	// the forced batch must exist, so the forced txs of the forced batches not from L1 can't be stored as
+	// they have no forced batch row, they are processed in a batch without forced batch number
+	_, err = db.Exec(addForcedTx, 2, "0x0001", "0x0002")
+	assert.Error(t, err)
+	const addBatch = "INSERT INTO state.batch (batch_num, global_exit_root, timestamp, coinbase, forced_batch_num, wip) VALUES ($1, $2, $3, $4, NULL, TRUE)"
+	_, err = db.Exec(addBatch, 1, globalExitRootValue, time.Now(), "0x0000")
+	assert.NoError(t, err)
+	var pendingCount int
+	const getPendingForcedTxsCount = "SELECT count(*) FROM state.forced_tx WHERE forced_batch_num > (SELECT COALESCE(MAX(forced_batch_num), 0) FROM state.batch)"
+	assert.NoError(t, db.QueryRow(getPendingForcedTxsCount).Scan(&pendingCount))
+	// the batch of a forced batch not from L1 doesn't change the pending forced txs of the L1 forced batches
+	assert.Equal(t, 1, pendingCount)
+
+	// the forced txs are removed with their forced batch
+	_, err = db.Exec("DELETE FROM state.forced_batch WHERE forced_batch_num = 1")
+	assert.NoError(t, err)
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM state.forced_tx").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getForcedTxTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema='state' and table_name='forced_tx'`
+	var result int
+	assert.NoError(t, db.QueryRow(getForcedTxTable).Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0014(t *testing.T) {
+	runMigrationTest(t, 14, migrationTest0014{})
+}
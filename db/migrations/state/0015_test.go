@@ -0,0 +1,43 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the time the batch was closed
+type migrationTest0015 struct{}
+
+func (m migrationTest0015) InsertData(db *sql.DB) error {
+	const addBatch = "INSERT INTO state.batch (batch_num, timestamp, wip) VALUES ($1, $2, FALSE)"
+	if _, err := db.Exec(addBatch, 1, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the batches closed before the migration don't have a closing time
+	var closedAt *time.Time
+	assert.NoError(t, db.QueryRow("SELECT closed_at FROM state.batch WHERE batch_num = 1").Scan(&closedAt))
+	assert.Nil(t, closedAt)
+
+	_, err := db.Exec("UPDATE state.batch SET closed_at = $1 WHERE batch_num = 1", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, db.QueryRow("SELECT closed_at FROM state.batch WHERE batch_num = 1").Scan(&closedAt))
+	assert.NotNil(t, closedAt)
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getClosedAtColumn = `SELECT count(*) FROM information_schema.columns WHERE table_schema='state' and table_name='batch' and column_name='closed_at'`
+	var result int
+	assert.NoError(t, db.QueryRow(getClosedAtColumn).Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0015(t *testing.T) {
+	runMigrationTest(t, 15, migrationTest0015{})
+}
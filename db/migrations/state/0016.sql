@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE state.batch
+    ADD COLUMN raw_txs_data_encoding VARCHAR NOT NULL DEFAULT 'none';
+ALTER TABLE state.forced_batch
+    ADD COLUMN raw_txs_data_encoding VARCHAR NOT NULL DEFAULT 'none';
+
+-- +migrate Down
+ALTER TABLE state.batch
+    DROP COLUMN IF EXISTS raw_txs_data_encoding;
+ALTER TABLE state.forced_batch
+    DROP COLUMN IF EXISTS raw_txs_data_encoding;
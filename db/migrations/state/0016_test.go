@@ -0,0 +1,45 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the encoding of the raw txs data of the batches
+type migrationTest0016 struct{}
+
+func (m migrationTest0016) InsertData(db *sql.DB) error {
+	const addBatch = "INSERT INTO state.batch (batch_num, timestamp, raw_txs_data, wip) VALUES ($1, $2, $3, FALSE)"
+	if _, err := db.Exec(addBatch, 1, time.Now(), []byte{0x1f, 0x8b, 0x08}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the batches stored before the migration are not compressed
+	var encoding string
+	assert.NoError(t, db.QueryRow("SELECT raw_txs_data_encoding FROM state.batch WHERE batch_num = 1").Scan(&encoding))
+	assert.Equal(t, "none", encoding)
+
+	const getForcedBatchEncodingDefault = `SELECT column_default FROM information_schema.columns WHERE table_schema='state' and table_name='forced_batch' and column_name='raw_txs_data_encoding'`
+	var columnDefault string
+	assert.NoError(t, db.QueryRow(getForcedBatchEncodingDefault).Scan(&columnDefault))
+	assert.Contains(t, columnDefault, "'none'")
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	for _, table := range []string{"batch", "forced_batch"} {
+		const getEncodingColumn = `SELECT count(*) FROM information_schema.columns WHERE table_schema='state' and table_name=$1 and column_name='raw_txs_data_encoding'`
+		var result int
+		assert.NoError(t, db.QueryRow(getEncodingColumn, table).Scan(&result))
+		assert.Equal(t, 0, result)
+	}
+}
+
+func TestMigration0016(t *testing.T) {
+	runMigrationTest(t, 16, migrationTest0016{})
+}
@@ -0,0 +1,266 @@
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	service          = "kms"
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	contentType      = "application/x-amz-json-1.1"
+	amzDateFormat    = "20060102T150405Z"
+	dateFormat       = "20060102"
+
+	targetGetPublicKey = "TrentService.GetPublicKey"
+	targetSign         = "TrentService.Sign"
+
+	// requestTimeout is the maximum time to wait for a response of the KMS service
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	now = time.Now
+
+	// ErrInvalidKeyARN is returned when the key ARN is not a valid AWS KMS key ARN
+	ErrInvalidKeyARN = errors.New("invalid AWS KMS key ARN")
+	// ErrMissingCredentials is returned when none of the providers of the AWS default credential chain has credentials
+	ErrMissingCredentials = errors.New("missing AWS credentials")
+)
+
+// Client for the AWS KMS service, it gives access to a secp256k1 (ECC_SECG_P256K1) key
+type Client struct {
+	Http        http.Client
+	Url         string
+	Region      string
+	KeyARN      string
+	Credentials aws.CredentialsProvider
+}
+
+// NewClient creates a client for the AWS KMS key with the provided ARN, the
+// credentials are retrieved with the AWS default credential chain (environment,
+// shared config files, web identity token and IAM role), which refreshes the
+// temporary credentials before they expire
+func NewClient(ctx context.Context, keyARN string) (*Client, error) {
+	// arn:aws:kms:<region>:<account>:key/<key id>
+	const arnParts = 6
+	parts := strings.SplitN(keyARN, ":", arnParts)
+	if len(parts) != arnParts || parts[0] != "arn" || parts[2] != service || parts[3] == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKeyARN, keyARN)
+	}
+	region := parts[3]
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	// The credentials are retrieved to fail on startup when there are none, they are cached by the provider
+	_, err = awsCfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMissingCredentials, err)
+	}
+
+	return &Client{
+		Http:        http.Client{Timeout: requestTimeout},
+		Url:         fmt.Sprintf("https://kms.%s.amazonaws.com/", region),
+		Region:      region,
+		KeyARN:      keyARN,
+		Credentials: awsCfg.Credentials,
+	}, nil
+}
+
+type getPublicKeyRequest struct {
+	KeyId string `json:"KeyId"`
+}
+
+type getPublicKeyResponse struct {
+	PublicKey string `json:"PublicKey"`
+}
+
+type signRequest struct {
+	KeyId            string `json:"KeyId"`
+	Message          string `json:"Message"`
+	MessageType      string `json:"MessageType"`
+	SigningAlgorithm string `json:"SigningAlgorithm"`
+}
+
+type signResponse struct {
+	Signature string `json:"Signature"`
+}
+
+// subjectPublicKeyInfo is the ASN.1 structure of the public key returned by KMS
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+// PublicKey retrieves the public key of the KMS key
+func (c *Client) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var res getPublicKeyResponse
+	err := c.call(ctx, targetGetPublicKey, getPublicKeyRequest{KeyId: c.KeyARN}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := base64.StdEncoding.DecodeString(res.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	var spki subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	return crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+}
+
+// SignDigest signs the digest with the KMS key, it returns the ASN.1 DER encoded signature
+func (c *Client) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	req := signRequest{
+		KeyId:            c.KeyARN,
+		Message:          base64.StdEncoding.EncodeToString(digest),
+		MessageType:      "DIGEST",
+		SigningAlgorithm: "ECDSA_SHA_256",
+	}
+	var res signResponse
+	err := c.call(ctx, targetSign, req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	return signature, nil
+}
+
+// call sends a request signed with the AWS Signature Version 4 to the KMS API
+func (c *Client) call(ctx context.Context, target string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// The credentials provider returns the cached credentials, refreshing them when they are expired
+	credentials, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Target", target)
+	signV4(req, body, credentials, c.Region, service, now())
+
+	res, err := c.Http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("http response is %d: %s", res.StatusCode, string(resBody))
+	}
+
+	err = json.Unmarshal(resBody, response)
+	if err != nil {
+		return fmt.Errorf("Reading body failed: %w", err)
+	}
+	return nil
+}
+
+// signV4 adds the AWS Signature Version 4 headers to the request
+func signV4(req *http.Request, body []byte, credentials aws.Credentials, region, serviceName string, t time.Time) {
+	amzDate := t.UTC().Format(amzDateFormat)
+	date := t.UTC().Format(dateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery(req.URL),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, serviceName, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, serviceName)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(u *url.URL) string {
+	return strings.ReplaceAll(u.Query().Encode(), "+", "%20")
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
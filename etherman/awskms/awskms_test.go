@@ -0,0 +1,125 @@
+package awskms
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+
+	c, err := NewClient(context.Background(), testKeyARN)
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", c.Region)
+	assert.Equal(t, "https://kms.us-east-1.amazonaws.com/", c.Url)
+	assert.Equal(t, requestTimeout, c.Http.Timeout)
+	creds, err := c.Credentials.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "AKIDEXAMPLE", creds.AccessKeyID)
+	assert.Equal(t, "token", creds.SessionToken)
+
+	_, err = NewClient(context.Background(), "arn:aws:s3:::bucket")
+	assert.ErrorIs(t, err, ErrInvalidKeyARN)
+
+	// None of the providers of the default credential chain has credentials
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/credentials")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	_, err = NewClient(context.Background(), testKeyARN)
+	assert.ErrorIs(t, err, ErrMissingCredentials)
+}
+
+func TestSignV4(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds := aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", req.Header.Get("Authorization"))
+}
+
+func TestPublicKeyAndSignDigest(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	digest := crypto.Keccak256([]byte("digest"))
+	derSignature := []byte{0x30, 0x01, 0x02}
+
+	// secp256k1 public key in the SubjectPublicKeyInfo format returned by KMS
+	var spki subjectPublicKeyInfo
+	spki.Algorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	spki.Algorithm.Parameters = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	spki.PublicKey = asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 65 * 8}
+	derPublicKey, err := asn1.Marshal(spki)
+	require.NoError(t, err)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch r.Header.Get("X-Amz-Target") {
+		case targetGetPublicKey:
+			var req getPublicKeyRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, testKeyARN, req.KeyId)
+			fmt.Fprintf(w, `{"KeyId":"%s","KeySpec":"ECC_SECG_P256K1","PublicKey":"%s"}`, testKeyARN, base64.StdEncoding.EncodeToString(derPublicKey))
+		case targetSign:
+			var req signRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, testKeyARN, req.KeyId)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(digest), req.Message)
+			assert.Equal(t, "DIGEST", req.MessageType)
+			assert.Equal(t, "ECDSA_SHA_256", req.SigningAlgorithm)
+			fmt.Fprintf(w, `{"KeyId":"%s","Signature":"%s"}`, testKeyARN, base64.StdEncoding.EncodeToString(derSignature))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type":"UnknownOperationException"}`)
+		}
+	}))
+	defer svr.Close()
+
+	c := &Client{
+		Url:         svr.URL,
+		Region:      "us-east-1",
+		KeyARN:      testKeyARN,
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+	}
+
+	publicKey, err := c.PublicKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*publicKey))
+
+	signature, err := c.SignDigest(context.Background(), digest)
+	require.NoError(t, err)
+	assert.Equal(t, derSignature, signature)
+
+	err = c.call(context.Background(), "TrentService.Unknown", getPublicKeyRequest{}, &getPublicKeyResponse{})
+	assert.ErrorContains(t, err, "http response is 400")
+}
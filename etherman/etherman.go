@@ -1013,6 +1013,15 @@ func (etherMan *Client) TrustedSequencer() (common.Address, error) {
 	return etherMan.ZkEVM.TrustedSequencer(&bind.CallOpts{Pending: false})
 }
 
+// ForceBatchTimeout gets the time after which anyone can sequence a forced batch that the trusted sequencer has not sequenced
+func (etherMan *Client) ForceBatchTimeout() (time.Duration, error) {
+	timeout, err := etherMan.ZkEVM.ForceBatchTimeout(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(timeout) * time.Second, nil
+}
+
 func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, blocks *[]Block, blocksOrder *map[common.Hash][]Order) error {
 	log.Debug("ForceBatch event detected")
 	fb, err := etherMan.ZkEVM.ParseForceBatch(vLog)
@@ -1461,6 +1470,12 @@ func (etherMan *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*t
 	return etherMan.EthClient.HeaderByNumber(ctx, number)
 }
 
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the given channel.
+func (etherMan *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return etherMan.EthClient.SubscribeNewHead(ctx, ch)
+}
+
 // EthBlockByNumber function retrieves the ethereum block information by ethereum block number.
 func (etherMan *Client) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*types.Block, error) {
 	block, err := etherMan.EthClient.BlockByNumber(ctx, new(big.Int).SetUint64(blockNumber))
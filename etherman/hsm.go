@@ -0,0 +1,115 @@
+package etherman
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	signatureLength = 65
+	// hsmTimeout is the maximum time to wait for the HSM to return the public key or a signature
+	hsmTimeout = 30 * time.Second
+)
+
+var (
+	// ErrInvalidHSMSignature is returned when the signature returned by the HSM
+	// doesn't belong to the HSM key
+	ErrInvalidHSMSignature = errors.New("invalid signature returned by the HSM")
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// HSMSigner provides access to a secp256k1 key stored in a hardware security module
+type HSMSigner interface {
+	// PublicKey returns the public key of the HSM key
+	PublicKey(ctx context.Context) (*ecdsa.PublicKey, error)
+	// SignDigest signs the digest with the HSM key, returning the ASN.1 DER encoded signature
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// LoadAuthFromHSM loads an authorization whose private key is stored in a hardware
+// security module, the address of the authorization is derived from the HSM public key
+func (etherMan *Client) LoadAuthFromHSM(ctx context.Context, hsm HSMSigner) (*bind.TransactOpts, error) {
+	auth, err := newAuthFromHSM(ctx, hsm, etherMan.l1Cfg.L1ChainID)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("loaded HSM authorization for address: %v", auth.From.String())
+	etherMan.auth[auth.From] = auth
+	return &auth, nil
+}
+
+// newAuthFromHSM creates an authorization instance that signs the txs with the HSM key
+func newAuthFromHSM(ctx context.Context, hsm HSMSigner, chainID uint64) (bind.TransactOpts, error) {
+	publicKeyCtx, cancel := context.WithTimeout(ctx, hsmTimeout)
+	defer cancel()
+	publicKey, err := hsm.PublicKey(publicKeyCtx)
+	if err != nil {
+		return bind.TransactOpts{}, fmt.Errorf("failed to get HSM public key: %w", err)
+	}
+	from := crypto.PubkeyToAddress(*publicKey)
+	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+
+	return bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			digest := signer.Hash(tx).Bytes()
+			signCtx, cancel := context.WithTimeout(context.Background(), hsmTimeout)
+			defer cancel()
+			derSignature, err := hsm.SignDigest(signCtx, digest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign tx with the HSM: %w", err)
+			}
+			signature, err := toEthereumSignature(derSignature, digest, from)
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(signer, signature)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+// toEthereumSignature converts an ASN.1 DER encoded signature into the [R || S || V] format,
+// S is normalized to the lower half of the curve order and V is the recovery id that
+// recovers the address of the signer
+func toEthereumSignature(derSignature []byte, digest []byte, signerAddr common.Address) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(derSignature, &sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHSMSignature, err)
+	}
+	// Ethereum only accepts signatures with S in the lower half of the curve order (EIP-2)
+	if sig.S.Cmp(secp256k1HalfN) > 0 {
+		sig.S = new(big.Int).Sub(secp256k1N, sig.S)
+	}
+
+	signature := make([]byte, signatureLength)
+	sig.R.FillBytes(signature[0:32])
+	sig.S.FillBytes(signature[32:64])
+	for _, v := range []byte{0, 1} {
+		signature[64] = v
+		publicKey, err := crypto.SigToPub(digest, signature)
+		if err == nil && crypto.PubkeyToAddress(*publicKey) == signerAddr {
+			return signature, nil
+		}
+	}
+
+	return nil, ErrInvalidHSMSignature
+}
@@ -0,0 +1,89 @@
+package etherman
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localHSM is an HSMSigner backed by an in memory key, it can return the
+// signatures with S in the upper half of the curve order like some HSMs do
+type localHSM struct {
+	key   *ecdsa.PrivateKey
+	highS bool
+}
+
+func (h *localHSM) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("missing HSM deadline")
+	}
+	return &h.key.PublicKey, nil
+}
+
+func (h *localHSM) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("missing HSM deadline")
+	}
+	signature, err := crypto.Sign(digest, h.key)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(signature[0:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	if h.highS {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func TestNewAuthFromHSM(t *testing.T) {
+	const chainID = 1000
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	expectedFrom := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, highS := range []bool{false, true} {
+		auth, err := newAuthFromHSM(context.Background(), &localHSM{key: key, highS: highS}, chainID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedFrom, auth.From)
+
+		tx := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+
+		sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chainID)), signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedFrom, sender)
+
+		_, err = auth.Signer(common.HexToAddress("0x2"), tx)
+		assert.ErrorIs(t, err, bind.ErrNotAuthorized)
+	}
+}
+
+func TestToEthereumSignatureWrongSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hsm := &localHSM{key: key}
+	digest := crypto.Keccak256([]byte("digest"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), hsmTimeout)
+	defer cancel()
+	derSignature, err := hsm.SignDigest(ctx, digest)
+	require.NoError(t, err)
+
+	_, err = toEthereumSignature(derSignature, digest, common.HexToAddress("0x1"))
+	assert.ErrorIs(t, err, ErrInvalidHSMSignature)
+
+	_, err = toEthereumSignature([]byte{0x1}, digest, crypto.PubkeyToAddress(key.PublicKey))
+	assert.ErrorIs(t, err, ErrInvalidHSMSignature)
+}
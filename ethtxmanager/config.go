@@ -48,4 +48,20 @@ type Config struct {
 	// max gas price limit: 110
 	// tx gas price = 110
 	MaxGasPriceLimit uint64 `mapstructure:"MaxGasPriceLimit"`
+
+	// L1TxTimeout is the time a sent tx can stay in the network without being mined,
+	// once this time is reached the tx is resubmitted with a bumped gas price,
+	// default value is 5m, 0 means the txs are never resubmitted because of a timeout.
+	L1TxTimeout types.Duration `mapstructure:"L1TxTimeout"`
+
+	// L1GasBumpPercent is the percentage the gas price is increased when a tx needs
+	// to be resubmitted, because it was not mined within the L1TxTimeout or it was
+	// rejected by the network as underpriced, default value is 10.
+	// The bumped gas price is limited by MaxGasPriceLimit.
+	//
+	// ex:
+	// tx gas price: 100
+	// L1GasBumpPercent: 10
+	// resubmitted tx gas price = 110
+	L1GasBumpPercent uint64 `mapstructure:"L1GasBumpPercent"`
 }
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum"
@@ -45,6 +46,9 @@ type Client struct {
 	etherman ethermanInterface
 	storage  storageInterface
 	state    stateInterface
+
+	pendingL1Txs    map[string]*pendingL1Tx
+	pendingL1TxsMux *sync.Mutex
 }
 
 // New creates new eth tx manager
@@ -54,8 +58,13 @@ func New(cfg Config, ethMan ethermanInterface, storage storageInterface, state s
 		etherman: ethMan,
 		storage:  storage,
 		state:    state,
+
+		pendingL1Txs:    make(map[string]*pendingL1Tx),
+		pendingL1TxsMux: &sync.Mutex{},
 	}
 
+	metrics.Register()
+
 	return c
 }
 
@@ -154,7 +163,13 @@ func (c *Client) setStatusDone(ctx context.Context, owner, id string, dbTx pgx.T
 
 	mTx.status = MonitoredTxStatusDone
 
-	return c.storage.Update(ctx, mTx, dbTx)
+	err = c.storage.Update(ctx, mTx, dbTx)
+	if err != nil {
+		return err
+	}
+
+	c.untrackPendingL1Tx(mTx)
+	return nil
 }
 
 func (c *Client) buildResult(ctx context.Context, mTx monitoredTx) (MonitoredTxResult, error) {
@@ -414,9 +429,18 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 			err := c.etherman.SendTx(ctx, signedTx)
 			if err != nil {
 				logger.Errorf("failed to send tx %v to network: %v", signedTx.Hash().String(), err)
+				// if the network rejected the tx because of its gas price, bump it
+				// to get the tx resubmitted in the next monitoring cycle
+				if isUnderpricedError(err) {
+					err := c.bumpGasPrice(ctx, &mTx, logger)
+					if err != nil {
+						logger.Errorf("failed to bump monitored tx gas price: %v", err)
+					}
+				}
 				return
 			}
 			logger.Infof("signed tx sent to the network: %v", signedTx.Hash().String())
+			c.trackPendingL1Tx(mTx)
 			if mTx.status == MonitoredTxStatusCreated {
 				// update tx status to sent
 				mTx.status = MonitoredTxStatusSent
@@ -430,6 +454,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 			}
 		} else {
 			logger.Infof("signed tx already found in the network")
+			c.trackPendingL1Tx(mTx)
 		}
 
 		log.Infof("waiting signedTx to be mined...")
@@ -442,6 +467,14 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 		}
 		if !confirmed {
 			log.Infof("signedTx not mined yet and timeout has been reached")
+			// if the tx is taking too long to be mined, bump the gas price
+			// to get the tx resubmitted in the next monitoring cycle
+			if c.isPendingL1TxTimedOut(mTx) {
+				err := c.bumpGasPrice(ctx, &mTx, logger)
+				if err != nil {
+					logger.Errorf("failed to bump monitored tx gas price: %v", err)
+				}
+			}
 			return
 		}
 
@@ -494,6 +527,9 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 		logger.Errorf("failed to update monitored tx: %v", err)
 		return
 	}
+
+	// the monitored tx is confirmed or failed, it doesn't need to be resubmitted anymore
+	c.untrackPendingL1Tx(mTx)
 }
 
 // shouldContinueToMonitorThisTx checks the the tx receipt and decides if it should
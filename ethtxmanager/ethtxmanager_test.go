@@ -140,6 +140,11 @@ func TestTxGetMined(t *testing.T) {
 	require.Equal(t, signedTx, result.Txs[signedTx.Hash()].Tx)
 	require.Equal(t, receipt, result.Txs[signedTx.Hash()].Receipt)
 	require.Equal(t, "", result.Txs[signedTx.Hash()].RevertMessage)
+
+	// the confirmed tx is no longer tracked to be resubmitted
+	ethTxManagerClient.pendingL1TxsMux.Lock()
+	require.Empty(t, ethTxManagerClient.pendingL1Txs)
+	ethTxManagerClient.pendingL1TxsMux.Unlock()
 }
 
 func TestTxGetMinedAfterReviewed(t *testing.T) {
@@ -982,3 +987,82 @@ func TestFailedToEstimateTxWithForcedGasGetMined(t *testing.T) {
 	require.Equal(t, receipt, result.Txs[signedTx.Hash()].Receipt)
 	require.Equal(t, "", result.Txs[signedTx.Hash()].RevertMessage)
 }
+
+func TestGasPriceBump(t *testing.T) {
+	type testCase struct {
+		name             string
+		gasBumpPercent   uint64
+		maxGasPrice      uint64
+		gasPrice         int64
+		expectedGasPrice int64
+	}
+
+	testCases := []testCase{
+		{
+			name:             "10% bump and no limit",
+			gasBumpPercent:   10,
+			maxGasPrice:      0,
+			gasPrice:         100,
+			expectedGasPrice: 110,
+		},
+		{
+			name:             "10% bump but limited",
+			gasBumpPercent:   10,
+			maxGasPrice:      105,
+			gasPrice:         100,
+			expectedGasPrice: 105,
+		},
+		{
+			name:             "max gas price already reached",
+			gasBumpPercent:   10,
+			maxGasPrice:      100,
+			gasPrice:         100,
+			expectedGasPrice: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbCfg := dbutils.NewStateConfigFromEnv()
+			require.NoError(t, dbutils.InitOrResetState(dbCfg))
+
+			etherman := newEthermanMock(t)
+			st := newStateMock(t)
+			storage, err := NewPostgresStorage(dbCfg)
+			require.NoError(t, err)
+
+			var cfg = Config{
+				FrequencyToMonitorTxs: defaultEthTxmanagerConfigForTests.FrequencyToMonitorTxs,
+				WaitTxToBeMined:       defaultEthTxmanagerConfigForTests.WaitTxToBeMined,
+				GasPriceMarginFactor:  defaultEthTxmanagerConfigForTests.GasPriceMarginFactor,
+				L1GasBumpPercent:      tc.gasBumpPercent,
+				MaxGasPriceLimit:      tc.maxGasPrice,
+			}
+
+			ethTxManagerClient := New(cfg, etherman, storage, st)
+
+			ctx := context.Background()
+
+			mTx := monitoredTx{
+				owner: "owner", id: "unique_id", from: common.HexToAddress(""),
+				gasPrice: big.NewInt(tc.gasPrice), status: MonitoredTxStatusSent,
+			}
+			err = storage.Add(ctx, mTx, nil)
+			require.NoError(t, err)
+
+			err = ethTxManagerClient.bumpGasPrice(ctx, &mTx, createMonitoredTxLogger(mTx))
+			require.NoError(t, err)
+
+			expectedGasPrice := big.NewInt(tc.expectedGasPrice)
+			monitoredTx, err := storage.Get(ctx, mTx.owner, mTx.id, nil)
+			require.NoError(t, err)
+			require.Equal(t, monitoredTx.gasPrice.Cmp(expectedGasPrice), 0, fmt.Sprintf("expected gas price %v, found %v", expectedGasPrice.String(), monitoredTx.gasPrice.String()))
+		})
+	}
+}
+
+func TestIsUnderpricedError(t *testing.T) {
+	require.True(t, isUnderpricedError(errors.New("transaction underpriced")))
+	require.True(t, isUnderpricedError(errors.New("replacement transaction underpriced")))
+	require.False(t, isUnderpricedError(errors.New("nonce too low")))
+}
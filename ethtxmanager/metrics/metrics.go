@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// L1TxResubmissionsName is the name of the metric that counts the L1 txs resubmitted with a bumped gas price.
+	// It keeps the sequencer prefix the dashboards already use for the L1 submissions.
+	L1TxResubmissionsName = "sequencer_l1_tx_resubmissions_total"
+)
+
+// Register the metrics for the ethtxmanager package.
+func Register() {
+	counters := []prometheus.CounterOpts{
+		{
+			Name: L1TxResubmissionsName,
+			Help: "[ETHTXMANAGER] total count of L1 txs resubmitted with a bumped gas price",
+		},
+	}
+
+	metrics.RegisterCounters(counters...)
+}
+
+// L1TxResubmission increases the counter for L1 txs that have been
+// resubmitted with a bumped gas price.
+func L1TxResubmission() {
+	metrics.CounterInc(L1TxResubmissionsName)
+}
@@ -0,0 +1,113 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/core/txpool"
+)
+
+const percentBase = 100
+
+// pendingL1Tx keeps track of the submissions of a monitored tx that were
+// sent to the network and are still waiting to be mined
+type pendingL1Tx struct {
+	// sentAt is the time when the tx with the current gas price was sent
+	sentAt time.Time
+	// attempts is the number of times the tx was resubmitted with a bumped gas price
+	attempts uint64
+}
+
+// pendingL1TxKey returns the key used to identify the monitored tx in the pending L1 txs tracker
+func pendingL1TxKey(mTx monitoredTx) string {
+	return mTx.owner + "-" + mTx.id
+}
+
+// trackPendingL1Tx registers the monitored tx as sent, if it was not already registered
+func (c *Client) trackPendingL1Tx(mTx monitoredTx) {
+	c.pendingL1TxsMux.Lock()
+	defer c.pendingL1TxsMux.Unlock()
+
+	key := pendingL1TxKey(mTx)
+	if _, found := c.pendingL1Txs[key]; !found {
+		c.pendingL1Txs[key] = &pendingL1Tx{sentAt: time.Now()}
+	}
+}
+
+// untrackPendingL1Tx removes the monitored tx from the pending L1 txs tracker, it must be
+// called once the monitored tx reaches a final status so the tracker doesn't grow forever
+func (c *Client) untrackPendingL1Tx(mTx monitoredTx) {
+	c.pendingL1TxsMux.Lock()
+	defer c.pendingL1TxsMux.Unlock()
+
+	delete(c.pendingL1Txs, pendingL1TxKey(mTx))
+}
+
+// isPendingL1TxTimedOut checks if the monitored tx was sent more than L1TxTimeout ago
+// without being mined
+func (c *Client) isPendingL1TxTimedOut(mTx monitoredTx) bool {
+	if c.cfg.L1TxTimeout.Duration == 0 {
+		return false
+	}
+
+	c.pendingL1TxsMux.Lock()
+	defer c.pendingL1TxsMux.Unlock()
+
+	pending, found := c.pendingL1Txs[pendingL1TxKey(mTx)]
+	if !found {
+		return false
+	}
+	return time.Since(pending.sentAt) >= c.cfg.L1TxTimeout.Duration
+}
+
+// bumpGasPrice increases the gas price of the monitored tx by L1GasBumpPercent, limited
+// by MaxGasPriceLimit, and stores the change so the next monitoring cycle resubmits the tx
+func (c *Client) bumpGasPrice(ctx context.Context, mTx *monitoredTx, mTxLogger *log.Logger) error {
+	bumpedGasPrice := new(big.Int).Mul(mTx.gasPrice, big.NewInt(0).SetUint64(percentBase+c.cfg.L1GasBumpPercent))
+	bumpedGasPrice.Div(bumpedGasPrice, big.NewInt(percentBase))
+
+	if c.cfg.MaxGasPriceLimit > 0 {
+		maxGasPrice := big.NewInt(0).SetUint64(c.cfg.MaxGasPriceLimit)
+		if bumpedGasPrice.Cmp(maxGasPrice) == 1 {
+			bumpedGasPrice.Set(maxGasPrice)
+		}
+	}
+
+	if bumpedGasPrice.Cmp(mTx.gasPrice) != 1 {
+		mTxLogger.Warnf("monitored tx gas price %v can't be bumped, max gas price %v reached", mTx.gasPrice.String(), c.cfg.MaxGasPriceLimit)
+		return nil
+	}
+
+	mTxLogger.Infof("monitored tx gas price bumped from %v to %v", mTx.gasPrice.String(), bumpedGasPrice.String())
+	mTx.gasPrice = bumpedGasPrice
+	err := c.storage.Update(ctx, *mTx, nil)
+	if err != nil {
+		return err
+	}
+
+	c.pendingL1TxsMux.Lock()
+	pending, found := c.pendingL1Txs[pendingL1TxKey(*mTx)]
+	if !found {
+		pending = &pendingL1Tx{}
+		c.pendingL1Txs[pendingL1TxKey(*mTx)] = pending
+	}
+	pending.sentAt = time.Now()
+	pending.attempts++
+	mTxLogger.Infof("monitored tx resubmission attempt %d", pending.attempts)
+	c.pendingL1TxsMux.Unlock()
+
+	metrics.L1TxResubmission()
+
+	return nil
+}
+
+// isUnderpricedError checks if the error returned by the network when sending a tx
+// means the gas price of the tx is too low
+func isUnderpricedError(err error) bool {
+	return strings.Contains(err.Error(), txpool.ErrUnderpriced.Error()) ||
+		strings.Contains(err.Error(), txpool.ErrReplaceUnderpriced.Error())
+}
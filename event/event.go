@@ -40,6 +40,8 @@ const (
 	EventID_SynchronizerRestart EventID = "SYNCHRONIZER RESTART"
 	// EventID_SynchronizerHalt is triggered when the synchronizer halts
 	EventID_SynchronizerHalt EventID = "SYNCHRONIZER HALT"
+	// EventID_ForcedBlockHashMismatch is triggered when the forced block hash of a forced batch doesn't match the L1 block hash
+	EventID_ForcedBlockHashMismatch EventID = "FORCED BLOCK HASH MISMATCH"
 	// Source_Node is the source of the event
 	Source_Node Source = "node"
 
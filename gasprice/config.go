@@ -1,6 +1,7 @@
 package gasprice
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
@@ -18,6 +19,20 @@ const (
 	FollowerType EstimatorType = "follower"
 )
 
+// Source returns how the gas prices of the estimator type are computed, as reported by zkevm_getL2GasPrice
+func (t EstimatorType) Source() string {
+	switch t {
+	case DefaultType:
+		return "fixed"
+	case LastNBatchesType:
+		return "last-n-batches"
+	case FollowerType:
+		return "L1-derived"
+	default:
+		return "unknown"
+	}
+}
+
 // Config for gas price estimator.
 type Config struct {
 	Type EstimatorType `mapstructure:"Type"`
@@ -35,4 +50,15 @@ type Config struct {
 	CleanHistoryTimeRetention types.Duration `mapstructure:"CleanHistoryTimeRetention"`
 
 	Factor float64 `mapstructure:"Factor"`
+	// GasPriceDropRateLimit is the max ratio the follower gas pricer can lower the L2 gas price on each update (e.g. 0.1 = 10%),
+	// the increases are applied at once. It must be in the range [0, 1) and it is ignored if 0.
+	GasPriceDropRateLimit float64 `mapstructure:"GasPriceDropRateLimit"`
+}
+
+// Validate checks the gas price suggester config
+func (c Config) Validate() error {
+	if c.GasPriceDropRateLimit < 0 || c.GasPriceDropRateLimit >= 1 {
+		return fmt.Errorf("invalid L2GasPriceSuggester.GasPriceDropRateLimit: it must be in the range [0, 1), got %v", c.GasPriceDropRateLimit)
+	}
+	return nil
 }
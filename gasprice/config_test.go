@@ -0,0 +1,30 @@
+package gasprice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidateGasPriceDropRateLimit(t *testing.T) {
+	testCases := []struct {
+		gasPriceDropRateLimit float64
+		valid                 bool
+	}{
+		{gasPriceDropRateLimit: -0.1, valid: false},
+		{gasPriceDropRateLimit: 0, valid: true},
+		{gasPriceDropRateLimit: 0.1, valid: true},
+		{gasPriceDropRateLimit: 0.99, valid: true},
+		{gasPriceDropRateLimit: 1, valid: false},
+		{gasPriceDropRateLimit: 1.5, valid: false},
+	}
+
+	for _, tc := range testCases {
+		err := Config{GasPriceDropRateLimit: tc.gasPriceDropRateLimit}.Validate()
+		if tc.valid {
+			assert.NoError(t, err, "GasPriceDropRateLimit %v", tc.gasPriceDropRateLimit)
+		} else {
+			assert.Error(t, err, "GasPriceDropRateLimit %v", tc.gasPriceDropRateLimit)
+		}
+	}
+}
@@ -16,6 +16,8 @@ type FollowerGasPrice struct {
 	pool poolInterface
 	ctx  context.Context
 	eth  ethermanInterface
+	// lastL2GasPrice is the L2 gas price of the last update, before truncating it
+	lastL2GasPrice *big.Int
 }
 
 // newFollowerGasPriceSuggester inits l2 follower gas price suggester which is based on the l1 gas price.
@@ -47,6 +49,7 @@ func (f *FollowerGasPrice) UpdateGasPriceAvg() {
 	// Store l2 gasPrice calculated
 	result := new(big.Int)
 	res.Int(result)
+	result = f.limitGasPriceDrop(result)
 	minGasPrice := big.NewInt(0).SetUint64(f.cfg.DefaultGasPriceWei)
 	if minGasPrice.Cmp(result) == 1 { // minGasPrice > result
 		log.Warn("setting DefaultGasPriceWei for L2")
@@ -57,6 +60,7 @@ func (f *FollowerGasPrice) UpdateGasPriceAvg() {
 		log.Warn("setting MaxGasPriceWei for L2")
 		result = maxGasPrice
 	}
+	f.lastL2GasPrice = result
 	var truncateValue *big.Int
 	log.Debug("Full L2 gas price value: ", result, ". Length: ", len(result.String()))
 	numLength := len(result.String())
@@ -81,3 +85,18 @@ func (f *FollowerGasPrice) UpdateGasPriceAvg() {
 		log.Error("nil value detected. Skipping...")
 	}
 }
+
+// limitGasPriceDrop returns the lowest gas price allowed by GasPriceDropRateLimit when the gas price
+// drops more than the limit since the last update, so a sudden L1 gas price drop is smoothed
+func (f *FollowerGasPrice) limitGasPriceDrop(gasPrice *big.Int) *big.Int {
+	if f.cfg.GasPriceDropRateLimit <= 0 || f.lastL2GasPrice == nil {
+		return gasPrice
+	}
+	minGasPrice := new(big.Int)
+	new(big.Float).Mul(big.NewFloat(1-f.cfg.GasPriceDropRateLimit), new(big.Float).SetInt(f.lastL2GasPrice)).Int(minGasPrice)
+	if gasPrice.Cmp(minGasPrice) < 0 {
+		log.Infof("L2 gas price drop from %v to %v limited to %v", f.lastL2GasPrice, gasPrice, minGasPrice)
+		return minGasPrice
+	}
+	return gasPrice
+}
@@ -60,3 +60,38 @@ func TestLimitMasGasPrice(t *testing.T) {
 	f := newFollowerGasPriceSuggester(ctx, cfg, poolM, ethM)
 	f.UpdateGasPriceAvg()
 }
+
+func TestGasPriceDropRateLimit(t *testing.T) {
+	ctx := context.Background()
+	var d time.Duration = 1000000000
+
+	cfg := Config{
+		Type:                  FollowerType,
+		DefaultGasPriceWei:    100000000,
+		UpdatePeriod:          types.NewDuration(d),
+		Factor:                0.5,
+		GasPriceDropRateLimit: 0.1,
+	}
+	l1GasPrice := big.NewInt(10000000000)
+	poolM := new(poolMock)
+	ethM := new(ethermanMock)
+	ethM.On("GetL1GasPrice", ctx).Return(l1GasPrice).Once()
+	poolM.On("SetGasPrices", ctx, uint64(5000000000), l1GasPrice.Uint64()).Return(nil).Once()
+	f := newFollowerGasPriceSuggester(ctx, cfg, poolM, ethM)
+
+	// A 90% L1 gas price drop lowers the L2 gas price 10% per update
+	droppedL1GasPrice := big.NewInt(1000000000)
+	ethM.On("GetL1GasPrice", ctx).Return(droppedL1GasPrice)
+	for _, l2GasPrice := range []uint64{4500000000, 4050000000, 3640000000} {
+		poolM.On("SetGasPrices", ctx, l2GasPrice, droppedL1GasPrice.Uint64()).Return(nil).Once()
+		f.UpdateGasPriceAvg()
+	}
+	poolM.AssertExpectations(t)
+
+	// The increases are applied at once
+	ethM.ExpectedCalls = nil
+	ethM.On("GetL1GasPrice", ctx).Return(l1GasPrice).Once()
+	poolM.On("SetGasPrices", ctx, uint64(5000000000), l1GasPrice.Uint64()).Return(nil).Once()
+	f.UpdateGasPriceAvg()
+	poolM.AssertExpectations(t)
+}
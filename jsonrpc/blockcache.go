@@ -0,0 +1,85 @@
+package jsonrpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// blockCacheKey identifies a block response, the response depends on fullTx
+type blockCacheKey struct {
+	number uint64
+	fullTx bool
+}
+
+// blockCache keeps the last requested block responses of eth_getBlockByNumber, a nil
+// blockCache is a disabled cache
+type blockCache struct {
+	cache *lru.Cache[blockCacheKey, *types.Block]
+}
+
+// newBlockCache creates a cache for up to size block responses, nil if size is zero
+func newBlockCache(size int) *blockCache {
+	if size <= 0 {
+		return nil
+	}
+	return &blockCache{cache: lru.NewCache[blockCacheKey, *types.Block](size)}
+}
+
+func (c *blockCache) get(number uint64, fullTx bool) (*types.Block, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.cache.Get(blockCacheKey{number: number, fullTx: fullTx})
+}
+
+func (c *blockCache) add(number uint64, fullTx bool, block *types.Block) {
+	if c == nil {
+		return
+	}
+	c.cache.Add(blockCacheKey{number: number, fullTx: fullTx}, block)
+}
+
+// invalidate removes the responses of the block number, a new block with the same number
+// replaces the cached one
+func (c *blockCache) invalidate(number uint64) {
+	if c == nil {
+		return
+	}
+	c.cache.Remove(blockCacheKey{number: number, fullTx: false})
+	c.cache.Remove(blockCacheKey{number: number, fullTx: true})
+}
+
+// pendingBlockCacheTTL is how long a built pending block is reused, the pool pending txs keep
+// changing so the pending block is only cached for a short time to not rebuild it on every request
+const pendingBlockCacheTTL = time.Second
+
+// pendingBlockCache keeps the last built pending block and the parent block it follows, the zero
+// value is an empty cache
+type pendingBlockCache struct {
+	mutex      sync.Mutex
+	parentHash common.Hash
+	block      *state.L2Block
+	builtAt    time.Time
+}
+
+func (c *pendingBlockCache) get(parentHash common.Hash) (*state.L2Block, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.block == nil || c.parentHash != parentHash || time.Since(c.builtAt) >= pendingBlockCacheTTL {
+		return nil, false
+	}
+	return c.block, true
+}
+
+func (c *pendingBlockCache) add(parentHash common.Hash, block *state.L2Block) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.parentHash = parentHash
+	c.block = block
+	c.builtAt = time.Now()
+}
@@ -0,0 +1,104 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockByNumberCache(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	dbTx := mocks.NewDBTxMock(t)
+	st.On("RegisterNewL2BlockEventHandler", mock.Anything).Once()
+
+	cfg := getSequencerDefaultConfig()
+	cfg.BlockCacheSize = 1
+	e := NewEthEndpoints(cfg, chainID, mocks.NewPoolMock(t), st, mocks.NewEthermanMock(t), mocks.NewSequencerMock(t), NewStorage())
+
+	newL2Block := func(number int64, extra string) *state.L2Block {
+		header := &ethTypes.Header{Number: big.NewInt(number), Extra: []byte(extra)}
+		return state.NewL2Block(state.NewL2Header(header), nil, nil, nil, &trie.StackTrie{})
+	}
+	getBlockByNumber := func(number int64, fullTx bool) *types.Block {
+		result, rpcErr := e.GetBlockByNumber(context.Background(), types.BlockNumber(number), fullTx)
+		require.Nil(t, rpcErr)
+		return result.(*types.Block)
+	}
+
+	st.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil)
+	dbTx.On("Commit", mock.Anything).Return(nil)
+
+	// The block is read from the state once, the responses with and without the full txs are cached apart
+	block1 := newL2Block(1, "first")
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(1), dbTx).Return(block1, nil).Twice()
+	st.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(1), dbTx).Return(block1.Header(), nil).Once()
+	assert.Equal(t, block1.Hash(), *getBlockByNumber(1, false).Hash)
+	assert.Equal(t, block1.Hash(), *getBlockByNumber(1, false).Hash)
+	assert.Equal(t, block1.Hash(), *getBlockByNumber(1, true).Hash)
+
+	// A reorged block without a new block event is detected by its stored header
+	reorgedBlock1 := newL2Block(1, "reorged")
+	st.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(1), dbTx).Return(reorgedBlock1.Header(), nil).Once()
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(1), dbTx).Return(reorgedBlock1, nil).Once()
+	assert.Equal(t, reorgedBlock1.Hash(), *getBlockByNumber(1, true).Hash)
+
+	// A new block with the same number replaces the cached one
+	newBlock1 := newL2Block(1, "new")
+	e.onNewL2Block(state.NewL2BlockEvent{Block: *newBlock1})
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(1), dbTx).Return(newBlock1, nil).Once()
+	assert.Equal(t, newBlock1.Hash(), *getBlockByNumber(1, true).Hash)
+
+	// The least recently requested block is evicted
+	block2 := newL2Block(2, "second")
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(2), dbTx).Return(block2, nil).Once()
+	assert.Equal(t, block2.Hash(), *getBlockByNumber(2, true).Hash)
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(1), dbTx).Return(newBlock1, nil).Once()
+	assert.Equal(t, newBlock1.Hash(), *getBlockByNumber(1, true).Hash)
+}
+
+func TestGetPendingBlockCache(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	p := mocks.NewPoolMock(t)
+	dbTx := mocks.NewDBTxMock(t)
+	st.On("RegisterNewL2BlockEventHandler", mock.Anything).Once()
+
+	e := NewEthEndpoints(getSequencerDefaultConfig(), chainID, p, st, mocks.NewEthermanMock(t), mocks.NewSequencerMock(t), NewStorage())
+
+	st.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil)
+	dbTx.On("Commit", mock.Anything).Return(nil)
+
+	getPendingBlock := func() *types.Block {
+		result, rpcErr := e.GetBlockByNumber(context.Background(), types.PendingBlockNumber, false)
+		require.Nil(t, rpcErr)
+		return result.(*types.Block)
+	}
+
+	// The pending block is built from the pool once while its parent is the last block
+	lastBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)}), nil, nil, nil, &trie.StackTrie{})
+	st.On("GetLastL2Block", mock.Anything, dbTx).Return(lastBlock, nil).Twice()
+	p.On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).Return([]pool.Transaction{}, nil).Once()
+	assert.Equal(t, lastBlock.Hash(), getPendingBlock().ParentHash)
+	assert.Equal(t, lastBlock.Hash(), getPendingBlock().ParentHash)
+
+	// A new last block builds the pending block again
+	newLastBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(2)}), nil, nil, nil, &trie.StackTrie{})
+	st.On("GetLastL2Block", mock.Anything, dbTx).Return(newLastBlock, nil).Twice()
+	p.On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).Return([]pool.Transaction{}, nil).Once()
+	assert.Equal(t, newLastBlock.Hash(), getPendingBlock().ParentHash)
+
+	// The cached pending block expires
+	e.pendingBlockCache.builtAt = time.Now().Add(-pendingBlockCacheTTL)
+	p.On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).Return([]pool.Transaction{}, nil).Once()
+	assert.Equal(t, newLastBlock.Hash(), getPendingBlock().ParentHash)
+}
@@ -58,6 +58,26 @@ func (c *Client) BatchByNumber(ctx context.Context, number *big.Int) (*types.Bat
 	return result, nil
 }
 
+// ForcedBatchByNumber returns the forced batch with the provided forced batch number
+func (c *Client) ForcedBatchByNumber(ctx context.Context, forcedBatchNumber uint64) (*types.ForcedBatch, error) {
+	response, err := JSONRPCCall(c.url, "zkevm_getForcedBatchByNumber", hex.EncodeUint64(forcedBatchNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.ForcedBatch
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // ExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (c *Client) ExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (*types.ExitRoots, error) {
 	response, err := JSONRPCCall(c.url, "zkevm_getExitRootsByGER", globalExitRoot.String())
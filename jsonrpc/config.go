@@ -1,6 +1,9 @@
 package jsonrpc
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -21,10 +24,18 @@ type Config struct {
 	// check net/http.server.WriteTimeout
 	WriteTimeout types.Duration `mapstructure:"WriteTimeout"`
 
+	// IdleTimeout is the HTTP server idle timeout, the max time to wait for the next request on a keep-alive connection
+	// check net/http.server.IdleTimeout
+	IdleTimeout types.Duration `mapstructure:"IdleTimeout"`
+
 	// MaxRequestsPerIPAndSecond defines how much requests a single IP can
 	// send within a single second
 	MaxRequestsPerIPAndSecond float64 `mapstructure:"MaxRequestsPerIPAndSecond"`
 
+	// MaxConnections is the max number of open HTTP connections, the new connections are refused
+	// with a 503 response while the limit is reached, if zero the connections are not limited
+	MaxConnections int `mapstructure:"MaxConnections"`
+
 	// SequencerNodeURI is used allow Non-Sequencer nodes
 	// to relay transactions to the Sequencer node
 	SequencerNodeURI string `mapstructure:"SequencerNodeURI"`
@@ -47,6 +58,9 @@ type Config struct {
 	// L2Coinbase defines which address is going to receive the fees
 	L2Coinbase common.Address
 
+	// BroadcastURI is the URI of the data stream, empty if the data stream is disabled
+	BroadcastURI string
+
 	// MaxLogsCount is a configuration to set the max number of logs that can be returned
 	// in a single call to the state, if zero it means no limit
 	MaxLogsCount uint64 `mapstructure:"MaxLogsCount"`
@@ -59,11 +73,85 @@ type Config struct {
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
 
+	// MaxCallGas is the max gas that can be used by eth_call and eth_estimateGas, the gas
+	// requested above it is clamped and reported in the X-Call-Gas-Capped HTTP response header,
+	// if zero the batch gas limit (MaxCumulativeGasUsed) is used
+	MaxCallGas uint64 `mapstructure:"MaxCallGas"`
+
+	// MaxCallGasExecution is the hard ceiling of the gas the executor can use to process
+	// eth_call and eth_estimateGas, the calls that run out of gas at it fail with an
+	// "execution reverted: gas exhausted" error, if zero it means no ceiling
+	MaxCallGasExecution uint64 `mapstructure:"MaxCallGasExecution"`
+
+	// GasEstimationTolerance is the max difference in gas allowed between the value returned
+	// by eth_estimateGas and the lowest gas that makes the tx succeed, a higher tolerance needs
+	// less executions to estimate the gas. If zero the exact value is returned
+	GasEstimationTolerance uint64 `mapstructure:"GasEstimationTolerance"`
+
+	// ConsolidatedBlockNumberCacheTTL is the max age of the cached consolidated block number returned
+	// by zkevm_consolidatedBlockNumber, the cache is refreshed in background, if zero the cache is disabled
+	ConsolidatedBlockNumberCacheTTL types.Duration `mapstructure:"ConsolidatedBlockNumberCacheTTL"`
+
+	// L2BridgeAddress is the address of the bridge contract in L2, its BridgeEvent logs are the leaves of
+	// the local exit tree used by zkevm_getL2ToL1MessageProof, if zero the endpoint is disabled
+	L2BridgeAddress common.Address `mapstructure:"L2BridgeAddress"`
+
+	// BlockCacheSize is the number of block responses of eth_getBlockByNumber kept in memory, the
+	// least recently requested are evicted first, if zero the cache is disabled. A cached response is
+	// only served when its hash matches the stored block header, so reorged blocks are read again
+	BlockCacheSize int `mapstructure:"BlockCacheSize"`
+
+	// AdminAllowedIPs are the IPs and the IP ranges in CIDR notation allowed to call the admin methods,
+	// the IP is the one of the client connection, if empty only the loopback IPs are allowed
+	AdminAllowedIPs []string `mapstructure:"AdminAllowedIPs"`
+
+	// EnableAdminForceBatchProcessing enables admin_forceBatchProcessing, the forced batches injected
+	// with it are not sent to L1 and are only kept in the sequencer memory until they are processed,
+	// so they are lost if the node restarts before. It must only be enabled for testing
+	EnableAdminForceBatchProcessing bool `mapstructure:"EnableAdminForceBatchProcessing"`
+
+	// EnableAdminPruneBatches enables admin_pruneBatches, which deletes the verified batches and
+	// all their L2 blocks, transactions, receipts and logs from the state
+	EnableAdminPruneBatches bool `mapstructure:"EnableAdminPruneBatches"`
+
+	// EnableAdminPauseForcedBatchProcessing enables admin_pauseForcedBatchProcessing, which stops the
+	// sequencer from processing the forced batches for a duration shorter than the L1 force batch timeout
+	EnableAdminPauseForcedBatchProcessing bool `mapstructure:"EnableAdminPauseForcedBatchProcessing"`
+
+	// EnableAdminSetDynamicConfig enables admin_setDynamicConfig, which changes the finalizer timing
+	// parameters of the sequencer until the node restarts
+	EnableAdminSetDynamicConfig bool `mapstructure:"EnableAdminSetDynamicConfig"`
+
+	// PruneBatchesChunkSize is the max number of batches admin_pruneBatches deletes in each db
+	// transaction, if zero DefaultPruneBatchesChunkSize is used
+	PruneBatchesChunkSize uint64 `mapstructure:"PruneBatchesChunkSize"`
+
+	// ProtocolVersion is the protocol version of the network returned by eth_protocolVersion
+	ProtocolVersion string `mapstructure:"ProtocolVersion"`
+
+	// IdempotencyKeyTTL is how long the response of a request sent with the X-Idempotency-Key header
+	// is returned to the retries with the same key from the same client address and authorization, if
+	// zero the header is ignored. The responses with a JSON-RPC error are not kept
+	IdempotencyKeyTTL types.Duration `mapstructure:"IdempotencyKeyTTL"`
+
+	// FilterTTL is the time after which the filters created by eth_newFilter, eth_newBlockFilter and
+	// eth_newPendingTransactionFilter are uninstalled if they are not polled, if zero they never expire
+	FilterTTL types.Duration `mapstructure:"FilterTTL"`
+
 	// EnableHttpLog allows the user to enable or disable the logs related to the HTTP
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 }
 
+// Validate checks the json rpc config, it returns an error listing all the invalid fields
+func (c Config) Validate() error {
+	var errs []error
+	if _, err := newIPAllowlist(c.AdminAllowedIPs); err != nil {
+		errs = append(errs, fmt.Errorf("invalid RPC.AdminAllowedIPs: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
 // WebSocketsConfig has parameters to config the rpc websocket support
 type WebSocketsConfig struct {
 	// Enabled defines if the WebSocket requests are enabled or disabled
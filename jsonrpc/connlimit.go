@@ -0,0 +1,113 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// connLimitRejectTimeout is the max time to send the response to a refused connection
+	connLimitRejectTimeout = time.Second
+	// connLimitMaxRejects is the max number of refused connections receiving the response at the
+	// same time, the ones refused beyond it are closed right away without response
+	connLimitMaxRejects = 64
+	// connLimitRejectDrainSize is the max size of the request discarded from a refused connection
+	connLimitRejectDrainSize = 64 * 1024
+)
+
+// connLimitRejectBody is the body of the response to the connections refused by connLimitListener
+const connLimitRejectBody = "too many open connections"
+
+// connLimitRejectResponse is written to the connections refused by connLimitListener
+var connLimitRejectResponse = []byte(fmt.Sprintf("HTTP/1.1 503 Service Unavailable\r\nContent-Type: text/plain\r\nContent-Length: %d\r\nConnection: close\r\n\r\n%s",
+	len(connLimitRejectBody), connLimitRejectBody))
+
+// connLimitListener is a net.Listener that refuses the new connections with a 503 response
+// while maxConns connections are open, so the server doesn't exhaust the file descriptors.
+// Unlike netutil.LimitListener the connections are not queued, the clients know right away
+// the server is busy
+type connLimitListener struct {
+	net.Listener
+	maxConns int64
+	active   atomic.Int64
+	rejects  chan struct{}
+}
+
+// newConnLimitListener wraps the listener, if maxConns is zero the connections are not limited
+func newConnLimitListener(l net.Listener, maxConns int) *connLimitListener {
+	return &connLimitListener{Listener: l, maxConns: int64(maxConns), rejects: make(chan struct{}, connLimitMaxRejects)}
+}
+
+// Accept waits for the next connection that fits in the limit
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		active := l.active.Add(1)
+		if l.maxConns > 0 && active > l.maxConns {
+			l.active.Add(-1)
+			log.Debugf("connection from %s refused, the limit of %d open connections is reached", conn.RemoteAddr(), l.maxConns)
+			select {
+			case l.rejects <- struct{}{}:
+				go l.reject(conn)
+			default:
+				closeRefusedConn(conn)
+			}
+			continue
+		}
+		metrics.ActiveConnections(active)
+		return &connLimitConn{Conn: conn, release: l.release}, nil
+	}
+}
+
+func (l *connLimitListener) release() {
+	metrics.ActiveConnections(l.active.Add(-1))
+}
+
+// reject writes the 503 response and closes the connection, releasing its slot of the rejects.
+// The first connLimitRejectDrainSize bytes of the request are discarded before closing it, closing
+// it with unread data would reset it before the client reads the response
+func (l *connLimitListener) reject(conn net.Conn) {
+	defer func() {
+		closeRefusedConn(conn)
+		<-l.rejects
+	}()
+	if err := conn.SetDeadline(time.Now().Add(connLimitRejectTimeout)); err != nil {
+		log.Debugf("failed to set the deadline of the refused connection: %v", err)
+		return
+	}
+	if _, err := conn.Write(connLimitRejectResponse); err != nil {
+		log.Debugf("failed to write the response to the refused connection: %v", err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(conn, connLimitRejectDrainSize))
+}
+
+func closeRefusedConn(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		log.Debugf("failed to close the refused connection: %v", err)
+	}
+}
+
+// connLimitConn releases its slot of the connLimitListener when it's closed
+type connLimitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+// Close closes the connection, only the first call releases the slot
+func (c *connLimitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
@@ -0,0 +1,98 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnLimitListener(t *testing.T) {
+	tcpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := newConnLimitListener(tcpLis, 1)
+	defer lis.Close()
+
+	accepted := make(chan net.Conn)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	// The first connection fits in the limit
+	first, err := net.Dial("tcp", tcpLis.Addr().String())
+	require.NoError(t, err)
+	defer first.Close()
+	firstAccepted := <-accepted
+	assert.Equal(t, int64(1), lis.active.Load())
+
+	// The second one is refused with a 503 response
+	second, err := net.Dial("tcp", tcpLis.Addr().String())
+	require.NoError(t, err)
+	defer second.Close()
+	req, err := http.NewRequest(http.MethodPost, "http://"+tcpLis.Addr().String(), nil)
+	require.NoError(t, err)
+	require.NoError(t, req.Write(second))
+	res, err := http.ReadResponse(bufio.NewReader(second), req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+
+	// Closing the accepted connection releases its slot, even if it's closed twice
+	require.NoError(t, firstAccepted.Close())
+	assert.Error(t, firstAccepted.Close())
+	assert.Equal(t, int64(0), lis.active.Load())
+
+	third, err := net.Dial("tcp", tcpLis.Addr().String())
+	require.NoError(t, err)
+	defer third.Close()
+	select {
+	case conn := <-accepted:
+		require.NoError(t, conn.Close())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection was not accepted after the slot was released")
+	}
+}
+
+func TestConnLimitListenerMaxRejects(t *testing.T) {
+	tcpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := newConnLimitListener(tcpLis, 1)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	first, err := net.Dial("tcp", tcpLis.Addr().String())
+	require.NoError(t, err)
+	defer first.Close()
+
+	// While connLimitMaxRejects refused connections are receiving the response, the next
+	// ones are closed without response
+	for i := 0; i < connLimitMaxRejects; i++ {
+		lis.rejects <- struct{}{}
+	}
+	refused, err := net.Dial("tcp", tcpLis.Addr().String())
+	require.NoError(t, err)
+	defer refused.Close()
+	require.NoError(t, refused.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, err := refused.Read(make([]byte, len(connLimitRejectResponse)))
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, io.EOF)
+}
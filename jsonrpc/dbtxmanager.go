@@ -18,9 +18,8 @@ type DBTxer interface {
 	BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 }
 
-// NewDbTxScope function to initiate DB scopped txs
-func (f *DBTxManager) NewDbTxScope(db DBTxer, scopedFn DBTxScopedFn) (interface{}, types.Error) {
-	ctx := context.Background()
+// NewDbTxScope function to initiate DB scopped txs, the scoped function gets the ctx of the request
+func (f *DBTxManager) NewDbTxScope(ctx context.Context, db DBTxer, scopedFn DBTxScopedFn) (interface{}, types.Error) {
 	dbTx, err := db.BeginStateTransaction(ctx)
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to connect to the state", err, true)
@@ -28,13 +27,13 @@ func (f *DBTxManager) NewDbTxScope(db DBTxer, scopedFn DBTxScopedFn) (interface{
 
 	v, rpcErr := scopedFn(ctx, dbTx)
 	if rpcErr != nil {
-		if txErr := dbTx.Rollback(context.Background()); txErr != nil {
+		if txErr := dbTx.Rollback(ctx); txErr != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to rollback db transaction", txErr, true)
 		}
 		return v, rpcErr
 	}
 
-	if txErr := dbTx.Commit(context.Background()); txErr != nil {
+	if txErr := dbTx.Commit(ctx); txErr != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to commit db transaction", txErr, true)
 	}
 	return v, rpcErr
@@ -7,11 +7,15 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewDbTxScope(t *testing.T) {
+	// the db tx and the scoped func use the ctx of the request
+	ctx := log.CtxWithLogger(context.Background(), log.WithFields(correlationIDLogField, "correlation id"))
+
 	type testCase struct {
 		Name           string
 		Fn             DBTxScopedFn
@@ -23,14 +27,15 @@ func TestNewDbTxScope(t *testing.T) {
 	testCases := []testCase{
 		{
 			Name: "Run scoped func commits DB tx",
-			Fn: func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+			Fn: func(scopedCtx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+				assert.Equal(t, ctx, scopedCtx)
 				return 1, nil
 			},
 			ExpectedResult: 1,
 			ExpectedError:  nil,
 			SetupMocks: func(s *mocks.StateMock, d *mocks.DBTxMock) {
-				d.On("Commit", context.Background()).Return(nil).Once()
-				s.On("BeginStateTransaction", context.Background()).Return(d, nil).Once()
+				d.On("Commit", ctx).Return(nil).Once()
+				s.On("BeginStateTransaction", ctx).Return(d, nil).Once()
 			},
 		},
 		{
@@ -41,8 +46,8 @@ func TestNewDbTxScope(t *testing.T) {
 			ExpectedResult: nil,
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "func returned an error"),
 			SetupMocks: func(s *mocks.StateMock, d *mocks.DBTxMock) {
-				d.On("Rollback", context.Background()).Return(nil).Once()
-				s.On("BeginStateTransaction", context.Background()).Return(d, nil).Once()
+				d.On("Rollback", ctx).Return(nil).Once()
+				s.On("BeginStateTransaction", ctx).Return(d, nil).Once()
 			},
 		},
 		{
@@ -53,7 +58,7 @@ func TestNewDbTxScope(t *testing.T) {
 			ExpectedResult: nil,
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to connect to the state"),
 			SetupMocks: func(s *mocks.StateMock, d *mocks.DBTxMock) {
-				s.On("BeginStateTransaction", context.Background()).Return(nil, errors.New("failed to create db tx")).Once()
+				s.On("BeginStateTransaction", ctx).Return(nil, errors.New("failed to create db tx")).Once()
 			},
 		},
 		{
@@ -64,8 +69,8 @@ func TestNewDbTxScope(t *testing.T) {
 			ExpectedResult: nil,
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to commit db transaction"),
 			SetupMocks: func(s *mocks.StateMock, d *mocks.DBTxMock) {
-				d.On("Commit", context.Background()).Return(errors.New("failed to commit db tx")).Once()
-				s.On("BeginStateTransaction", context.Background()).Return(d, nil).Once()
+				d.On("Commit", ctx).Return(errors.New("failed to commit db tx")).Once()
+				s.On("BeginStateTransaction", ctx).Return(d, nil).Once()
 			},
 		},
 		{
@@ -76,8 +81,8 @@ func TestNewDbTxScope(t *testing.T) {
 			ExpectedResult: nil,
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to rollback db transaction"),
 			SetupMocks: func(s *mocks.StateMock, d *mocks.DBTxMock) {
-				d.On("Rollback", context.Background()).Return(errors.New("failed to rollback db tx")).Once()
-				s.On("BeginStateTransaction", context.Background()).Return(d, nil).Once()
+				d.On("Rollback", ctx).Return(errors.New("failed to rollback db tx")).Once()
+				s.On("BeginStateTransaction", ctx).Return(d, nil).Once()
 			},
 		},
 	}
@@ -91,7 +96,7 @@ func TestNewDbTxScope(t *testing.T) {
 			tc := testCase
 			tc.SetupMocks(s, d)
 
-			result, err := dbTxManager.NewDbTxScope(s, tc.Fn)
+			result, err := dbTxManager.NewDbTxScope(ctx, s, tc.Fn)
 			assert.Equal(t, tc.ExpectedResult, result)
 			assert.Equal(t, tc.ExpectedError, err)
 		})
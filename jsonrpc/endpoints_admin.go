@@ -0,0 +1,178 @@
+package jsonrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultPruneBatchesChunkSize is the number of batches admin_pruneBatches deletes in each db
+// transaction when RPC.PruneBatchesChunkSize is not set
+const DefaultPruneBatchesChunkSize = 100
+
+// AdminEndpoints contains implementations for the "admin" RPC endpoints
+type AdminEndpoints struct {
+	cfg       Config
+	state     types.StateInterface
+	sequencer types.SequencerInterface
+	txMan     DBTxManager
+}
+
+// NewAdminEndpoints returns AdminEndpoints. The sequencer endpoints are only available when
+// the sequencer is running in the same instance
+func NewAdminEndpoints(cfg Config, state types.StateInterface, sequencer types.SequencerInterface) *AdminEndpoints {
+	return &AdminEndpoints{
+		cfg:       cfg,
+		state:     state,
+		sequencer: sequencer,
+	}
+}
+
+// ForceBatchProcessing injects a forced batch in the sequencer without sending it to L1,
+// it returns the forced batch number assigned to it, which is numbered apart from the L1 forced
+// batches. It's intended for testing purposes and
+// it's only available when RPC.EnableAdminForceBatchProcessing is set
+func (a *AdminEndpoints) ForceBatchProcessing(ctx context.Context, rawTxsData types.ArgBytes, globalExitRoot common.Hash, forcedAt types.ArgUint64) (interface{}, types.Error) {
+	if !a.cfg.EnableAdminForceBatchProcessing || a.sequencer == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_forceBatchProcessing does not exist/is not available", nil, false)
+	}
+
+	forcedBatchNumber, err := a.sequencer.ForceBatchProcessing(ctx, rawTxsData, globalExitRoot, time.Unix(int64(forcedAt), 0))
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to inject forced batch, %s", err, true, err.Error())
+	}
+
+	return hex.EncodeUint64(forcedBatchNumber), nil
+}
+
+// GetSequencerState returns a snapshot of the sequencer internal state. Unlike the rest of
+// the admin endpoints it's available in all the environments when the sequencer is running
+// in the same instance
+func (a *AdminEndpoints) GetSequencerState() (interface{}, types.Error) {
+	if a.sequencer == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_getSequencerState does not exist/is not available", nil, false)
+	}
+
+	seqState, err := a.sequencer.GetSequencerState()
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get sequencer state, %s", err, true, err.Error())
+	}
+
+	return seqState, nil
+}
+
+// SetDynamicConfig changes a finalizer timing parameter of the sequencer without restarting the node,
+// the value is a duration expressed in units (e.g. "3s"). The change only lasts until the node restarts
+// or the config file is re-read. It's only available when RPC.EnableAdminSetDynamicConfig is set
+func (a *AdminEndpoints) SetDynamicConfig(field string, value string) (interface{}, types.Error) {
+	if !a.cfg.EnableAdminSetDynamicConfig || a.sequencer == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_setDynamicConfig does not exist/is not available", nil, false)
+	}
+
+	err := a.sequencer.SetDynamicConfig(field, value)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to set dynamic config, %s", err, true, err.Error())
+	}
+
+	return nil, nil
+}
+
+// PauseForcedBatchProcessing pauses the processing of the forced batches in the sequencer for the duration
+// (e.g. "30m"), the regular batches are still built and the pause expires automatically. A "0s" duration
+// resumes the processing. The duration must be shorter than the L1 force batch timeout, otherwise anyone
+// could sequence the delayed forced batches. It's only available when RPC.EnableAdminPauseForcedBatchProcessing
+// is set
+func (a *AdminEndpoints) PauseForcedBatchProcessing(duration string) (interface{}, types.Error) {
+	if !a.cfg.EnableAdminPauseForcedBatchProcessing || a.sequencer == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_pauseForcedBatchProcessing does not exist/is not available", nil, false)
+	}
+
+	pauseDuration, err := time.ParseDuration(duration)
+	if err != nil || pauseDuration < 0 {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid pause duration %q", nil, false, duration)
+	}
+
+	err = a.sequencer.PauseForcedBatchProcessing(pauseDuration)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to pause forced batch processing, %s", err, true, err.Error())
+	}
+
+	return nil, nil
+}
+
+// PruneBatches deletes the batches older than beforeBatchNumber that are already verified on L1,
+// along with their L2 blocks, transactions, receipts and logs, and returns the number of deleted
+// batches. The batches are deleted in chunks of RPC.PruneBatchesChunkSize, each one in its own db
+// transaction, so an error leaves the chunks already deleted. With dryRun the batches are only
+// counted. It's only available when RPC.EnableAdminPruneBatches is set
+func (a *AdminEndpoints) PruneBatches(ctx context.Context, beforeBatchNumber types.ArgUint64, dryRun bool) (interface{}, types.Error) {
+	if !a.cfg.EnableAdminPruneBatches || a.state == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_pruneBatches does not exist/is not available", nil, false)
+	}
+
+	if dryRun {
+		return a.txMan.NewDbTxScope(ctx, a.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+			count, err := a.state.CountPrunableBatches(ctx, uint64(beforeBatchNumber), dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to count the batches to prune", err, true)
+			}
+			return hex.EncodeUint64(uint64(count)), nil
+		})
+	}
+
+	chunkSize := a.cfg.PruneBatchesChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultPruneBatchesChunkSize
+	}
+
+	var total int64
+	for {
+		count, err := a.pruneBatchesChunk(ctx, uint64(beforeBatchNumber), chunkSize)
+		if err != nil {
+			log.Errorf("failed to prune batches after pruning %d verified batches before batch %d", total, beforeBatchNumber)
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to prune batches", err, true)
+		}
+		total += count
+		if count < int64(chunkSize) {
+			break
+		}
+	}
+	log.Infof("pruned %d verified batches before batch %d", total, beforeBatchNumber)
+	return hex.EncodeUint64(uint64(total)), nil
+}
+
+// pruneBatchesChunk deletes up to chunkSize verified batches in a db transaction
+func (a *AdminEndpoints) pruneBatchesChunk(ctx context.Context, beforeBatchNumber uint64, chunkSize uint64) (int64, error) {
+	dbTx, err := a.state.BeginStateTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, err := a.state.PruneBatches(ctx, beforeBatchNumber, chunkSize, dbTx)
+	if err != nil {
+		if rollbackErr := dbTx.Rollback(ctx); rollbackErr != nil {
+			log.Errorf("failed to rollback the prune batches db transaction, err: %v", rollbackErr)
+		}
+		return 0, err
+	}
+	return count, dbTx.Commit(ctx)
+}
+
+// RotateAuditLog closes the current state audit log file, signs it with HMAC-SHA256 and continues
+// writing to a new file. It returns the path and the signature of the closed file
+func (a *AdminEndpoints) RotateAuditLog() (interface{}, types.Error) {
+	if a.state == nil {
+		return RPCErrorResponse(types.NotFoundErrorCode, "the method admin_rotateAuditLog does not exist/is not available", nil, false)
+	}
+
+	rotation, err := a.state.RotateAuditLog()
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to rotate the audit log, %s", err, true, err.Error())
+	}
+
+	return rotation, nil
+}
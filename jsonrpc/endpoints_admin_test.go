@@ -0,0 +1,421 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForceBatchProcessing(t *testing.T) {
+	rawTxsData := types.ArgBytes{0x1, 0x2, 0x3}
+	globalExitRoot := common.HexToHash("0x1")
+	forcedAt := types.ArgUint64(1700000000)
+
+	type testCase struct {
+		Name              string
+		Enabled           bool
+		WithSequencer     bool
+		ExpectedResult    interface{}
+		ExpectedErrorCode int
+		SetupMocks        func(m *mocks.SequencerMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:              "disabled by the config",
+			Enabled:           false,
+			WithSequencer:     true,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "disabled when the sequencer is not running",
+			Enabled:           true,
+			WithSequencer:     false,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "sequencer fails to add the forced batch",
+			Enabled:           true,
+			WithSequencer:     true,
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("ForceBatchProcessing", context.Background(), []byte(rawTxsData), globalExitRoot, time.Unix(int64(forcedAt), 0)).
+					Return(uint64(0), errors.New("sequencer not started")).
+					Once()
+			},
+		},
+		{
+			Name:           "forced batch added successfully",
+			Enabled:        true,
+			WithSequencer:  true,
+			ExpectedResult: "0x5",
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("ForceBatchProcessing", context.Background(), []byte(rawTxsData), globalExitRoot, time.Unix(int64(forcedAt), 0)).
+					Return(uint64(5), nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sequencer types.SequencerInterface
+			if tc.WithSequencer {
+				sequencerMock := mocks.NewSequencerMock(t)
+				if tc.SetupMocks != nil {
+					tc.SetupMocks(sequencerMock)
+				}
+				sequencer = sequencerMock
+			}
+
+			a := NewAdminEndpoints(Config{EnableAdminForceBatchProcessing: tc.Enabled}, nil, sequencer)
+			result, rpcErr := a.ForceBatchProcessing(context.Background(), rawTxsData, globalExitRoot, forcedAt)
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
+
+func TestGetSequencerState(t *testing.T) {
+	seqState := state.SequencerState{
+		BatchNumber:          10,
+		PendingForcedBatches: 2,
+		StoredFlushID:        5,
+		LastPendingFlushID:   7,
+		CurrentGERHash:       common.HexToHash("0x1"),
+		SequencerAddress:     common.HexToAddress("0x2"),
+	}
+
+	type testCase struct {
+		Name              string
+		WithSequencer     bool
+		ExpectedResult    interface{}
+		ExpectedErrorCode int
+		SetupMocks        func(m *mocks.SequencerMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:              "disabled when the sequencer is not running",
+			WithSequencer:     false,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "sequencer not started",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("GetSequencerState").
+					Return(state.SequencerState{}, errors.New("sequencer not started")).
+					Once()
+			},
+		},
+		{
+			Name:           "sequencer state returned successfully",
+			WithSequencer:  true,
+			ExpectedResult: seqState,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("GetSequencerState").
+					Return(seqState, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sequencer types.SequencerInterface
+			if tc.WithSequencer {
+				sequencerMock := mocks.NewSequencerMock(t)
+				if tc.SetupMocks != nil {
+					tc.SetupMocks(sequencerMock)
+				}
+				sequencer = sequencerMock
+			}
+
+			a := NewAdminEndpoints(Config{}, nil, sequencer)
+			result, rpcErr := a.GetSequencerState()
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
+
+func TestSetDynamicConfig(t *testing.T) {
+	type testCase struct {
+		Name              string
+		Disabled          bool
+		WithSequencer     bool
+		ExpectedErrorCode int
+		SetupMocks        func(m *mocks.SequencerMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:              "disabled when the sequencer is not running",
+			WithSequencer:     false,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "disabled by config",
+			Disabled:          true,
+			WithSequencer:     true,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "invalid field",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("SetDynamicConfig", "L2BlockTime", "5s").
+					Return(errors.New("unknown dynamic config field")).
+					Once()
+			},
+		},
+		{
+			Name:          "dynamic config set successfully",
+			WithSequencer: true,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("SetDynamicConfig", "L2BlockTime", "5s").
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sequencer types.SequencerInterface
+			if tc.WithSequencer {
+				sequencerMock := mocks.NewSequencerMock(t)
+				if tc.SetupMocks != nil {
+					tc.SetupMocks(sequencerMock)
+				}
+				sequencer = sequencerMock
+			}
+
+			a := NewAdminEndpoints(Config{EnableAdminSetDynamicConfig: !tc.Disabled}, nil, sequencer)
+			result, rpcErr := a.SetDynamicConfig("L2BlockTime", "5s")
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestPruneBatches(t *testing.T) {
+	const beforeBatchNumber = uint64(100)
+	const chunkSize = uint64(2)
+
+	type testCase struct {
+		Name              string
+		DryRun            bool
+		ExpectedResult    interface{}
+		ExpectedErrorCode int
+		SetupMocks        func(s *mocks.StateMock, dbTx *mocks.DBTxMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "dry run only counts the batches",
+			DryRun:         true,
+			ExpectedResult: "0x5",
+			SetupMocks: func(s *mocks.StateMock, dbTx *mocks.DBTxMock) {
+				s.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Once()
+				s.On("CountPrunableBatches", context.Background(), beforeBatchNumber, dbTx).Return(int64(5), nil).Once()
+				dbTx.On("Commit", context.Background()).Return(nil).Once()
+			},
+		},
+		{
+			Name:           "batches pruned in chunks",
+			ExpectedResult: "0x5",
+			SetupMocks: func(s *mocks.StateMock, dbTx *mocks.DBTxMock) {
+				s.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Times(3)
+				s.On("PruneBatches", context.Background(), beforeBatchNumber, chunkSize, dbTx).Return(int64(2), nil).Twice()
+				s.On("PruneBatches", context.Background(), beforeBatchNumber, chunkSize, dbTx).Return(int64(1), nil).Once()
+				dbTx.On("Commit", context.Background()).Return(nil).Times(3)
+			},
+		},
+		{
+			Name:              "failed to prune batches",
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(s *mocks.StateMock, dbTx *mocks.DBTxMock) {
+				s.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Twice()
+				s.On("PruneBatches", context.Background(), beforeBatchNumber, chunkSize, dbTx).Return(int64(2), nil).Once()
+				s.On("PruneBatches", context.Background(), beforeBatchNumber, chunkSize, dbTx).Return(int64(0), errors.New("failed to delete")).Once()
+				dbTx.On("Commit", context.Background()).Return(nil).Once()
+				dbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+	}
+
+	cfg := Config{EnableAdminPruneBatches: true, PruneBatchesChunkSize: chunkSize}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stateMock := mocks.NewStateMock(t)
+			dbTx := mocks.NewDBTxMock(t)
+			tc.SetupMocks(stateMock, dbTx)
+
+			a := NewAdminEndpoints(cfg, stateMock, nil)
+			result, rpcErr := a.PruneBatches(context.Background(), types.ArgUint64(beforeBatchNumber), tc.DryRun)
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+
+	// Not available without state or when it's not enabled
+	a := NewAdminEndpoints(cfg, nil, nil)
+	_, rpcErr := a.PruneBatches(context.Background(), types.ArgUint64(beforeBatchNumber), true)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.NotFoundErrorCode, rpcErr.ErrorCode())
+
+	a = NewAdminEndpoints(Config{}, mocks.NewStateMock(t), nil)
+	_, rpcErr = a.PruneBatches(context.Background(), types.ArgUint64(beforeBatchNumber), false)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.NotFoundErrorCode, rpcErr.ErrorCode())
+}
+
+func TestRotateAuditLog(t *testing.T) {
+	a := NewAdminEndpoints(Config{}, nil, nil)
+	_, rpcErr := a.RotateAuditLog()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.NotFoundErrorCode, rpcErr.ErrorCode())
+
+	stateMock := mocks.NewStateMock(t)
+	a = NewAdminEndpoints(Config{}, stateMock, nil)
+	stateMock.On("RotateAuditLog").Return(nil, state.ErrAuditLogDisabled).Once()
+	_, rpcErr = a.RotateAuditLog()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.DefaultErrorCode, rpcErr.ErrorCode())
+
+	rotation := &state.AuditLogRotation{File: "audit.log.20240101T000000.000000000Z", HMAC: "0x1"}
+	stateMock.On("RotateAuditLog").Return(rotation, nil).Once()
+	result, rpcErr := a.RotateAuditLog()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, rotation, result)
+}
+
+func TestPauseForcedBatchProcessing(t *testing.T) {
+	type testCase struct {
+		Name              string
+		Duration          string
+		Disabled          bool
+		WithSequencer     bool
+		ExpectedErrorCode int
+		SetupMocks        func(m *mocks.SequencerMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:              "disabled when the sequencer is not running",
+			Duration:          "30m",
+			WithSequencer:     false,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "disabled by config",
+			Duration:          "30m",
+			Disabled:          true,
+			WithSequencer:     true,
+			ExpectedErrorCode: types.NotFoundErrorCode,
+		},
+		{
+			Name:              "invalid duration",
+			Duration:          "30 minutes",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.InvalidParamsErrorCode,
+		},
+		{
+			Name:              "negative duration",
+			Duration:          "-30m",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.InvalidParamsErrorCode,
+		},
+		{
+			Name:              "sequencer not started",
+			Duration:          "30m",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("PauseForcedBatchProcessing", 30*time.Minute).
+					Return(errors.New("sequencer not started")).
+					Once()
+			},
+		},
+		{
+			Name:          "forced batch processing paused",
+			Duration:      "30m",
+			WithSequencer: true,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("PauseForcedBatchProcessing", 30*time.Minute).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name:          "forced batch processing resumed",
+			Duration:      "0s",
+			WithSequencer: true,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("PauseForcedBatchProcessing", time.Duration(0)).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sequencer types.SequencerInterface
+			if tc.WithSequencer {
+				sequencerMock := mocks.NewSequencerMock(t)
+				if tc.SetupMocks != nil {
+					tc.SetupMocks(sequencerMock)
+				}
+				sequencer = sequencerMock
+			}
+
+			a := NewAdminEndpoints(Config{EnableAdminPauseForcedBatchProcessing: !tc.Disabled}, nil, sequencer)
+			result, rpcErr := a.PauseForcedBatchProcessing(tc.Duration)
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -63,16 +63,16 @@ type traceBatchTransactionResponse struct {
 
 // TraceTransaction creates a response for debug_traceTransaction request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtracetransaction
-func (d *DebugEndpoints) TraceTransaction(hash types.ArgHash, cfg *traceConfig) (interface{}, types.Error) {
-	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (d *DebugEndpoints) TraceTransaction(ctx context.Context, hash types.ArgHash, cfg *traceConfig) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(ctx, d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		return d.buildTraceTransaction(ctx, hash.Hash(), cfg, dbTx)
 	})
 }
 
 // TraceBlockByNumber creates a response for debug_traceBlockByNumber request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtraceblockbynumber
-func (d *DebugEndpoints) TraceBlockByNumber(number types.BlockNumber, cfg *traceConfig) (interface{}, types.Error) {
-	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (d *DebugEndpoints) TraceBlockByNumber(ctx context.Context, number types.BlockNumber, cfg *traceConfig) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(ctx, d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, d.state, d.etherman, dbTx)
 		if rpcErr != nil {
 			return nil, rpcErr
@@ -80,7 +80,7 @@ func (d *DebugEndpoints) TraceBlockByNumber(number types.BlockNumber, cfg *trace
 
 		block, err := d.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
 		if errors.Is(err, state.ErrNotFound) {
-			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block #%d not found", blockNumber))
+			return nil, types.NewRPCError(types.DefaultErrorCode, "block #%d not found", blockNumber)
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
 		}
@@ -96,11 +96,11 @@ func (d *DebugEndpoints) TraceBlockByNumber(number types.BlockNumber, cfg *trace
 
 // TraceBlockByHash creates a response for debug_traceBlockByHash request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtraceblockbyhash
-func (d *DebugEndpoints) TraceBlockByHash(hash types.ArgHash, cfg *traceConfig) (interface{}, types.Error) {
-	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (d *DebugEndpoints) TraceBlockByHash(ctx context.Context, hash types.ArgHash, cfg *traceConfig) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(ctx, d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		block, err := d.state.GetL2BlockByHash(ctx, hash.Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
-			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block %s not found", hash.Hash().String()))
+			return nil, types.NewRPCError(types.DefaultErrorCode, "block %s not found", hash.Hash().String())
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by hash", err, true)
 		}
@@ -131,7 +131,7 @@ func (d *DebugEndpoints) TraceBlockByHash(hash types.ArgHash, cfg *traceConfig)
 // -> jRPC balancer sends each request to a different jRPC to handle the trace transaction requests
 // -> picked jRPC server group trace transaction responses from other jRPC servers
 // -> picked jRPC respond the initial request to the user with all the tx traces
-func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number types.BatchNumber, cfg *traceConfig) (interface{}, types.Error) {
+func (d *DebugEndpoints) TraceBatchByNumber(ctx context.Context, httpRequest *http.Request, number types.BatchNumber, cfg *traceConfig) (interface{}, types.Error) {
 	type traceResponse struct {
 		blockNumber uint64
 		txIndex     uint64
@@ -144,7 +144,7 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 	// how many txs it will process in parallel.
 	const bufferSize = 10
 
-	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(ctx, d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		batchNumber, rpcErr := number.GetNumericBatchNumber(ctx, d.state, d.etherman, dbTx)
 		if rpcErr != nil {
 			return nil, rpcErr
@@ -152,21 +152,21 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 
 		batch, err := d.state.GetBatchByNumber(ctx, batchNumber, dbTx)
 		if errors.Is(err, state.ErrNotFound) {
-			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("batch #%d not found", batchNumber))
+			return nil, types.NewRPCError(types.DefaultErrorCode, "batch #%d not found", batchNumber)
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get batch by number", err, true)
 		}
 
 		txs, _, err := d.state.GetTransactionsByBatchNumber(ctx, batch.BatchNumber, dbTx)
 		if !errors.Is(err, state.ErrNotFound) && err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch txs from state by number %v to create the traces", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load batch txs from state by number %v to create the traces", err, true, batchNumber)
 		}
 
 		receipts := make([]ethTypes.Receipt, 0, len(txs))
 		for _, tx := range txs {
 			receipt, err := d.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
 			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v to get trace", tx.Hash().String()), err, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipt for tx %v to get trace", err, true, tx.Hash().String())
 			}
 			receipts = append(receipts, *receipt)
 		}
@@ -187,7 +187,7 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 			}
 
 			defer wg.Done()
-			trace, err := d.TraceTransaction(types.ArgHash(receipt.TxHash), cfg)
+			trace, err := d.TraceTransaction(ctx, types.ArgHash(receipt.TxHash), cfg)
 			if err != nil {
 				err := fmt.Errorf("failed to get tx trace for tx %v, err: %w", receipt.TxHash.String(), err)
 				log.Errorf(err.Error())
@@ -219,8 +219,9 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 		}
 
 		// wait the traces to be loaded
-		if waitTimeout(&wg, d.cfg.ReadTimeout.Duration) {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get traces for batch %v: timeout reached", batchNumber), nil, true)
+		// the response must be written before the HTTP server write timeout
+		if waitTimeout(&wg, d.cfg.WriteTimeout.Duration) {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get traces for batch %v: timeout reached", nil, true, batchNumber)
 		}
 
 		close(requests)
@@ -241,7 +242,7 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 		traces := make([]traceBatchTransactionResponse, 0, len(receipts))
 		for _, response := range responses {
 			if response.err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get traces for batch %v: failed to get trace for tx: %v, err: %v", batchNumber, response.txHash.String(), response.err.Error()), nil, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get traces for batch %v: failed to get trace for tx: %v, err: %v", nil, true, batchNumber, response.txHash.String(), response.err.Error())
 			}
 
 			traces = append(traces, traceBatchTransactionResponse{
@@ -258,8 +259,7 @@ func (d *DebugEndpoints) buildTraceBlock(ctx context.Context, txs []*ethTypes.Tr
 	for _, tx := range txs {
 		traceTransaction, err := d.buildTraceTransaction(ctx, tx.Hash(), cfg, dbTx)
 		if err != nil {
-			errMsg := fmt.Sprintf("failed to get trace for transaction %v: %v", tx.Hash().String(), err.Error())
-			return RPCErrorResponse(types.DefaultErrorCode, errMsg, err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get trace for transaction %v: %v", err, true, tx.Hash().String(), err.Error())
 		}
 		traceBlockTransaction := traceBlockTransactionResponse{
 			Result: traceTransaction,
@@ -288,8 +288,7 @@ func (d *DebugEndpoints) buildTraceTransaction(ctx context.Context, hash common.
 	if errors.Is(err, state.ErrNotFound) {
 		return RPCErrorResponse(types.DefaultErrorCode, "transaction not found", nil, false)
 	} else if err != nil {
-		errorMessage := fmt.Sprintf("failed to get trace: %v", err.Error())
-		return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get trace: %v", err.Error())
 	}
 
 	return result.TraceResult, nil
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,36 +22,59 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/jackc/pgx/v4"
 )
 
 const (
 	// maxTopics is the max number of topics a log can have
 	maxTopics = 4
+	// maxPendingBlockTxs is the max number of pending txs read from the pool to build the pending block
+	maxPendingBlockTxs = 1000
+	// gasPriceNotInitializedErrMsg is returned while the gas price suggester hasn't set any gas price
+	gasPriceNotInitializedErrMsg = "the gas price is not available yet, the gas price oracle is not initialized"
+	// gasExhaustedErrMsg is returned when eth_call or eth_estimateGas run out of gas at the MaxCallGasExecution ceiling
+	gasExhaustedErrMsg = "execution reverted: gas exhausted"
 )
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
 type EthEndpoints struct {
-	cfg      Config
-	chainID  uint64
-	pool     types.PoolInterface
-	state    types.StateInterface
-	etherman types.EthermanInterface
-	storage  storageInterface
-	txMan    DBTxManager
+	cfg       Config
+	chainID   uint64
+	pool      types.PoolInterface
+	state     types.StateInterface
+	etherman  types.EthermanInterface
+	sequencer types.SequencerInterface
+	storage   storageInterface
+	txMan     DBTxManager
+
+	// blockCache keeps the last responses of eth_getBlockByNumber
+	blockCache *blockCache
+	// pendingBlockCache keeps the last pending block built from the pool
+	pendingBlockCache pendingBlockCache
+
+	// lastSyncing is the syncing status last notified to the syncing subscriptions
+	lastSyncing  *bool
+	syncingMutex sync.Mutex
 }
 
 // NewEthEndpoints creates an new instance of Eth
-func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface, etherman types.EthermanInterface, storage storageInterface) *EthEndpoints {
-	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage}
+func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface, etherman types.EthermanInterface, sequencer types.SequencerInterface, storage storageInterface) *EthEndpoints {
+	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, sequencer: sequencer, storage: storage, blockCache: newBlockCache(cfg.BlockCacheSize)}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
 
 	return e
 }
 
+// Accounts returns the list of addresses owned by the client, the node
+// doesn't manage accounts so the response is always empty
+func (e *EthEndpoints) Accounts() (interface{}, types.Error) {
+	return []common.Address{}, nil
+}
+
 // BlockNumber returns current block number
-func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) BlockNumber(ctx context.Context) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		lastBlockNumber, err := e.state.GetLastL2BlockNumber(ctx, dbTx)
 		if err != nil {
 			return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state")
@@ -63,8 +88,8 @@ func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
 // executed contract and potential error.
 // Note, this function doesn't make any changes in the state/blockchain and is
 // useful to execute view/pure methods and retrieve values.
-func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) Call(ctx context.Context, arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
 		} else if blockArg == nil {
@@ -85,6 +110,8 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 			}
 		}
 
+		maxGas, executionCapped := e.getMaxCallGas(ctx, arg)
+
 		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
 			header, err := e.state.GetL2BlockHeaderByNumber(ctx, block.NumberU64(), dbTx)
@@ -97,16 +124,19 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 		}
 
 		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
-		sender, tx, err := arg.ToTransaction(ctx, e.state, e.cfg.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, dbTx)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, maxGas, block.Root(), defaultSenderAddress, dbTx)
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
 		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, dbTx)
+		outOfGas := errors.Is(err, runtime.ErrOutOfGas) || (err == nil && errors.Is(result.Err, runtime.ErrOutOfGas))
+		if executionCapped && tx.Gas() == maxGas && outOfGas {
+			return nil, types.NewRPCError(types.DefaultErrorCode, gasExhaustedErrMsg)
+		}
 		if err != nil {
-			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
 			logError := !runtime.IsOutOfCounterError(err) && !errors.Is(err, runtime.ErrOutOfGas)
-			return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to execute the unsigned transaction: %v", nil, logError, err.Error())
 		}
 
 		if result.Reverted() {
@@ -127,6 +157,8 @@ func (e *EthEndpoints) ChainId() (interface{}, types.Error) { //nolint:revive
 }
 
 // Coinbase Returns the client coinbase address.
+// On zkEVM this is the L1 address of the trusted sequencer that receives the
+// L2 fees (L2Coinbase), not a mining coinbase. It's formatted as a checksum address.
 func (e *EthEndpoints) Coinbase() (interface{}, types.Error) { //nolint:revive
 	if e.cfg.SequencerNodeURI != "" {
 		return e.getCoinbaseFromSequencerNode()
@@ -158,8 +190,8 @@ func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error)
 // Note that the estimate may be significantly more than the amount of gas actually
 // used by the transaction, for a variety of reasons including EVM mechanics and
 // node performance.
-func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) EstimateGas(ctx context.Context, arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
 		}
@@ -180,34 +212,65 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 			}
 		}
 
+		maxGas, executionCapped := e.getMaxCallGas(ctx, arg)
+
 		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
-		sender, tx, err := arg.ToTransaction(ctx, e.state, e.cfg.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, dbTx)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, maxGas, block.Root(), defaultSenderAddress, dbTx)
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
 		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, dbTx)
-		if errors.Is(err, runtime.ErrExecutionReverted) {
+		var allowanceErr *state.GasRequiredExceedsAllowanceError
+		if executionCapped && errors.As(err, &allowanceErr) && allowanceErr.Gas == maxGas && errors.Is(allowanceErr.Err, runtime.ErrOutOfGas) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, gasExhaustedErrMsg)
+		} else if errors.Is(err, runtime.ErrExecutionReverted) {
 			data := make([]byte, len(returnValue))
 			copy(data, returnValue)
 			return nil, types.NewRPCErrorWithData(types.RevertedErrorCode, err.Error(), data)
 		} else if err != nil {
-			errMsg := fmt.Sprintf("failed to estimate gas: %v", err.Error())
-			return nil, types.NewRPCError(types.DefaultErrorCode, errMsg)
+			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to estimate gas: %v", err.Error())
 		}
 		return hex.EncodeUint64(gasEstimation), nil
 	})
 }
 
-// GasPrice returns the average gas price based on the last x blocks
-func (e *EthEndpoints) GasPrice() (interface{}, types.Error) {
-	ctx := context.Background()
+// getMaxCallGas returns the max gas that can be used to process the call, it's the
+// configured MaxCallGas bounded by the batch gas limit and by the MaxCallGasExecution
+// ceiling, executionCapped is true if the MaxCallGasExecution ceiling applies.
+// When the call asks for more gas, the max gas it's clamped to is reported in the
+// X-Call-Gas-Capped response header
+func (e *EthEndpoints) getMaxCallGas(ctx context.Context, arg *types.TxArgs) (maxGas uint64, executionCapped bool) {
+	maxGas = e.cfg.MaxCumulativeGasUsed
+	if e.cfg.MaxCallGas > 0 && e.cfg.MaxCallGas < maxGas {
+		maxGas = e.cfg.MaxCallGas
+	}
+	if e.cfg.MaxCallGasExecution > 0 && e.cfg.MaxCallGasExecution < maxGas {
+		maxGas = e.cfg.MaxCallGasExecution
+		executionCapped = true
+	}
+
+	if arg.Gas != nil && uint64(*arg.Gas) > maxGas {
+		log.Ctx(ctx).Debugf("gas %d requested for the call is over the max call gas, clamped to %d", uint64(*arg.Gas), maxGas)
+		setResponseHeader(ctx, callGasCappedHeader, strconv.FormatUint(maxGas, 10))
+	}
+
+	return maxGas, executionCapped
+}
+
+// GasPrice returns the average gas price based on the last x blocks. Until the gas price
+// suggester sets the first gas price a resource unavailable error is returned, so the
+// wallets don't send txs with a zero gas price
+func (e *EthEndpoints) GasPrice(ctx context.Context) (interface{}, types.Error) {
 	if e.cfg.SequencerNodeURI != "" {
 		return e.getPriceFromSequencerNode()
 	}
 	gasPrices, err := e.pool.GetGasPrices(ctx)
 	if err != nil {
-		return "0x0", nil
+		return RPCErrorResponse(types.ResourceUnavailableErrorCode, "failed to get gas prices from pool", err, true)
+	}
+	if gasPrices.UpdatedAt.IsZero() {
+		return RPCErrorResponse(types.ResourceUnavailableErrorCode, gasPriceNotInitializedErrMsg, nil, false)
 	}
 	return hex.EncodeUint64(gasPrices.L2GasPrice), nil
 }
@@ -231,8 +294,24 @@ func (e *EthEndpoints) getPriceFromSequencerNode() (interface{}, types.Error) {
 }
 
 // GetBalance returns the account's balance at the referenced block
-func (e *EthEndpoints) GetBalance(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetBalance(ctx context.Context, address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if blockArg != nil {
+			blockNumArg := blockArg.Number()
+			if blockNumArg != nil && *blockNumArg == types.PendingBlockNumber {
+				if e.cfg.SequencerNodeURI != "" {
+					return e.getBalanceFromSequencerNode(address.Address(), blockNumArg)
+				}
+				balance, err := e.pool.GetPendingBalance(ctx, address.Address())
+				if errors.Is(err, state.ErrNotFound) {
+					return hex.EncodeUint64(0), nil
+				} else if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "failed to get pending balance", err, true)
+				}
+				return hex.EncodeBig(balance), nil
+			}
+		}
+
 		block, rpcErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if rpcErr != nil {
 			return nil, rpcErr
@@ -249,6 +328,24 @@ func (e *EthEndpoints) GetBalance(address types.ArgAddress, blockArg *types.Bloc
 	})
 }
 
+func (e *EthEndpoints) getBalanceFromSequencerNode(address common.Address, number *types.BlockNumber) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_getBalance", address.String(), number.StringOrHex())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get balance from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var balance types.ArgBig
+	err = json.Unmarshal(res.Result, &balance)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read balance from sequencer node", err, true)
+	}
+	return hex.EncodeBig((*big.Int)(&balance)), nil
+}
+
 func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
 	// If no block argument is provided, return the latest block
 	if blockArg == nil {
@@ -265,7 +362,7 @@ func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockN
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, types.NewRPCError(types.DefaultErrorCode, "header for hash not found")
 		} else if err != nil {
-			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by hash %v", blockArg.Hash().Hash()))
+			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get block by hash %v", blockArg.Hash().Hash())
 		}
 		return block, nil
 	}
@@ -275,19 +372,19 @@ func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockN
 	if rpcErr != nil {
 		return nil, rpcErr
 	}
-	block, err := e.state.GetL2BlockByNumber(context.Background(), blockNum, dbTx)
+	block, err := e.state.GetL2BlockByNumber(ctx, blockNum, dbTx)
 	if errors.Is(err, state.ErrNotFound) || block == nil {
 		return nil, types.NewRPCError(types.DefaultErrorCode, "header not found")
 	} else if err != nil {
-		return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by number %v", blockNum))
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get block by number %v", blockNum)
 	}
 
 	return block, nil
 }
 
 // GetBlockByHash returns information about a block by hash
-func (e *EthEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetBlockByHash(ctx context.Context, hash types.ArgHash, fullTx bool) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		l2Block, err := e.state.GetL2BlockByHash(ctx, hash.Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
@@ -295,40 +392,111 @@ func (e *EthEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool) (interfac
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by hash from state", err, true)
 		}
 
-		txs := l2Block.Transactions()
-		receipts := make([]ethTypes.Receipt, 0, len(txs))
-		for _, tx := range txs {
-			receipt, err := e.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
-			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), err, true)
+		// the receipts are only used to build the full txs
+		var receipts []ethTypes.Receipt
+		if fullTx {
+			txs := l2Block.Transactions()
+			receipts = make([]ethTypes.Receipt, 0, len(txs))
+			for _, tx := range txs {
+				receipt, err := e.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
+				if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipt for tx %v", err, true, tx.Hash().String())
+				}
+				receipts = append(receipts, *receipt)
 			}
-			receipts = append(receipts, *receipt)
 		}
 
 		rpcBlock, err := types.NewBlock(state.HashPtr(l2Block.Hash()), l2Block, receipts, fullTx, false)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by hash %v", hash.Hash()), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't build block response for block by hash %v", err, true, hash.Hash())
 		}
 
 		return rpcBlock, nil
 	})
 }
 
+// newPendingBlock builds the block following the last block with the pending txs of the pool, the txs
+// of each sender are added in nonce order starting at the sender nonce in the state and stop at the
+// first nonce gap or at the first tx that doesn't fit in the batch gas limit, the same way the
+// sequencer would include them. The timestamp is an estimation since the block is not closed yet
+func (e *EthEndpoints) newPendingBlock(ctx context.Context, lastBlock *state.L2Block, pendingTxs []pool.Transaction) (*state.L2Block, error) {
+	// the senders are kept in the order the pool returned their first tx
+	senders := make([]common.Address, 0, len(pendingTxs))
+	txsBySender := make(map[common.Address][]*ethTypes.Transaction, len(pendingTxs))
+	for i := range pendingTxs {
+		tx := &pendingTxs[i].Transaction
+		from, err := state.GetSender(*tx)
+		if err != nil {
+			log.Ctx(ctx).Debugf("pending tx %v skipped from the pending block, failed to get its sender: %v", tx.Hash().String(), err)
+			continue
+		}
+		if _, found := txsBySender[from]; !found {
+			senders = append(senders, from)
+		}
+		txsBySender[from] = append(txsBySender[from], tx)
+	}
+
+	gasLimit := e.cfg.MaxCumulativeGasUsed
+	txs := make([]*ethTypes.Transaction, 0, len(pendingTxs))
+	usedGas := uint64(0)
+	for _, from := range senders {
+		senderTxs := txsBySender[from]
+		sort.SliceStable(senderTxs, func(i, j int) bool { return senderTxs[i].Nonce() < senderTxs[j].Nonce() })
+		nonce, err := e.state.GetNonce(ctx, from, lastBlock.Root())
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range senderTxs {
+			if tx.Nonce() < nonce {
+				// the nonce is already used by a mined tx or by a previous tx of the sender
+				continue
+			}
+			if tx.Nonce() > nonce || (gasLimit > 0 && usedGas+tx.Gas() > gasLimit) {
+				// the next txs of the sender can't be included without this one
+				break
+			}
+			usedGas += tx.Gas()
+			txs = append(txs, tx)
+			nonce++
+		}
+	}
+
+	timestamp := uint64(time.Now().Unix())
+	if timestamp < lastBlock.Time() {
+		timestamp = lastBlock.Time()
+	}
+
+	l2Header := state.NewL2Header(&ethTypes.Header{
+		ParentHash: lastBlock.Hash(),
+		Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
+		UncleHash:  ethTypes.EmptyUncleHash,
+		GasLimit:   gasLimit,
+		Time:       timestamp,
+	})
+	return state.NewL2Block(l2Header, txs, nil, nil, trie.NewStackTrie(nil)), nil
+}
+
 // GetBlockByNumber returns information about a block by block number
-func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetBlockByNumber(ctx context.Context, number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if number == types.PendingBlockNumber {
 			lastBlock, err := e.state.GetLastL2Block(ctx, dbTx)
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load last block from state to compute the pending block", err, true)
 			}
-			l2Header := state.NewL2Header(&ethTypes.Header{
-				ParentHash: lastBlock.Hash(),
-				Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
-				TxHash:     ethTypes.EmptyRootHash,
-				UncleHash:  ethTypes.EmptyUncleHash,
-			})
-			l2Block := state.NewL2BlockWithHeader(l2Header)
+			l2Block, found := e.pendingBlockCache.get(lastBlock.Hash())
+			if !found {
+				pendingTxs, err := e.pool.GetPendingTxs(ctx, maxPendingBlockTxs)
+				if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "couldn't load pending txs from pool to compute the pending block", err, true)
+				}
+				l2Block, err = e.newPendingBlock(ctx, lastBlock, pendingTxs)
+				if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the pending txs senders nonces from state to compute the pending block", err, true)
+				}
+				e.pendingBlockCache.add(lastBlock.Hash(), l2Block)
+			}
+			// the pending block is not final, so it has no hash
 			rpcBlock, err := types.NewBlock(nil, l2Block, nil, fullTx, false)
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "couldn't build the pending block response", err, true)
@@ -342,11 +510,21 @@ func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool) (
 			return nil, rpcErr
 		}
 
+		if rpcBlock, ok := e.blockCache.get(blockNumber, fullTx); ok {
+			// the new block events are only sent for block numbers above the last one seen, so a
+			// reorged block is detected checking the cached hash against the stored header
+			header, err := e.state.GetL2BlockHeaderByNumber(ctx, blockNumber, dbTx)
+			if err == nil && header.Hash() == *rpcBlock.Hash {
+				return rpcBlock, nil
+			}
+			e.blockCache.invalidate(blockNumber)
+		}
+
 		l2Block, err := e.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load block from state by number %v", err, true, blockNumber)
 		}
 
 		txs := l2Block.Transactions()
@@ -354,23 +532,24 @@ func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool) (
 		for _, tx := range txs {
 			receipt, err := e.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
 			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), err, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipt for tx %v", err, true, tx.Hash().String())
 			}
 			receipts = append(receipts, *receipt)
 		}
 
 		rpcBlock, err := types.NewBlock(state.HashPtr(l2Block.Hash()), l2Block, receipts, fullTx, false)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by number %v", blockNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't build block response for block by number %v", err, true, blockNumber)
 		}
+		e.blockCache.add(blockNumber, fullTx, rpcBlock)
 
 		return rpcBlock, nil
 	})
 }
 
 // GetCode returns account code at given block number
-func (e *EthEndpoints) GetCode(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetCode(ctx context.Context, address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		var err error
 		block, rpcErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if rpcErr != nil {
@@ -395,7 +574,7 @@ func (e *EthEndpoints) GetCompilers() (interface{}, types.Error) {
 
 // GetFilterChanges polling method for a filter, which returns
 // an array of logs which occurred since last poll.
-func (e *EthEndpoints) GetFilterChanges(filterID string) (interface{}, types.Error) {
+func (e *EthEndpoints) GetFilterChanges(ctx context.Context, filterID string) (interface{}, types.Error) {
 	filter, err := e.storage.GetFilter(filterID)
 	if errors.Is(err, ErrNotFound) {
 		return RPCErrorResponse(types.DefaultErrorCode, "filter not found", err, false)
@@ -406,7 +585,7 @@ func (e *EthEndpoints) GetFilterChanges(filterID string) (interface{}, types.Err
 	switch filter.Type {
 	case FilterTypeBlock:
 		{
-			res, err := e.state.GetL2BlockHashesSince(context.Background(), filter.LastPoll, nil)
+			res, err := e.state.GetL2BlockHashesSince(ctx, filter.LastPoll, nil)
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "failed to get block hashes", err, true)
 			}
@@ -421,7 +600,7 @@ func (e *EthEndpoints) GetFilterChanges(filterID string) (interface{}, types.Err
 		}
 	case FilterTypePendingTx:
 		{
-			res, err := e.pool.GetPendingTxHashesSince(context.Background(), filter.LastPoll)
+			res, err := e.pool.GetPendingTxHashesSince(ctx, filter.LastPoll)
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "failed to get pending transaction hashes", err, true)
 			}
@@ -439,7 +618,7 @@ func (e *EthEndpoints) GetFilterChanges(filterID string) (interface{}, types.Err
 			filterParameters := filter.Parameters.(LogFilter)
 			filterParameters.Since = &filter.LastPoll
 
-			resInterface, err := e.internalGetLogs(context.Background(), nil, filterParameters)
+			resInterface, err := e.internalGetLogs(ctx, nil, filterParameters)
 			if err != nil {
 				return nil, err
 			}
@@ -460,7 +639,7 @@ func (e *EthEndpoints) GetFilterChanges(filterID string) (interface{}, types.Err
 
 // GetFilterLogs returns an array of all logs matching filter
 // with given id.
-func (e *EthEndpoints) GetFilterLogs(filterID string) (interface{}, types.Error) {
+func (e *EthEndpoints) GetFilterLogs(ctx context.Context, filterID string) (interface{}, types.Error) {
 	filter, err := e.storage.GetFilter(filterID)
 	if errors.Is(err, ErrNotFound) {
 		return nil, nil
@@ -475,12 +654,12 @@ func (e *EthEndpoints) GetFilterLogs(filterID string) (interface{}, types.Error)
 	filterParameters := filter.Parameters.(LogFilter)
 	filterParameters.Since = nil
 
-	return e.GetLogs(filterParameters)
+	return e.GetLogs(ctx, filterParameters)
 }
 
 // GetLogs returns a list of logs accordingly to the provided filter
-func (e *EthEndpoints) GetLogs(filter LogFilter) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetLogs(ctx context.Context, filter LogFilter) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		return e.internalGetLogs(ctx, dbTx, filter)
 	})
 }
@@ -494,11 +673,9 @@ func (e *EthEndpoints) internalGetLogs(ctx context.Context, dbTx pgx.Tx, filter
 	var err error
 	logs, err := e.state.GetLogs(ctx, fromBlockNumber, toBlockNumber, filter.Addresses, filter.Topics, filter.BlockHash, filter.Since, dbTx)
 	if errors.Is(err, state.ErrMaxLogsCountLimitExceeded) {
-		errMsg := fmt.Sprintf(state.ErrMaxLogsCountLimitExceeded.Error(), e.cfg.MaxLogsCount)
-		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		return RPCErrorResponse(types.InvalidParamsErrorCode, state.ErrMaxLogsCountLimitExceeded.Error(), nil, false, e.cfg.MaxLogsCount)
 	} else if errors.Is(err, state.ErrMaxLogsBlockRangeLimitExceeded) {
-		errMsg := fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), e.cfg.MaxLogsBlockRange)
-		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		return RPCErrorResponse(types.InvalidParamsErrorCode, state.ErrMaxLogsBlockRangeLimitExceeded.Error(), nil, false, e.cfg.MaxLogsBlockRange)
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get logs from state", err, true)
 	}
@@ -512,21 +689,22 @@ func (e *EthEndpoints) internalGetLogs(ctx context.Context, dbTx pgx.Tx, filter
 }
 
 // GetStorageAt gets the value stored for an specific address and position
-func (e *EthEndpoints) GetStorageAt(address types.ArgAddress, storageKeyStr string, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) GetStorageAt(ctx context.Context, address types.ArgAddress, storageKeyStr string, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	storageKey := types.ArgHash{}
 	err := storageKey.UnmarshalText([]byte(storageKeyStr))
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "unable to decode storage key: hex string invalid", nil, false)
 	}
 
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if respErr != nil {
 			return nil, respErr
 		}
 
 		value, err := e.state.GetStorageAt(ctx, address.Address(), storageKey.Hash().Big(), block.Root())
-		if errors.Is(err, state.ErrNotFound) {
+		if errors.Is(err, state.ErrNotFound) || (err == nil && value == nil) {
+			// uninitialized storage slots are zero
 			return types.ArgBytesPtr(common.Hash{}.Bytes()), nil
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get storage value from state", err, true)
@@ -538,8 +716,8 @@ func (e *EthEndpoints) GetStorageAt(address types.ArgAddress, storageKeyStr stri
 
 // GetTransactionByBlockHashAndIndex returns information about a transaction by
 // block hash and transaction index position.
-func (e *EthEndpoints) GetTransactionByBlockHashAndIndex(hash types.ArgHash, index types.Index) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetTransactionByBlockHashAndIndex(ctx context.Context, hash types.ArgHash, index types.Index) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		tx, err := e.state.GetTransactionByL2BlockHashAndIndex(ctx, hash.Hash(), uint64(index), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
@@ -565,8 +743,8 @@ func (e *EthEndpoints) GetTransactionByBlockHashAndIndex(hash types.ArgHash, ind
 
 // GetTransactionByBlockNumberAndIndex returns information about a transaction by
 // block number and transaction index position.
-func (e *EthEndpoints) GetTransactionByBlockNumberAndIndex(number *types.BlockNumber, index types.Index) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetTransactionByBlockNumberAndIndex(ctx context.Context, number *types.BlockNumber, index types.Index) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		var err error
 		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
 		if rpcErr != nil {
@@ -596,9 +774,10 @@ func (e *EthEndpoints) GetTransactionByBlockNumberAndIndex(number *types.BlockNu
 	})
 }
 
-// GetTransactionByHash returns a transaction by his hash
-func (e *EthEndpoints) GetTransactionByHash(hash types.ArgHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+// GetTransactionByHash returns a transaction by his hash, the txs not found in the state are looked
+// for in the pool and the pending ones are returned with null blockHash, blockNumber and transactionIndex
+func (e *EthEndpoints) GetTransactionByHash(ctx context.Context, hash types.ArgHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		// try to get tx from state
 		tx, err := e.state.GetTransactionByHash(ctx, hash.Hash(), dbTx)
 		if err != nil && !errors.Is(err, state.ErrNotFound) {
@@ -661,8 +840,8 @@ func (e *EthEndpoints) getTransactionByHashFromSequencerNode(hash common.Hash) (
 }
 
 // GetTransactionCount returns account nonce
-func (e *EthEndpoints) GetTransactionCount(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetTransactionCount(ctx context.Context, address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		var (
 			pendingNonce uint64
 			nonce        uint64
@@ -684,6 +863,12 @@ func (e *EthEndpoints) GetTransactionCount(address types.ArgAddress, blockArg *t
 				if err != nil {
 					return RPCErrorResponse(types.DefaultErrorCode, "failed to count pending transactions", err, true)
 				}
+				// the worker also knows the txs already executed in the WIP batch
+				if e.sequencer != nil {
+					if workerNonce := e.sequencer.GetPendingNonce(address.Address()); workerNonce > pendingNonce {
+						pendingNonce = workerNonce
+					}
+				}
 			}
 		}
 
@@ -723,8 +908,8 @@ func (e *EthEndpoints) getTransactionCountFromSequencerNode(address common.Addre
 
 // GetBlockTransactionCountByHash returns the number of transactions in a
 // block from a block matching the given block hash.
-func (e *EthEndpoints) GetBlockTransactionCountByHash(hash types.ArgHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetBlockTransactionCountByHash(ctx context.Context, hash types.ArgHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		c, err := e.state.GetL2BlockTransactionCountByHash(ctx, hash.Hash(), dbTx)
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to count transactions", err, true)
@@ -736,8 +921,8 @@ func (e *EthEndpoints) GetBlockTransactionCountByHash(hash types.ArgHash) (inter
 
 // GetBlockTransactionCountByNumber returns the number of transactions in a
 // block from a block matching the given block number.
-func (e *EthEndpoints) GetBlockTransactionCountByNumber(number *types.BlockNumber) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) GetBlockTransactionCountByNumber(ctx context.Context, number *types.BlockNumber) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if number != nil && *number == types.PendingBlockNumber {
 			if e.cfg.SequencerNodeURI != "" {
 				return e.getBlockTransactionCountByNumberFromSequencerNode(number)
@@ -782,29 +967,76 @@ func (e *EthEndpoints) getBlockTransactionCountByNumberFromSequencerNode(number
 	return count, nil
 }
 
-// GetTransactionReceipt returns a transaction receipt by his hash
-func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		tx, err := e.state.GetTransactionByHash(ctx, hash.Hash(), dbTx)
+// GetTransactionReceipt returns a transaction receipt by his hash, the result is null while the
+// transaction is pending in the pool or if it's unknown, only the internal failures are errors
+func (e *EthEndpoints) GetTransactionReceipt(ctx context.Context, hash types.ArgHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		tx, r, err := e.state.GetTransactionWithReceipt(ctx, hash.Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx from state", err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx receipt from state", err, true)
 		}
 
-		r, err := e.state.GetTransactionReceipt(ctx, hash.Hash(), dbTx)
+		receipt, err := types.NewReceipt(*tx, r)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+		}
+
+		return receipt, nil
+	})
+}
+
+// GetBlockReceipts returns the receipts of all the txs of the block ordered by tx index, the
+// receipts are read with a single query. It returns null if the block is not found, the
+// pending block has no receipts
+func (e *EthEndpoints) GetBlockReceipts(ctx context.Context, blockArg types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		var l2Block *state.L2Block
+		var err error
+		if blockArg.IsHash() {
+			l2Block, err = e.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		} else {
+			number := blockArg.Number()
+			if number != nil && *number == types.PendingBlockNumber {
+				return nil, nil
+			}
+			blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+			l2Block, err = e.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		}
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx receipt from state", err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block from state", err, true)
 		}
 
-		receipt, err := types.NewReceipt(*tx, r)
+		receipts, err := e.state.GetTransactionReceiptsByL2BlockNumber(ctx, l2Block.NumberU64(), dbTx)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipts of block %v", err, true, l2Block.NumberU64())
 		}
 
-		return receipt, nil
+		txs := make(map[common.Hash]*ethTypes.Transaction, len(l2Block.Transactions()))
+		for _, tx := range l2Block.Transactions() {
+			txs[tx.Hash()] = tx
+		}
+
+		rpcReceipts := make([]types.Receipt, 0, len(receipts))
+		for i := range receipts {
+			tx, found := txs[receipts[i].TxHash]
+			if !found {
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't find tx %v of the receipt in block %v", nil, true, receipts[i].TxHash.String(), l2Block.NumberU64())
+			}
+			rpcReceipt, err := types.NewReceipt(*tx, &receipts[i])
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+			}
+			rpcReceipts = append(rpcReceipts, rpcReceipt)
+		}
+
+		return rpcReceipts, nil
 	})
 }
 
@@ -828,8 +1060,8 @@ func (e *EthEndpoints) newBlockFilter(wsConn *concurrentWsConn) (interface{}, ty
 // NewFilter creates a filter object, based on filter options,
 // to notify when the state changes (logs). To check if the state
 // has changed, call eth_getFilterChanges.
-func (e *EthEndpoints) NewFilter(filter LogFilter) (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) NewFilter(ctx context.Context, filter LogFilter) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		return e.newFilter(ctx, nil, filter, dbTx)
 	})
 }
@@ -844,7 +1076,7 @@ func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn,
 	}
 
 	id, err := e.storage.NewLogFilter(wsConn, filter)
-	if errors.Is(err, ErrFilterInvalidPayload) {
+	if errors.Is(err, ErrFilterInvalidPayload) || errors.Is(err, ErrFilterTooManyTopics) {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new log filter", err, true)
@@ -860,15 +1092,18 @@ func (e *EthEndpoints) NewPendingTransactionFilter() (interface{}, types.Error)
 	return e.newPendingTransactionFilter(nil)
 }
 
-// internal
+// internal, the pending txs are polled from the pool, they are not pushed to the WS subscriptions yet
 func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
-	return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
-	// id, err := e.storage.NewPendingTransactionFilter(wsConn)
-	// if err != nil {
-	// 	return rpcErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err)
-	// }
+	if wsConn != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
+	}
+
+	id, err := e.storage.NewPendingTransactionFilter(wsConn)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err, true)
+	}
 
-	// return id, nil
+	return id, nil
 }
 
 // SendRawTransaction has two different ways to handle new transactions:
@@ -883,13 +1118,14 @@ func (e *EthEndpoints) SendRawTransaction(httpRequest *http.Request, input strin
 
 		// TODO: this is temporary patch remove this log
 		realIp := httpRequest.Header.Get("X-Real-IP")
-		log.Debugf("X-Forwarded-For: %s, X-Real-IP: %s", ips, realIp)
+		logger := log.Ctx(httpRequest.Context())
+		logger.Debugf("X-Forwarded-For: %s, X-Real-IP: %s", ips, realIp)
 
 		if ips != "" {
 			ip = strings.Split(ips, ",")[0]
 		}
 
-		return e.tryToAddTxToPool(input, ip)
+		return e.tryToAddTxToPool(httpRequest.Context(), input, ip)
 	}
 }
 
@@ -908,18 +1144,22 @@ func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.
 	return txHash, nil
 }
 
-func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Error) {
+func (e *EthEndpoints) tryToAddTxToPool(ctx context.Context, input, ip string) (interface{}, types.Error) {
+	logger := log.Ctx(ctx)
 	tx, err := hexToTx(input)
 	if err != nil {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
 	}
-	log.Infof("adding TX to the pool: %v", tx.Hash().Hex())
-	if err := e.pool.AddTx(context.Background(), *tx, ip); err != nil {
+	logger.Infof("adding TX to the pool: %v", tx.Hash().Hex())
+	if err := e.pool.AddTx(ctx, *tx, ip); errors.Is(err, pool.ErrAlreadyKnown) {
+		// clients rely on this exact message to detect duplicated txs
+		return RPCErrorResponse(types.DefaultErrorCode, pool.ErrAlreadyKnown.Error(), nil, false)
+	} else if err != nil {
 		// it's not needed to log the error here, because we check and log if needed
 		// for each specific case during the "pool.AddTx" internal steps
 		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
 	}
-	log.Infof("TX added to the pool: %v", tx.Hash().Hex())
+	logger.Infof("TX added to the pool: %v", tx.Hash().Hex())
 
 	return tx.Hash().Hex(), nil
 }
@@ -938,8 +1178,8 @@ func (e *EthEndpoints) UninstallFilter(filterID string) (interface{}, types.Erro
 
 // Syncing returns an object with data about the sync status or false.
 // https://eth.wiki/json-rpc/API#eth_syncing
-func (e *EthEndpoints) Syncing() (interface{}, types.Error) {
-	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) Syncing(ctx context.Context) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		_, err := e.state.GetLastL2BlockNumber(ctx, dbTx)
 		if errors.Is(err, state.ErrStateNotSynchronized) {
 			return nil, types.NewRPCError(types.DefaultErrorCode, state.ErrStateNotSynchronized.Error())
@@ -952,22 +1192,40 @@ func (e *EthEndpoints) Syncing() (interface{}, types.Error) {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get syncing info from state", err, true)
 		}
 
-		if syncInfo.CurrentBlockNumber >= syncInfo.LastBlockNumberSeen {
+		if !isSyncing(syncInfo) {
 			return false, nil
 		}
 
-		return struct {
-			S types.ArgUint64 `json:"startingBlock"`
-			C types.ArgUint64 `json:"currentBlock"`
-			H types.ArgUint64 `json:"highestBlock"`
-		}{
-			S: types.ArgUint64(syncInfo.InitialSyncingBlock),
-			C: types.ArgUint64(syncInfo.CurrentBlockNumber),
-			H: types.ArgUint64(syncInfo.LastBlockNumberSeen),
-		}, nil
+		return newSyncingStatus(syncInfo), nil
 	})
 }
 
+// syncingStatus is the sync progress reported while the node is syncing
+type syncingStatus struct {
+	StartingBlock types.ArgUint64 `json:"startingBlock"`
+	CurrentBlock  types.ArgUint64 `json:"currentBlock"`
+	HighestBlock  types.ArgUint64 `json:"highestBlock"`
+}
+
+// syncingNotification is sent to the syncing subscriptions when the node starts syncing
+type syncingNotification struct {
+	Syncing bool          `json:"syncing"`
+	Status  syncingStatus `json:"status"`
+}
+
+func newSyncingStatus(syncInfo state.SyncingInfo) syncingStatus {
+	return syncingStatus{
+		StartingBlock: types.ArgUint64(syncInfo.InitialSyncingBlock),
+		CurrentBlock:  types.ArgUint64(syncInfo.CurrentBlockNumber),
+		HighestBlock:  types.ArgUint64(syncInfo.LastBlockNumberSeen),
+	}
+}
+
+// isSyncing checks if the node is behind the last block seen in L1
+func isSyncing(syncInfo state.SyncingInfo) bool {
+	return syncInfo.CurrentBlockNumber < syncInfo.LastBlockNumberSeen
+}
+
 // GetUncleByBlockHashAndIndex returns information about a uncle of a
 // block by hash and uncle index position
 func (e *EthEndpoints) GetUncleByBlockHashAndIndex(hash types.ArgHash, index types.Index) (interface{}, types.Error) {
@@ -992,11 +1250,23 @@ func (e *EthEndpoints) GetUncleCountByBlockNumber(number types.BlockNumber) (int
 	return "0x0", nil
 }
 
-// ProtocolVersion returns the protocol version.
+// ProtocolVersion returns the protocol version of the network set in the config
 func (e *EthEndpoints) ProtocolVersion() (interface{}, types.Error) {
+	return e.cfg.ProtocolVersion, nil
+}
+
+// Hashrate returns the number of hashes per second the node is mining with,
+// the node doesn't mine so the response is always zero
+func (e *EthEndpoints) Hashrate() (interface{}, types.Error) {
 	return "0x0", nil
 }
 
+// Mining returns true if the node is actively mining new blocks,
+// the node doesn't mine so the response is always false
+func (e *EthEndpoints) Mining() (interface{}, types.Error) {
+	return false, nil
+}
+
 func hexToTx(str string) (*ethTypes.Transaction, error) {
 	tx := new(ethTypes.Transaction)
 
@@ -1024,12 +1294,12 @@ func (e *EthEndpoints) updateFilterLastPoll(filterID string) types.Error {
 // The node will return a subscription id.
 // For each event that matches the subscription a notification with relevant
 // data is sent together with the subscription id.
-func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilter *LogFilter) (interface{}, types.Error) {
+func (e *EthEndpoints) Subscribe(ctx context.Context, wsConn *concurrentWsConn, name string, logFilter *LogFilter) (interface{}, types.Error) {
 	switch name {
 	case "newHeads":
 		return e.newBlockFilter(wsConn)
 	case "logs":
-		return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 			var lf LogFilter
 			if logFilter != nil {
 				lf = *logFilter
@@ -1039,12 +1309,38 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 	case "pendingTransactions", "newPendingTransactions":
 		return e.newPendingTransactionFilter(wsConn)
 	case "syncing":
-		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
+		return e.txMan.NewDbTxScope(ctx, e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+			return e.newSyncingFilter(ctx, wsConn, dbTx)
+		})
 	default:
 		return nil, types.NewRPCError(types.DefaultErrorCode, "invalid filter name")
 	}
 }
 
+// newSyncingFilter creates a subscription to be notified when the node
+// starts or stops syncing
+func (e *EthEndpoints) newSyncingFilter(ctx context.Context, wsConn *concurrentWsConn, dbTx pgx.Tx) (interface{}, types.Error) {
+	syncInfo, err := e.state.GetSyncingInfo(ctx, dbTx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get syncing info from state", err, true)
+	}
+
+	id, err := e.storage.NewSyncingFilter(wsConn)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new syncing filter", err, true)
+	}
+
+	// the current status is the reference to detect the next change
+	e.syncingMutex.Lock()
+	if e.lastSyncing == nil {
+		syncing := isSyncing(syncInfo)
+		e.lastSyncing = &syncing
+	}
+	e.syncingMutex.Unlock()
+
+	return id, nil
+}
+
 // Unsubscribe uninstalls the filter based on the provided filterID
 func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (interface{}, types.Error) {
 	return e.UninstallFilter(filterID)
@@ -1059,6 +1355,7 @@ func (e *EthEndpoints) uninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 // onNewL2Block is triggered when the state triggers the event for a new l2 block
 func (e *EthEndpoints) onNewL2Block(event state.NewL2BlockEvent) {
 	log.Debugf("[onNewL2Block] new l2 block event detected for block %v", event.Block.NumberU64())
+	e.blockCache.invalidate(event.Block.NumberU64())
 	start := time.Now()
 	wg := sync.WaitGroup{}
 
@@ -1068,6 +1365,9 @@ func (e *EthEndpoints) onNewL2Block(event state.NewL2BlockEvent) {
 	wg.Add(1)
 	go e.notifyNewLogs(&wg, event)
 
+	wg.Add(1)
+	go e.notifySyncing(&wg, event)
+
 	wg.Wait()
 	log.Debugf("[onNewL2Block] new l2 block %v took %v to send the messages to all ws connections", event.Block.NumberU64(), time.Since(start))
 }
@@ -1103,6 +1403,59 @@ func (e *EthEndpoints) notifyNewHeads(wg *sync.WaitGroup, event state.NewL2Block
 	log.Debugf("[notifyNewHeads] new l2 block event for block %v took %v to send all the messages for block filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// notifySyncing notifies the syncing subscriptions when the syncing status changes,
+// the sync progress is sent when the node starts syncing and false when it is synced
+func (e *EthEndpoints) notifySyncing(wg *sync.WaitGroup, event state.NewL2BlockEvent) {
+	defer wg.Done()
+	start := time.Now()
+
+	filters := e.storage.GetAllSyncingFiltersWithWSConn()
+	log.Debugf("[notifySyncing] took %v to get syncing filters with ws connections", time.Since(start))
+	if len(filters) == 0 {
+		// without subscriptions the status is not tracked, the next
+		// subscription sets it again
+		e.syncingMutex.Lock()
+		e.lastSyncing = nil
+		e.syncingMutex.Unlock()
+		return
+	}
+
+	syncInfo, err := e.state.GetSyncingInfo(context.Background(), nil)
+	if err != nil {
+		log.Errorf("failed to get syncing info to notify syncing subscriptions: %v", err)
+		return
+	}
+
+	syncing := isSyncing(syncInfo)
+	e.syncingMutex.Lock()
+	changed := e.lastSyncing == nil || *e.lastSyncing != syncing
+	e.lastSyncing = &syncing
+	e.syncingMutex.Unlock()
+	if !changed {
+		return
+	}
+
+	var result interface{} = false
+	if syncing {
+		result = syncingNotification{Syncing: true, Status: newSyncingStatus(syncInfo)}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Errorf("failed to marshal syncing response to subscription: %v", err)
+		return
+	}
+
+	const maxWorkers = 32
+	parallelize(maxWorkers, filters, func(worker int, filters []*Filter) {
+		for _, filter := range filters {
+			f := filter
+			f.EnqueueSubscriptionDataToBeSent(data)
+		}
+	})
+
+	log.Debugf("[notifySyncing] new l2 block event for block %v took %v to send all the messages for syncing filters", event.Block.NumberU64(), time.Since(start))
+}
+
 func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockEvent) {
 	defer wg.Done()
 	start := time.Now()
@@ -1221,14 +1574,8 @@ func filterLogs(logsToFilter []*ethTypes.Log, filter *Filter) []types.Log {
 					break out
 				}
 
-				// check if the topic filter allows any topic
-				acceptAnyTopic := len(logFilter.Topics[i]) == 0
-				if acceptAnyTopic {
-					// since any topic is allowed, we continue to the next topic filters
-					continue
-				}
-
-				// check if the log has the required topic set
+				// check if the log has the required topic set, even a
+				// position that accepts any topic requires a topic in the log
 				logHasTopic := len(l.Topics) > i
 				if !logHasTopic {
 					// if the log doesn't have the required topic set, skip this log
@@ -1236,6 +1583,13 @@ func filterLogs(logsToFilter []*ethTypes.Log, filter *Filter) []types.Log {
 					break out
 				}
 
+				// check if the topic filter allows any topic
+				acceptAnyTopic := len(logFilter.Topics[i]) == 0
+				if acceptAnyTopic {
+					// since any topic is allowed, we continue to the next topic filters
+					continue
+				}
+
 				// check if the any topic in the filter matches the log topic
 				if !contains(logFilter.Topics[i], l.Topics[i]) {
 					match = false
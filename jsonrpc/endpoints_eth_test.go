@@ -1,11 +1,16 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -45,6 +50,19 @@ var (
 	nilUint64         *uint64
 )
 
+func TestAccounts(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("eth_accounts")
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), res.ID)
+	assert.Equal(t, "2.0", res.JSONRPC)
+	assert.Nil(t, res.Error)
+	assert.Equal(t, "[]", string(res.Result))
+}
+
 func TestBlockNumber(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -65,17 +83,17 @@ func TestBlockNumber(t *testing.T) {
 			ExpectedResult: blockNumTen.Uint64(),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumTen.Uint64(), nil).
 					Once()
 			},
@@ -86,17 +104,17 @@ func TestBlockNumber(t *testing.T) {
 			ExpectedResult: 0,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -156,8 +174,8 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -172,10 +190,10 @@ func TestCall(t *testing.T) {
 					return match
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -199,11 +217,11 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
@@ -217,9 +235,9 @@ func TestCall(t *testing.T) {
 						hex.EncodeToHex(tx.Data()) == hex.EncodeToHex(*txArgs.Data) &&
 						tx.Nonce() == nonce
 				})
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -241,9 +259,9 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -258,10 +276,10 @@ func TestCall(t *testing.T) {
 					return match
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -283,11 +301,11 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
@@ -301,9 +319,9 @@ func TestCall(t *testing.T) {
 						hex.EncodeToHex(tx.Data()) == hex.EncodeToHex(*txArgs.Data) &&
 						tx.Nonce() == nonce
 				})
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -325,8 +343,8 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -340,10 +358,10 @@ func TestCall(t *testing.T) {
 						tx.Nonce() == nonce
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -363,10 +381,10 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.MaxCumulativeGasUsed})
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
-				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(blockHeader, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.State.On("GetL2BlockHeaderByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(blockHeader, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -380,9 +398,9 @@ func TestCall(t *testing.T) {
 					return hasTx && gasMatch && toMatch && gasPriceMatch && valueMatch && dataMatch
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -402,10 +420,10 @@ func TestCall(t *testing.T) {
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.MaxCumulativeGasUsed})
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
-				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(blockHeader, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.State.On("GetL2BlockHeaderByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(blockHeader, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -419,9 +437,9 @@ func TestCall(t *testing.T) {
 					return hasTx && gasMatch && toMatch && gasPriceMatch && valueMatch && dataMatch
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -440,12 +458,12 @@ func TestCall(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get block header"),
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
-				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(nil, errors.New("failed to get block header")).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockHeaderByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(nil, errors.New("failed to get block header")).Once()
 			},
 		},
 		{
@@ -465,9 +483,9 @@ func TestCall(t *testing.T) {
 			expectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to process unsigned transaction"),
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -482,10 +500,10 @@ func TestCall(t *testing.T) {
 					return hasTx && gasMatch && toMatch && gasPriceMatch && valueMatch && dataMatch && nonceMatch
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{Err: errors.New("failed to process unsigned transaction")}, nil).
 					Once()
 			},
@@ -507,9 +525,9 @@ func TestCall(t *testing.T) {
 			expectedError:  types.NewRPCError(types.RevertedErrorCode, "execution reverted"),
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
 				nonce := uint64(7)
-				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
 					gasPrice := big.NewInt(0).SetBytes(*txArgs.GasPrice)
@@ -524,10 +542,10 @@ func TestCall(t *testing.T) {
 					return hasTx && gasMatch && toMatch && gasPriceMatch && valueMatch && dataMatch && nonceMatch
 				})
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
-				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", mock.Anything, txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
 					Return(&runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil).
 					Once()
 			},
@@ -585,6 +603,7 @@ func TestCoinbase(t *testing.T) {
 	}{
 		{"Coinbase not configured", true, nil, nil, nil, common.Address{}},
 		{"Get trusted sequencer coinbase directly", true, state.AddressPtr(common.HexToAddress("0x1")), nil, nil, common.HexToAddress("0x1")},
+		{"Get trusted sequencer coinbase as checksum address", true, state.AddressPtr(common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")), nil, nil, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")},
 		{"Get trusted sequencer coinbase via permissionless", false, state.AddressPtr(common.HexToAddress("0x1")), nil, nil, common.HexToAddress("0x1")},
 		{"Ignore permissionless config", false, state.AddressPtr(common.HexToAddress("0x2")), state.AddressPtr(common.HexToAddress("0x1")), nil, common.HexToAddress("0x2")},
 	}
@@ -624,6 +643,7 @@ func TestCoinbase(t *testing.T) {
 			result := common.HexToAddress(s)
 
 			assert.Equal(t, tc.expectedCoinbase.String(), result.String())
+			assert.Equal(t, tc.expectedCoinbase.String(), s)
 
 			sequencerServer.Stop()
 			if !tc.callSequencer {
@@ -633,6 +653,208 @@ func TestCoinbase(t *testing.T) {
 	}
 }
 
+func TestMaxCallGas(t *testing.T) {
+	const maxCallGas = uint64(50000)
+
+	cfg := getSequencerDefaultConfig()
+	cfg.MaxCallGas = maxCallGas
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	txArgs := types.TxArgs{
+		From: state.HexToAddressPtr("0x1"),
+		To:   state.HexToAddressPtr("0x2"),
+		Gas:  types.ArgUint64Ptr(types.ArgUint64(cfg.MaxCumulativeGasUsed - 1)),
+		Data: types.ArgBytesPtr([]byte("data")),
+	}
+	txMatchByGas := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx != nil && tx.Gas() == maxCallGas
+	})
+	nonce := uint64(7)
+
+	t.Run("eth_call gas is clamped to the max call gas", func(t *testing.T) {
+		m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+		m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+		block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+		m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+		m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
+		m.State.
+			On("ProcessUnsignedTransaction", mock.Anything, txMatchByGas, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+			Return(&runtime.ExecutionResult{ReturnValue: []byte("hello world")}, nil).
+			Once()
+
+		res, err := s.JSONRPCCall("eth_call", txArgs, hex.EncodeBig(blockNumOne))
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result types.ArgBytes
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		assert.Equal(t, []byte("hello world"), []byte(result))
+	})
+
+	t.Run("eth_call out of gas at the max call gas isn't reported as gas exhausted", func(t *testing.T) {
+		m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+		m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+		block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+		m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+		m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
+		m.State.
+			On("ProcessUnsignedTransaction", mock.Anything, txMatchByGas, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+			Return(&runtime.ExecutionResult{Err: runtime.ErrOutOfGas}, nil).
+			Once()
+
+		res, err := s.JSONRPCCall("eth_call", txArgs, hex.EncodeBig(blockNumOne))
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+		assert.Equal(t, runtime.ErrOutOfGas.Error(), res.Error.Message)
+	})
+
+	t.Run("eth_estimateGas gas is clamped to the max call gas", func(t *testing.T) {
+		m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+		m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+		block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+		m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
+		m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
+		m.State.
+			On("EstimateGas", txMatchByGas, *txArgs.From, nilUint64, m.DbTx).
+			Return(uint64(21000), nil, nil).
+			Once()
+
+		res, err := s.JSONRPCCall("eth_estimateGas", txArgs)
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result types.ArgUint64
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		assert.Equal(t, uint64(21000), uint64(result))
+	})
+}
+
+func TestGetMaxCallGasCappedHeader(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.MaxCallGas = 30000
+	e := &EthEndpoints{cfg: cfg}
+
+	gas := func(gas uint64) *types.ArgUint64 {
+		argGas := types.ArgUint64(gas)
+		return &argGas
+	}
+	testCases := []struct {
+		name           string
+		gas            *types.ArgUint64
+		expectedHeader string
+	}{
+		{name: "gas not requested", gas: nil, expectedHeader: ""},
+		{name: "gas below the max call gas", gas: gas(21000), expectedHeader: ""},
+		{name: "gas at the max call gas", gas: gas(30000), expectedHeader: ""},
+		{name: "gas above the max call gas", gas: gas(50000), expectedHeader: "30000"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			req := withResponseHeader(httptest.NewRequest(http.MethodPost, "/", nil), header)
+
+			maxGas, executionCapped := e.getMaxCallGas(req.Context(), &types.TxArgs{Gas: tc.gas})
+			assert.Equal(t, cfg.MaxCallGas, maxGas)
+			assert.False(t, executionCapped)
+			assert.Equal(t, tc.expectedHeader, header.Get(callGasCappedHeader))
+		})
+	}
+
+	// The requests without HTTP response, like the WS ones, don't report it
+	maxGas, _ := e.getMaxCallGas(context.Background(), &types.TxArgs{Gas: gas(50000)})
+	assert.Equal(t, cfg.MaxCallGas, maxGas)
+
+	// The MaxCallGasExecution ceiling below the max call gas bounds it
+	e.cfg.MaxCallGasExecution = 25000
+	header := http.Header{}
+	req := withResponseHeader(httptest.NewRequest(http.MethodPost, "/", nil), header)
+	maxGas, executionCapped := e.getMaxCallGas(req.Context(), &types.TxArgs{Gas: gas(50000)})
+	assert.Equal(t, uint64(25000), maxGas)
+	assert.True(t, executionCapped)
+	assert.Equal(t, "25000", header.Get(callGasCappedHeader))
+}
+
+func TestMaxCallGasExecution(t *testing.T) {
+	const maxCallGas = uint64(30000)
+
+	cfg := getSequencerDefaultConfig()
+	cfg.MaxCallGasExecution = maxCallGas
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	txArgs := types.TxArgs{
+		From: state.HexToAddressPtr("0x1"),
+		To:   state.HexToAddressPtr("0x2"),
+		Data: types.ArgBytesPtr([]byte("data")),
+	}
+	txMatchByGas := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx != nil && tx.Gas() == maxCallGas
+	})
+	nonce := uint64(7)
+
+	t.Run("eth_call out of gas at the ceiling", func(t *testing.T) {
+		m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+		m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+		block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot, GasLimit: cfg.MaxCumulativeGasUsed}))
+		m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+		m.State.On("GetL2BlockHeaderByNumber", mock.Anything, blockNumOneUint64, m.DbTx).Return(block.Header(), nil).Once()
+		m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
+		m.State.
+			On("ProcessUnsignedTransaction", mock.Anything, txMatchByGas, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+			Return(&runtime.ExecutionResult{Err: runtime.ErrOutOfGas}, nil).
+			Once()
+
+		res, err := s.JSONRPCCall("eth_call", txArgs, hex.EncodeBig(blockNumOne))
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+		assert.Equal(t, "execution reverted: gas exhausted", res.Error.Message)
+	})
+
+	estimateGasTestCases := []struct {
+		name            string
+		estimateErr     error
+		expectedMessage string
+	}{
+		{
+			name:            "eth_estimateGas out of gas at the ceiling",
+			estimateErr:     &state.GasRequiredExceedsAllowanceError{Gas: maxCallGas, Err: runtime.ErrOutOfGas},
+			expectedMessage: "execution reverted: gas exhausted",
+		},
+		{
+			name:            "eth_estimateGas out of gas below the ceiling",
+			estimateErr:     &state.GasRequiredExceedsAllowanceError{Gas: maxCallGas - 1, Err: runtime.ErrOutOfGas},
+			expectedMessage: fmt.Sprintf("failed to estimate gas: gas required exceeds allowance (%d)", maxCallGas-1),
+		},
+		{
+			name:            "eth_estimateGas failed at the ceiling without running out of gas",
+			estimateErr:     &state.GasRequiredExceedsAllowanceError{Gas: maxCallGas, Err: runtime.ErrInvalidJump},
+			expectedMessage: fmt.Sprintf("failed to estimate gas: gas required exceeds allowance (%d)", maxCallGas),
+		},
+	}
+	for _, tc := range estimateGasTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+			m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+			block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+			m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
+			m.State.On("GetNonce", mock.Anything, *txArgs.From, blockRoot).Return(nonce, nil).Once()
+			m.State.
+				On("EstimateGas", txMatchByGas, *txArgs.From, nilUint64, m.DbTx).
+				Return(uint64(0), nil, tc.estimateErr).
+				Once()
+
+			res, err := s.JSONRPCCall("eth_estimateGas", txArgs)
+			require.NoError(t, err)
+			require.NotNil(t, res.Error)
+			assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+			assert.Equal(t, tc.expectedMessage, res.Error.Message)
+		})
+	}
+}
+
 func TestEstimateGas(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -678,14 +900,14 @@ func TestEstimateGas(t *testing.T) {
 					return matchTo && matchGasPrice && matchValue && matchData && matchNonce
 				})
 
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetNonce", context.Background(), *txArgs.From, blockRoot).
+					On("GetNonce", mock.Anything, *txArgs.From, blockRoot).
 					Return(nonce, nil).
 					Once()
 				m.State.
@@ -723,11 +945,11 @@ func TestEstimateGas(t *testing.T) {
 					return matchTo && matchGasPrice && matchValue && matchData && matchNonce
 				})
 
-				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
 
 				m.State.
 					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, m.DbTx).
@@ -774,26 +996,35 @@ func TestGasPrice(t *testing.T) {
 	testCases := []struct {
 		name               string
 		gasPrice           uint64
+		updatedAt          time.Time
 		error              error
 		expectedL2GasPrice uint64
-		expectedL1GasPrice uint64
+		expectedError      types.Error
 	}{
-		{"GasPrice nil", 0, nil, 0, 0},
-		{"GasPrice with value", 50, nil, 50, 100},
-		{"failed to get gas price", 50, errors.New("failed to get gas price"), 0, 0},
+		{"GasPrice nil", 0, time.Now(), nil, 0, nil},
+		{"GasPrice with value", 50, time.Now(), nil, 50, nil},
+		{"failed to get gas price", 50, time.Now(), errors.New("failed to get gas price"), 0, types.NewRPCError(types.ResourceUnavailableErrorCode, "failed to get gas prices from pool")},
+		{"GasPrice not initialized", 0, time.Time{}, nil, 0, types.NewRPCError(types.ResourceUnavailableErrorCode, gasPriceNotInitializedErrMsg)},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			m.Pool.
-				On("GetGasPrices", context.Background()).
+				On("GetGasPrices", mock.Anything).
 				Return(pool.GasPrices{
 					L2GasPrice: testCase.gasPrice,
 					L1GasPrice: testCase.gasPrice,
+					UpdatedAt:  testCase.updatedAt,
 				}, testCase.error).
 				Once()
 
 			gasPrices, err := c.SuggestGasPrice(context.Background())
+			if testCase.expectedError != nil {
+				rpcErr := err.(rpc.Error)
+				assert.Equal(t, testCase.expectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, testCase.expectedError.Error(), rpcErr.Error())
+				return
+			}
 			require.NoError(t, err)
 			assert.Equal(t, testCase.expectedL2GasPrice, gasPrices.Uint64())
 		})
@@ -824,17 +1055,17 @@ func TestGetBalance(t *testing.T) {
 			expectedError:   types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			setupMocks: func(m *mocksWrapper, t *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(nil, errors.New("failed to get last block number")).Once()
 			},
 		},
@@ -848,22 +1079,22 @@ func TestGetBalance(t *testing.T) {
 			expectedError:   nil,
 			setupMocks: func(m *mocksWrapper, t *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(block, nil).Once()
 
 				m.State.
-					On("GetBalance", context.Background(), addressArg, blockRoot).
+					On("GetBalance", mock.Anything, addressArg, blockRoot).
 					Return(t.balance, nil).
 					Once()
 			},
@@ -881,23 +1112,23 @@ func TestGetBalance(t *testing.T) {
 			expectedError:   nil,
 			setupMocks: func(m *mocksWrapper, t *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				m.State.
-					On("GetBalance", context.Background(), addressArg, blockRoot).
+					On("GetBalance", mock.Anything, addressArg, blockRoot).
 					Return(t.balance, nil).
 					Once()
 			},
@@ -912,20 +1143,20 @@ func TestGetBalance(t *testing.T) {
 			expectedError:   nil,
 			setupMocks: func(m *mocksWrapper, t *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetBalance", context.Background(), addressArg, blockRoot).
+					On("GetBalance", mock.Anything, addressArg, blockRoot).
 					Return(big.NewInt(0), state.ErrNotFound).
 					Once()
 			},
@@ -940,24 +1171,76 @@ func TestGetBalance(t *testing.T) {
 			expectedError:   types.NewRPCError(types.DefaultErrorCode, "failed to get balance from state"),
 			setupMocks: func(m *mocksWrapper, t *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetBalance", context.Background(), addressArg, blockRoot).
+					On("GetBalance", mock.Anything, addressArg, blockRoot).
 					Return(nil, errors.New("failed to get balance")).
 					Once()
 			},
 		},
+		{
+			name: "get pending balance",
+			params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			balance:         big.NewInt(1000),
+			expectedBalance: 1000,
+			expectedError:   nil,
+			setupMocks: func(m *mocksWrapper, t *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.Pool.
+					On("GetPendingBalance", mock.Anything, addressArg).
+					Return(t.balance, nil).
+					Once()
+			},
+		},
+		{
+			name: "get pending balance with pool failure",
+			params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			balance:         big.NewInt(1000),
+			expectedBalance: 0,
+			expectedError:   types.NewRPCError(types.DefaultErrorCode, "failed to get pending balance"),
+			setupMocks: func(m *mocksWrapper, t *testCase) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.Pool.
+					On("GetPendingBalance", mock.Anything, addressArg).
+					Return(nil, errors.New("failed to get pending balance")).
+					Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1006,17 +1289,17 @@ func TestGetL2BlockByHash(t *testing.T) {
 			ExpectedError:  ethereum.NotFound,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound)
 			},
 		},
@@ -1027,17 +1310,17 @@ func TestGetL2BlockByHash(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get block by hash from state"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, errors.New("failed to get block from state")).
 					Once()
 			},
@@ -1061,23 +1344,23 @@ func TestGetL2BlockByHash(t *testing.T) {
 				block := state.NewL2Block(state.NewL2Header(tc.ExpectedResult.Header()), tc.ExpectedResult.Transactions(), uncles, []*ethTypes.Receipt{ethTypes.NewReceipt([]byte{}, false, uint64(0))}, &trie.StackTrie{})
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				for _, tx := range tc.ExpectedResult.Transactions() {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 						Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil).
 						Once()
 				}
@@ -1112,6 +1395,76 @@ func TestGetL2BlockByHash(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("get block successfully without tx detail", func(t *testing.T) {
+		tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
+		header := &ethTypes.Header{Number: big.NewInt(1), UncleHash: ethTypes.EmptyUncleHash, Root: ethTypes.EmptyRootHash}
+		block := state.NewL2Block(state.NewL2Header(header), []*ethTypes.Transaction{tx}, []*state.L2Header{}, []*ethTypes.Receipt{ethTypes.NewReceipt([]byte{}, false, uint64(0))}, &trie.StackTrie{})
+		hash := common.HexToHash("0x345")
+
+		m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+		m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+		// the receipts are not loaded for the tx hashes
+		m.State.On("GetL2BlockByHash", mock.Anything, hash, m.DbTx).Return(block, nil).Once()
+
+		res, err := s.JSONRPCCall("eth_getBlockByHash", hash.String(), false)
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result types.Block
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		require.Len(t, result.Transactions, 1)
+		assert.Equal(t, tx.Hash(), *result.Transactions[0].Hash)
+		assert.Nil(t, result.Transactions[0].Tx)
+	})
+}
+
+func TestGetBlockByNumberResponseFields(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	// a zkEVM block has no coinbase, nonce nor difficulty in the header
+	header := &ethTypes.Header{Number: big.NewInt(1), ParentHash: common.HexToHash("0x1"), Time: 2, GasLimit: 3}
+	l2Block := state.NewL2Block(state.NewL2Header(header), nil, nil, nil, &trie.StackTrie{})
+
+	m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).Return(l2Block, nil).Once()
+
+	reqBody := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1",false]}`)
+	httpRes, err := http.Post(s.ServerURL, "application/json", bytes.NewReader(reqBody))
+	require.NoError(t, err)
+	defer httpRes.Body.Close()
+	resBody, err := io.ReadAll(httpRes.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, httpRes.StatusCode)
+
+	var res struct {
+		Result map[string]interface{} `json:"result"`
+		Error  interface{}            `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(resBody, &res))
+	require.Nil(t, res.Error)
+
+	// every field of the block is returned and none of them is null
+	fields := []string{"parentHash", "sha3Uncles", "miner", "stateRoot", "transactionsRoot", "receiptsRoot", "logsBloom",
+		"difficulty", "totalDifficulty", "size", "number", "gasLimit", "gasUsed", "timestamp", "extraData", "mixHash",
+		"nonce", "hash", "transactions", "uncles", "globalExitRoot", "blockInfoRoot"}
+	for _, field := range fields {
+		value, found := res.Result[field]
+		require.True(t, found, "field %s not found", field)
+		require.NotNil(t, value, "field %s is null", field)
+	}
+	assert.Len(t, res.Result, len(fields))
+
+	assert.Equal(t, "0x0", res.Result["difficulty"])
+	assert.Equal(t, res.Result["difficulty"], res.Result["totalDifficulty"])
+	assert.Equal(t, common.Address{}.String(), res.Result["miner"])
+	assert.Equal(t, "0x0000000000000000", res.Result["nonce"])
+	assert.Equal(t, "0x", res.Result["extraData"])
+	assert.Equal(t, l2Block.Hash().String(), res.Result["hash"])
+	assert.Equal(t, []interface{}{}, res.Result["transactions"])
+	assert.Equal(t, []interface{}{}, res.Result["uncles"])
 }
 
 func TestGetL2BlockByNumber(t *testing.T) {
@@ -1216,37 +1569,23 @@ func TestGetL2BlockByNumber(t *testing.T) {
 		rpcUncles = append(rpcUncles, uncle.Hash())
 	}
 
-	var miner *common.Address
-	if l2Block.Coinbase().String() != state.ZeroAddress.String() {
-		cb := l2Block.Coinbase()
-		miner = &cb
-	}
+	miner := l2Block.Coinbase()
 
-	var rpcBlockNonce *types.ArgBytes
-	if l2Block.Nonce() > 0 {
-		nBig := big.NewInt(0).SetUint64(l2Block.Nonce())
-		nBytes := common.LeftPadBytes(nBig.Bytes(), 8) //nolint:gomnd
-		n := types.ArgBytes(nBytes)
-		rpcBlockNonce = &n
-	}
+	nBig := big.NewInt(0).SetUint64(l2Block.Nonce())
+	rpcBlockNonce := types.ArgBytes(common.LeftPadBytes(nBig.Bytes(), 8)) //nolint:gomnd
 
-	difficulty := types.ArgUint64(0)
-	var totalDifficulty *types.ArgUint64
-	if l2Block.Difficulty() != nil {
-		difficulty = types.ArgUint64(l2Block.Difficulty().Uint64())
-		totalDifficulty = &difficulty
-	}
+	difficulty := types.ArgUint64(l2Block.Difficulty().Uint64())
 
 	rpcBlock := &types.Block{
 		ParentHash:      l2Block.ParentHash(),
 		Sha3Uncles:      l2Block.UncleHash(),
-		Miner:           miner,
+		Miner:           &miner,
 		StateRoot:       l2Block.Root(),
 		TxRoot:          l2Block.TxHash(),
 		ReceiptsRoot:    l2Block.ReceiptHash(),
 		LogsBloom:       ethTypes.CreateBloom(receipts),
 		Difficulty:      difficulty,
-		TotalDifficulty: totalDifficulty,
+		TotalDifficulty: &difficulty,
 		Size:            types.ArgUint64(l2Block.Size()),
 		Number:          types.ArgUint64(l2Block.NumberU64()),
 		GasLimit:        types.ArgUint64(l2Block.GasLimit()),
@@ -1254,7 +1593,7 @@ func TestGetL2BlockByNumber(t *testing.T) {
 		Timestamp:       types.ArgUint64(l2Block.Time()),
 		ExtraData:       l2Block.Extra(),
 		MixHash:         l2Block.MixDigest(),
-		Nonce:           rpcBlockNonce,
+		Nonce:           &rpcBlockNonce,
 		Hash:            state.HashPtr(l2Block.Hash()),
 		GlobalExitRoot:  l2Block.GlobalExitRoot(),
 		BlockInfoRoot:   l2Block.BlockInfoRoot(),
@@ -1270,17 +1609,17 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), tc.Number.Uint64(), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, tc.Number.Uint64(), m.DbTx).
 					Return(nil, state.ErrNotFound)
 			},
 		},
@@ -1291,23 +1630,23 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), tc.Number.Uint64(), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, tc.Number.Uint64(), m.DbTx).
 					Return(l2Block, nil).
 					Once()
 
 				for _, receipt := range receipts {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), receipt.TxHash, m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, receipt.TxHash, m.DbTx).
 						Return(receipt, nil).
 						Once()
 				}
@@ -1320,28 +1659,28 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(tc.ExpectedResult.Number), nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(l2Block, nil).
 					Once()
 
 				for _, receipt := range receipts {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), receipt.TxHash, m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, receipt.TxHash, m.DbTx).
 						Return(receipt, nil).
 						Once()
 				}
@@ -1354,17 +1693,17 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -1376,22 +1715,22 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load block from state by number 1"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(1), nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), uint64(1), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).
 					Return(nil, errors.New("failed to load block by number")).
 					Once()
 			},
@@ -1402,32 +1741,165 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedResult: rpcBlock,
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
-				lastBlockHeader := &ethTypes.Header{Number: big.NewInt(0).SetUint64(uint64(rpcBlock.Number))}
+				// the last block timestamp is in the future so the pending block timestamp is deterministic
+				lastBlockHeader := &ethTypes.Header{
+					Number: big.NewInt(0).SetUint64(uint64(rpcBlock.Number)),
+					Time:   uint64(time.Now().Add(time.Hour).Unix()),
+				}
 				lastBlockHeader.Number.Sub(lastBlockHeader.Number, big.NewInt(1))
 				lastBlock := state.NewL2Block(state.NewL2Header(lastBlockHeader), nil, nil, nil, &trie.StackTrie{})
 
-				tc.ExpectedResult = &types.Block{}
-				tc.ExpectedResult.ParentHash = lastBlock.Hash()
-				tc.ExpectedResult.Number = types.ArgUint64(lastBlock.Number().Uint64() + 1)
-				tc.ExpectedResult.TxRoot = ethTypes.EmptyRootHash
-				tc.ExpectedResult.Sha3Uncles = ethTypes.EmptyUncleHash
-				tc.ExpectedResult.Size = 501
-				tc.ExpectedResult.ExtraData = []byte{}
+				pendingBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{
+					ParentHash: lastBlock.Hash(),
+					Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
+					UncleHash:  ethTypes.EmptyUncleHash,
+					GasLimit:   getSequencerDefaultConfig().MaxCumulativeGasUsed,
+					Time:       lastBlock.Time(),
+				}), nil, nil, nil, &trie.StackTrie{})
+				expectedResult, err := types.NewBlock(nil, pendingBlock, nil, false, false)
+				require.NoError(t, err)
+				expectedResult.ExtraData = []byte{}
+				tc.ExpectedResult = expectedResult
+
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2Block", mock.Anything, m.DbTx).
+					Return(lastBlock, nil).
+					Once()
+
+				m.Pool.
+					On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).
+					Return([]pool.Transaction{}, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "get pending block with the pool pending txs",
+			Number:         common.Big0.SetInt64(int64(types.PendingBlockNumber)),
+			ExpectedResult: rpcBlock,
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				lastBlockHeader := &ethTypes.Header{
+					Number: big.NewInt(0).SetUint64(uint64(rpcBlock.Number)),
+					Time:   uint64(time.Now().Add(time.Hour).Unix()),
+				}
+				lastBlock := state.NewL2Block(state.NewL2Header(lastBlockHeader), nil, nil, nil, &trie.StackTrie{})
+
+				gasLimit := getSequencerDefaultConfig().MaxCumulativeGasUsed
+				signer := ethTypes.NewEIP155Signer(big.NewInt(0).SetUint64(chainID))
+				newSenderTx := func(privateKey *ecdsa.PrivateKey, nonce uint64, gas uint64) pool.Transaction {
+					tx, err := ethTypes.SignTx(ethTypes.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(1), gas, big.NewInt(1), nil), signer, privateKey)
+					require.NoError(t, err)
+					return pool.Transaction{Transaction: *tx}
+				}
+				keyA, err := crypto.GenerateKey()
+				require.NoError(t, err)
+				keyB, err := crypto.GenerateKey()
+				require.NoError(t, err)
+				keyC, err := crypto.GenerateKey()
+				require.NoError(t, err)
+
+				// the txs of A are out of order and one nonce is already used, B has a nonce gap and the
+				// first tx of C doesn't fit in the batch gas limit
+				pendingTxs := []pool.Transaction{
+					newSenderTx(keyA, 5, 21000),
+					newSenderTx(keyB, 0, 21000),
+					newSenderTx(keyA, 3, 21000),
+					newSenderTx(keyC, 0, gasLimit),
+					newSenderTx(keyA, 2, 21000),
+					newSenderTx(keyB, 2, 21000),
+					newSenderTx(keyA, 4, 21000),
+					newSenderTx(keyC, 1, 21000),
+				}
+				expectedTxs := []*ethTypes.Transaction{
+					&pendingTxs[2].Transaction, &pendingTxs[6].Transaction, &pendingTxs[0].Transaction,
+					&pendingTxs[1].Transaction,
+				}
+
+				pendingBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{
+					ParentHash: lastBlock.Hash(),
+					Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
+					UncleHash:  ethTypes.EmptyUncleHash,
+					GasLimit:   gasLimit,
+					Time:       lastBlock.Time(),
+				}), expectedTxs, nil, nil, &trie.StackTrie{})
+				expectedResult, err := types.NewBlock(nil, pendingBlock, nil, false, false)
+				require.NoError(t, err)
+				expectedResult.ExtraData = []byte{}
+				tc.ExpectedResult = expectedResult
+
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2Block", mock.Anything, m.DbTx).
+					Return(lastBlock, nil).
+					Once()
+
+				m.Pool.
+					On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).
+					Return(pendingTxs, nil).
+					Once()
+
+				m.State.
+					On("GetNonce", mock.Anything, crypto.PubkeyToAddress(keyA.PublicKey), lastBlock.Root()).
+					Return(uint64(3), nil).
+					Once()
+
+				m.State.
+					On("GetNonce", mock.Anything, crypto.PubkeyToAddress(keyB.PublicKey), lastBlock.Root()).
+					Return(uint64(0), nil).
+					Once()
+
+				m.State.
+					On("GetNonce", mock.Anything, crypto.PubkeyToAddress(keyC.PublicKey), lastBlock.Root()).
+					Return(uint64(0), nil).
+					Once()
+			},
+		},
+		{
+			Name:           "get pending block fails to load the pool pending txs",
+			Number:         common.Big0.SetInt64(int64(types.PendingBlockNumber)),
+			ExpectedResult: nil,
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load pending txs from pool to compute the pending block"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				lastBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)}), nil, nil, nil, &trie.StackTrie{})
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(lastBlock, nil).
 					Once()
+
+				m.Pool.
+					On("GetPendingTxs", mock.Anything, uint64(maxPendingBlockTxs)).
+					Return(nil, errors.New("failed to load pending txs")).
+					Once()
 			},
 		},
 		{
@@ -1437,17 +1909,17 @@ func TestGetL2BlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load last block from state to compute the pending block"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(nil, errors.New("failed to load last block")).
 					Once()
 			},
@@ -1605,17 +2077,17 @@ func TestGetCode(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(nil, errors.New("failed to get last block number")).
 					Once()
 			},
@@ -1631,20 +2103,20 @@ func TestGetCode(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetCode", context.Background(), addressArg, blockRoot).
+					On("GetCode", mock.Anything, addressArg, blockRoot).
 					Return(nil, errors.New("failed to get code")).
 					Once()
 			},
@@ -1660,20 +2132,20 @@ func TestGetCode(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetCode", context.Background(), addressArg, blockRoot).
+					On("GetCode", mock.Anything, addressArg, blockRoot).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -1689,20 +2161,85 @@ func TestGetCode(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumOne.Uint64(), m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("GetCode", mock.Anything, addressArg, blockRoot).
+					Return(tc.ExpectedResult, nil).
+					Once()
+			},
+		},
+		{
+			Name: "get code of an EOA at the latest block",
+			Params: []interface{}{
+				addressArg.String(),
+			},
+			ExpectedResult: []byte{},
+			ExpectedError:  nil,
+
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.
+					On("GetLastL2Block", mock.Anything, m.DbTx).
+					Return(block, nil).
+					Once()
+
+				m.State.
+					On("GetCode", mock.Anything, addressArg, blockRoot).
+					Return([]byte{}, nil).
+					Once()
+			},
+		},
+		{
+			Name: "get code successfully at the pending block",
+			Params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			ExpectedResult: []byte{1, 2, 3},
+			ExpectedError:  nil,
+
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
+					Return(blockNumTen.Uint64(), nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTen.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetCode", context.Background(), addressArg, blockRoot).
+					On("GetCode", mock.Anything, addressArg, blockRoot).
 					Return(tc.ExpectedResult, nil).
 					Once()
 			},
@@ -1717,23 +2254,23 @@ func TestGetCode(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				m.State.
-					On("GetCode", context.Background(), addressArg, blockRoot).
+					On("GetCode", mock.Anything, addressArg, blockRoot).
 					Return(tc.ExpectedResult, nil).
 					Once()
 			},
@@ -1798,17 +2335,17 @@ func TestGetStorageAt(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(nil, errors.New("failed to get last block number")).
 					Once()
 			},
@@ -1827,21 +2364,21 @@ func TestGetStorageAt(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				blockNumber := big.NewInt(1)
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumber, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetStorageAt", context.Background(), addressArg, keyArg.Big(), blockRoot).
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
 					Return(nil, errors.New("failed to get storage at")).
 					Once()
 			},
@@ -1860,21 +2397,21 @@ func TestGetStorageAt(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				blockNumber := big.NewInt(1)
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumber, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetStorageAt", context.Background(), addressArg, keyArg.Big(), blockRoot).
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -1893,21 +2430,89 @@ func TestGetStorageAt(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				blockNumber := big.NewInt(1)
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumber, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetStorageAt", context.Background(), addressArg, keyArg.Big(), blockRoot).
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
+					Return(big.NewInt(123), nil).
+					Once()
+			},
+		},
+		{
+			Name: "uninitialized storage slot",
+			Params: []interface{}{
+				addressArg.String(),
+				keyArg.String(),
+				map[string]interface{}{
+					types.BlockNumberKey: hex.EncodeBig(blockNumOne),
+				},
+			},
+			ExpectedResult: common.Hash{}.Bytes(),
+			ExpectedError:  nil,
+
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				blockNumber := big.NewInt(1)
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumber, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
+					Return(big.NewInt(0), nil).
+					Once()
+			},
+		},
+		{
+			Name: "get storage at pending block successfully",
+			Params: []interface{}{
+				addressArg.String(),
+				keyArg.String(),
+				"pending",
+			},
+			ExpectedResult: common.BigToHash(big.NewInt(123)).Bytes(),
+			ExpectedError:  nil,
+
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
+					Return(blockNumTen.Uint64(), nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTen.Uint64(), m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
 					Return(big.NewInt(123), nil).
 					Once()
 			},
@@ -1926,23 +2531,23 @@ func TestGetStorageAt(t *testing.T) {
 
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				m.State.
-					On("GetStorageAt", context.Background(), addressArg, keyArg.Big(), blockRoot).
+					On("GetStorageAt", mock.Anything, addressArg, keyArg.Big(), blockRoot).
 					Return(big.NewInt(123), nil).
 					Once()
 			},
@@ -2009,17 +2614,17 @@ func TestSyncing(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last l2 block number from state")).
 					Once()
 			},
@@ -2030,22 +2635,22 @@ func TestSyncing(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get syncing info from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 
 				m.State.
-					On("GetSyncingInfo", context.Background(), m.DbTx).
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
 					Return(state.SyncingInfo{}, errors.New("failed to get syncing info from state")).
 					Once()
 			},
@@ -2056,22 +2661,22 @@ func TestSyncing(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 
 				m.State.
-					On("GetSyncingInfo", context.Background(), m.DbTx).
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
 					Return(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 2, LastBlockNumberSeen: 3, LastBlockNumberConsolidated: 3}, nil).
 					Once()
 			},
@@ -2082,22 +2687,22 @@ func TestSyncing(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 
 				m.State.
-					On("GetSyncingInfo", context.Background(), m.DbTx).
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
 					Return(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 1, LastBlockNumberSeen: 1, LastBlockNumberConsolidated: 1}, nil).
 					Once()
 			},
@@ -2108,22 +2713,22 @@ func TestSyncing(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 
 				m.State.
-					On("GetSyncingInfo", context.Background(), m.DbTx).
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
 					Return(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 2, LastBlockNumberSeen: 1, LastBlockNumberConsolidated: 1}, nil).
 					Once()
 			},
@@ -2186,17 +2791,17 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				tx := tc.ExpectedResult
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockHashAndIndex", context.Background(), tc.Hash, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockHashAndIndex", mock.Anything, tc.Hash, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
@@ -2206,7 +2811,7 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 				receipt.TransactionIndex = tc.Index
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(receipt, nil).
 					Once()
 			},
@@ -2219,17 +2824,17 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 			ExpectedError:  ethereum.NotFound,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockHashAndIndex", context.Background(), tc.Hash, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockHashAndIndex", mock.Anything, tc.Hash, uint64(tc.Index), m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -2242,17 +2847,17 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get transaction"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockHashAndIndex", context.Background(), tc.Hash, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockHashAndIndex", mock.Anything, tc.Hash, uint64(tc.Index), m.DbTx).
 					Return(nil, errors.New("failed to get transaction by block and index from state")).
 					Once()
 			},
@@ -2266,22 +2871,22 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				tx := ethTypes.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), []byte{})
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockHashAndIndex", context.Background(), tc.Hash, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockHashAndIndex", mock.Anything, tc.Hash, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -2295,22 +2900,22 @@ func TestGetTransactionL2onByBlockHashAndIndex(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				tx := ethTypes.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), []byte{})
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockHashAndIndex", context.Background(), tc.Hash, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockHashAndIndex", mock.Anything, tc.Hash, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(nil, errors.New("failed to get transaction receipt from state")).
 					Once()
 			},
@@ -2374,17 +2979,17 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 				tx := tc.ExpectedResult
 				blockNumber, _ := encoding.DecodeUint64orHex(&tc.BlockNumber)
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockNumberAndIndex", context.Background(), blockNumber, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockNumberAndIndex", mock.Anything, blockNumber, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
@@ -2393,7 +2998,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 				receipt.BlockNumber = big.NewInt(1)
 				receipt.TransactionIndex = tc.Index
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(receipt, nil).
 					Once()
 			},
@@ -2406,17 +3011,17 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -2430,17 +3035,17 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				blockNumber, _ := encoding.DecodeUint64orHex(&tc.BlockNumber)
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockNumberAndIndex", context.Background(), blockNumber, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockNumberAndIndex", mock.Anything, blockNumber, uint64(tc.Index), m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -2454,17 +3059,17 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				blockNumber, _ := encoding.DecodeUint64orHex(&tc.BlockNumber)
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockNumberAndIndex", context.Background(), blockNumber, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockNumberAndIndex", mock.Anything, blockNumber, uint64(tc.Index), m.DbTx).
 					Return(nil, errors.New("failed to get transaction by block and index from state")).
 					Once()
 			},
@@ -2480,22 +3085,22 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				blockNumber, _ := encoding.DecodeUint64orHex(&tc.BlockNumber)
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockNumberAndIndex", context.Background(), blockNumber, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockNumberAndIndex", mock.Anything, blockNumber, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -2511,22 +3116,22 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				blockNumber, _ := encoding.DecodeUint64orHex(&tc.BlockNumber)
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByL2BlockNumberAndIndex", context.Background(), blockNumber, uint64(tc.Index), m.DbTx).
+					On("GetTransactionByL2BlockNumberAndIndex", mock.Anything, blockNumber, uint64(tc.Index), m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 					Return(nil, errors.New("failed to get transaction receipt from state")).
 					Once()
 			},
@@ -2594,17 +3199,17 @@ func TestGetTransactionByHash(t *testing.T) {
 			ExpectedError:   nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(tc.ExpectedResult, nil).
 					Once()
 
@@ -2613,7 +3218,7 @@ func TestGetTransactionByHash(t *testing.T) {
 				receipt.BlockNumber = big.NewInt(1)
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tc.Hash, m.DbTx).
 					Return(receipt, nil).
 					Once()
 			},
@@ -2626,22 +3231,22 @@ func TestGetTransactionByHash(t *testing.T) {
 			ExpectedError:   nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 
 				m.Pool.
-					On("GetTxByHash", context.Background(), tc.Hash).
+					On("GetTxByHash", mock.Anything, tc.Hash).
 					Return(&pool.Transaction{Transaction: *tc.ExpectedResult, Status: pool.TxStatusPending}, nil).
 					Once()
 			},
@@ -2654,22 +3259,22 @@ func TestGetTransactionByHash(t *testing.T) {
 			ExpectedError:   ethereum.NotFound,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 
 				m.Pool.
-					On("GetTxByHash", context.Background(), tc.Hash).
+					On("GetTxByHash", mock.Anything, tc.Hash).
 					Return(nil, pool.ErrNotFound).
 					Once()
 			},
@@ -2682,17 +3287,17 @@ func TestGetTransactionByHash(t *testing.T) {
 			ExpectedError:   types.NewRPCError(types.DefaultErrorCode, "failed to load transaction by hash from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, errors.New("failed to load transaction by hash from state")).
 					Once()
 			},
@@ -2705,22 +3310,22 @@ func TestGetTransactionByHash(t *testing.T) {
 			ExpectedError:   types.NewRPCError(types.DefaultErrorCode, "failed to load transaction by hash from pool"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 
 				m.Pool.
-					On("GetTxByHash", context.Background(), tc.Hash).
+					On("GetTxByHash", mock.Anything, tc.Hash).
 					Return(nil, errors.New("failed to load transaction by hash from pool")).
 					Once()
 			},
@@ -2734,22 +3339,22 @@ func TestGetTransactionByHash(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				tx := &ethTypes.Transaction{}
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -2763,22 +3368,22 @@ func TestGetTransactionByHash(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				tx := &ethTypes.Transaction{}
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(tx, nil).
 					Once()
 
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
+					On("GetTransactionReceipt", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, errors.New("failed to load transaction receipt from state")).
 					Once()
 			},
@@ -2810,6 +3415,46 @@ func TestGetTransactionByHash(t *testing.T) {
 	}
 }
 
+func TestGetTransactionByHashOfPoolTx(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
+	m.DbTx.
+		On("Commit", mock.Anything).
+		Return(nil).
+		Once()
+
+	m.State.
+		On("BeginStateTransaction", mock.Anything).
+		Return(m.DbTx, nil).
+		Once()
+
+	m.State.
+		On("GetTransactionByHash", mock.Anything, tx.Hash(), m.DbTx).
+		Return(nil, state.ErrNotFound).
+		Once()
+
+	m.Pool.
+		On("GetTxByHash", mock.Anything, tx.Hash()).
+		Return(&pool.Transaction{Transaction: *tx, Status: pool.TxStatusPending}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("eth_getTransactionByHash", tx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	// the tx is not in a block yet, so the block fields are null instead of missing
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, tx.Hash().String(), result["hash"])
+	for _, field := range []string{"blockHash", "blockNumber", "transactionIndex"} {
+		value, found := result[field]
+		assert.True(t, found, field)
+		assert.Nil(t, value, field)
+	}
+}
+
 func TestGetBlockTransactionCountByHash(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -2830,17 +3475,17 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockTransactionCountByHash", context.Background(), tc.BlockHash, m.DbTx).
+					On("GetL2BlockTransactionCountByHash", mock.Anything, tc.BlockHash, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -2852,17 +3497,17 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to count transactions"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockTransactionCountByHash", context.Background(), tc.BlockHash, m.DbTx).
+					On("GetL2BlockTransactionCountByHash", mock.Anything, tc.BlockHash, m.DbTx).
 					Return(uint64(0), errors.New("failed to count txs")).
 					Once()
 			},
@@ -2911,22 +3556,22 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				blockNumber := uint64(10)
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumber, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockTransactionCountByNumber", context.Background(), blockNumber, m.DbTx).
+					On("GetL2BlockTransactionCountByNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -2938,17 +3583,17 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.Pool.
-					On("CountPendingTransactions", context.Background()).
+					On("CountPendingTransactions", mock.Anything).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -2960,17 +3605,17 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -2983,22 +3628,22 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				blockNumber := uint64(10)
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumber, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockTransactionCountByNumber", context.Background(), blockNumber, m.DbTx).
+					On("GetL2BlockTransactionCountByNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(uint64(0), errors.New("failed to count")).
 					Once()
 			},
@@ -3010,17 +3655,17 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to count pending transactions"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.Pool.
-					On("CountPendingTransactions", context.Background()).
+					On("CountPendingTransactions", mock.Anything).
 					Return(uint64(0), errors.New("failed to count")).
 					Once()
 			},
@@ -3072,20 +3717,20 @@ func TestGetTransactionCount(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetLastL2Block", mock.Anything, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetNonce", context.Background(), addressArg, blockRoot).
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -3100,23 +3745,109 @@ func TestGetTransactionCount(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
 				m.State.
-					On("GetL2BlockByHash", context.Background(), blockHash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, blockHash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				m.State.
-					On("GetNonce", context.Background(), addressArg, blockRoot).
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
+					Return(uint64(10), nil).
+					Once()
+			},
+		},
+		{
+			Name: "Count pending txs including the txs queued in the worker",
+			Params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			ExpectedResult: uint(13),
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
+					Return(blockNumTen.Uint64(), nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+
+				m.Pool.
+					On("GetNonce", mock.Anything, addressArg).
+					Return(uint64(11), nil).
+					Once()
+
+				m.Sequencer.
+					On("GetPendingNonce", addressArg).
+					Return(uint64(13)).
+					Once()
+
+				m.State.
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
+					Return(uint64(10), nil).
+					Once()
+			},
+		},
+		{
+			Name: "Count pending txs from the pool when the worker has no txs for the address",
+			Params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			ExpectedResult: uint(11),
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
+					Return(blockNumTen.Uint64(), nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+
+				m.Pool.
+					On("GetNonce", mock.Anything, addressArg).
+					Return(uint64(11), nil).
+					Once()
+
+				m.Sequencer.
+					On("GetPendingNonce", addressArg).
+					Return(uint64(0)).
+					Once()
+
+				m.State.
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -3131,25 +3862,25 @@ func TestGetTransactionCount(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumTen.Uint64(), nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTenUint64, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetNonce", context.Background(), addressArg, blockRoot).
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
 					Return(uint64(0), state.ErrNotFound).
 					Once()
 			},
@@ -3164,17 +3895,17 @@ func TestGetTransactionCount(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -3189,25 +3920,25 @@ func TestGetTransactionCount(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to count transactions"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumTen.Uint64(), nil).
 					Once()
 
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
-				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, blockNumTenUint64, m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("GetNonce", context.Background(), addressArg, blockRoot).
+					On("GetNonce", mock.Anything, addressArg, blockRoot).
 					Return(uint64(0), errors.New("failed to get nonce")).
 					Once()
 			},
@@ -3239,96 +3970,175 @@ func TestGetTransactionCount(t *testing.T) {
 	}
 }
 
-func TestGetTransactionReceipt(t *testing.T) {
-	s, m, c := newSequencerMockedServer(t)
+func TestGetBlockReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
 
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+	require.NoError(t, err)
+
+	txs := make([]*ethTypes.Transaction, 0, 2)
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := ethTypes.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		txs = append(txs, signedTx)
+	}
+	header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)})
+	l2Block := state.NewL2Block(header, txs, nil, nil, &trie.StackTrie{})
+
+	receipts := make([]ethTypes.Receipt, 0, len(txs))
+	for i, tx := range txs {
+		receipts = append(receipts, ethTypes.Receipt{
+			Status:            ethTypes.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			TxHash:            tx.Hash(),
+			GasUsed:           21000,
+			BlockHash:         l2Block.Hash(),
+			BlockNumber:       big.NewInt(1),
+			TransactionIndex:  uint(i),
+			Logs:              []*ethTypes.Log{},
+		})
+	}
+
 	type testCase struct {
 		Name           string
-		Hash           common.Hash
-		ExpectedResult *ethTypes.Receipt
-		ExpectedError  interface{}
-		SetupMocks     func(m *mocksWrapper, tc testCase)
+		BlockArg       interface{}
+		ExpectedResult []ethTypes.Receipt
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
 	}
 
 	testCases := []testCase{
 		{
-			Name:           "Get TX receipt Successfully",
-			Hash:           common.HexToHash("0x123"),
-			ExpectedResult: ethTypes.NewReceipt([]byte{}, false, 0),
-			ExpectedError:  nil,
-			SetupMocks: func(m *mocksWrapper, tc testCase) {
-				m.DbTx.
-					On("Commit", context.Background()).
-					Return(nil).
-					Once()
+			Name:           "get block receipts by number successfully",
+			BlockArg:       "0x1",
+			ExpectedResult: receipts,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByL2BlockNumber", mock.Anything, uint64(1), m.DbTx).Return(receipts, nil).Once()
+			},
+		},
+		{
+			Name:           "get block receipts by hash successfully",
+			BlockArg:       map[string]interface{}{"blockHash": l2Block.Hash().String()},
+			ExpectedResult: receipts,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByHash", mock.Anything, l2Block.Hash(), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByL2BlockNumber", mock.Anything, uint64(1), m.DbTx).Return(receipts, nil).Once()
+			},
+		},
+		{
+			Name:     "block not found",
+			BlockArg: "0x1",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:     "pending block has no receipts",
+			BlockArg: "pending",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			Name:          "failed to load the block receipts",
+			BlockArg:      "0x1",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load receipts of block 1"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByL2BlockNumber", mock.Anything, uint64(1), m.DbTx).Return(nil, errors.New("failed to load receipts")).Once()
+			},
+		},
+	}
 
-				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
 
-				tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
-				privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
-				require.NoError(t, err)
-				auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
-				require.NoError(t, err)
+			res, err := s.JSONRPCCall("eth_getBlockReceipts", tc.BlockArg)
+			require.NoError(t, err)
 
-				signedTx, err := auth.Signer(auth.From, tx)
-				require.NoError(t, err)
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
 
-				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(signedTx, nil).
-					Once()
+			if tc.ExpectedResult == nil {
+				assert.Equal(t, "null", string(res.Result))
+				return
+			}
 
-				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
-					Return(tc.ExpectedResult, nil).
-					Once()
-			},
-		},
-		{
-			Name:           "Get TX receipt but tx not found",
-			Hash:           common.HexToHash("0x123"),
-			ExpectedResult: nil,
-			ExpectedError:  ethereum.NotFound,
-			SetupMocks: func(m *mocksWrapper, tc testCase) {
-				m.DbTx.
-					On("Commit", context.Background()).
-					Return(nil).
-					Once()
+			var result []types.Receipt
+			require.NoError(t, json.Unmarshal(res.Result, &result))
+			require.Len(t, result, len(tc.ExpectedResult))
+			for i, receipt := range result {
+				assert.Equal(t, tc.ExpectedResult[i].TxHash, receipt.TxHash)
+				assert.Equal(t, tc.ExpectedResult[i].TransactionIndex, uint(receipt.TxIndex))
+				assert.Equal(t, tc.ExpectedResult[i].CumulativeGasUsed, uint64(receipt.CumulativeGasUsed))
+				assert.Equal(t, auth.From, receipt.FromAddr)
+			}
+		})
+	}
+}
 
-				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
+func TestGetTransactionReceipt(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
+	defer s.Stop()
 
-				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(nil, state.ErrNotFound).
-					Once()
-			},
-		},
+	type testCase struct {
+		Name           string
+		Hash           common.Hash
+		ExpectedResult *ethTypes.Receipt
+		ExpectedError  interface{}
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
 		{
-			Name:           "Get TX receipt but failed to get tx",
+			Name:           "Get TX receipt Successfully",
 			Hash:           common.HexToHash("0x123"),
-			ExpectedResult: nil,
-			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get tx from state"),
+			ExpectedResult: ethTypes.NewReceipt([]byte{}, false, 0),
+			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
+				tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
+				privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
+				require.NoError(t, err)
+				auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+				require.NoError(t, err)
+
+				signedTx, err := auth.Signer(auth.From, tx)
+				require.NoError(t, err)
+
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(nil, errors.New("failed to get tx")).
+					On("GetTransactionWithReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(signedTx, tc.ExpectedResult, nil).
 					Once()
 			},
 		},
@@ -3339,32 +4149,18 @@ func TestGetTransactionReceipt(t *testing.T) {
 			ExpectedError:  ethereum.NotFound,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
-				tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
-				privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
-				require.NoError(t, err)
-				auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
-				require.NoError(t, err)
-
-				signedTx, err := auth.Signer(auth.From, tx)
-				require.NoError(t, err)
-
-				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(signedTx, nil).
-					Once()
-
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
-					Return(nil, state.ErrNotFound).
+					On("GetTransactionWithReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(nil, nil, state.ErrNotFound).
 					Once()
 			},
 		},
@@ -3375,32 +4171,18 @@ func TestGetTransactionReceipt(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get tx receipt from state"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
-				tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
-				privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
-				require.NoError(t, err)
-				auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
-				require.NoError(t, err)
-
-				signedTx, err := auth.Signer(auth.From, tx)
-				require.NoError(t, err)
-
-				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(signedTx, nil).
-					Once()
-
 				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
-					Return(nil, errors.New("failed to get tx receipt from state")).
+					On("GetTransactionWithReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(nil, nil, errors.New("failed to get tx receipt from state")).
 					Once()
 			},
 		},
@@ -3411,25 +4193,20 @@ func TestGetTransactionReceipt(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to build the receipt response"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				tx := ethTypes.NewTransaction(1, common.Address{}, big.NewInt(1), 1, big.NewInt(1), []byte{})
 
 				m.State.
-					On("GetTransactionByHash", context.Background(), tc.Hash, m.DbTx).
-					Return(tx, nil).
-					Once()
-
-				m.State.
-					On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).
-					Return(ethTypes.NewReceipt([]byte{}, false, 0), nil).
+					On("GetTransactionWithReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(tx, ethTypes.NewReceipt([]byte{}, false, 0), nil).
 					Once()
 			},
 		},
@@ -3459,6 +4236,34 @@ func TestGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func TestGetTransactionReceiptOfPendingTx(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	// a pending tx is not in the state until it's included in a L2 block
+	hash := common.HexToHash("0x123")
+	m.DbTx.
+		On("Commit", mock.Anything).
+		Return(nil).
+		Once()
+
+	m.State.
+		On("BeginStateTransaction", mock.Anything).
+		Return(m.DbTx, nil).
+		Once()
+
+	m.State.
+		On("GetTransactionWithReceipt", mock.Anything, hash, m.DbTx).
+		Return(nil, nil, state.ErrNotFound).
+		Once()
+
+	res, err := s.JSONRPCCall("eth_getTransactionReceipt", hash.String())
+	require.NoError(t, err)
+
+	assert.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
+
 func TestSendRawTransactionViaGeth(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3483,7 +4288,7 @@ func TestSendRawTransactionViaGeth(t *testing.T) {
 				})
 
 				m.Pool.
-					On("AddTx", context.Background(), txMatchByHash, "").
+					On("AddTx", mock.Anything, txMatchByHash, "").
 					Return(nil).
 					Once()
 			},
@@ -3500,11 +4305,45 @@ func TestSendRawTransactionViaGeth(t *testing.T) {
 				})
 
 				m.Pool.
-					On("AddTx", context.Background(), txMatchByHash, "").
+					On("AddTx", mock.Anything, txMatchByHash, "").
 					Return(errors.New("failed to add TX to the pool")).
 					Once()
 			},
 		},
+		{
+			Name:          "Send TX already known",
+			Tx:            ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{}),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "already known"),
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				txMatchByHash := mock.MatchedBy(func(tx ethTypes.Transaction) bool {
+					h1 := tx.Hash().Hex()
+					h2 := tc.Tx.Hash().Hex()
+					return h1 == h2
+				})
+
+				m.Pool.
+					On("AddTx", mock.Anything, txMatchByHash, "").
+					Return(fmt.Errorf("failed to store tx: %w", pool.ErrAlreadyKnown)).
+					Once()
+			},
+		},
+		{
+			Name:          "Send TX with nonce too low",
+			Tx:            ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{}),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "nonce too low: next nonce is 5"),
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				txMatchByHash := mock.MatchedBy(func(tx ethTypes.Transaction) bool {
+					h1 := tx.Hash().Hex()
+					h2 := tc.Tx.Hash().Hex()
+					return h1 == h2
+				})
+
+				m.Pool.
+					On("AddTx", mock.Anything, txMatchByHash, "").
+					Return(fmt.Errorf("%w: next nonce is %d", pool.ErrNonceTooLow, 5)).
+					Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -3558,7 +4397,7 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 			},
 			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
 				m.Pool.
-					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					On("AddTx", mock.Anything, mock.IsType(ethTypes.Transaction{}), "").
 					Return(nil).
 					Once()
 			},
@@ -3580,7 +4419,7 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 			},
 			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
 				m.Pool.
-					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					On("AddTx", mock.Anything, mock.IsType(ethTypes.Transaction{}), "").
 					Return(errors.New("failed to add TX to the pool")).
 					Once()
 			},
@@ -3648,7 +4487,7 @@ func TestSendRawTransactionViaGethForNonSequencerNode(t *testing.T) {
 				})
 
 				m.Pool.
-					On("AddTx", context.Background(), txMatchByHash, "").
+					On("AddTx", mock.Anything, txMatchByHash, "").
 					Return(nil).
 					Once()
 			},
@@ -3665,7 +4504,7 @@ func TestSendRawTransactionViaGethForNonSequencerNode(t *testing.T) {
 				})
 
 				m.Pool.
-					On("AddTx", context.Background(), txMatchByHash, "").
+					On("AddTx", mock.Anything, txMatchByHash, "").
 					Return(errors.New("failed to add TX to the pool")).
 					Once()
 			},
@@ -3744,9 +4583,45 @@ func TestProtocolVersion(t *testing.T) {
 	err = json.Unmarshal(res.Result, &result)
 	require.NoError(t, err)
 
+	assert.Equal(t, "0x41", result)
+}
+
+func TestHashrate(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("eth_hashrate")
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), res.ID)
+	assert.Equal(t, "2.0", res.JSONRPC)
+	assert.Nil(t, res.Error)
+
+	var result string
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
 	assert.Equal(t, "0x0", result)
 }
 
+func TestMining(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("eth_mining")
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), res.ID)
+	assert.Equal(t, "2.0", res.JSONRPC)
+	assert.Nil(t, res.Error)
+
+	var result bool
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	assert.False(t, result)
+}
+
 func TestNewFilter(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3774,12 +4649,12 @@ func TestNewFilter(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -3798,12 +4673,12 @@ func TestNewFilter(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -3823,12 +4698,12 @@ func TestNewFilter(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "invalid block range"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -3843,12 +4718,12 @@ func TestNewFilter(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "logs are limited to a 10000 block range"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -3862,12 +4737,12 @@ func TestNewFilter(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new log filter"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 				m.Storage.
@@ -3978,33 +4853,27 @@ func TestNewPendingTransactionFilter(t *testing.T) {
 	}
 
 	testCases := []testCase{
-		// {
-		// 	Name:           "New pending transaction filter created successfully",
-		// 	ExpectedResult: "1",
-		// 	ExpectedError:  nil,
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("1", nil).
-		// 			Once()
-		// 	},
-		// },
-		// {
-		// 	Name:           "failed to create new pending transaction filter",
-		// 	ExpectedResult: "",
-		// 	ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("", errors.New("failed to add new pending transaction filter")).
-		// 			Once()
-		// 	},
-		// },
-		{
-			Name:           "can't create pending tx filter",
+		{
+			Name:           "New pending transaction filter created successfully",
+			ExpectedResult: "1",
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
+					Return("1", nil).
+					Once()
+			},
+		},
+		{
+			Name:           "failed to create new pending transaction filter",
 			ExpectedResult: "",
-			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "not supported yet"),
-			SetupMocks:     func(m *mocksWrapper, tc testCase) {},
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
+					Return("", errors.New("failed to add new pending transaction filter")).
+					Once()
+			},
 		},
 	}
 
@@ -4137,17 +5006,17 @@ func TestGetLogs(t *testing.T) {
 				}
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
+					On("GetLogs", mock.Anything, tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
 					Return(logs, nil).
 					Once()
 			},
@@ -4166,17 +5035,17 @@ func TestGetLogs(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				var since *time.Time
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
+					On("GetLogs", mock.Anything, tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
 					Return(nil, errors.New("failed to get logs from state")).
 					Once()
 			},
@@ -4194,17 +5063,17 @@ func TestGetLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number from state")).
 					Once()
 			},
@@ -4222,17 +5091,17 @@ func TestGetLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number from state")).
 					Once()
 			},
@@ -4250,12 +5119,12 @@ func TestGetLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -4274,17 +5143,17 @@ func TestGetLogs(t *testing.T) {
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				var since *time.Time
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
+					On("GetLogs", mock.Anything, tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, m.DbTx).
 					Return(nil, state.ErrMaxLogsCountLimitExceeded).
 					Once()
 			},
@@ -4366,12 +5235,12 @@ func TestGetFilterLogs(t *testing.T) {
 				}
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -4381,7 +5250,7 @@ func TestGetFilterLogs(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, since, m.DbTx).
+					On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, since, m.DbTx).
 					Return(logs, nil).
 					Once()
 			},
@@ -4519,7 +5388,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetL2BlockHashesSince", context.Background(), filter.LastPoll, mock.IsType(nilTx)).
+					On("GetL2BlockHashesSince", mock.Anything, filter.LastPoll, mock.IsType(nilTx)).
 					Return(tc.ExpectedResults[0].([]common.Hash), nil).
 					Once()
 
@@ -4534,7 +5403,7 @@ func TestGetFilterChanges(t *testing.T) {
 							Once()
 
 						m.State.
-							On("GetL2BlockHashesSince", context.Background(), filter.LastPoll, mock.IsType(nilTx)).
+							On("GetL2BlockHashesSince", mock.Anything, filter.LastPoll, mock.IsType(nilTx)).
 							Return(tc.ExpectedResults[1].([]common.Hash), nil).
 							Once()
 
@@ -4549,7 +5418,7 @@ func TestGetFilterChanges(t *testing.T) {
 									Once()
 
 								m.State.
-									On("GetL2BlockHashesSince", context.Background(), filter.LastPoll, mock.IsType(nilTx)).
+									On("GetL2BlockHashesSince", mock.Anything, filter.LastPoll, mock.IsType(nilTx)).
 									Return(tc.ExpectedResults[2].([]common.Hash), nil).
 									Once()
 
@@ -4600,7 +5469,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.Pool.
-					On("GetPendingTxHashesSince", context.Background(), filter.LastPoll).
+					On("GetPendingTxHashesSince", mock.Anything, filter.LastPoll).
 					Return(tc.ExpectedResults[0].([]common.Hash), nil).
 					Once()
 
@@ -4615,7 +5484,7 @@ func TestGetFilterChanges(t *testing.T) {
 							Once()
 
 						m.Pool.
-							On("GetPendingTxHashesSince", context.Background(), filter.LastPoll).
+							On("GetPendingTxHashesSince", mock.Anything, filter.LastPoll).
 							Return(tc.ExpectedResults[1].([]common.Hash), nil).
 							Once()
 
@@ -4630,7 +5499,7 @@ func TestGetFilterChanges(t *testing.T) {
 									Once()
 
 								m.Pool.
-									On("GetPendingTxHashesSince", context.Background(), filter.LastPoll).
+									On("GetPendingTxHashesSince", mock.Anything, filter.LastPoll).
 									Return(tc.ExpectedResults[2].([]common.Hash), nil).
 									Once()
 
@@ -4703,7 +5572,7 @@ func TestGetFilterChanges(t *testing.T) {
 				}
 
 				m.State.
-					On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
+					On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
 					Return(logs, nil).
 					Once()
 
@@ -4725,7 +5594,7 @@ func TestGetFilterChanges(t *testing.T) {
 						}
 
 						m.State.
-							On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
+							On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
 							Return(logs, nil).
 							Once()
 
@@ -4740,7 +5609,7 @@ func TestGetFilterChanges(t *testing.T) {
 									Once()
 
 								m.State.
-									On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
+									On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
 									Return([]*ethTypes.Log{}, nil).
 									Once()
 
@@ -4807,7 +5676,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetL2BlockHashesSince", context.Background(), filter.LastPoll, mock.IsType(nilTx)).
+					On("GetL2BlockHashesSince", mock.Anything, filter.LastPoll, mock.IsType(nilTx)).
 					Return([]common.Hash{}, errors.New("failed to get hashes")).
 					Once()
 			},
@@ -4833,7 +5702,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetL2BlockHashesSince", context.Background(), filter.LastPoll, mock.IsType(nilTx)).
+					On("GetL2BlockHashesSince", mock.Anything, filter.LastPoll, mock.IsType(nilTx)).
 					Return([]common.Hash{}, nil).
 					Once()
 
@@ -4864,7 +5733,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.Pool.
-					On("GetPendingTxHashesSince", context.Background(), filter.LastPoll).
+					On("GetPendingTxHashesSince", mock.Anything, filter.LastPoll).
 					Return([]common.Hash{}, errors.New("failed to get pending tx hashes")).
 					Once()
 			},
@@ -4890,7 +5759,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.Pool.
-					On("GetPendingTxHashesSince", context.Background(), filter.LastPoll).
+					On("GetPendingTxHashesSince", mock.Anything, filter.LastPoll).
 					Return([]common.Hash{}, nil).
 					Once()
 
@@ -4930,7 +5799,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
+					On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
 					Return(nil, errors.New("failed to get logs")).
 					Once()
 			},
@@ -4964,7 +5833,7 @@ func TestGetFilterChanges(t *testing.T) {
 					Once()
 
 				m.State.
-					On("GetLogs", context.Background(), uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
+					On("GetLogs", mock.Anything, uint64(*logFilter.FromBlock), uint64(*logFilter.ToBlock), logFilter.Addresses, logFilter.Topics, logFilter.BlockHash, &filter.LastPoll, mock.IsType(nilTx)).
 					Return([]*ethTypes.Log{}, nil).
 					Once()
 
@@ -5120,12 +5989,12 @@ func TestSubscribeNewLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -5135,6 +6004,70 @@ func TestSubscribeNewLogs(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name: "Subscribe to new logs by addresses and topics successfully",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tc.Filter = ethereum.FilterQuery{
+					FromBlock: big.NewInt(1), ToBlock: big.NewInt(10),
+					Addresses: []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")},
+					Topics: [][]common.Hash{
+						{common.HexToHash("0xA"), common.HexToHash("0xB")},
+						nil,
+						{common.HexToHash("0xC")},
+					},
+				}
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				expectedTopics := [][]common.Hash{
+					{common.HexToHash("0xA"), common.HexToHash("0xB")},
+					{},
+					{common.HexToHash("0xC")},
+				}
+				m.Storage.
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.MatchedBy(func(filter LogFilter) bool {
+						return assert.ObjectsAreEqual(tc.Filter.Addresses, filter.Addresses) &&
+							assert.ObjectsAreEqual(expectedTopics, filter.Topics)
+					})).
+					Return("0x1", nil).
+					Once()
+			},
+		},
+		{
+			Name:          "Subscribe to new logs fails due to too many topics",
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, ErrFilterTooManyTopics.Error()),
+			Prepare: func(t *testing.T, tc *testCase) {
+				tc.Filter = ethereum.FilterQuery{
+					BlockHash: &blockHash,
+					Topics:    [][]common.Hash{nil, nil, nil, nil, {common.HexToHash("0xA")}},
+				}
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.Storage.
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					Return("", ErrFilterTooManyTopics).
+					Once()
+			},
+		},
 		{
 			Name:          "Subscribe to new logs fails to add new filter to storage",
 			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to create new log filter"),
@@ -5145,12 +6078,12 @@ func TestSubscribeNewLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -5170,12 +6103,12 @@ func TestSubscribeNewLogs(t *testing.T) {
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -5211,6 +6144,159 @@ func TestSubscribeNewLogs(t *testing.T) {
 	}
 }
 
+func TestSubscribeSyncing(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name          string
+		ExpectedError interface{}
+		SetupMocks    func(m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name: "Subscribe to syncing successfully",
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
+					Return(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 5, LastBlockNumberSeen: 10}, nil).
+					Once()
+
+				m.Storage.
+					On("NewSyncingFilter", mock.IsType(&concurrentWsConn{})).
+					Return("0x1", nil).
+					Once()
+			},
+		},
+		{
+			Name:          "Subscribe to syncing fails to add filter to storage",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to create new syncing filter"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetSyncingInfo", mock.Anything, m.DbTx).
+					Return(state.SyncingInfo{}, nil).
+					Once()
+
+				m.Storage.
+					On("NewSyncingFilter", mock.IsType(&concurrentWsConn{})).
+					Return("", fmt.Errorf("failed to add filter to storage")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			c := s.GetWSClient()
+
+			ctx := context.Background()
+			syncingChannel := make(chan interface{}, 100)
+			sub, err := c.Client().EthSubscribe(ctx, syncingChannel, "syncing")
+
+			if sub != nil {
+				assert.NotNil(t, sub)
+			}
+
+			if err != nil || tc.ExpectedError != nil {
+				if expectedErr, ok := tc.ExpectedError.(*types.RPCError); ok {
+					rpcErr := err.(rpc.Error)
+					assert.Equal(t, expectedErr.ErrorCode(), rpcErr.ErrorCode())
+					assert.Equal(t, expectedErr.Error(), rpcErr.Error())
+				} else {
+					assert.Equal(t, tc.ExpectedError, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifySyncing(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	syncing := true
+	e := &EthEndpoints{state: m.State, storage: m.Storage, lastSyncing: &syncing}
+	filter := &Filter{
+		ID:            "0x1",
+		Type:          FilterTypeSyncing,
+		wsQueue:       state.NewQueue[[]byte](),
+		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
+	}
+	event := state.NewL2BlockEvent{Block: *state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)}))}
+
+	notify := func(syncInfo state.SyncingInfo) {
+		m.Storage.
+			On("GetAllSyncingFiltersWithWSConn").
+			Return([]*Filter{filter}).
+			Once()
+
+		m.State.
+			On("GetSyncingInfo", mock.Anything, nil).
+			Return(syncInfo, nil).
+			Once()
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		e.notifySyncing(&wg, event)
+		wg.Wait()
+	}
+
+	// still syncing, nothing to notify
+	notify(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 5, LastBlockNumberSeen: 10})
+	assert.True(t, filter.wsQueue.IsEmpty())
+
+	// sync completed, a single false is notified
+	notify(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 10, LastBlockNumberSeen: 10})
+	data, err := filter.wsQueue.Pop()
+	require.NoError(t, err)
+	assert.Equal(t, "false", string(data))
+	assert.True(t, filter.wsQueue.IsEmpty())
+
+	notify(state.SyncingInfo{InitialSyncingBlock: 1, CurrentBlockNumber: 11, LastBlockNumberSeen: 11})
+	assert.True(t, filter.wsQueue.IsEmpty())
+
+	// syncing again, the sync progress is notified
+	notify(state.SyncingInfo{InitialSyncingBlock: 11, CurrentBlockNumber: 11, LastBlockNumberSeen: 20})
+	data, err = filter.wsQueue.Pop()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"syncing":true,"status":{"startingBlock":"0xb","currentBlock":"0xb","highestBlock":"0x14"}}`, string(data))
+
+	// without subscriptions the status is no longer tracked
+	m.Storage.
+		On("GetAllSyncingFiltersWithWSConn").
+		Return([]*Filter{}).
+		Once()
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	e.notifySyncing(&wg, event)
+	wg.Wait()
+	assert.Nil(t, e.lastSyncing)
+}
+
 func TestFilterLogs(t *testing.T) {
 	logs := []*ethTypes.Log{{
 		Address: common.HexToAddress("0x1"),
@@ -5304,6 +6390,45 @@ func TestFilterLogs(t *testing.T) {
 		{common.HexToHash("0xA")},
 	}}})
 	assert.Equal(t, 0, len(filteredLogs))
+
+	// topic positions are combined with AND and the topics in a position with OR
+	logs = []*ethTypes.Log{
+		{Address: common.HexToAddress("0x1"), Topics: []common.Hash{common.HexToHash("0xA"), common.HexToHash("0xC")}},
+		{Address: common.HexToAddress("0x1"), Topics: []common.Hash{common.HexToHash("0xB"), common.HexToHash("0xC")}},
+		{Address: common.HexToAddress("0x2"), Topics: []common.Hash{common.HexToHash("0xA"), common.HexToHash("0xD")}},
+		{Address: common.HexToAddress("0x3"), Topics: []common.Hash{common.HexToHash("0xC")}},
+	}
+	filteredLogs = filterLogs(logs, &Filter{Parameters: LogFilter{Topics: [][]common.Hash{
+		{common.HexToHash("0xA"), common.HexToHash("0xB")},
+		{common.HexToHash("0xC")},
+	}}})
+	require.Equal(t, 2, len(filteredLogs))
+	assert.Equal(t, common.HexToHash("0xA"), filteredLogs[0].Topics[0])
+	assert.Equal(t, common.HexToHash("0xB"), filteredLogs[1].Topics[0])
+
+	// addresses are combined with OR and with AND with the topics
+	filteredLogs = filterLogs(logs, &Filter{Parameters: LogFilter{
+		Addresses: []common.Address{common.HexToAddress("0x2"), common.HexToAddress("0x3")},
+		Topics:    [][]common.Hash{{common.HexToHash("0xA"), common.HexToHash("0xC")}},
+	}})
+	require.Equal(t, 2, len(filteredLogs))
+	assert.Equal(t, common.HexToAddress("0x2"), filteredLogs[0].Address)
+	assert.Equal(t, common.HexToAddress("0x3"), filteredLogs[1].Address)
+
+	// null wildcards decoded from the filter object
+	var logFilter LogFilter
+	err := json.Unmarshal([]byte(`{"topics":[null,["0x000000000000000000000000000000000000000000000000000000000000000d",null]]}`), &logFilter)
+	require.NoError(t, err)
+	assert.Equal(t, [][]common.Hash{{}, {}}, logFilter.Topics)
+	filteredLogs = filterLogs(logs, &Filter{Parameters: logFilter})
+	assert.Equal(t, 3, len(filteredLogs))
+
+	logFilter = LogFilter{}
+	err = json.Unmarshal([]byte(`{"topics":[null,["0x000000000000000000000000000000000000000000000000000000000000000d"]]}`), &logFilter)
+	require.NoError(t, err)
+	filteredLogs = filterLogs(logs, &Filter{Parameters: logFilter})
+	require.Equal(t, 1, len(filteredLogs))
+	assert.Equal(t, common.HexToAddress("0x2"), filteredLogs[0].Address)
 }
 
 func TestContains(t *testing.T) {
@@ -0,0 +1,29 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+)
+
+const rpcModuleVersion = "1.0"
+
+// RPCEndpoints contains implementations for the "rpc" RPC endpoints
+type RPCEndpoints struct {
+	handler *Handler
+}
+
+// NewRPCEndpoints returns RPCEndpoints
+func NewRPCEndpoints(handler *Handler) *RPCEndpoints {
+	return &RPCEndpoints{
+		handler: handler,
+	}
+}
+
+// Modules returns the list of namespaces registered in the server
+// and their version
+func (e *RPCEndpoints) Modules() (interface{}, types.Error) {
+	modules := make(map[string]string, len(e.handler.serviceMap))
+	for name := range e.handler.serviceMap {
+		modules[name] = rpcModuleVersion
+	}
+	return modules, nil
+}
@@ -0,0 +1,37 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModules(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("rpc_modules")
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), res.ID)
+	assert.Equal(t, "2.0", res.JSONRPC)
+	assert.Nil(t, res.Error)
+
+	var result map[string]string
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		APIEth:    rpcModuleVersion,
+		APINet:    rpcModuleVersion,
+		APIDebug:  rpcModuleVersion,
+		APIZKEVM:  rpcModuleVersion,
+		APITxPool: rpcModuleVersion,
+		APIWeb3:   rpcModuleVersion,
+		APIRPC:    rpcModuleVersion,
+		APITrace:  rpcModuleVersion,
+	}
+	assert.Equal(t, expected, result)
+}
@@ -0,0 +1,263 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	parityTraceTypeCall    = "call"
+	parityTraceTypeCreate  = "create"
+	parityTraceTypeSuicide = "suicide"
+)
+
+var callTracerName = "callTracer"
+
+// TraceEndpoints is the trace jsonrpc endpoint, it provides the
+// transaction traces in the Parity/OpenEthereum format
+type TraceEndpoints struct {
+	cfg      Config
+	state    types.StateInterface
+	etherman types.EthermanInterface
+	txMan    DBTxManager
+}
+
+// NewTraceEndpoints returns TraceEndpoints
+func NewTraceEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *TraceEndpoints {
+	return &TraceEndpoints{
+		cfg:      cfg,
+		state:    state,
+		etherman: etherman,
+	}
+}
+
+// parityTrace is a single entry of the flat list of traces of a transaction
+type parityTrace struct {
+	Action              interface{} `json:"action"`
+	BlockHash           common.Hash `json:"blockHash"`
+	BlockNumber         uint64      `json:"blockNumber"`
+	Error               string      `json:"error,omitempty"`
+	Result              interface{} `json:"result"`
+	Subtraces           int         `json:"subtraces"`
+	TraceAddress        []int       `json:"traceAddress"`
+	TransactionHash     common.Hash `json:"transactionHash"`
+	TransactionPosition uint64      `json:"transactionPosition"`
+	Type                string      `json:"type"`
+}
+
+type parityCallAction struct {
+	CallType string          `json:"callType"`
+	From     common.Address  `json:"from"`
+	Gas      types.ArgUint64 `json:"gas"`
+	Input    types.ArgBytes  `json:"input"`
+	To       common.Address  `json:"to"`
+	Value    types.ArgBig    `json:"value"`
+}
+
+type parityCallResult struct {
+	GasUsed types.ArgUint64 `json:"gasUsed"`
+	Output  types.ArgBytes  `json:"output"`
+}
+
+type parityCreateAction struct {
+	From  common.Address  `json:"from"`
+	Gas   types.ArgUint64 `json:"gas"`
+	Init  types.ArgBytes  `json:"init"`
+	Value types.ArgBig    `json:"value"`
+}
+
+type parityCreateResult struct {
+	Address common.Address  `json:"address"`
+	Code    types.ArgBytes  `json:"code"`
+	GasUsed types.ArgUint64 `json:"gasUsed"`
+}
+
+type paritySuicideAction struct {
+	Address       common.Address `json:"address"`
+	Balance       types.ArgBig   `json:"balance"`
+	RefundAddress common.Address `json:"refundAddress"`
+}
+
+// callTracerFrame is the frame returned by the callTracer
+type callTracerFrame struct {
+	Type    string            `json:"type"`
+	From    common.Address    `json:"from"`
+	Gas     types.ArgUint64   `json:"gas"`
+	GasUsed types.ArgUint64   `json:"gasUsed"`
+	To      *common.Address   `json:"to"`
+	Input   types.ArgBytes    `json:"input"`
+	Output  types.ArgBytes    `json:"output"`
+	Error   string            `json:"error"`
+	Calls   []callTracerFrame `json:"calls"`
+	Value   *types.ArgBig     `json:"value"`
+}
+
+// parityTraceContext identifies the transaction the traces belong to
+type parityTraceContext struct {
+	blockHash   common.Hash
+	blockNumber uint64
+	txHash      common.Hash
+	txIndex     uint64
+}
+
+// Transaction creates a response for trace_transaction request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_transaction
+func (t *TraceEndpoints) Transaction(ctx context.Context, hash types.ArgHash) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(ctx, t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		return t.buildTransactionTraces(ctx, hash.Hash(), dbTx)
+	})
+}
+
+// Block creates a response for trace_block request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_block
+func (t *TraceEndpoints) Block(ctx context.Context, number types.BlockNumber) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(ctx, t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, "block #%d not found", blockNumber)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
+		}
+
+		traces := []parityTrace{}
+		for _, tx := range block.Transactions() {
+			txTraces, rpcErr := t.buildTransactionTraces(ctx, tx.Hash(), dbTx)
+			if rpcErr != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get trace for transaction %v: %v", rpcErr, true, tx.Hash().String(), rpcErr.Error())
+			}
+			traces = append(traces, txTraces...)
+		}
+
+		return traces, nil
+	})
+}
+
+func (t *TraceEndpoints) buildTransactionTraces(ctx context.Context, hash common.Hash, dbTx pgx.Tx) ([]parityTrace, types.Error) {
+	receipt, err := t.state.GetTransactionReceipt(ctx, hash, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "transaction not found")
+	} else if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get tx receipt: %v", err.Error())
+	}
+
+	traceConfig := state.TraceConfig{Tracer: &callTracerName}
+	result, err := t.state.DebugTransaction(ctx, hash, traceConfig, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "transaction not found")
+	} else if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get trace: %v", err.Error())
+	}
+
+	var frame callTracerFrame
+	if err := json.Unmarshal(result.TraceResult, &frame); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to parse trace: %v", err.Error())
+	}
+
+	traceCtx := parityTraceContext{
+		blockHash:   receipt.BlockHash,
+		blockNumber: receipt.BlockNumber.Uint64(),
+		txHash:      hash,
+		txIndex:     uint64(receipt.TransactionIndex),
+	}
+
+	return appendParityTraces([]parityTrace{}, frame, []int{}, traceCtx), nil
+}
+
+// appendParityTraces converts the call frame and all its inner calls
+// into parity traces, appending them in depth-first order
+func appendParityTraces(traces []parityTrace, frame callTracerFrame, traceAddress []int, traceCtx parityTraceContext) []parityTrace {
+	trace := parityTrace{
+		BlockHash:           traceCtx.blockHash,
+		BlockNumber:         traceCtx.blockNumber,
+		Subtraces:           len(frame.Calls),
+		TraceAddress:        traceAddress,
+		TransactionHash:     traceCtx.txHash,
+		TransactionPosition: traceCtx.txIndex,
+	}
+
+	value := types.ArgBig{}
+	if frame.Value != nil {
+		value = *frame.Value
+	}
+	to := common.Address{}
+	if frame.To != nil {
+		to = *frame.To
+	}
+
+	switch frame.Type {
+	case "CREATE", "CREATE2":
+		trace.Type = parityTraceTypeCreate
+		trace.Action = parityCreateAction{
+			From:  frame.From,
+			Gas:   frame.Gas,
+			Init:  frame.Input,
+			Value: value,
+		}
+		if frame.Error == "" {
+			trace.Result = parityCreateResult{
+				Address: to,
+				Code:    frame.Output,
+				GasUsed: frame.GasUsed,
+			}
+		}
+	case "SELFDESTRUCT":
+		trace.Type = parityTraceTypeSuicide
+		trace.Action = paritySuicideAction{
+			Address:       frame.From,
+			Balance:       value,
+			RefundAddress: to,
+		}
+	default:
+		trace.Type = parityTraceTypeCall
+		trace.Action = parityCallAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     frame.From,
+			Gas:      frame.Gas,
+			Input:    frame.Input,
+			To:       to,
+			Value:    value,
+		}
+		if frame.Error == "" {
+			trace.Result = parityCallResult{
+				GasUsed: frame.GasUsed,
+				Output:  frame.Output,
+			}
+		}
+	}
+	trace.Error = toParityTraceError(frame.Error)
+
+	traces = append(traces, trace)
+	for i, call := range frame.Calls {
+		callTraceAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(callTraceAddress, traceAddress)
+		callTraceAddress = append(callTraceAddress, i)
+		traces = appendParityTraces(traces, call, callTraceAddress, traceCtx)
+	}
+
+	return traces
+}
+
+// toParityTraceError maps the errors reported by the callTracer
+// to the messages used by the parity traces
+func toParityTraceError(err string) string {
+	switch err {
+	case "execution reverted":
+		return "Reverted"
+	case "out of gas":
+		return "Out of gas"
+	default:
+		return err
+	}
+}
@@ -0,0 +1,219 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const callTracerResult = `{
+	"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x0000000000000000000000000000000000000002",
+	"gas": "0x5208", "gasUsed": "0x5000", "input": "0x01", "output": "0x02", "value": "0x1",
+	"calls": [
+		{
+			"type": "CREATE", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000003",
+			"gas": "0x100", "gasUsed": "0x50", "input": "0x6000", "output": "0x60", "value": "0x0",
+			"calls": [
+				{
+					"type": "SELFDESTRUCT", "from": "0x0000000000000000000000000000000000000003", "to": "0x0000000000000000000000000000000000000004",
+					"gas": "0x0", "gasUsed": "0x0", "input": "0x", "value": "0x5"
+				}
+			]
+		},
+		{
+			"type": "STATICCALL", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000005",
+			"gas": "0x10", "gasUsed": "0x10", "input": "0x", "error": "execution reverted"
+		}
+	]
+}`
+
+const parityTracesResult = `[
+	{
+		"action": {"callType": "call", "from": "0x0000000000000000000000000000000000000001", "gas": "0x5208", "input": "0x01", "to": "0x0000000000000000000000000000000000000002", "value": "0x1"},
+		"blockHash": "0x000000000000000000000000000000000000000000000000000000000000000a", "blockNumber": 10,
+		"result": {"gasUsed": "0x5000", "output": "0x02"},
+		"subtraces": 2, "traceAddress": [],
+		"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000123", "transactionPosition": 1,
+		"type": "call"
+	},
+	{
+		"action": {"from": "0x0000000000000000000000000000000000000002", "gas": "0x100", "init": "0x6000", "value": "0x0"},
+		"blockHash": "0x000000000000000000000000000000000000000000000000000000000000000a", "blockNumber": 10,
+		"result": {"address": "0x0000000000000000000000000000000000000003", "code": "0x60", "gasUsed": "0x50"},
+		"subtraces": 1, "traceAddress": [0],
+		"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000123", "transactionPosition": 1,
+		"type": "create"
+	},
+	{
+		"action": {"address": "0x0000000000000000000000000000000000000003", "balance": "0x5", "refundAddress": "0x0000000000000000000000000000000000000004"},
+		"blockHash": "0x000000000000000000000000000000000000000000000000000000000000000a", "blockNumber": 10,
+		"result": null,
+		"subtraces": 0, "traceAddress": [0, 0],
+		"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000123", "transactionPosition": 1,
+		"type": "suicide"
+	},
+	{
+		"action": {"callType": "staticcall", "from": "0x0000000000000000000000000000000000000002", "gas": "0x10", "input": "0x", "to": "0x0000000000000000000000000000000000000005", "value": "0x0"},
+		"blockHash": "0x000000000000000000000000000000000000000000000000000000000000000a", "blockNumber": 10,
+		"error": "Reverted",
+		"result": null,
+		"subtraces": 0, "traceAddress": [1],
+		"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000123", "transactionPosition": 1,
+		"type": "call"
+	}
+]`
+
+func TestTraceTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Hash           common.Hash
+		ExpectedResult string
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "trace transaction successfully",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: parityTracesResult,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				receipt := &ethTypes.Receipt{
+					TxHash:           tc.Hash,
+					BlockHash:        common.HexToHash("0xa"),
+					BlockNumber:      big.NewInt(10),
+					TransactionIndex: 1,
+				}
+				m.State.
+					On("GetTransactionReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(receipt, nil).
+					Once()
+
+				m.State.
+					On("DebugTransaction", mock.Anything, tc.Hash, mock.MatchedBy(func(cfg state.TraceConfig) bool {
+						return cfg.IsCallTracer()
+					}), m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(callTracerResult)}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "transaction not found",
+			Hash:          common.HexToHash("0x123"),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "transaction not found"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetTransactionReceipt", mock.Anything, tc.Hash, m.DbTx).
+					Return(nil, state.ErrNotFound).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("trace_transaction", tc.Hash.String())
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+
+			require.Nil(t, res.Error)
+			assert.JSONEq(t, tc.ExpectedResult, string(res.Result))
+		})
+	}
+}
+
+func TestTraceBlock(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x2"), big.NewInt(1), 21000, big.NewInt(1), []byte{0x1})
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10)}))
+	block = block.WithBody([]*ethTypes.Transaction{tx}, nil)
+
+	m.DbTx.
+		On("Commit", mock.Anything).
+		Return(nil).
+		Once()
+
+	m.State.
+		On("BeginStateTransaction", mock.Anything).
+		Return(m.DbTx, nil).
+		Once()
+
+	m.State.
+		On("GetL2BlockByNumber", mock.Anything, uint64(10), m.DbTx).
+		Return(block, nil).
+		Once()
+
+	receipt := &ethTypes.Receipt{
+		TxHash:           tx.Hash(),
+		BlockHash:        common.HexToHash("0xa"),
+		BlockNumber:      big.NewInt(10),
+		TransactionIndex: 0,
+	}
+	m.State.
+		On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
+		Return(receipt, nil).
+		Once()
+
+	m.State.
+		On("DebugTransaction", mock.Anything, tx.Hash(), mock.AnythingOfType("state.TraceConfig"), m.DbTx).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(`{"type":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","gas":"0x5208","gasUsed":"0x5208","input":"0x01","value":"0x1"}`)}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("trace_block", "0xa")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var traces []parityTrace
+	err = json.Unmarshal(res.Result, &traces)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	assert.Equal(t, parityTraceTypeCall, traces[0].Type)
+	assert.Equal(t, tx.Hash(), traces[0].TransactionHash)
+	assert.Equal(t, uint64(10), traces[0].BlockNumber)
+	assert.Equal(t, uint64(0), traces[0].TransactionPosition)
+	assert.Equal(t, 0, traces[0].Subtraces)
+	assert.Equal(t, []int{}, traces[0].TraceAddress)
+}
@@ -1,12 +1,22 @@
 package jsonrpc
 
 import (
+	"fmt"
+
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	sequencer types.SequencerInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints, the sequencer is nil when it isn't running in the same instance
+func NewTxPoolEndpoints(sequencer types.SequencerInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{sequencer: sequencer}
+}
 
 type contentResponse struct {
 	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
@@ -27,6 +37,11 @@ type txPoolTransaction struct {
 	TxIndex     interface{}     `json:"transactionIndex"`
 }
 
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
 // Content creates a response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
@@ -37,3 +52,42 @@ func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
 
 	return resp, nil
 }
+
+// Inspect creates a response for txpool_inspect request with the txs waiting in the sequencer
+// worker, the response is empty when the sequencer isn't running in the same instance.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+func (e *TxPoolEndpoints) Inspect() (interface{}, types.Error) {
+	resp := inspectResponse{
+		Pending: make(map[common.Address]map[uint64]string),
+		Queued:  make(map[common.Address]map[uint64]string),
+	}
+	if e.sequencer == nil {
+		return resp, nil
+	}
+
+	txs, err := e.sequencer.InspectWorker()
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to inspect the txpool, %s", err, true, err.Error())
+	}
+
+	for _, tx := range txs {
+		txsByNonce := resp.Pending
+		if tx.Queued {
+			txsByNonce = resp.Queued
+		}
+		if _, found := txsByNonce[tx.From]; !found {
+			txsByNonce[tx.From] = make(map[uint64]string)
+		}
+		txsByNonce[tx.From][tx.Nonce] = inspectSummary(tx)
+	}
+
+	return resp, nil
+}
+
+// inspectSummary formats the tx like geth does in txpool_inspect
+func inspectSummary(tx state.PendingTxSummary) string {
+	if tx.To != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value, tx.GasLimit, tx.GasPrice)
+	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value, tx.GasLimit, tx.GasPrice)
+}
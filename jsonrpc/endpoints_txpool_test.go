@@ -0,0 +1,94 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPoolInspect(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	txs := []state.PendingTxSummary{
+		{Hash: common.HexToHash("0x1"), From: from, Nonce: 1, GasPrice: big.NewInt(10), GasLimit: 21000, ReceivedAt: time.Now(), To: &to, Value: big.NewInt(5)},
+		{Hash: common.HexToHash("0x2"), From: from, Nonce: 3, GasPrice: big.NewInt(20), GasLimit: 50000, ReceivedAt: time.Now(), Value: big.NewInt(0), Queued: true},
+	}
+
+	type testCase struct {
+		Name              string
+		WithSequencer     bool
+		ExpectedResult    interface{}
+		ExpectedErrorCode int
+		SetupMocks        func(m *mocks.SequencerMock)
+	}
+
+	testCases := []testCase{
+		{
+			Name:          "empty when the sequencer is not running",
+			WithSequencer: false,
+			ExpectedResult: inspectResponse{
+				Pending: map[common.Address]map[uint64]string{},
+				Queued:  map[common.Address]map[uint64]string{},
+			},
+		},
+		{
+			Name:              "sequencer not started",
+			WithSequencer:     true,
+			ExpectedErrorCode: types.DefaultErrorCode,
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("InspectWorker").
+					Return(nil, errors.New("sequencer not started")).
+					Once()
+			},
+		},
+		{
+			Name:          "worker txs returned successfully",
+			WithSequencer: true,
+			ExpectedResult: inspectResponse{
+				Pending: map[common.Address]map[uint64]string{
+					from: {1: "0x0000000000000000000000000000000000000002: 5 wei + 21000 gas × 10 wei"},
+				},
+				Queued: map[common.Address]map[uint64]string{
+					from: {3: "contract creation: 0 wei + 50000 gas × 20 wei"},
+				},
+			},
+			SetupMocks: func(m *mocks.SequencerMock) {
+				m.On("InspectWorker").
+					Return(txs, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sequencer types.SequencerInterface
+			if tc.WithSequencer {
+				sequencerMock := mocks.NewSequencerMock(t)
+				if tc.SetupMocks != nil {
+					tc.SetupMocks(sequencerMock)
+				}
+				sequencer = sequencerMock
+			}
+
+			e := NewTxPoolEndpoints(sequencer)
+			result, rpcErr := e.Inspect()
+
+			if tc.ExpectedErrorCode != 0 {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, tc.ExpectedErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.Nil(t, rpcErr)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
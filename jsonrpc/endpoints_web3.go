@@ -1,8 +1,6 @@
 package jsonrpc
 
 import (
-	"math/big"
-
 	"github.com/0xPolygonHermez/zkevm-node"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"golang.org/x/crypto/sha3"
@@ -12,16 +10,16 @@ import (
 type Web3Endpoints struct {
 }
 
-// ClientVersion returns the client version.
+// ClientVersion returns the client version, with the version and the git revision set at build time
 func (e *Web3Endpoints) ClientVersion() (interface{}, types.Error) {
-	return zkevm.Version, nil
+	return zkevm.ClientVersion(), nil
 }
 
-// Sha3 returns the keccak256 hash of the given data.
-func (e *Web3Endpoints) Sha3(data types.ArgBig) (interface{}, types.Error) {
-	b := (*big.Int)(&data)
+// Sha3 returns the keccak256 hash of the given data. The data is hashed as bytes, so the
+// leading zeros are kept, the odd length values accepted by the big integers are left padded
+func (e *Web3Endpoints) Sha3(data types.ArgBytes) (interface{}, types.Error) {
 	hash := sha3.NewLegacyKeccak256()
-	hash.Write(b.Bytes()) //nolint:errcheck,gosec
+	hash.Write(data) //nolint:errcheck,gosec
 	keccak256Hash := hash.Sum(nil)
 	return types.ArgBytes(keccak256Hash), nil
 }
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/0xPolygonHermez/zkevm-node"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,23 +27,43 @@ func TestClientVersion(t *testing.T) {
 	err = json.Unmarshal(res.Result, &result)
 	require.NoError(t, err)
 
-	assert.Equal(t, zkevm.Version, result)
+	assert.Equal(t, zkevm.ClientVersion(), result)
 }
 
 func TestSha3(t *testing.T) {
 	s, _, _ := newSequencerMockedServer(t)
 	defer s.Stop()
 
-	res, err := s.JSONRPCCall("web3_sha3", "0x68656c6c6f20776f726c64")
-	require.NoError(t, err)
+	testCases := []struct {
+		name           string
+		data           string
+		expectedResult string
+	}{
+		{"hash data", "0x68656c6c6f20776f726c64", "0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad"},
+		{"hash data with leading zeros", "0x0000000000000000000000000000000000000000000000000000000000000001", crypto.Keccak256Hash(common.LeftPadBytes([]byte{1}, 32)).String()},
+		{"hash odd length data", "0x123", crypto.Keccak256Hash([]byte{0x01, 0x23}).String()},
+		{"hash empty data", "0x", crypto.Keccak256Hash().String()},
+	}
 
-	assert.Equal(t, float64(1), res.ID)
-	assert.Equal(t, "2.0", res.JSONRPC)
-	assert.Nil(t, res.Error)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := s.JSONRPCCall("web3_sha3", tc.data)
+			require.NoError(t, err)
 
-	var result string
-	err = json.Unmarshal(res.Result, &result)
-	require.NoError(t, err)
+			assert.Equal(t, float64(1), res.ID)
+			assert.Equal(t, "2.0", res.JSONRPC)
+			assert.Nil(t, res.Error)
+
+			var result string
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
 
-	assert.Equal(t, "0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad", result)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+
+	res, err := s.JSONRPCCall("web3_sha3", "0xzz")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
 }
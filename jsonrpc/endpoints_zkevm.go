@@ -2,12 +2,14 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -18,24 +20,104 @@ import (
 
 // ZKEVMEndpoints contains implementations for the "zkevm" RPC endpoints
 type ZKEVMEndpoints struct {
-	cfg      Config
-	state    types.StateInterface
-	etherman types.EthermanInterface
-	txMan    DBTxManager
+	cfg            Config
+	pool           types.PoolInterface
+	state          types.StateInterface
+	etherman       types.EthermanInterface
+	gasPriceSource string
+	txMan          DBTxManager
+
+	consolidatedBlockNumber          uint64
+	consolidatedBlockNumberUpdatedAt time.Time
+	consolidatedBlockNumberMutex     sync.RWMutex
+	// stopPolling is closed when the server stops to stop polling the consolidated block number
+	stopPolling     chan struct{}
+	stopPollingOnce sync.Once
+
+	// exitTree is the local exit tree used by GetL2ToL1MessageProof
+	exitTree *exitTree
 }
 
-// NewZKEVMEndpoints returns ZKEVMEndpoints
-func NewZKEVMEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *ZKEVMEndpoints {
-	return &ZKEVMEndpoints{
-		cfg:      cfg,
-		state:    state,
-		etherman: etherman,
+// NewZKEVMEndpoints returns ZKEVMEndpoints, gasPriceSource is how the L2 gas price is computed
+// by the gas price suggester
+func NewZKEVMEndpoints(cfg Config, pool types.PoolInterface, state types.StateInterface, etherman types.EthermanInterface, gasPriceSource string) *ZKEVMEndpoints {
+	z := &ZKEVMEndpoints{
+		cfg:            cfg,
+		pool:           pool,
+		state:          state,
+		etherman:       etherman,
+		gasPriceSource: gasPriceSource,
+		exitTree:       newExitTree(),
+		stopPolling:    make(chan struct{}),
 	}
+
+	if cfg.ConsolidatedBlockNumberCacheTTL.Duration > 0 {
+		go z.pollConsolidatedBlockNumber()
+	}
+
+	return z
+}
+
+// stop stops polling the consolidated block number, it's called when the server stops
+func (z *ZKEVMEndpoints) stop() {
+	z.stopPollingOnce.Do(func() { close(z.stopPolling) })
+}
+
+// pollConsolidatedBlockNumber keeps the cached consolidated block number updated,
+// it polls twice per TTL so the cache doesn't expire between polls
+func (z *ZKEVMEndpoints) pollConsolidatedBlockNumber() {
+	ticker := time.NewTicker(halfTTLInterval(z.cfg.ConsolidatedBlockNumberCacheTTL.Duration))
+	defer ticker.Stop()
+	for {
+		lastBlockNumber, err := z.state.GetLastConsolidatedL2BlockNumber(context.Background(), nil)
+		if err != nil {
+			log.Errorf("failed to poll last consolidated block number from state: %v", err)
+		} else {
+			z.consolidatedBlockNumberMutex.Lock()
+			z.consolidatedBlockNumber = lastBlockNumber
+			z.consolidatedBlockNumberUpdatedAt = time.Now()
+			z.consolidatedBlockNumberMutex.Unlock()
+		}
+		select {
+		case <-ticker.C:
+		case <-z.stopPolling:
+			return
+		}
+	}
+}
+
+// halfTTLInterval returns the interval to refresh a value that expires after the ttl, half the ttl
+// but at least 1ns, time.NewTicker panics with a zero interval
+func halfTTLInterval(ttl time.Duration) time.Duration {
+	interval := ttl / 2 //nolint:gomnd
+	if interval <= 0 {
+		interval = time.Nanosecond
+	}
+	return interval
+}
+
+// getCachedConsolidatedBlockNumber returns the cached consolidated block number
+// if the cache is enabled and the value has not expired
+func (z *ZKEVMEndpoints) getCachedConsolidatedBlockNumber() (uint64, bool) {
+	if z.cfg.ConsolidatedBlockNumberCacheTTL.Duration <= 0 {
+		return 0, false
+	}
+
+	z.consolidatedBlockNumberMutex.RLock()
+	defer z.consolidatedBlockNumberMutex.RUnlock()
+	if z.consolidatedBlockNumberUpdatedAt.IsZero() || time.Since(z.consolidatedBlockNumberUpdatedAt) > z.cfg.ConsolidatedBlockNumberCacheTTL.Duration {
+		return 0, false
+	}
+	return z.consolidatedBlockNumber, true
 }
 
 // ConsolidatedBlockNumber returns last block number related to the last verified batch
-func (z *ZKEVMEndpoints) ConsolidatedBlockNumber() (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) ConsolidatedBlockNumber(ctx context.Context) (interface{}, types.Error) {
+	if lastBlockNumber, ok := z.getCachedConsolidatedBlockNumber(); ok {
+		return hex.EncodeUint64(lastBlockNumber), nil
+	}
+
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		lastBlockNumber, err := z.state.GetLastConsolidatedL2BlockNumber(ctx, dbTx)
 		if err != nil {
 			const errorMessage = "failed to get last consolidated block number from state"
@@ -47,9 +129,15 @@ func (z *ZKEVMEndpoints) ConsolidatedBlockNumber() (interface{}, types.Error) {
 	})
 }
 
+// GetBroadcastURI returns the URI of the data stream, the response is
+// empty if the data stream is disabled
+func (z *ZKEVMEndpoints) GetBroadcastURI() (interface{}, types.Error) {
+	return z.cfg.BroadcastURI, nil
+}
+
 // IsBlockConsolidated returns the consolidation status of a provided block number
-func (z *ZKEVMEndpoints) IsBlockConsolidated(blockNumber types.ArgUint64) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) IsBlockConsolidated(ctx context.Context, blockNumber types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		IsL2BlockConsolidated, err := z.state.IsL2BlockConsolidated(ctx, uint64(blockNumber), dbTx)
 		if err != nil {
 			const errorMessage = "failed to check if the block is consolidated"
@@ -62,8 +150,8 @@ func (z *ZKEVMEndpoints) IsBlockConsolidated(blockNumber types.ArgUint64) (inter
 }
 
 // IsBlockVirtualized returns the virtualization status of a provided block number
-func (z *ZKEVMEndpoints) IsBlockVirtualized(blockNumber types.ArgUint64) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) IsBlockVirtualized(ctx context.Context, blockNumber types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		IsL2BlockVirtualized, err := z.state.IsL2BlockVirtualized(ctx, uint64(blockNumber), dbTx)
 		if err != nil {
 			const errorMessage = "failed to check if the block is virtualized"
@@ -76,8 +164,8 @@ func (z *ZKEVMEndpoints) IsBlockVirtualized(blockNumber types.ArgUint64) (interf
 }
 
 // BatchNumberByBlockNumber returns the batch number from which the passed block number is created
-func (z *ZKEVMEndpoints) BatchNumberByBlockNumber(blockNumber types.ArgUint64) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) BatchNumberByBlockNumber(ctx context.Context, blockNumber types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		batchNum, err := z.state.BatchNumberByL2BlockNumber(ctx, uint64(blockNumber), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
@@ -92,8 +180,8 @@ func (z *ZKEVMEndpoints) BatchNumberByBlockNumber(blockNumber types.ArgUint64) (
 }
 
 // BatchNumber returns the latest trusted batch number
-func (z *ZKEVMEndpoints) BatchNumber() (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) BatchNumber(ctx context.Context) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		lastBatchNumber, err := z.state.GetLastBatchNumber(ctx, dbTx)
 		if err != nil {
 			return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last batch number from state")
@@ -103,32 +191,30 @@ func (z *ZKEVMEndpoints) BatchNumber() (interface{}, types.Error) {
 	})
 }
 
-// VirtualBatchNumber returns the latest virtualized batch number
-func (z *ZKEVMEndpoints) VirtualBatchNumber() (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		lastBatchNumber, err := z.state.GetLastVirtualBatchNum(ctx, dbTx)
-		if err != nil {
-			return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last virtual batch number from state")
-		}
+// VirtualBatchNumber returns the latest virtualized batch number, it's read without
+// dbTx so the state can serve it from its cache
+func (z *ZKEVMEndpoints) VirtualBatchNumber(ctx context.Context) (interface{}, types.Error) {
+	lastBatchNumber, err := z.state.GetLastVirtualBatchNum(ctx, nil)
+	if err != nil {
+		return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last virtual batch number from state")
+	}
 
-		return hex.EncodeUint64(lastBatchNumber), nil
-	})
+	return hex.EncodeUint64(lastBatchNumber), nil
 }
 
-// VerifiedBatchNumber returns the latest verified batch number
-func (z *ZKEVMEndpoints) VerifiedBatchNumber() (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		lastBatch, err := z.state.GetLastVerifiedBatch(ctx, dbTx)
-		if err != nil {
-			return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last verified batch number from state")
-		}
-		return hex.EncodeUint64(lastBatch.BatchNumber), nil
-	})
+// VerifiedBatchNumber returns the latest verified batch number, it's read without
+// dbTx so the state can serve it from its cache
+func (z *ZKEVMEndpoints) VerifiedBatchNumber(ctx context.Context) (interface{}, types.Error) {
+	lastBatch, err := z.state.GetLastVerifiedBatch(ctx, nil)
+	if err != nil {
+		return "0x0", types.NewRPCError(types.DefaultErrorCode, "failed to get the last verified batch number from state")
+	}
+	return hex.EncodeUint64(lastBatch.BatchNumber), nil
 }
 
 // GetBatchByNumber returns information about a batch by batch number
-func (z *ZKEVMEndpoints) GetBatchByNumber(batchNumber types.BatchNumber, fullTx bool) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) GetBatchByNumber(ctx context.Context, batchNumber types.BatchNumber, fullTx bool) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		var err error
 		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
 		if rpcErr != nil {
@@ -139,11 +225,11 @@ func (z *ZKEVMEndpoints) GetBatchByNumber(batchNumber types.BatchNumber, fullTx
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load batch from state by number %v", err, true, batchNumber)
 		}
 		batchTimestamp, err := z.state.GetBatchTimestamp(ctx, batchNumber, nil, dbTx)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch timestamp from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load batch timestamp from state by number %v", err, true, batchNumber)
 		}
 
 		if batchTimestamp == nil {
@@ -154,52 +240,52 @@ func (z *ZKEVMEndpoints) GetBatchByNumber(batchNumber types.BatchNumber, fullTx
 
 		txs, _, err := z.state.GetTransactionsByBatchNumber(ctx, batchNumber, dbTx)
 		if !errors.Is(err, state.ErrNotFound) && err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch txs from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load batch txs from state by number %v", err, true, batchNumber)
 		}
 
-		receipts := make([]ethTypes.Receipt, 0, len(txs))
-		for _, tx := range txs {
-			receipt, err := z.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
+		// the receipts are only included in the full txs, all of them are loaded at once
+		var receipts []ethTypes.Receipt
+		if fullTx && len(txs) > 0 {
+			receipts, err = z.state.GetTransactionReceiptsByBatchNumber(ctx, batchNumber, dbTx)
 			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), err, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipts of batch %v", err, true, batchNumber)
 			}
-			receipts = append(receipts, *receipt)
 		}
 
 		virtualBatch, err := z.state.GetVirtualBatch(ctx, batchNumber, dbTx)
 		if err != nil && !errors.Is(err, state.ErrNotFound) {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load virtual batch from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load virtual batch from state by number %v", err, true, batchNumber)
 		}
 
 		verifiedBatch, err := z.state.GetVerifiedBatch(ctx, batchNumber, dbTx)
 		if err != nil && !errors.Is(err, state.ErrNotFound) {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load virtual batch from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load virtual batch from state by number %v", err, true, batchNumber)
 		}
 
 		ger, err := z.state.GetExitRootByGlobalExitRoot(ctx, batch.GlobalExitRoot, dbTx)
 		if err != nil && !errors.Is(err, state.ErrNotFound) {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load full GER from state by number %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load full GER from state by number %v", err, true, batchNumber)
 		} else if errors.Is(err, state.ErrNotFound) {
 			ger = &state.GlobalExitRoot{}
 		}
 
 		blocks, err := z.state.GetL2BlocksByBatchNumber(ctx, batchNumber, dbTx)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load blocks associated to the batch %v", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load blocks associated to the batch %v", err, true, batchNumber)
 		}
 
 		batch.Transactions = txs
 		rpcBatch, err := types.NewBatch(batch, virtualBatch, verifiedBatch, blocks, receipts, fullTx, true, ger)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build the batch %v response", batchNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't build the batch %v response", err, true, batchNumber)
 		}
 		return rpcBatch, nil
 	})
 }
 
 // GetFullBlockByNumber returns information about a block by block number
-func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) GetFullBlockByNumber(ctx context.Context, number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if number == types.PendingBlockNumber {
 			lastBlock, err := z.state.GetLastL2Block(ctx, dbTx)
 			if err != nil {
@@ -229,7 +315,7 @@ func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx b
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load block from state by number %v", err, true, blockNumber)
 		}
 
 		txs := l2Block.Transactions()
@@ -237,14 +323,14 @@ func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx b
 		for _, tx := range txs {
 			receipt, err := z.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
 			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), err, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipt for tx %v", err, true, tx.Hash().String())
 			}
 			receipts = append(receipts, *receipt)
 		}
 
 		rpcBlock, err := types.NewBlock(state.HashPtr(l2Block.Hash()), l2Block, receipts, fullTx, true)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by number %v", blockNumber), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't build block response for block by number %v", err, true, blockNumber)
 		}
 
 		return rpcBlock, nil
@@ -252,8 +338,8 @@ func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx b
 }
 
 // GetFullBlockByHash returns information about a block by hash
-func (z *ZKEVMEndpoints) GetFullBlockByHash(hash types.ArgHash, fullTx bool) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) GetFullBlockByHash(ctx context.Context, hash types.ArgHash, fullTx bool) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		l2Block, err := z.state.GetL2BlockByHash(ctx, hash.Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
@@ -266,14 +352,14 @@ func (z *ZKEVMEndpoints) GetFullBlockByHash(hash types.ArgHash, fullTx bool) (in
 		for _, tx := range txs {
 			receipt, err := z.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
 			if err != nil {
-				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), err, true)
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load receipt for tx %v", err, true, tx.Hash().String())
 			}
 			receipts = append(receipts, *receipt)
 		}
 
 		rpcBlock, err := types.NewBlock(state.HashPtr(l2Block.Hash()), l2Block, receipts, fullTx, true)
 		if err != nil {
-			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by hash %v", hash.Hash()), err, true)
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't build block response for block by hash %v", err, true, hash.Hash())
 		}
 
 		return rpcBlock, nil
@@ -281,8 +367,8 @@ func (z *ZKEVMEndpoints) GetFullBlockByHash(hash types.ArgHash, fullTx bool) (in
 }
 
 // GetNativeBlockHashesInRange return the state root for the blocks in range
-func (z *ZKEVMEndpoints) GetNativeBlockHashesInRange(filter NativeBlockHashBlockRangeFilter) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+func (z *ZKEVMEndpoints) GetNativeBlockHashesInRange(ctx context.Context, filter NativeBlockHashBlockRangeFilter) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		fromBlockNumber, toBlockNumber, rpcErr := filter.GetNumericBlockNumbers(ctx, z.cfg, z.state, z.etherman, dbTx)
 		if rpcErr != nil {
 			return nil, rpcErr
@@ -292,8 +378,7 @@ func (z *ZKEVMEndpoints) GetNativeBlockHashesInRange(filter NativeBlockHashBlock
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
 		} else if errors.Is(err, state.ErrMaxNativeBlockHashBlockRangeLimitExceeded) {
-			errMsg := fmt.Sprintf(state.ErrMaxNativeBlockHashBlockRangeLimitExceeded.Error(), z.cfg.MaxNativeBlockHashBlockRange)
-			return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+			return RPCErrorResponse(types.InvalidParamsErrorCode, state.ErrMaxNativeBlockHashBlockRangeLimitExceeded.Error(), nil, false, z.cfg.MaxNativeBlockHashBlockRange)
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by hash from state", err, true)
 		}
@@ -302,9 +387,221 @@ func (z *ZKEVMEndpoints) GetNativeBlockHashesInRange(filter NativeBlockHashBlock
 	})
 }
 
-// GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
-func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
-	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+// GetL2BlockHashesByBatchNumber returns the hashes of the L2 blocks of the batch ordered by block number
+func (z *ZKEVMEndpoints) GetL2BlockHashesByBatchNumber(ctx context.Context, batchNumber types.BatchNumber) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		blockHashes, err := z.state.GetL2BlockHashesByBatchNumber(ctx, batchNumber, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the block hashes of the batch %v", err, true, batchNumber)
+		}
+
+		return blockHashes, nil
+	})
+}
+
+// GetForcedBatchByNumber returns the forced batch by the provided forced batch number
+func (z *ZKEVMEndpoints) GetForcedBatchByNumber(ctx context.Context, forcedBatchNumber types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		forcedBatch, err := z.state.GetForcedBatch(ctx, uint64(forcedBatchNumber), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load forced batch from state by number %v", err, true, forcedBatchNumber)
+		}
+
+		var batchNumber *uint64
+		batch, err := z.state.GetBatchByForcedBatchNum(ctx, uint64(forcedBatchNumber), dbTx)
+		if err != nil && !errors.Is(err, state.ErrStateNotSynchronized) && !errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load batch from state by forced batch number %v", err, true, forcedBatchNumber)
+		} else if err == nil {
+			batchNumber = &batch.BatchNumber
+		}
+
+		return types.NewForcedBatch(forcedBatch, batchNumber), nil
+	})
+}
+
+// GetL1InfoTreeRootByIndex returns the L1 info tree root after adding the leaf with the provided index,
+// the index must be a leaf already synced
+func (z *ZKEVMEndpoints) GetL1InfoTreeRootByIndex(ctx context.Context, index types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		lastIndex, err := z.state.GetLatestIndex(ctx, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "l1 info tree index %d out of range, the l1 info tree is empty", nil, false, uint64(index))
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last l1 info tree index from state", err, true)
+		}
+		if uint64(index) > uint64(lastIndex) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "l1 info tree index %d out of range, the last index is %d", nil, false, uint64(index), lastIndex)
+		}
+
+		leaf, err := z.state.GetL1InfoRootLeafByIndex(ctx, uint32(index), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the l1 info tree leaf %d from state", err, true, uint64(index))
+		}
+
+		return leaf.L1InfoTreeRoot, nil
+	})
+}
+
+// GetL2ToL1MessageProof returns the merkle proof of the local exit tree leaf added by the transaction,
+// the proof is computed against the local exit root of the last batch verified on L1, the one the
+// claims on L1 are checked against. The tree is kept in memory and only the new L2 blocks are read
+func (z *ZKEVMEndpoints) GetL2ToL1MessageProof(ctx context.Context, txHash types.ArgHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if z.cfg.L2BridgeAddress == (common.Address{}) {
+			return RPCErrorResponse(types.DefaultErrorCode, "the L2 bridge address is not configured", nil, false)
+		}
+
+		receipt, err := z.state.GetTransactionReceipt(ctx, txHash.Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction receipt from state", err, true)
+		}
+
+		var leafIndex *uint32
+		for _, l := range receipt.Logs {
+			event, err := parseBridgeEvent(z.cfg.L2BridgeAddress, l)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to parse the bridge event", err, true)
+			} else if event != nil {
+				leafIndex = &event.DepositCount
+				break
+			}
+		}
+		if leafIndex == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "transaction %s is not an exit transaction", nil, false, txHash.Hash().String())
+		}
+
+		lastVerifiedBatch, err := z.state.GetLastVerifiedBatch(ctx, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "there are no verified batches yet", nil, false)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last verified batch from state", err, true)
+		}
+		verifiedBatch, err := z.state.GetBatchByNumber(ctx, lastVerifiedBatch.BatchNumber, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the verified batch %d from state", err, true, lastVerifiedBatch.BatchNumber)
+		}
+		lastBlockNumber, err := z.state.GetLastL2BlockNumber(ctx, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last block number from state", err, true)
+		}
+
+		z.exitTree.mu.Lock()
+		defer z.exitTree.mu.Unlock()
+		if err := z.syncExitTree(ctx, lastBlockNumber, dbTx); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the local exit tree leaves from state", err, true)
+		}
+		leafCount, found := z.exitTree.leafCountByRoot(verifiedBatch.LocalExitRoot)
+		if !found {
+			return RPCErrorResponse(types.DefaultErrorCode, "the local exit root of the verified batch %d is not in the local exit tree", nil, true, verifiedBatch.BatchNumber)
+		}
+		if uint64(*leafIndex) >= leafCount {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "the exit of transaction %s is not verified yet, the last verified batch is %d", nil, false, txHash.Hash().String(), verifiedBatch.BatchNumber)
+		}
+
+		return types.L2ToL1MessageProof{
+			LeafIndex:     types.ArgUint64(*leafIndex),
+			LeafCount:     types.ArgUint64(leafCount),
+			BatchNumber:   types.ArgUint64(verifiedBatch.BatchNumber),
+			LocalExitRoot: verifiedBatch.LocalExitRoot,
+			Proof:         z.exitTree.proof(uint64(*leafIndex), leafCount),
+		}, nil
+	})
+}
+
+// GetTransactionByL2Hash returns the transaction by its L2 hash, the response includes the l2Hash field.
+// The L2 hash is computed by the zkEVM from the tx fields and the sender (see state.GetL2Hash), unlike the
+// hash returned by eth_getTransactionByHash, which is the ethereum hash of the signed tx sent to L1 in the
+// batch data
+func (z *ZKEVMEndpoints) GetTransactionByL2Hash(ctx context.Context, l2Hash types.ArgHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		tx, err := z.state.GetTransactionByL2Hash(ctx, l2Hash.Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by l2 hash from state", err, true)
+		}
+
+		receipt, err := z.state.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "transaction receipt not found", err, false)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction receipt from state", err, true)
+		}
+
+		res, err := types.NewTransaction(*tx, receipt, false)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build transaction response", err, true)
+		}
+		txL2Hash := l2Hash.Hash()
+		res.L2Hash = &txL2Hash
+
+		return res, nil
+	})
+}
+
+// GetForkIDActivationBatchNumber returns the batch number where the provided fork id was activated
+func (z *ZKEVMEndpoints) GetForkIDActivationBatchNumber(ctx context.Context, forkID types.ArgUint64) (interface{}, types.Error) {
+	batchNumber, err := z.state.GetForkIDActivationBatchNumber(ctx, uint64(forkID))
+	if errors.Is(err, state.ErrForkIDNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get activation batch number of fork id %v", err, true, uint64(forkID))
+	}
+
+	return hex.EncodeUint64(batchNumber), nil
+}
+
+// GetL2GasPrice returns the current L2 gas price, how it's computed and when it was last updated
+func (z *ZKEVMEndpoints) GetL2GasPrice(ctx context.Context) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		return z.getL2GasPriceFromSequencerNode()
+	}
+	gasPrices, err := z.pool.GetGasPrices(ctx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get gas prices from pool", err, true)
+	}
+	if gasPrices.UpdatedAt.IsZero() {
+		return RPCErrorResponse(types.ResourceUnavailableErrorCode, gasPriceNotInitializedErrMsg, nil, false)
+	}
+
+	return types.L2GasPrice{
+		GasPrice:    types.ArgUint64(gasPrices.L2GasPrice),
+		Source:      z.gasPriceSource,
+		LastUpdated: gasPrices.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (z *ZKEVMEndpoints) getL2GasPriceFromSequencerNode() (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getL2GasPrice")
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get L2 gas price from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var l2GasPrice types.L2GasPrice
+	err = json.Unmarshal(res.Result, &l2GasPrice)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read L2 gas price from sequencer node", err, true)
+	}
+	return l2GasPrice, nil
+}
+
+// GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root, along with
+// the L1 block where the GER was synced and its timestamp. It returns null if the GER is not found
+func (z *ZKEVMEndpoints) GetExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(ctx, z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		exitRoots, err := z.state.GetExitRootByGlobalExitRoot(ctx, globalExitRoot, dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, nil
@@ -313,8 +610,11 @@ func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interfac
 		}
 
 		return types.ExitRoots{
+			GlobalExitRoot:  exitRoots.GlobalExitRoot,
 			MainnetExitRoot: exitRoots.MainnetExitRoot,
 			RollupExitRoot:  exitRoots.RollupExitRoot,
+			BlockNumber:     types.ArgUint64(exitRoots.BlockNumber),
+			Timestamp:       types.ArgUint64(exitRoots.Timestamp.Unix()),
 		}, nil
 	})
 }
@@ -10,9 +10,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevmbridge"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/test/operations"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -46,17 +50,17 @@ func TestConsolidatedBlockNumber(t *testing.T) {
 			ExpectedResult: ptrUint64(10),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastConsolidatedL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastConsolidatedL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -67,17 +71,17 @@ func TestConsolidatedBlockNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get last consolidated block number from state"),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastConsolidatedL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastConsolidatedL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last consolidated block number")).
 					Once()
 			},
@@ -107,6 +111,115 @@ func TestConsolidatedBlockNumber(t *testing.T) {
 	}
 }
 
+func TestConsolidatedBlockNumberCache(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	cfg := getSequencerDefaultConfig()
+	cfg.ConsolidatedBlockNumberCacheTTL.Duration = 100 * time.Millisecond
+
+	st.
+		On("GetLastConsolidatedL2BlockNumber", mock.Anything, nil).
+		Return(uint64(10), nil)
+
+	z := NewZKEVMEndpoints(cfg, nil, st, nil, "")
+	defer z.stop()
+	require.Eventually(t, func() bool {
+		_, ok := z.getCachedConsolidatedBlockNumber()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	// the cached value is returned without opening a db tx
+	res, rpcErr := z.ConsolidatedBlockNumber(context.Background())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "0xa", res)
+
+	// the value is only returned while it has not expired
+	cached := &ZKEVMEndpoints{cfg: cfg, consolidatedBlockNumber: 5, consolidatedBlockNumberUpdatedAt: time.Now()}
+	lastBlockNumber, ok := cached.getCachedConsolidatedBlockNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), lastBlockNumber)
+
+	cached.consolidatedBlockNumberUpdatedAt = time.Now().Add(-time.Second)
+	_, ok = cached.getCachedConsolidatedBlockNumber()
+	assert.False(t, ok)
+
+	// the cache is disabled without TTL
+	cached.cfg.ConsolidatedBlockNumberCacheTTL.Duration = 0
+	cached.consolidatedBlockNumberUpdatedAt = time.Now()
+	_, ok = cached.getCachedConsolidatedBlockNumber()
+	assert.False(t, ok)
+}
+
+func TestConsolidatedBlockNumberPollingStops(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	cfg.ConsolidatedBlockNumberCacheTTL.Duration = 0
+
+	st.
+		On("GetLastConsolidatedL2BlockNumber", mock.Anything, nil).
+		Return(uint64(10), nil)
+
+	// A TTL of 1ns polls every 1ns instead of making the ticker panic
+	z := NewZKEVMEndpoints(cfg, nil, st, nil, "")
+	z.cfg.ConsolidatedBlockNumberCacheTTL.Duration = time.Nanosecond
+	polling := make(chan struct{})
+	go func() {
+		z.pollConsolidatedBlockNumber()
+		close(polling)
+	}()
+	require.Eventually(t, func() bool {
+		z.consolidatedBlockNumberMutex.RLock()
+		defer z.consolidatedBlockNumberMutex.RUnlock()
+		return !z.consolidatedBlockNumberUpdatedAt.IsZero()
+	}, time.Second, time.Millisecond)
+
+	// The polling stops with the server
+	s := NewServer(cfg, chainID, nil, st, nil, []Service{{Name: APIZKEVM, Service: z}})
+	require.NoError(t, s.Stop())
+	select {
+	case <-polling:
+	case <-time.After(time.Second):
+		t.Fatal("the consolidated block number polling didn't stop with the server")
+	}
+}
+
+func TestGetBroadcastURI(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		BroadcastURI   string
+		ExpectedResult string
+	}{
+		{
+			Name:           "data stream disabled",
+			BroadcastURI:   "",
+			ExpectedResult: "",
+		},
+		{
+			Name:           "data stream enabled",
+			BroadcastURI:   "tcp://127.0.0.1:6900",
+			ExpectedResult: "tcp://127.0.0.1:6900",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := getSequencerDefaultConfig()
+			cfg.BroadcastURI = tc.BroadcastURI
+			s, _, _ := newMockedServerWithCustomConfig(t, cfg)
+			defer s.Stop()
+
+			res, err := s.JSONRPCCall("zkevm_getBroadcastURI")
+			require.NoError(t, err)
+			require.Nil(t, res.Error)
+
+			var result string
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
+
 func TestIsBlockConsolidated(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -124,17 +237,17 @@ func TestIsBlockConsolidated(t *testing.T) {
 			ExpectedResult: true,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("IsL2BlockConsolidated", context.Background(), uint64(1), m.DbTx).
+					On("IsL2BlockConsolidated", mock.Anything, uint64(1), m.DbTx).
 					Return(true, nil).
 					Once()
 			},
@@ -145,17 +258,17 @@ func TestIsBlockConsolidated(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to check if the block is consolidated"),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("IsL2BlockConsolidated", context.Background(), uint64(1), m.DbTx).
+					On("IsL2BlockConsolidated", mock.Anything, uint64(1), m.DbTx).
 					Return(false, errors.New("failed to check if the block is consolidated")).
 					Once()
 			},
@@ -202,17 +315,17 @@ func TestIsBlockVirtualized(t *testing.T) {
 			ExpectedResult: true,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("IsL2BlockVirtualized", context.Background(), uint64(1), m.DbTx).
+					On("IsL2BlockVirtualized", mock.Anything, uint64(1), m.DbTx).
 					Return(true, nil).
 					Once()
 			},
@@ -223,17 +336,17 @@ func TestIsBlockVirtualized(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to check if the block is virtualized"),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("IsL2BlockVirtualized", context.Background(), uint64(1), m.DbTx).
+					On("IsL2BlockVirtualized", mock.Anything, uint64(1), m.DbTx).
 					Return(false, errors.New("failed to check if the block is virtualized")).
 					Once()
 			},
@@ -282,17 +395,17 @@ func TestBatchNumberByBlockNumber(t *testing.T) {
 			ExpectedResult: &batchNumber,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("BatchNumberByL2BlockNumber", context.Background(), blockNumber, m.DbTx).
+					On("BatchNumberByL2BlockNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(batchNumber, nil).
 					Once()
 			},
@@ -303,17 +416,17 @@ func TestBatchNumberByBlockNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get batch number from block number"),
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("BatchNumberByL2BlockNumber", context.Background(), blockNumber, m.DbTx).
+					On("BatchNumberByL2BlockNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(uint64(0), errors.New("failed to get batch number of l2 batchNum")).
 					Once()
 			},
@@ -324,17 +437,17 @@ func TestBatchNumberByBlockNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("BatchNumberByL2BlockNumber", context.Background(), blockNumber, m.DbTx).
+					On("BatchNumberByL2BlockNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(uint64(0), state.ErrNotFound).
 					Once()
 			},
@@ -393,17 +506,17 @@ func TestBatchNumber(t *testing.T) {
 			ExpectedResult: 10,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastBatchNumber", context.Background(), m.DbTx).
+					On("GetLastBatchNumber", mock.Anything, m.DbTx).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -414,17 +527,17 @@ func TestBatchNumber(t *testing.T) {
 			ExpectedResult: 0,
 			SetupMocks: func(m *mocksWrapper) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastBatchNumber", context.Background(), m.DbTx).
+					On("GetLastBatchNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last batch number")).
 					Once()
 			},
@@ -471,18 +584,8 @@ func TestVirtualBatchNumber(t *testing.T) {
 			ExpectedError:  nil,
 			ExpectedResult: 10,
 			SetupMocks: func(m *mocksWrapper) {
-				m.DbTx.
-					On("Commit", context.Background()).
-					Return(nil).
-					Once()
-
 				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
-
-				m.State.
-					On("GetLastVirtualBatchNum", context.Background(), m.DbTx).
+					On("GetLastVirtualBatchNum", mock.Anything, nil).
 					Return(uint64(10), nil).
 					Once()
 			},
@@ -492,18 +595,8 @@ func TestVirtualBatchNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last virtual batch number from state"),
 			ExpectedResult: 0,
 			SetupMocks: func(m *mocksWrapper) {
-				m.DbTx.
-					On("Rollback", context.Background()).
-					Return(nil).
-					Once()
-
 				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
-
-				m.State.
-					On("GetLastVirtualBatchNum", context.Background(), m.DbTx).
+					On("GetLastVirtualBatchNum", mock.Anything, nil).
 					Return(uint64(0), errors.New("failed to get last batch number")).
 					Once()
 			},
@@ -550,18 +643,8 @@ func TestVerifiedBatchNumber(t *testing.T) {
 			ExpectedError:  nil,
 			ExpectedResult: 10,
 			SetupMocks: func(m *mocksWrapper) {
-				m.DbTx.
-					On("Commit", context.Background()).
-					Return(nil).
-					Once()
-
 				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
-
-				m.State.
-					On("GetLastVerifiedBatch", context.Background(), m.DbTx).
+					On("GetLastVerifiedBatch", mock.Anything, nil).
 					Return(&state.VerifiedBatch{BatchNumber: uint64(10)}, nil).
 					Once()
 			},
@@ -571,18 +654,8 @@ func TestVerifiedBatchNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last verified batch number from state"),
 			ExpectedResult: 0,
 			SetupMocks: func(m *mocksWrapper) {
-				m.DbTx.
-					On("Rollback", context.Background()).
-					Return(nil).
-					Once()
-
 				m.State.
-					On("BeginStateTransaction", context.Background()).
-					Return(m.DbTx, nil).
-					Once()
-
-				m.State.
-					On("GetLastVerifiedBatch", context.Background(), m.DbTx).
+					On("GetLastVerifiedBatch", mock.Anything, nil).
 					Return(nil, errors.New("failed to get last batch number")).
 					Once()
 			},
@@ -630,17 +703,17 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetBatchByNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetBatchByNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(nil, state.ErrNotFound)
 			},
 		},
@@ -661,12 +734,12 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError: nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -732,7 +805,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetBatchByNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetBatchByNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(batch, nil).
 					Once()
 
@@ -746,7 +819,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVirtualBatch", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetVirtualBatch", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(virtualBatch, nil).
 					Once()
 
@@ -755,7 +828,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVerifiedBatch", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetVerifiedBatch", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(verifiedBatch, nil).
 					Once()
 
@@ -765,23 +838,25 @@ func TestGetBatchByNumber(t *testing.T) {
 					GlobalExitRoot:  common.HexToHash("0x4"),
 				}
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), batch.GlobalExitRoot, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, batch.GlobalExitRoot, m.DbTx).
 					Return(&ger, nil).
 					Once()
 
-				for i, tx := range txs {
-					m.State.
-						On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
-						Return(receipts[i], nil).
-						Once()
+				batchReceipts := make([]ethTypes.Receipt, 0, len(receipts))
+				for _, receipt := range receipts {
+					batchReceipts = append(batchReceipts, *receipt)
 				}
 				m.State.
-					On("GetTransactionsByBatchNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetTransactionReceiptsByBatchNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					Return(batchReceipts, nil).
+					Once()
+				m.State.
+					On("GetTransactionsByBatchNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(batchTxs, effectivePercentages, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlocksByBatchNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetL2BlocksByBatchNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(blocks, nil).
 					Once()
 			},
@@ -803,12 +878,12 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError: nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -821,17 +896,10 @@ func TestGetBatchByNumber(t *testing.T) {
 				effectivePercentages := make([]uint8, 0, len(txs))
 				tc.ExpectedResult.Transactions = []types.TransactionOrHash{}
 
-				receipts := []*ethTypes.Receipt{}
 				blocks := []state.L2Block{}
 				for i, tx := range txs {
 					block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(int64(i))})).WithBody([]*ethTypes.Transaction{tx}, []*state.L2Header{})
 					blocks = append(blocks, *block)
-					receipt := ethTypes.NewReceipt([]byte{}, false, uint64(0))
-					receipt.TxHash = tx.Hash()
-					receipt.TransactionIndex = uint(i)
-					receipt.BlockNumber = block.Number()
-					receipt.BlockHash = block.Hash()
-					receipts = append(receipts, receipt)
 
 					tc.ExpectedResult.Transactions = append(tc.ExpectedResult.Transactions,
 						types.TransactionOrHash{
@@ -856,7 +924,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetBatchByNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetBatchByNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(batch, nil).
 					Once()
 
@@ -870,7 +938,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVirtualBatch", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetVirtualBatch", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(virtualBatch, nil).
 					Once()
 
@@ -879,7 +947,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVerifiedBatch", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetVerifiedBatch", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(verifiedBatch, nil).
 					Once()
 
@@ -889,22 +957,16 @@ func TestGetBatchByNumber(t *testing.T) {
 					GlobalExitRoot:  common.HexToHash("0x4"),
 				}
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), batch.GlobalExitRoot, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, batch.GlobalExitRoot, m.DbTx).
 					Return(&ger, nil).
 					Once()
-				for i, tx := range txs {
-					m.State.
-						On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
-						Return(receipts[i], nil).
-						Once()
-				}
 				m.State.
-					On("GetTransactionsByBatchNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetTransactionsByBatchNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(batchTxs, effectivePercentages, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlocksByBatchNumber", context.Background(), hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
+					On("GetL2BlocksByBatchNumber", mock.Anything, hex.DecodeBig(tc.Number).Uint64(), m.DbTx).
 					Return(blocks, nil).
 					Once()
 
@@ -929,17 +991,17 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError: nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastClosedBatchNumber", context.Background(), m.DbTx).
+					On("GetLastClosedBatchNumber", mock.Anything, m.DbTx).
 					Return(uint64(tc.ExpectedResult.Number), nil).
 					Once()
 
@@ -1011,7 +1073,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetBatchByNumber", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetBatchByNumber", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(batch, nil).
 					Once()
 
@@ -1025,7 +1087,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVirtualBatch", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetVirtualBatch", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(virtualBatch, nil).
 					Once()
 
@@ -1034,7 +1096,7 @@ func TestGetBatchByNumber(t *testing.T) {
 				}
 
 				m.State.
-					On("GetVerifiedBatch", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetVerifiedBatch", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(verifiedBatch, nil).
 					Once()
 
@@ -1044,22 +1106,24 @@ func TestGetBatchByNumber(t *testing.T) {
 					GlobalExitRoot:  common.HexToHash("0x4"),
 				}
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), batch.GlobalExitRoot, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, batch.GlobalExitRoot, m.DbTx).
 					Return(&ger, nil).
 					Once()
 
-				for i, tx := range txs {
-					m.State.
-						On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
-						Return(receipts[i], nil).
-						Once()
+				batchReceipts := make([]ethTypes.Receipt, 0, len(receipts))
+				for _, receipt := range receipts {
+					batchReceipts = append(batchReceipts, *receipt)
 				}
 				m.State.
-					On("GetTransactionsByBatchNumber", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetTransactionReceiptsByBatchNumber", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
+					Return(batchReceipts, nil).
+					Once()
+				m.State.
+					On("GetTransactionsByBatchNumber", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(batchTxs, effectivePercentages, nil).
 					Once()
 				m.State.
-					On("GetL2BlocksByBatchNumber", context.Background(), uint64(tc.ExpectedResult.Number), m.DbTx).
+					On("GetL2BlocksByBatchNumber", mock.Anything, uint64(tc.ExpectedResult.Number), m.DbTx).
 					Return(blocks, nil).
 					Once()
 				tc.ExpectedResult.BatchL2Data = batchL2Data
@@ -1072,17 +1136,17 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last batch number from state"),
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastClosedBatchNumber", context.Background(), m.DbTx).
+					On("GetLastClosedBatchNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last batch number")).
 					Once()
 			},
@@ -1094,22 +1158,22 @@ func TestGetBatchByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load batch from state by number 1"),
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastClosedBatchNumber", context.Background(), m.DbTx).
+					On("GetLastClosedBatchNumber", mock.Anything, m.DbTx).
 					Return(uint64(1), nil).
 					Once()
 
 				m.State.
-					On("GetBatchByNumber", context.Background(), uint64(1), m.DbTx).
+					On("GetBatchByNumber", mock.Anything, uint64(1), m.DbTx).
 					Return(nil, errors.New("failed to load batch by number")).
 					Once()
 			},
@@ -1205,17 +1269,17 @@ func TestGetL2FullBlockByHash(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, state.ErrNotFound)
 			},
 		},
@@ -1226,17 +1290,17 @@ func TestGetL2FullBlockByHash(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get block by hash from state"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(nil, errors.New("failed to get block from state")).
 					Once()
 			},
@@ -1260,23 +1324,23 @@ func TestGetL2FullBlockByHash(t *testing.T) {
 				block := state.NewL2Block(state.NewL2Header(tc.ExpectedResult.Header()), tc.ExpectedResult.Transactions(), uncles, []*ethTypes.Receipt{ethTypes.NewReceipt([]byte{}, false, uint64(0))}, &trie.StackTrie{})
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByHash", context.Background(), tc.Hash, m.DbTx).
+					On("GetL2BlockByHash", mock.Anything, tc.Hash, m.DbTx).
 					Return(block, nil).
 					Once()
 
 				for _, tx := range tc.ExpectedResult.Transactions() {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), tx.Hash(), m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, tx.Hash(), m.DbTx).
 						Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil).
 						Once()
 				}
@@ -1422,37 +1486,23 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 		rpcUncles = append(rpcUncles, uncle.Hash())
 	}
 
-	var miner *common.Address
-	if l2Block.Coinbase().String() != state.ZeroAddress.String() {
-		cb := l2Block.Coinbase()
-		miner = &cb
-	}
+	miner := l2Block.Coinbase()
 
-	var rpcBlockNonce *types.ArgBytes
-	if l2Block.Nonce() > 0 {
-		nBig := big.NewInt(0).SetUint64(l2Block.Nonce())
-		nBytes := common.LeftPadBytes(nBig.Bytes(), 8) //nolint:gomnd
-		n := types.ArgBytes(nBytes)
-		rpcBlockNonce = &n
-	}
+	nBig := big.NewInt(0).SetUint64(l2Block.Nonce())
+	rpcBlockNonce := types.ArgBytes(common.LeftPadBytes(nBig.Bytes(), 8)) //nolint:gomnd
 
-	difficulty := types.ArgUint64(0)
-	var totalDifficulty *types.ArgUint64
-	if l2Block.Difficulty() != nil {
-		difficulty = types.ArgUint64(l2Block.Difficulty().Uint64())
-		totalDifficulty = &difficulty
-	}
+	difficulty := types.ArgUint64(l2Block.Difficulty().Uint64())
 
 	rpcBlock := &types.Block{
 		ParentHash:      l2Block.ParentHash(),
 		Sha3Uncles:      l2Block.UncleHash(),
-		Miner:           miner,
+		Miner:           &miner,
 		StateRoot:       l2Block.Root(),
 		TxRoot:          l2Block.TxHash(),
 		ReceiptsRoot:    l2Block.ReceiptHash(),
 		LogsBloom:       ethTypes.CreateBloom(receipts),
 		Difficulty:      difficulty,
-		TotalDifficulty: totalDifficulty,
+		TotalDifficulty: &difficulty,
 		Size:            types.ArgUint64(l2Block.Size()),
 		Number:          types.ArgUint64(l2Block.NumberU64()),
 		GasLimit:        types.ArgUint64(l2Block.GasLimit()),
@@ -1460,7 +1510,7 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 		Timestamp:       types.ArgUint64(l2Block.Time()),
 		ExtraData:       l2Block.Extra(),
 		MixHash:         l2Block.MixDigest(),
-		Nonce:           rpcBlockNonce,
+		Nonce:           &rpcBlockNonce,
 		Hash:            state.HashPtr(l2Block.Hash()),
 		GlobalExitRoot:  l2Block.GlobalExitRoot(),
 		BlockInfoRoot:   l2Block.BlockInfoRoot(),
@@ -1476,17 +1526,17 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), hex.DecodeUint64(tc.Number), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, hex.DecodeUint64(tc.Number), m.DbTx).
 					Return(nil, state.ErrNotFound).
 					Once()
 			},
@@ -1498,23 +1548,23 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), hex.DecodeUint64(tc.Number), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, hex.DecodeUint64(tc.Number), m.DbTx).
 					Return(l2Block, nil).
 					Once()
 
 				for _, receipt := range receipts {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), receipt.TxHash, m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, receipt.TxHash, m.DbTx).
 						Return(receipt, nil).
 						Once()
 				}
@@ -1527,30 +1577,30 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				blockNumber := uint64(1)
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(blockNumber, nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), blockNumber, m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, blockNumber, m.DbTx).
 					Return(l2Block, nil).
 					Once()
 
 				for _, receipt := range receipts {
 					m.State.
-						On("GetTransactionReceipt", context.Background(), receipt.TxHash, m.DbTx).
+						On("GetTransactionReceipt", mock.Anything, receipt.TxHash, m.DbTx).
 						Return(receipt, nil).
 						Once()
 				}
@@ -1563,17 +1613,17 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(0), errors.New("failed to get last block number")).
 					Once()
 			},
@@ -1585,22 +1635,22 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load block from state by number 1"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					On("GetLastL2BlockNumber", mock.Anything, m.DbTx).
 					Return(uint64(1), nil).
 					Once()
 
 				m.State.
-					On("GetL2BlockByNumber", context.Background(), uint64(1), m.DbTx).
+					On("GetL2BlockByNumber", mock.Anything, uint64(1), m.DbTx).
 					Return(nil, errors.New("failed to load block by number")).
 					Once()
 			},
@@ -1621,19 +1671,22 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 				tc.ExpectedResult.Sha3Uncles = ethTypes.EmptyUncleHash
 				tc.ExpectedResult.Size = 501
 				tc.ExpectedResult.ExtraData = []byte{}
+				tc.ExpectedResult.Miner = &common.Address{}
+				tc.ExpectedResult.TotalDifficulty = ptr(types.ArgUint64(0))
+				tc.ExpectedResult.Nonce = ptr(types.ArgBytes(make([]byte, 8)))
 
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(lastBlock, nil).
 					Once()
 			},
@@ -1645,17 +1698,17 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load last block from state to compute the pending block"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetLastL2Block", context.Background(), m.DbTx).
+					On("GetLastL2Block", mock.Anything, m.DbTx).
 					Return(nil, errors.New("failed to load last block")).
 					Once()
 			},
@@ -1743,12 +1796,12 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -1756,7 +1809,7 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 				toBlock, _ := tc.Filter.ToBlock.GetNumericBlockNumber(context.Background(), nil, nil, nil)
 
 				m.State.
-					On("GetNativeBlockHashesInRange", context.Background(), fromBlock, toBlock, m.DbTx).
+					On("GetNativeBlockHashesInRange", mock.Anything, fromBlock, toBlock, m.DbTx).
 					Return([]common.Hash{}, nil).
 					Once()
 			},
@@ -1771,12 +1824,12 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
@@ -1793,7 +1846,7 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 				tc.ExpectedResult = &expectedResult
 
 				m.State.
-					On("GetNativeBlockHashesInRange", context.Background(), fromBlock, toBlock, m.DbTx).
+					On("GetNativeBlockHashesInRange", mock.Anything, fromBlock, toBlock, m.DbTx).
 					Return(hashes, nil).
 					Once()
 			},
@@ -1808,12 +1861,12 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "invalid block range"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -1828,12 +1881,12 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "native block hashes are limited to a 60000 block range"),
 			SetupMocks: func(m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Rollback", context.Background()).
+					On("Rollback", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 			},
@@ -1892,17 +1945,17 @@ func TestGetExitRootsByGER(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), tc.GER, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, tc.GER, m.DbTx).
 					Return(nil, state.ErrNotFound)
 			},
 		},
@@ -1913,17 +1966,17 @@ func TestGetExitRootsByGER(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), tc.GER, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, tc.GER, m.DbTx).
 					Return(nil, fmt.Errorf("failed to load exit roots from state"))
 			},
 		},
@@ -1931,28 +1984,34 @@ func TestGetExitRootsByGER(t *testing.T) {
 			Name: "get exit roots successfully",
 			GER:  common.HexToHash("0x345"),
 			ExpectedResult: &types.ExitRoots{
+				GlobalExitRoot:  common.HexToHash("0x345"),
 				MainnetExitRoot: common.HexToHash("0x1"),
 				RollupExitRoot:  common.HexToHash("0x2"),
+				BlockNumber:     types.ArgUint64(10),
+				Timestamp:       types.ArgUint64(1700000000),
 			},
 			ExpectedError: nil,
 			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
 				m.DbTx.
-					On("Commit", context.Background()).
+					On("Commit", mock.Anything).
 					Return(nil).
 					Once()
 
 				m.State.
-					On("BeginStateTransaction", context.Background()).
+					On("BeginStateTransaction", mock.Anything).
 					Return(m.DbTx, nil).
 					Once()
 
 				er := &state.GlobalExitRoot{
+					GlobalExitRoot:  tc.GER,
 					MainnetExitRoot: tc.ExpectedResult.MainnetExitRoot,
 					RollupExitRoot:  tc.ExpectedResult.RollupExitRoot,
+					BlockNumber:     uint64(tc.ExpectedResult.BlockNumber),
+					Timestamp:       time.Unix(int64(tc.ExpectedResult.Timestamp), 0),
 				}
 
 				m.State.
-					On("GetExitRootByGlobalExitRoot", context.Background(), tc.GER, m.DbTx).
+					On("GetExitRootByGlobalExitRoot", mock.Anything, tc.GER, m.DbTx).
 					Return(er, nil)
 			},
 		},
@@ -1972,8 +2031,172 @@ func TestGetExitRootsByGER(t *testing.T) {
 			require.NoError(t, err)
 
 			if exitRoots != nil || tc.ExpectedResult != nil {
-				assert.Equal(t, tc.ExpectedResult.MainnetExitRoot.String(), exitRoots.MainnetExitRoot.String())
-				assert.Equal(t, tc.ExpectedResult.RollupExitRoot.String(), exitRoots.RollupExitRoot.String())
+				assert.Equal(t, tc.ExpectedResult, exitRoots)
+			}
+
+			if err != nil || tc.ExpectedError != nil {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetForcedBatchByNumber(t *testing.T) {
+	type testCase struct {
+		Name              string
+		ForcedBatchNumber uint64
+		ExpectedResult    *types.ForcedBatch
+		ExpectedError     types.Error
+		SetupMocks        func(*mockedServer, *mocksWrapper, *testCase)
+	}
+
+	forcedAt := time.Unix(1700000000, 0).UTC()
+
+	testCases := []testCase{
+		{
+			Name:              "forced batch not found",
+			ForcedBatchNumber: 1,
+			ExpectedResult:    nil,
+			ExpectedError:     nil,
+			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetForcedBatch", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(nil, state.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:              "failed to load forced batch from state",
+			ForcedBatchNumber: 2,
+			ExpectedResult:    nil,
+			ExpectedError:     types.NewRPCError(types.DefaultErrorCode, "couldn't load forced batch from state by number 2"),
+			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetForcedBatch", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(nil, fmt.Errorf("failed to load forced batch from state")).
+					Once()
+			},
+		},
+		{
+			Name:              "forced batch not processed yet",
+			ForcedBatchNumber: 3,
+			ExpectedResult: &types.ForcedBatch{
+				ForcedBatchNumber: 3,
+				GlobalExitRoot:    common.HexToHash("0x1"),
+				RawTxsData:        []byte{0x1, 0x2},
+				ForcedAt:          forcedAt,
+			},
+			ExpectedError: nil,
+			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetForcedBatch", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(&state.ForcedBatch{
+						ForcedBatchNumber: tc.ForcedBatchNumber,
+						GlobalExitRoot:    tc.ExpectedResult.GlobalExitRoot,
+						RawTxsData:        tc.ExpectedResult.RawTxsData,
+						ForcedAt:          forcedAt,
+					}, nil).
+					Once()
+
+				m.State.
+					On("GetBatchByForcedBatchNum", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(nil, state.ErrStateNotSynchronized).
+					Once()
+			},
+		},
+		{
+			Name:              "forced batch processed",
+			ForcedBatchNumber: 4,
+			ExpectedResult: &types.ForcedBatch{
+				ForcedBatchNumber: 4,
+				GlobalExitRoot:    common.HexToHash("0x2"),
+				RawTxsData:        []byte{0x3, 0x4},
+				ForcedAt:          forcedAt,
+				BatchNumber:       ptrArgUint64FromUint64(10),
+			},
+			ExpectedError: nil,
+			SetupMocks: func(s *mockedServer, m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetForcedBatch", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(&state.ForcedBatch{
+						ForcedBatchNumber: tc.ForcedBatchNumber,
+						GlobalExitRoot:    tc.ExpectedResult.GlobalExitRoot,
+						RawTxsData:        tc.ExpectedResult.RawTxsData,
+						ForcedAt:          forcedAt,
+					}, nil).
+					Once()
+
+				m.State.
+					On("GetBatchByForcedBatchNum", mock.Anything, tc.ForcedBatchNumber, m.DbTx).
+					Return(&state.Batch{BatchNumber: 10}, nil).
+					Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	c := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(s, m, &tc)
+
+			forcedBatch, err := c.ForcedBatchByNumber(context.Background(), tc.ForcedBatchNumber)
+
+			if tc.ExpectedResult != nil {
+				require.NotNil(t, forcedBatch)
+				assert.Equal(t, tc.ExpectedResult.ForcedBatchNumber, forcedBatch.ForcedBatchNumber)
+				assert.Equal(t, tc.ExpectedResult.GlobalExitRoot.String(), forcedBatch.GlobalExitRoot.String())
+				assert.Equal(t, tc.ExpectedResult.RawTxsData, forcedBatch.RawTxsData)
+				assert.True(t, tc.ExpectedResult.ForcedAt.Equal(forcedBatch.ForcedAt))
+				assert.Equal(t, tc.ExpectedResult.BatchNumber, forcedBatch.BatchNumber)
+			} else {
+				assert.Nil(t, forcedBatch)
 			}
 
 			if err != nil || tc.ExpectedError != nil {
@@ -2013,3 +2236,707 @@ func signTx(tx *ethTypes.Transaction, chainID uint64) *ethTypes.Transaction {
 	signedTx, _ := auth.Signer(auth.From, tx)
 	return signedTx
 }
+
+func TestGetForkIDActivationBatchNumber(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+	forkID := uint64(7)
+
+	type testCase struct {
+		Name           string
+		ExpectedResult *uint64
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	activationBatchNumber := uint64(100)
+	testCases := []testCase{
+		{
+			Name:           "get fork id activation batch number successfully",
+			ExpectedResult: &activationBatchNumber,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.
+					On("GetForkIDActivationBatchNumber", mock.Anything, forkID).
+					Return(activationBatchNumber, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "fork id not found",
+			ExpectedResult: nil,
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.
+					On("GetForkIDActivationBatchNumber", mock.Anything, forkID).
+					Return(uint64(0), state.ErrForkIDNotFound).
+					Once()
+			},
+		},
+		{
+			Name:           "failed to get fork id activation batch number",
+			ExpectedResult: nil,
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to get activation batch number of fork id 7"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.
+					On("GetForkIDActivationBatchNumber", mock.Anything, forkID).
+					Return(uint64(0), errors.New("failed to get fork ids")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("zkevm_getForkIDActivationBatchNumber", hex.EncodeUint64(forkID))
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				var result types.ArgUint64
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, uint64(result))
+			} else {
+				var result *uint64
+				if res.Result != nil {
+					err = json.Unmarshal(res.Result, &result)
+					require.NoError(t, err)
+				}
+				assert.Nil(t, result)
+			}
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			} else {
+				assert.Nil(t, res.Error)
+			}
+		})
+	}
+}
+
+func TestGetL2BlockHashesByBatchNumber(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+	batchNumber := uint64(5)
+
+	type testCase struct {
+		Name           string
+		ExpectedResult []common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	blockHashes := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	testCases := []testCase{
+		{
+			Name:           "get block hashes successfully",
+			ExpectedResult: blockHashes,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL2BlockHashesByBatchNumber", mock.Anything, batchNumber, m.DbTx).
+					Return(blockHashes, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "batch without blocks",
+			ExpectedResult: []common.Hash{},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL2BlockHashesByBatchNumber", mock.Anything, batchNumber, m.DbTx).
+					Return([]common.Hash{}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get block hashes",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load the block hashes of the batch 5"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL2BlockHashesByBatchNumber", mock.Anything, batchNumber, m.DbTx).
+					Return(nil, errors.New("failed to get block hashes")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("zkevm_getL2BlockHashesByBatchNumber", hex.EncodeUint64(batchNumber))
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+
+			require.Nil(t, res.Error)
+			var result []common.Hash
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
+
+func TestGetTransactionByL2Hash(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x111"), big.NewInt(2), 3, big.NewInt(4), []byte{5, 6, 7, 8})
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+	require.NoError(t, err)
+	signedTx, err := auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+	l2Hash := common.HexToHash("0x123")
+
+	type testCase struct {
+		Name          string
+		ExpectedFound bool
+		ExpectedError types.Error
+		SetupMocks    func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:          "get tx by l2 hash successfully",
+			ExpectedFound: true,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetTransactionByL2Hash", mock.Anything, l2Hash, m.DbTx).
+					Return(signedTx, nil).
+					Once()
+
+				receipt := ethTypes.NewReceipt([]byte{}, false, 0)
+				receipt.BlockHash = common.HexToHash("0x456")
+				receipt.BlockNumber = big.NewInt(1)
+
+				m.State.
+					On("GetTransactionReceipt", mock.Anything, signedTx.Hash(), m.DbTx).
+					Return(receipt, nil).
+					Once()
+			},
+		},
+		{
+			Name: "tx not found",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.
+					On("Commit", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetTransactionByL2Hash", mock.Anything, l2Hash, m.DbTx).
+					Return(nil, state.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get tx by l2 hash",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to load transaction by l2 hash from state"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.
+					On("Rollback", mock.Anything).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", mock.Anything).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetTransactionByL2Hash", mock.Anything, l2Hash, m.DbTx).
+					Return(nil, errors.New("failed to get tx")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("zkevm_getTransactionByL2Hash", l2Hash.String())
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result *types.Transaction
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			if !tc.ExpectedFound {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, signedTx.Hash(), result.Hash)
+			assert.Equal(t, auth.From, result.From)
+			assert.Equal(t, common.HexToHash("0x456"), *result.BlockHash)
+			require.NotNil(t, result.L2Hash)
+			assert.Equal(t, l2Hash, *result.L2Hash)
+		})
+	}
+}
+
+func TestGetL1InfoTreeRootByIndex(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	l1InfoTreeRoot := common.HexToHash("0x123")
+
+	type testCase struct {
+		Name           string
+		Index          uint64
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "get l1 info tree root successfully",
+			Index:          2,
+			ExpectedResult: &l1InfoTreeRoot,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", mock.Anything, m.DbTx).Return(uint32(2), nil).Once()
+				m.State.
+					On("GetL1InfoRootLeafByIndex", mock.Anything, uint32(tc.Index), m.DbTx).
+					Return(state.L1InfoTreeExitRootStorageEntry{L1InfoTreeRoot: l1InfoTreeRoot, L1InfoTreeIndex: uint32(tc.Index)}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "index out of range",
+			Index:         3,
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "l1 info tree index 3 out of range, the last index is 2"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", mock.Anything, m.DbTx).Return(uint32(2), nil).Once()
+			},
+		},
+		{
+			Name:          "empty l1 info tree",
+			Index:         0,
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "l1 info tree index 0 out of range, the l1 info tree is empty"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", mock.Anything, m.DbTx).Return(uint32(0), state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "failed to get the last index",
+			Index:         0,
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get the last l1 info tree index from state"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", mock.Anything).Return(nil).Once()
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", mock.Anything, m.DbTx).Return(uint32(0), errors.New("failed to get index")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("zkevm_getL1InfoTreeRootByIndex", hex.EncodeUint64(tc.Index))
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result common.Hash
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.ExpectedResult, result)
+		})
+	}
+}
+
+func TestGetL2ToL1MessageProof(t *testing.T) {
+	bridgeAddress := common.HexToAddress("0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe")
+	destinationAddress := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	cfg := getSequencerDefaultConfig()
+	cfg.L2BridgeAddress = bridgeAddress
+	cfg.MaxLogsBlockRange = 2
+
+	bridgeABI, err := polygonzkevmbridge.PolygonzkevmbridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	bridgeEventLog := func(blockNumber uint64, depositCount uint32) *ethTypes.Log {
+		data, err := bridgeABI.Events["BridgeEvent"].Inputs.Pack(uint8(0), uint32(0), common.Address{}, uint32(0),
+			destinationAddress, big.NewInt(int64(depositCount+1)), []byte{}, depositCount)
+		require.NoError(t, err)
+		return &ethTypes.Log{
+			Address:     bridgeAddress,
+			Topics:      []common.Hash{bridgeEventTopic},
+			Data:        data,
+			BlockNumber: blockNumber,
+			TxHash:      common.BigToHash(big.NewInt(int64(depositCount + 1))),
+		}
+	}
+	exitLogs := []*ethTypes.Log{bridgeEventLog(1, 0), bridgeEventLog(2, 1), bridgeEventLog(3, 2)}
+	txHash := exitLogs[1].TxHash
+
+	// the leaves are the keccak hashes of the packed leaf values of the logs, the amount is depositCount+1
+	leaves := make([][32]byte, 0, len(exitLogs))
+	for i := range exitLogs {
+		packed := common.FromHex(fmt.Sprintf("00%08x%040x%08x%s%064x%s", 0, 0, 0, destinationAddress.Hex()[2:], i+1,
+			"c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"))
+		leaves = append(leaves, common.BytesToHash(crypto.Keccak256(packed)))
+	}
+	exitRoot := func(leafCount int) common.Hash {
+		mt, err := l1infotree.NewL1InfoTree(exitTreeHeight, nil)
+		require.NoError(t, err)
+		root, err := mt.BuildL1InfoRoot(append([][32]byte{}, leaves[:leafCount]...))
+		require.NoError(t, err)
+		return root
+	}
+	header := func(blockNumber uint64) *state.L2Header {
+		return state.NewL2Header(&ethTypes.Header{Number: new(big.Int).SetUint64(blockNumber)})
+	}
+	exitReceipt := &ethTypes.Receipt{TxHash: txHash, Logs: []*ethTypes.Log{{Address: common.HexToAddress("0x1")}, exitLogs[1]}}
+	setupVerifiedBatch := func(m *mocks.StateMock, dbTx *mocks.DBTxMock, localExitRoot common.Hash, lastBlockNumber uint64) {
+		m.On("GetTransactionReceipt", mock.Anything, txHash, dbTx).Return(exitReceipt, nil).Once()
+		m.On("GetLastVerifiedBatch", mock.Anything, dbTx).Return(&state.VerifiedBatch{BatchNumber: 7}, nil).Once()
+		m.On("GetBatchByNumber", mock.Anything, uint64(7), dbTx).Return(&state.Batch{BatchNumber: 7, LocalExitRoot: localExitRoot}, nil).Once()
+		m.On("GetLastL2BlockNumber", mock.Anything, dbTx).Return(lastBlockNumber, nil).Once()
+	}
+	setupLogs := func(m *mocks.StateMock, dbTx *mocks.DBTxMock, fromBlock, toBlock uint64, logs []*ethTypes.Log, err error) {
+		m.On("GetLogs", mock.Anything, fromBlock, toBlock, []common.Address{bridgeAddress}, [][]common.Hash{{bridgeEventTopic}}, (*common.Hash)(nil), (*time.Time)(nil), dbTx).
+			Return(logs, err).Once()
+	}
+
+	type call struct {
+		ExpectedResult *types.L2ToL1MessageProof
+		ExpectedNil    bool
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocks.StateMock, dbTx *mocks.DBTxMock)
+	}
+	type testCase struct {
+		Name  string
+		Calls []call
+	}
+
+	testCases := []testCase{
+		{
+			Name: "get proof against the last verified batch and read only the new blocks after",
+			Calls: []call{
+				{
+					ExpectedResult: &types.L2ToL1MessageProof{LeafIndex: 1, LeafCount: 2, BatchNumber: 7, LocalExitRoot: exitRoot(2)},
+					SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+						dbTx.On("Commit", mock.Anything).Return(nil).Once()
+						setupVerifiedBatch(m, dbTx, exitRoot(2), 3)
+						setupLogs(m, dbTx, 0, 2, exitLogs[:2], nil)
+						setupLogs(m, dbTx, 3, 3, exitLogs[2:], nil)
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(3), dbTx).Return(header(3), nil).Once()
+					},
+				},
+				{
+					ExpectedResult: &types.L2ToL1MessageProof{LeafIndex: 1, LeafCount: 3, BatchNumber: 7, LocalExitRoot: exitRoot(3)},
+					SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+						dbTx.On("Commit", mock.Anything).Return(nil).Once()
+						setupVerifiedBatch(m, dbTx, exitRoot(3), 4)
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(3), dbTx).Return(header(3), nil).Once()
+						setupLogs(m, dbTx, 4, 4, nil, nil)
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(4), dbTx).Return(header(4), nil).Once()
+					},
+				},
+			},
+		},
+		{
+			Name: "the tree is rebuilt when the last block read is reorged",
+			Calls: []call{
+				{
+					ExpectedResult: &types.L2ToL1MessageProof{LeafIndex: 1, LeafCount: 2, BatchNumber: 7, LocalExitRoot: exitRoot(2)},
+					SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+						dbTx.On("Commit", mock.Anything).Return(nil).Once()
+						setupVerifiedBatch(m, dbTx, exitRoot(2), 2)
+						setupLogs(m, dbTx, 0, 2, exitLogs[:2], nil)
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(2), dbTx).Return(header(2), nil).Once()
+					},
+				},
+				{
+					ExpectedResult: &types.L2ToL1MessageProof{LeafIndex: 1, LeafCount: 2, BatchNumber: 7, LocalExitRoot: exitRoot(2)},
+					SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+						dbTx.On("Commit", mock.Anything).Return(nil).Once()
+						setupVerifiedBatch(m, dbTx, exitRoot(2), 2)
+						reorgedHeader := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(2), Extra: []byte{0x1}})
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(2), dbTx).Return(reorgedHeader, nil).Once()
+						setupLogs(m, dbTx, 0, 2, exitLogs[:2], nil)
+						m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(2), dbTx).Return(reorgedHeader, nil).Once()
+					},
+				},
+			},
+		},
+		{
+			Name: "transaction not found",
+			Calls: []call{{
+				ExpectedNil: true,
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Commit", mock.Anything).Return(nil).Once()
+					m.On("GetTransactionReceipt", mock.Anything, txHash, dbTx).Return(nil, state.ErrNotFound).Once()
+				},
+			}},
+		},
+		{
+			Name: "not an exit transaction",
+			Calls: []call{{
+				ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("transaction %s is not an exit transaction", txHash.String())),
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+					m.On("GetTransactionReceipt", mock.Anything, txHash, dbTx).
+						Return(&ethTypes.Receipt{TxHash: txHash, Logs: []*ethTypes.Log{{Address: bridgeAddress, Topics: []common.Hash{common.HexToHash("0x1")}}}}, nil).Once()
+				},
+			}},
+		},
+		{
+			Name: "the exit is not verified yet",
+			Calls: []call{{
+				ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("the exit of transaction %s is not verified yet, the last verified batch is 7", txHash.String())),
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+					setupVerifiedBatch(m, dbTx, exitRoot(1), 3)
+					setupLogs(m, dbTx, 0, 2, exitLogs[:2], nil)
+					setupLogs(m, dbTx, 3, 3, exitLogs[2:], nil)
+					m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(3), dbTx).Return(header(3), nil).Once()
+				},
+			}},
+		},
+		{
+			Name: "the local exit root of the verified batch is not in the tree",
+			Calls: []call{{
+				ExpectedError: types.NewRPCError(types.DefaultErrorCode, "the local exit root of the verified batch 7 is not in the local exit tree"),
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+					setupVerifiedBatch(m, dbTx, common.HexToHash("0x1"), 1)
+					setupLogs(m, dbTx, 0, 1, exitLogs[:1], nil)
+					m.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(1), dbTx).Return(header(1), nil).Once()
+				},
+			}},
+		},
+		{
+			Name: "there are no verified batches",
+			Calls: []call{{
+				ExpectedError: types.NewRPCError(types.DefaultErrorCode, "there are no verified batches yet"),
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+					m.On("GetTransactionReceipt", mock.Anything, txHash, dbTx).Return(exitReceipt, nil).Once()
+					m.On("GetLastVerifiedBatch", mock.Anything, dbTx).Return(nil, state.ErrNotFound).Once()
+				},
+			}},
+		},
+		{
+			Name: "failed to get the logs",
+			Calls: []call{{
+				ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get the local exit tree leaves from state"),
+				SetupMocks: func(m *mocks.StateMock, dbTx *mocks.DBTxMock) {
+					dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+					setupVerifiedBatch(m, dbTx, exitRoot(2), 1)
+					setupLogs(m, dbTx, 0, 1, nil, errors.New("failed to get logs"))
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stateMock := mocks.NewStateMock(t)
+			z := NewZKEVMEndpoints(cfg, nil, stateMock, nil, "")
+
+			for _, c := range tc.Calls {
+				dbTx := mocks.NewDBTxMock(t)
+				stateMock.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+				c.SetupMocks(stateMock, dbTx)
+
+				res, rpcErr := z.GetL2ToL1MessageProof(context.Background(), types.ArgHash(txHash))
+				if c.ExpectedError != nil {
+					require.NotNil(t, rpcErr)
+					assert.Equal(t, c.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+					assert.Equal(t, c.ExpectedError.Error(), rpcErr.Error())
+					continue
+				}
+				require.Nil(t, rpcErr)
+				if c.ExpectedNil {
+					assert.Nil(t, res)
+					continue
+				}
+
+				proof, ok := res.(types.L2ToL1MessageProof)
+				require.True(t, ok)
+				assert.Equal(t, c.ExpectedResult.LeafIndex, proof.LeafIndex)
+				assert.Equal(t, c.ExpectedResult.LeafCount, proof.LeafCount)
+				assert.Equal(t, c.ExpectedResult.BatchNumber, proof.BatchNumber)
+				assert.Equal(t, c.ExpectedResult.LocalExitRoot, proof.LocalExitRoot)
+				require.Len(t, proof.Proof, exitTreeHeight)
+
+				// the proof of the leaf leads to the local exit root of the verified batch
+				node := leaves[proof.LeafIndex]
+				for h, sibling := range proof.Proof {
+					if (uint64(proof.LeafIndex)>>h)&1 == 1 {
+						node = l1infotree.Hash(sibling, node)
+					} else {
+						node = l1infotree.Hash(node, sibling)
+					}
+				}
+				assert.Equal(t, proof.LocalExitRoot, common.Hash(node))
+			}
+		})
+	}
+}
+
+func TestGetL2GasPrice(t *testing.T) {
+	sequencerServer, m, _ := newSequencerMockedServer(t)
+	defer sequencerServer.Stop()
+	nonSequencerServer, _, _ := newNonSequencerMockedServer(t, sequencerServer.ServerURL)
+	defer nonSequencerServer.Stop()
+
+	updatedAt := time.Date(2023, time.November, 14, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	type testCase struct {
+		Name           string
+		Server         *mockedServer
+		ExpectedResult *types.L2GasPrice
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:   "get L2 gas price successfully",
+			Server: sequencerServer,
+			ExpectedResult: &types.L2GasPrice{
+				GasPrice:    types.ArgUint64(1000000000),
+				Source:      "L1-derived",
+				LastUpdated: updatedAt.UTC(),
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetGasPrices", mock.Anything).
+					Return(pool.GasPrices{L2GasPrice: 1000000000, L1GasPrice: 4000000000, UpdatedAt: updatedAt}, nil).
+					Once()
+			},
+		},
+		{
+			Name:   "get L2 gas price from the sequencer node",
+			Server: nonSequencerServer,
+			ExpectedResult: &types.L2GasPrice{
+				GasPrice:    types.ArgUint64(2000000000),
+				Source:      "L1-derived",
+				LastUpdated: updatedAt.UTC(),
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetGasPrices", mock.Anything).
+					Return(pool.GasPrices{L2GasPrice: 2000000000, L1GasPrice: 8000000000, UpdatedAt: updatedAt}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get gas prices from pool",
+			Server:        sequencerServer,
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get gas prices from pool"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetGasPrices", mock.Anything).
+					Return(pool.GasPrices{}, errors.New("failed to get gas prices")).
+					Once()
+			},
+		},
+		{
+			Name:          "gas price not initialized",
+			Server:        sequencerServer,
+			ExpectedError: types.NewRPCError(types.ResourceUnavailableErrorCode, gasPriceNotInitializedErrMsg),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetGasPrices", mock.Anything).
+					Return(pool.GasPrices{}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get gas prices from the pool of the sequencer node",
+			Server:        nonSequencerServer,
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get gas prices from pool"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetGasPrices", mock.Anything).
+					Return(pool.GasPrices{}, errors.New("failed to get gas prices")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := tc.Server.JSONRPCCall("zkevm_getL2GasPrice")
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.Nil(t, res.Error)
+				var result types.L2GasPrice
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevmbridge"
+	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+)
+
+// exitTreeHeight is the height of the local exit tree of the bridge contract
+const exitTreeHeight = 32
+
+// bridgeEventTopic is the topic of the BridgeEvent log, every BridgeEvent adds a leaf to the local exit tree
+var bridgeEventTopic = crypto.Keccak256Hash([]byte("BridgeEvent(uint8,uint32,address,uint32,address,uint256,bytes,uint32)"))
+
+// parseBridgeEvent returns the BridgeEvent of the log, nil if the log is not a BridgeEvent of the bridge
+func parseBridgeEvent(bridge common.Address, l *ethTypes.Log) (*polygonzkevmbridge.PolygonzkevmbridgeBridgeEvent, error) {
+	if l.Address != bridge || len(l.Topics) == 0 || l.Topics[0] != bridgeEventTopic {
+		return nil, nil
+	}
+	filterer, err := polygonzkevmbridge.NewPolygonzkevmbridgeFilterer(bridge, nil)
+	if err != nil {
+		return nil, err
+	}
+	return filterer.ParseBridgeEvent(*l)
+}
+
+// exitLeafHash calculates the local exit tree leaf of the BridgeEvent, it's the keccak hash of the
+// packed leaf values as computed by the bridge contract getLeafValue
+func exitLeafHash(e *polygonzkevmbridge.PolygonzkevmbridgeBridgeEvent) [32]byte {
+	originNetwork := make([]byte, 4) //nolint:gomnd
+	binary.BigEndian.PutUint32(originNetwork, e.OriginNetwork)
+	destinationNetwork := make([]byte, 4) //nolint:gomnd
+	binary.BigEndian.PutUint32(destinationNetwork, e.DestinationNetwork)
+	amount := common.BigToHash(e.Amount)
+	metadataHash := crypto.Keccak256(e.Metadata)
+
+	var res [32]byte
+	copy(res[:], crypto.Keccak256([]byte{e.LeafType}, originNetwork, e.OriginAddress.Bytes(),
+		destinationNetwork, e.DestinationAddress.Bytes(), amount.Bytes(), metadataHash))
+	return res
+}
+
+// exitTree is the local exit tree of the L2 bridge built incrementally from the BridgeEvent logs. It
+// keeps the complete nodes of every level and the root after every leaf, so the proofs against the
+// root of any number of leaves are computed without reading the logs again
+type exitTree struct {
+	mu sync.Mutex
+	// nodes are the nodes of every level whose subtree is complete, nodes[0] are the leaves
+	nodes [exitTreeHeight + 1][][32]byte
+	// roots are the roots of the tree with every number of leaves, roots[0] is the empty tree root
+	roots      []common.Hash
+	zeroHashes [exitTreeHeight + 1][32]byte
+	// syncedBlockNumber and syncedBlockHash are the last L2 block whose logs were added, the hash
+	// is used to detect the reorgs of the block
+	synced            bool
+	syncedBlockNumber uint64
+	syncedBlockHash   common.Hash
+}
+
+// newExitTree returns an empty exitTree
+func newExitTree() *exitTree {
+	t := &exitTree{}
+	for h := 1; h <= exitTreeHeight; h++ {
+		t.zeroHashes[h] = l1infotree.Hash(t.zeroHashes[h-1], t.zeroHashes[h-1])
+	}
+	t.reset()
+	return t
+}
+
+// reset removes all the leaves of the tree
+func (t *exitTree) reset() {
+	for h := range t.nodes {
+		t.nodes[h] = nil
+	}
+	t.roots = []common.Hash{common.Hash(t.zeroHashes[exitTreeHeight])}
+	t.synced = false
+	t.syncedBlockNumber = 0
+	t.syncedBlockHash = common.Hash{}
+}
+
+// leafCount returns the number of leaves of the tree
+func (t *exitTree) leafCount() uint64 {
+	return uint64(len(t.nodes[0]))
+}
+
+// addLeaf appends the leaf to the tree, the nodes completed by the leaf are stored
+func (t *exitTree) addLeaf(leaf [32]byte) {
+	t.nodes[0] = append(t.nodes[0], leaf)
+	for h := 1; h <= exitTreeHeight; h++ {
+		children := t.nodes[h-1]
+		if len(children)/2 == len(t.nodes[h]) {
+			break
+		}
+		t.nodes[h] = append(t.nodes[h], l1infotree.Hash(children[len(children)-2], children[len(children)-1]))
+	}
+	t.roots = append(t.roots, common.Hash(t.node(exitTreeHeight, 0, t.leafCount())))
+}
+
+// node returns the node of the level in the tree with the first leafCount leaves, the incomplete
+// nodes are hashed from their children
+func (t *exitTree) node(height int, index uint64, leafCount uint64) [32]byte {
+	start := index << height
+	if start >= leafCount {
+		return t.zeroHashes[height]
+	}
+	if start+(1<<height) <= leafCount {
+		return t.nodes[height][index]
+	}
+	return l1infotree.Hash(t.node(height-1, 2*index, leafCount), t.node(height-1, 2*index+1, leafCount)) //nolint:gomnd
+}
+
+// proof returns the siblings of the leaf in the tree with the first leafCount leaves
+func (t *exitTree) proof(leafIndex uint64, leafCount uint64) []common.Hash {
+	proof := make([]common.Hash, 0, exitTreeHeight)
+	for h := 0; h < exitTreeHeight; h++ {
+		proof = append(proof, common.Hash(t.node(h, (leafIndex>>h)^1, leafCount)))
+	}
+	return proof
+}
+
+// leafCountByRoot returns the number of leaves of the tree when it had the root, the search starts
+// from the last leaf as the roots looked for are the recent ones. The zero hash is the empty tree
+func (t *exitTree) leafCountByRoot(root common.Hash) (uint64, bool) {
+	if root == (common.Hash{}) {
+		return 0, true
+	}
+	for i := len(t.roots) - 1; i >= 0; i-- {
+		if t.roots[i] == root {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+// syncExitTree adds to the exit tree the leaves of the L2 blocks up to toBlock that were not added yet,
+// the logs are read in chunks of MaxLogsBlockRange blocks so the state limits are respected. The tree is
+// rebuilt if the last block added was reorged. The caller must hold the exit tree lock
+func (z *ZKEVMEndpoints) syncExitTree(ctx context.Context, toBlock uint64, dbTx pgx.Tx) error {
+	t := z.exitTree
+	if t.synced {
+		header, err := z.state.GetL2BlockHeaderByNumber(ctx, t.syncedBlockNumber, dbTx)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return err
+		}
+		if err != nil || header.Hash() != t.syncedBlockHash {
+			log.Infof("L2 block %d of the local exit tree was reorged, rebuilding the tree", t.syncedBlockNumber)
+			t.reset()
+		}
+	}
+
+	fromBlock := uint64(0)
+	if t.synced {
+		fromBlock = t.syncedBlockNumber + 1
+	}
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	err := z.addExitTreeLeaves(ctx, fromBlock, toBlock, dbTx)
+	if err != nil {
+		// the leaves of the blocks already read are removed so the next sync doesn't add them twice
+		t.reset()
+		return err
+	}
+	header, err := z.state.GetL2BlockHeaderByNumber(ctx, toBlock, dbTx)
+	if err != nil {
+		t.reset()
+		return err
+	}
+	t.synced = true
+	t.syncedBlockNumber = toBlock
+	t.syncedBlockHash = header.Hash()
+	return nil
+}
+
+// addExitTreeLeaves adds to the exit tree the leaves of the BridgeEvent logs of the L2 blocks in the range
+func (z *ZKEVMEndpoints) addExitTreeLeaves(ctx context.Context, fromBlock uint64, toBlock uint64, dbTx pgx.Tx) error {
+	t := z.exitTree
+	for fromBlock <= toBlock {
+		chunkToBlock := toBlock
+		if z.cfg.MaxLogsBlockRange > 0 && toBlock-fromBlock > z.cfg.MaxLogsBlockRange {
+			chunkToBlock = fromBlock + z.cfg.MaxLogsBlockRange
+		}
+		logs, err := z.state.GetLogs(ctx, fromBlock, chunkToBlock, []common.Address{z.cfg.L2BridgeAddress}, [][]common.Hash{{bridgeEventTopic}}, nil, nil, dbTx)
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			event, err := parseBridgeEvent(z.cfg.L2BridgeAddress, l)
+			if err != nil {
+				return err
+			} else if event == nil {
+				continue
+			}
+			if uint64(event.DepositCount) != t.leafCount() {
+				return fmt.Errorf("unexpected exit tree leaf %d in tx %s, the next leaf is %d", event.DepositCount, l.TxHash.String(), t.leafCount())
+			}
+			t.addLeaf(exitLeafHash(event))
+		}
+		fromBlock = chunkToBlock + 1
+	}
+	return nil
+}
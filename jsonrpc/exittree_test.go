@@ -0,0 +1,83 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevmbridge"
+	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emptyExitTreeRoot is the root of the local exit tree of the bridge without leaves
+var emptyExitTreeRoot = common.HexToHash("0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757")
+
+func TestExitLeafHash(t *testing.T) {
+	event := &polygonzkevmbridge.PolygonzkevmbridgeBridgeEvent{
+		LeafType:           0,
+		OriginNetwork:      0,
+		OriginAddress:      common.Address{},
+		DestinationNetwork: 1,
+		DestinationAddress: common.HexToAddress("0xc949254d682d8c9ad5682521675b8f43b102aec4"),
+		Amount:             new(big.Int).SetUint64(10000000000000000000),
+		Metadata:           []byte{},
+	}
+
+	// leafType (1 byte), originNetwork (4), originAddress (20), destinationNetwork (4),
+	// destinationAddress (20), amount (32) and the keccak hash of the metadata (32)
+	packed := common.FromHex("00" + "00000000" + "0000000000000000000000000000000000000000" + "00000001" +
+		"c949254d682d8c9ad5682521675b8f43b102aec4" + "0000000000000000000000000000000000000000000000008ac7230489e80000" +
+		"c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+	assert.Equal(t, common.BytesToHash(crypto.Keccak256(packed)), common.Hash(exitLeafHash(event)))
+	assert.Equal(t, common.HexToHash("0x22ed288677b4c2afd83a6d7d55f7df7f4eaaf60f7310210c030fd27adacbc5e0"), common.Hash(exitLeafHash(event)))
+}
+
+func TestExitTree(t *testing.T) {
+	tree := newExitTree()
+	count, found := tree.leafCountByRoot(emptyExitTreeRoot)
+	require.True(t, found)
+	assert.Equal(t, uint64(0), count)
+
+	const leafCount = 21
+	leaves := make([][32]byte, 0, leafCount)
+	for i := 0; i < leafCount; i++ {
+		leaf := crypto.Keccak256Hash(big.NewInt(int64(i)).Bytes())
+		leaves = append(leaves, leaf)
+		tree.addLeaf(leaf)
+	}
+
+	// the roots and the proofs of every number of leaves match the ones of the l1 info tree,
+	// which is built with the same hashing from all the leaves. It appends to the leaves, so
+	// it gets a copy of them
+	mt, err := l1infotree.NewL1InfoTree(exitTreeHeight, nil)
+	require.NoError(t, err)
+	leavesCopy := func(n int) [][32]byte { return append([][32]byte{}, leaves[:n]...) }
+	for n := 1; n <= leafCount; n++ {
+		expectedRoot, err := mt.BuildL1InfoRoot(leavesCopy(n))
+		require.NoError(t, err)
+		count, found := tree.leafCountByRoot(expectedRoot)
+		require.True(t, found)
+		assert.Equal(t, uint64(n), count)
+
+		for i := 0; i < n; i++ {
+			expectedProof, _, err := mt.ComputeMerkleProof(uint32(i), leavesCopy(n))
+			require.NoError(t, err)
+			proof := tree.proof(uint64(i), uint64(n))
+			require.Len(t, proof, exitTreeHeight)
+			for h := range proof {
+				assert.Equal(t, common.Hash(expectedProof[h]), proof[h], "leaf %d of %d, height %d", i, n, h)
+			}
+		}
+	}
+
+	_, found = tree.leafCountByRoot(common.HexToHash("0x1"))
+	assert.False(t, found)
+
+	tree.reset()
+	assert.Equal(t, uint64(0), tree.leafCount())
+	_, found = tree.leafCountByRoot(common.Hash(leaves[0]))
+	assert.False(t, found)
+}
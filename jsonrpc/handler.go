@@ -1,15 +1,19 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"runtime/debug"
 	"strings"
 	"unicode"
 
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/google/uuid"
 )
 
 const (
@@ -38,6 +42,32 @@ type handleRequest struct {
 	HttpRequest *http.Request
 }
 
+// remoteAddr returns the address of the client connection, the forwarding headers are not used
+// because the clients can set them
+func (r *handleRequest) remoteAddr() string {
+	if r.HttpRequest == nil {
+		return ""
+	}
+	return r.HttpRequest.RemoteAddr
+}
+
+// logger returns the logger of the request, it has the correlation id of the HTTP request
+// or WS message
+func (r *handleRequest) logger() *log.Logger {
+	if r.HttpRequest == nil {
+		return log.WithFields()
+	}
+	return log.Ctx(r.HttpRequest.Context())
+}
+
+// context returns the context of the HTTP request, it carries the logger with the correlation id
+func (r *handleRequest) context() context.Context {
+	if r.HttpRequest == nil {
+		return context.Background()
+	}
+	return r.HttpRequest.Context()
+}
+
 // Handler manage services to handle jsonrpc requests
 //
 // Services are public structures containing public methods
@@ -56,12 +86,17 @@ type handleRequest struct {
 //
 // the public methods must follow the conventions:
 // - return interface{}, rpcError
-// - if the method depend on a Web Socket connection, it must be the first parameters as f(*websocket.Conn)
+// - if the method needs the context of the request, it must be the first parameter as f(context.Context),
+// the context carries the logger with the correlation id of the request
+// - if the method depend on a Web Socket connection, it must be the first parameters as f(*websocket.Conn),
+// after the context if any
 // - parameter types must match the type of the data provided for the method
 //
 // check the `eth.go` file for more example on how the methods are implemented
 type Handler struct {
 	serviceMap map[string]*serviceData
+	// adminAllowedIPs are the IPs allowed to call the admin methods
+	adminAllowedIPs ipAllowlist
 }
 
 func newJSONRpcHandler() *Handler {
@@ -73,10 +108,24 @@ func newJSONRpcHandler() *Handler {
 
 // Handle is the function that knows which and how a function should
 // be executed when a JSON RPC request is received
-func (h *Handler) Handle(req handleRequest) types.Response {
-	log := log.WithFields("method", req.Method, "requestId", req.ID)
+func (h *Handler) Handle(req handleRequest) (resp types.Response) {
+	log := req.logger().WithFields("method", req.Method, "requestId", req.ID)
 	log.Debugf("request params %v", string(req.Params))
 
+	// A panic handling the request must not stop the server, we return an internal error instead
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("panic recovered handling request: %v. Params: %v\n%s", r, string(req.Params), debug.Stack())
+			metrics.PanicRecovered()
+			resp = types.NewResponse(req.Request, nil, types.NewRPCError(types.InternalErrorCode, "internal error"))
+		}
+	}()
+
+	if strings.HasPrefix(req.Method, APIAdmin+"_") && !h.adminAllowedIPs.allows(req.remoteAddr()) {
+		log.Warnf("admin request from %s refused, the IP is not allowed", req.remoteAddr())
+		return types.NewResponse(req.Request, nil, types.NewRPCError(types.InvalidRequestErrorCode, "the IP is not allowed to call the admin methods"))
+	}
+
 	service, fd, err := h.getFnHandler(req.Request)
 	if err != nil {
 		return types.NewResponse(req.Request, nil, err)
@@ -86,28 +135,33 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
+	if len(fd.reqt) > 1 && fd.reqt[1] == contextType {
+		inArgs[1] = reflect.ValueOf(req.context())
+		inArgsOffset++
+	}
+
 	requestHasWebSocketConn := req.wsConn != nil
-	funcHasMoreThanOneInputParams := len(fd.reqt) > 1
+	funcHasMoreInputParams := len(fd.reqt) > inArgsOffset+1
 	firstFuncParamIsWebSocketConn := false
 	firstFuncParamIsHttpRequest := false
-	if funcHasMoreThanOneInputParams {
-		firstFuncParamIsWebSocketConn = fd.reqt[1].AssignableTo(reflect.TypeOf(&concurrentWsConn{}))
-		firstFuncParamIsHttpRequest = fd.reqt[1].AssignableTo(reflect.TypeOf(&http.Request{}))
+	if funcHasMoreInputParams {
+		firstFuncParamIsWebSocketConn = fd.reqt[inArgsOffset+1].AssignableTo(reflect.TypeOf(&concurrentWsConn{}))
+		firstFuncParamIsHttpRequest = fd.reqt[inArgsOffset+1].AssignableTo(reflect.TypeOf(&http.Request{}))
 	}
 	if requestHasWebSocketConn && firstFuncParamIsWebSocketConn {
-		inArgs[1] = reflect.ValueOf(req.wsConn)
+		inArgs[inArgsOffset+1] = reflect.ValueOf(req.wsConn)
 		inArgsOffset++
 	} else if firstFuncParamIsHttpRequest {
 		// If in the future one endponit needs to have both a websocket connection and an http request
 		// we will need to modify this code to properly handle it
-		inArgs[1] = reflect.ValueOf(req.HttpRequest)
+		inArgs[inArgsOffset+1] = reflect.ValueOf(req.HttpRequest)
 		inArgsOffset++
 	}
 
 	// check params passed by request match function params
 	var testStruct []interface{}
-	if err := json.Unmarshal(req.Params, &testStruct); err == nil && len(testStruct) > fd.numParams() {
-		return types.NewResponse(req.Request, nil, types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("too many arguments, want at most %d", fd.numParams())))
+	if err := json.Unmarshal(req.Params, &testStruct); err == nil && len(testStruct) > fd.numParams()-inArgsOffset {
+		return types.NewResponse(req.Request, nil, types.NewRPCError(types.InvalidParamsErrorCode, "too many arguments, want at most %d", fd.numParams()-inArgsOffset))
 	}
 
 	inputs := make([]interface{}, fd.numParams()-inArgsOffset)
@@ -118,7 +172,7 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		inArgs[i+1] = val.Elem()
 	}
 
-	if fd.numParams() > 0 {
+	if fd.numParams()-inArgsOffset > 0 {
 		if err := json.Unmarshal(req.Params, &inputs); err != nil {
 			return types.NewResponse(req.Request, nil, types.NewRPCError(types.InvalidParamsErrorCode, "Invalid Params"))
 		}
@@ -145,9 +199,13 @@ func (h *Handler) HandleWs(reqBody []byte, wsConn *concurrentWsConn, httpReq *ht
 	log.Debugf("WS message received: %v", string(reqBody))
 	var req types.Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
-		return types.NewResponse(req, nil, types.NewRPCError(types.InvalidRequestErrorCode, "Invalid json request")).Bytes()
+		return types.NewResponse(req, nil, types.NewRPCError(types.ParserErrorCode, "Invalid json request")).Bytes()
 	}
 
+	// every WS message is a new request, so it has its own correlation id
+	if httpReq != nil {
+		httpReq = withCorrelationID(httpReq, uuid.NewString())
+	}
 	handleReq := handleRequest{
 		Request:     req,
 		wsConn:      wsConn,
@@ -222,11 +280,11 @@ func (h *Handler) registerService(service Service) {
 }
 
 func (h *Handler) getFnHandler(req types.Request) (*serviceData, *funcData, types.Error) {
-	methodNotFoundErrorMessage := fmt.Sprintf("the method %s does not exist/is not available", req.Method)
+	methodNotFoundError := types.NewRPCError(types.NotFoundErrorCode, "the method %s does not exist/is not available", req.Method)
 
 	callName := strings.SplitN(req.Method, "_", 2) //nolint:gomnd
 	if len(callName) != 2 {                        //nolint:gomnd
-		return nil, nil, types.NewRPCError(types.NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, methodNotFoundError
 	}
 
 	serviceName, funcName := callName[0], callName[1]
@@ -234,11 +292,11 @@ func (h *Handler) getFnHandler(req types.Request) (*serviceData, *funcData, type
 	service, ok := h.serviceMap[serviceName]
 	if !ok {
 		log.Debugf("Method %s not found", req.Method)
-		return nil, nil, types.NewRPCError(types.NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, methodNotFoundError
 	}
 	fd, ok := service.funcMap[funcName]
 	if !ok {
-		return nil, nil, types.NewRPCError(types.NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, methodNotFoundError
 	}
 	return service, fd, nil
 }
@@ -276,6 +334,8 @@ func validateFunc(funcName string, fv reflect.Value, isMethod bool) (inNum int,
 
 var rpcErrType = reflect.TypeOf((*types.Error)(nil)).Elem()
 
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 func isRPCErrorType(t reflect.Type) bool {
 	return t.Implements(rpcErrType)
 }
@@ -0,0 +1,263 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicEndpoints struct{}
+
+func (e *panicEndpoints) Panic() (interface{}, types.Error) {
+	panic("unexpected error")
+}
+
+func (e *panicEndpoints) NilPointer(arg *types.ArgUint64) (interface{}, types.Error) {
+	return uint64(*arg), nil
+}
+
+func TestHandlePanicRecovery(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: &panicEndpoints{}}})
+
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "Panic in endpoint",
+			body: `{"jsonrpc":"2.0","id":1,"method":"test_panic","params":[]}`,
+		},
+		{
+			name: "Nil pointer dereference in endpoint",
+			body: `{"jsonrpc":"2.0","id":1,"method":"test_nilPointer","params":[null]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.body)))
+			req.Header.Set("Content-Type", contentType)
+			res := httptest.NewRecorder()
+
+			require.NotPanics(t, func() { s.handle(res, req) })
+			assert.Equal(t, http.StatusOK, res.Code)
+
+			var response types.Response
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+			assert.Equal(t, float64(1), response.ID)
+			require.NotNil(t, response.Error)
+			assert.Equal(t, types.InternalErrorCode, response.Error.Code)
+			assert.Nil(t, response.Result)
+		})
+	}
+}
+
+func TestCorrelationIDHeader(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: &panicEndpoints{}}})
+
+	testCases := []struct {
+		name        string
+		body        string
+		contentType string
+	}{
+		{
+			name:        "Failed request",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"test_panic","params":[]}`,
+			contentType: contentType,
+		},
+		{
+			name:        "Invalid request",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"test_panic","params":[]}`,
+			contentType: "text/plain",
+		},
+	}
+
+	correlationIDs := map[string]struct{}{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.body)))
+			req.Header.Set("Content-Type", tc.contentType)
+			res := httptest.NewRecorder()
+
+			s.handle(res, req)
+
+			correlationID := res.Header().Get(correlationIDHeader)
+			_, err := uuid.Parse(correlationID)
+			require.NoError(t, err)
+			assert.NotContains(t, correlationIDs, correlationID)
+			correlationIDs[correlationID] = struct{}{}
+		})
+	}
+}
+
+type headerEndpoints struct{}
+
+func (e *headerEndpoints) SetHeader(ctx context.Context, value string) (interface{}, types.Error) {
+	setResponseHeader(ctx, callGasCappedHeader, value)
+	return nil, nil
+}
+
+func TestResponseHeader(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: &headerEndpoints{}}})
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"method":"test_setHeader","params":["30000"]}`)))
+	req.Header.Set("Content-Type", contentType)
+	res := httptest.NewRecorder()
+
+	s.handle(res, req)
+
+	assert.Equal(t, "30000", res.Header().Get(callGasCappedHeader))
+	assert.Contains(t, res.Header().Get("Access-Control-Expose-Headers"), callGasCappedHeader)
+}
+
+type pingEndpoints struct{}
+
+func (e *pingEndpoints) Ping() (interface{}, types.Error) {
+	return "pong", nil
+}
+
+type ctxKey struct{}
+
+type ctxEndpoints struct{}
+
+func (e *ctxEndpoints) Value(ctx context.Context, suffix string) (interface{}, types.Error) {
+	value, _ := ctx.Value(ctxKey{}).(string)
+	return value + suffix, nil
+}
+
+func TestHandleRequestContext(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: &ctxEndpoints{}}})
+
+	testCases := []struct {
+		name           string
+		params         string
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name:           "The endpoint gets the request context",
+			params:         `["-suffix"]`,
+			expectedResult: `"value-suffix"`,
+		},
+		{
+			name:          "The context is not a param of the request",
+			params:        `["-suffix","-suffix"]`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"test_value","params":` + tc.params + `}`
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "value"))
+			req.Header.Set("Content-Type", contentType)
+			res := httptest.NewRecorder()
+
+			s.handle(res, req)
+			assert.Equal(t, http.StatusOK, res.Code)
+
+			var response types.Response
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+			if tc.expectedError {
+				require.NotNil(t, response.Error)
+				assert.Equal(t, types.InvalidParamsErrorCode, response.Error.Code)
+			} else {
+				require.Nil(t, response.Error)
+				assert.Equal(t, tc.expectedResult, string(response.Result))
+			}
+		})
+	}
+}
+
+func TestAdminAllowedIPs(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	cfg.AdminAllowedIPs = []string{"10.0.0.0/8"}
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: APIAdmin, Service: &pingEndpoints{}}, {Name: "test", Service: &pingEndpoints{}}})
+
+	testCases := []struct {
+		name          string
+		method        string
+		remoteAddr    string
+		expectedError bool
+	}{
+		{
+			name:       "Admin method from an allowed IP",
+			method:     "admin_ping",
+			remoteAddr: "10.1.2.3:1234",
+		},
+		{
+			name:          "Admin method from an IP not allowed",
+			method:        "admin_ping",
+			remoteAddr:    "192.0.2.1:1234",
+			expectedError: true,
+		},
+		{
+			name:       "Other method from an IP not allowed",
+			method:     "test_ping",
+			remoteAddr: "192.0.2.1:1234",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"` + tc.method + `","params":[]}`
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set("Content-Type", contentType)
+			req.RemoteAddr = tc.remoteAddr
+			res := httptest.NewRecorder()
+
+			s.handle(res, req)
+			assert.Equal(t, http.StatusOK, res.Code)
+
+			var response types.Response
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+			if tc.expectedError {
+				require.NotNil(t, response.Error)
+				assert.Equal(t, types.InvalidRequestErrorCode, response.Error.Code)
+				assert.Nil(t, response.Result)
+			} else {
+				require.Nil(t, response.Error)
+				assert.Equal(t, `"pong"`, string(response.Result))
+			}
+		})
+	}
+
+	// an invalid config is reported by Validate and refuses the admin methods to all the IPs
+	cfg.AdminAllowedIPs = []string{"not an ip"}
+	assert.Error(t, cfg.Validate())
+	s = NewServer(cfg, chainID, nil, nil, nil, nil)
+	assert.False(t, s.handler.adminAllowedIPs.allows("10.1.2.3:1234"))
+}
+
+func TestHandleWsInvalidJSON(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: &pingEndpoints{}}})
+
+	resBody, err := s.handler.HandleWs([]byte(`{"jsonrpc":"2.0","id":1,"method":`), nil, nil)
+	require.NoError(t, err)
+
+	var res types.Response
+	require.NoError(t, json.Unmarshal(resBody, &res))
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.ParserErrorCode, res.Error.Code)
+	assert.Equal(t, "Invalid json request", res.Error.Message)
+}
@@ -0,0 +1,199 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// idempotencyKeyHeader is the request header with the key the client uses to retry a request
+	idempotencyKeyHeader = "X-Idempotency-Key"
+	// idempotencyReplayedHeader is set in the responses served from the idempotency cache
+	idempotencyReplayedHeader = "X-Idempotency-Replayed"
+	// idempotencyMaxEntries is the max number of idempotency keys kept, the requests with a new key
+	// are handled without caching their response once it's reached
+	idempotencyMaxEntries = 10000
+	// idempotencyMaxBytes is the max size of the cached responses
+	idempotencyMaxBytes = 64 * 1024 * 1024
+)
+
+// errIdempotencyKeyReused is returned when a request reuses the idempotency key of a different request
+var errIdempotencyKeyReused = errors.New("the idempotency key was already used by a different request")
+
+// idempotencyEntry is the response of a request sent with an idempotency key, done is
+// closed once the request is handled
+type idempotencyEntry struct {
+	requestHash [sha256.Size]byte
+	done        chan struct{}
+	response    []byte
+	cached      bool
+	expiresAt   time.Time
+}
+
+// idempotencyCache keeps the responses of the requests sent with an idempotency key, so the
+// retries of a request get the response of the first one instead of handling it again. A nil
+// idempotencyCache is a disabled cache
+type idempotencyCache struct {
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int
+	mu         sync.Mutex
+	entries    map[string]*idempotencyEntry
+	bytes      int
+	lastSweep  time.Time
+}
+
+// newIdempotencyCache creates a cache that keeps the responses for the ttl, nil if ttl is zero
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &idempotencyCache{
+		ttl:        ttl,
+		maxEntries: idempotencyMaxEntries,
+		maxBytes:   idempotencyMaxBytes,
+		entries:    map[string]*idempotencyEntry{},
+		lastSweep:  time.Now(),
+	}
+}
+
+// idempotencyScopedKey returns the cache key of the idempotency key of the request, the key is
+// scoped by the client address and authorization so a client never gets the response of another one
+func idempotencyScopedKey(req *http.Request, key string) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	scope := sha256.Sum256([]byte(host + "\n" + req.Header.Get("Authorization")))
+	return hex.EncodeToString(scope[:]) + ":" + key
+}
+
+// begin returns the entry of the key, first is true if the request has to be handled and the
+// response stored with finish, otherwise the response of the entry is available once its done
+// channel is closed. A nil entry with first true means the cache is full and the request has to
+// be handled without caching its response
+func (c *idempotencyCache) begin(key string, request []byte) (entry *idempotencyEntry, first bool, err error) {
+	requestHash := sha256.Sum256(request)
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) >= c.ttl {
+		c.sweep(now)
+	}
+
+	if entry, found := c.entries[key]; found {
+		if !entry.expired(now) {
+			if entry.requestHash != requestHash {
+				return nil, false, errIdempotencyKeyReused
+			}
+			return entry, false, nil
+		}
+		c.remove(key)
+	}
+	if len(c.entries) >= c.maxEntries {
+		return nil, true, nil
+	}
+
+	entry = &idempotencyEntry{requestHash: requestHash, done: make(chan struct{})}
+	c.entries[key] = entry
+	return entry, true, nil
+}
+
+// finish stores the response of the entry if the request succeeded without a JSON-RPC error and
+// the response fits in the cache, otherwise the entry is removed so the request can be retried
+func (c *idempotencyCache) finish(key string, entry *idempotencyEntry, w *idempotentResponseWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response := w.body.Bytes()
+	if w.status == http.StatusOK && !hasRPCError(response) && c.bytes+len(response) <= c.maxBytes {
+		entry.response = response
+		entry.cached = true
+		entry.expiresAt = time.Now().Add(c.ttl)
+		c.bytes += len(response)
+	} else if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	close(entry.done)
+}
+
+// remove removes the entry of the key, the caller must hold the lock
+func (c *idempotencyCache) remove(key string) {
+	if entry, found := c.entries[key]; found {
+		c.bytes -= len(entry.response)
+		delete(c.entries, key)
+	}
+}
+
+// sweep removes the expired entries, the caller must hold the lock
+func (c *idempotencyCache) sweep(now time.Time) {
+	for key, entry := range c.entries {
+		if entry.expired(now) {
+			c.remove(key)
+		}
+	}
+	c.lastSweep = now
+}
+
+// hasRPCError returns if the response, or any response of a batch, has an error member. A response
+// that can't be decoded is handled as an error
+func hasRPCError(response []byte) bool {
+	type rpcResponse struct {
+		Error json.RawMessage `json:"error"`
+	}
+	var responses []rpcResponse
+	response = bytes.TrimSpace(response)
+	if len(response) > 0 && response[0] == '[' {
+		if err := json.Unmarshal(response, &responses); err != nil {
+			return true
+		}
+	} else {
+		responses = make([]rpcResponse, 1)
+		if err := json.Unmarshal(response, &responses[0]); err != nil {
+			return true
+		}
+	}
+	for _, r := range responses {
+		if len(r.Error) > 0 && string(r.Error) != "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// expired returns if the response of the entry is no longer valid, the entries of the
+// requests being handled don't expire
+func (e *idempotencyEntry) expired(now time.Time) bool {
+	return e.cached && now.After(e.expiresAt)
+}
+
+// idempotentResponseWriter keeps a copy of the response written to the client
+type idempotentResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *idempotentResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *idempotentResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
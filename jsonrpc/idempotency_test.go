@@ -0,0 +1,166 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counterEndpoints struct {
+	calls atomic.Int64
+}
+
+func (e *counterEndpoints) Increase() (interface{}, types.Error) {
+	return e.calls.Add(1), nil
+}
+
+func (e *counterEndpoints) Fail() (interface{}, types.Error) {
+	e.calls.Add(1)
+	return nil, types.NewRPCError(types.DefaultErrorCode, "failed")
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+	cfg.IdempotencyKeyTTL.Duration = 100 * time.Millisecond
+	endpoints := &counterEndpoints{}
+	s := NewServer(cfg, chainID, nil, nil, nil, []Service{{Name: "test", Service: endpoints}})
+
+	const body = `{"jsonrpc":"2.0","id":1,"method":"test_increase","params":[]}`
+	callFrom := func(remoteAddr, body, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Content-Type", contentType)
+		if idempotencyKey != "" {
+			req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+		}
+		res := httptest.NewRecorder()
+		s.handle(res, req)
+		return res
+	}
+	call := func(body, idempotencyKey string) *httptest.ResponseRecorder {
+		return callFrom("192.0.2.1:1234", body, idempotencyKey)
+	}
+
+	// The retries with the same key get the response of the first request
+	first := call(body, "key-1")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Empty(t, first.Header().Get(idempotencyReplayedHeader))
+	retry := call(body, "key-1")
+	require.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(idempotencyReplayedHeader))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, int64(1), endpoints.calls.Load())
+
+	// The requests without a key or with another key are handled
+	call(body, "")
+	call(body, "key-2")
+	assert.Equal(t, int64(3), endpoints.calls.Load())
+
+	// A different request can't reuse the key
+	reused := call(`{"jsonrpc":"2.0","id":2,"method":"test_increase","params":[]}`, "key-1")
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, int64(3), endpoints.calls.Load())
+
+	// The failed requests are not cached
+	call(body[:10], "key-3")
+	call(body, "key-3")
+	assert.Equal(t, int64(4), endpoints.calls.Load())
+
+	// The responses with a JSON-RPC error are not cached
+	const failBody = `{"jsonrpc":"2.0","id":1,"method":"test_fail","params":[]}`
+	call(failBody, "key-4")
+	call(failBody, "key-4")
+	assert.Equal(t, int64(6), endpoints.calls.Load())
+
+	// The key is scoped by the client, another client using the same key is handled
+	other := callFrom("192.0.2.2:1234", body, "key-1")
+	assert.Empty(t, other.Header().Get(idempotencyReplayedHeader))
+	assert.Equal(t, int64(7), endpoints.calls.Load())
+
+	// The key can be used again once the response expires
+	time.Sleep(150 * time.Millisecond)
+	call(body, "key-1")
+	assert.Equal(t, int64(8), endpoints.calls.Load())
+}
+
+func TestIdempotencyCacheBounds(t *testing.T) {
+	c := newIdempotencyCache(time.Minute)
+	c.maxEntries = 2
+	c.maxBytes = 10
+	finish := func(key string, entry *idempotencyEntry, response string) {
+		w := &idempotentResponseWriter{ResponseWriter: httptest.NewRecorder()}
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+		c.finish(key, entry, w)
+	}
+
+	entry, first, err := c.begin("key-1", []byte("request"))
+	require.NoError(t, err)
+	require.True(t, first)
+	finish("key-1", entry, `{"id":1}`)
+	assert.True(t, entry.cached)
+
+	// A response that doesn't fit in the cache is not kept
+	entry, first, err = c.begin("key-2", []byte("request"))
+	require.NoError(t, err)
+	require.True(t, first)
+	finish("key-2", entry, `{"id":2,"result":"0x1"}`)
+	assert.False(t, entry.cached)
+	assert.Len(t, c.entries, 1)
+
+	// Once the max entries are reached the new keys are handled without caching
+	_, _, err = c.begin("key-3", []byte("request"))
+	require.NoError(t, err)
+	entry, first, err = c.begin("key-4", []byte("request"))
+	require.NoError(t, err)
+	assert.True(t, first)
+	assert.Nil(t, entry)
+}
+
+func TestHasRPCError(t *testing.T) {
+	assert.False(t, hasRPCError([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`)))
+	assert.True(t, hasRPCError([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"failed"}}`)))
+	assert.False(t, hasRPCError([]byte(`[{"id":1,"result":"0x1"},{"id":2,"result":"0x2"}]`)))
+	assert.True(t, hasRPCError([]byte(`[{"id":1,"result":"0x1"},{"id":2,"error":{"code":-32000,"message":"failed"}}]`)))
+	assert.True(t, hasRPCError([]byte(`not json`)))
+}
+
+func TestIdempotencyCacheConcurrentRetry(t *testing.T) {
+	c := newIdempotencyCache(time.Minute)
+	request := []byte("request")
+
+	entry, first, err := c.begin("key", request)
+	require.NoError(t, err)
+	require.True(t, first)
+
+	retry, first, err := c.begin("key", request)
+	require.NoError(t, err)
+	require.False(t, first)
+
+	select {
+	case <-retry.done:
+		t.Fatal("the retry is done before the first request")
+	default:
+	}
+
+	w := &idempotentResponseWriter{ResponseWriter: httptest.NewRecorder()}
+	_, err = w.Write([]byte(`{"id":1,"result":"0x1"}`))
+	require.NoError(t, err)
+	c.finish("key", entry, w)
+
+	<-retry.done
+	assert.True(t, retry.cached)
+	assert.Equal(t, []byte(`{"id":1,"result":"0x1"}`), retry.response)
+}
+
+func TestIdempotencyCacheDisabled(t *testing.T) {
+	assert.Nil(t, newIdempotencyCache(0))
+}
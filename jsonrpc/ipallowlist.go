@@ -0,0 +1,60 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ipAllowlist is a list of IP ranges, an empty ipAllowlist doesn't allow any IP
+type ipAllowlist []netip.Prefix
+
+// loopbackAllowlist allows only the loopback IPs, it's used when no IPs are configured
+var loopbackAllowlist = ipAllowlist{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+// newIPAllowlist parses the IPs and the IP ranges in CIDR notation, if there are none only
+// the loopback IPs are allowed
+func newIPAllowlist(entries []string) (ipAllowlist, error) {
+	if len(entries) == 0 {
+		return loopbackAllowlist, nil
+	}
+	l := make(ipAllowlist, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP range %q: %w", entry, err)
+			}
+			l = append(l, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP %q: %w", entry, err)
+		}
+		addr = addr.Unmap()
+		l = append(l, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return l, nil
+}
+
+// allows returns if the IP of the remote address, in host:port or host form, is in the list
+func (l ipAllowlist) allows(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range l {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
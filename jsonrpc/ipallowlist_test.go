@@ -0,0 +1,45 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	// only the loopback IPs are allowed by default
+	l, err := newIPAllowlist(nil)
+	require.NoError(t, err)
+	assert.False(t, l.allows("192.0.2.1:1234"))
+	assert.True(t, l.allows("127.0.0.1:1234"))
+	assert.True(t, l.allows("[::1]:1234"))
+
+	// a nil allowlist doesn't allow any IP
+	assert.False(t, ipAllowlist(nil).allows("127.0.0.1:1234"))
+
+	_, err = newIPAllowlist([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = newIPAllowlist([]string{"localhost"})
+	assert.Error(t, err)
+
+	l, err = newIPAllowlist([]string{"10.0.0.0/8", " 192.0.2.1 ", "2001:db8::/32"})
+	require.NoError(t, err)
+	testCases := []struct {
+		remoteAddr string
+		allowed    bool
+	}{
+		{remoteAddr: "10.1.2.3:1234", allowed: true},
+		{remoteAddr: "10.1.2.3", allowed: true},
+		{remoteAddr: "[::ffff:10.1.2.3]:1234", allowed: true},
+		{remoteAddr: "11.0.0.1:1234", allowed: false},
+		{remoteAddr: "192.0.2.1:1234", allowed: true},
+		{remoteAddr: "192.0.2.2:1234", allowed: false},
+		{remoteAddr: "[2001:db8::1]:1234", allowed: true},
+		{remoteAddr: "[2001:db9::1]:1234", allowed: false},
+		{remoteAddr: "", allowed: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.allowed, l.allows(tc.remoteAddr), tc.remoteAddr)
+	}
+}
@@ -13,6 +13,8 @@ const (
 	requestsHandledName = requestPrefix + "handled"
 	requestDurationName = requestPrefix + "duration"
 	connName            = requestPrefix + "connection"
+	panicsRecoveredName = prefix + "panics_recovered_total"
+	activeConnsName     = prefix + "active_connections"
 
 	requestHandledTypeLabelName = "type"
 )
@@ -44,10 +46,19 @@ const (
 // Register the metrics for the jsonrpc package.
 func Register() {
 	var (
+		counters    []prometheus.CounterOpts
 		counterVecs []metrics.CounterVecOpts
+		gauges      []prometheus.GaugeOpts
 		histograms  []prometheus.HistogramOpts
 	)
 
+	counters = []prometheus.CounterOpts{
+		{
+			Name: panicsRecoveredName,
+			Help: "[JSONRPC] number of panics recovered when handling requests",
+		},
+	}
+
 	counterVecs = []metrics.CounterVecOpts{
 		{
 			CounterOpts: prometheus.CounterOpts{
@@ -58,6 +69,13 @@ func Register() {
 		},
 	}
 
+	gauges = []prometheus.GaugeOpts{
+		{
+			Name: activeConnsName,
+			Help: "[JSONRPC] number of open HTTP connections",
+		},
+	}
+
 	start := 0.1
 	width := 0.1
 	count := 10
@@ -69,7 +87,9 @@ func Register() {
 		},
 	}
 
+	metrics.RegisterCounters(counters...)
 	metrics.RegisterCounterVecs(counterVecs...)
+	metrics.RegisterGauges(gauges...)
 	metrics.RegisterHistograms(histograms...)
 }
 
@@ -79,12 +99,22 @@ func CountConn(label ConnLabel) {
 	metrics.CounterVecInc(connName, string(label))
 }
 
+// ActiveConnections sets the gauge of open HTTP connections.
+func ActiveConnections(count int64) {
+	metrics.GaugeSet(activeConnsName, float64(count))
+}
+
 // RequestHandled increments the requests handled counter vector by one for the
 // given label.
 func RequestHandled(label RequestHandledLabel) {
 	metrics.CounterVecInc(requestsHandledName, string(label))
 }
 
+// PanicRecovered increments the panics recovered counter by one.
+func PanicRecovered() {
+	metrics.CounterInc(panicsRecoveredName)
+}
+
 // RequestDuration observes (histogram) the duration of a request from the
 // provided starting time.
 func RequestDuration(start time.Time) {
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package jsonrpc
 
@@ -13,6 +13,10 @@ type storageMock struct {
 func (_m *storageMock) GetAllBlockFiltersWithWSConn() []*Filter {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBlockFiltersWithWSConn")
+	}
+
 	var r0 []*Filter
 	if rf, ok := ret.Get(0).(func() []*Filter); ok {
 		r0 = rf()
@@ -29,6 +33,30 @@ func (_m *storageMock) GetAllBlockFiltersWithWSConn() []*Filter {
 func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllLogFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func() []*Filter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
+// GetAllSyncingFiltersWithWSConn provides a mock function with given fields:
+func (_m *storageMock) GetAllSyncingFiltersWithWSConn() []*Filter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSyncingFiltersWithWSConn")
+	}
+
 	var r0 []*Filter
 	if rf, ok := ret.Get(0).(func() []*Filter); ok {
 		r0 = rf()
@@ -45,6 +73,10 @@ func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	ret := _m.Called(filterID)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilter")
+	}
+
 	var r0 *Filter
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*Filter, error)); ok {
@@ -71,6 +103,10 @@ func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
 	ret := _m.Called(wsConn)
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewBlockFilter")
+	}
+
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
@@ -95,6 +131,10 @@ func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error) {
 	ret := _m.Called(wsConn, filter)
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewLogFilter")
+	}
+
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*concurrentWsConn, LogFilter) (string, error)); ok {
@@ -119,6 +159,38 @@ func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter)
 func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
 	ret := _m.Called(wsConn)
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewPendingTransactionFilter")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
+		return rf(wsConn)
+	}
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn) string); ok {
+		r0 = rf(wsConn)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn) error); ok {
+		r1 = rf(wsConn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSyncingFilter provides a mock function with given fields: wsConn
+func (_m *storageMock) NewSyncingFilter(wsConn *concurrentWsConn) (string, error) {
+	ret := _m.Called(wsConn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewSyncingFilter")
+	}
+
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
@@ -143,6 +215,10 @@ func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (st
 func (_m *storageMock) UninstallFilter(filterID string) error {
 	ret := _m.Called(filterID)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UninstallFilter")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
 		r0 = rf(filterID)
@@ -157,6 +233,10 @@ func (_m *storageMock) UninstallFilter(filterID string) error {
 func (_m *storageMock) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	ret := _m.Called(wsConn)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UninstallFilterByWSConn")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*concurrentWsConn) error); ok {
 		r0 = rf(wsConn)
@@ -171,6 +251,10 @@ func (_m *storageMock) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 func (_m *storageMock) UpdateFilterLastPoll(filterID string) error {
 	ret := _m.Called(filterID)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFilterLastPoll")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
 		r0 = rf(filterID)
@@ -1,9 +1,10 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package mocks
 
 import (
 	context "context"
+	big "math/big"
 
 	common "github.com/ethereum/go-ethereum/common"
 
@@ -25,6 +26,10 @@ type PoolMock struct {
 func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
 	ret := _m.Called(ctx, tx, ip)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AddTx")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) error); ok {
 		r0 = rf(ctx, tx, ip)
@@ -39,6 +44,10 @@ func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string)
 func (_m *PoolMock) CountPendingTransactions(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CountPendingTransactions")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
@@ -63,6 +72,10 @@ func (_m *PoolMock) CountPendingTransactions(ctx context.Context) (uint64, error
 func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetGasPrices")
+	}
+
 	var r0 pool.GasPrices
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (pool.GasPrices, error)); ok {
@@ -87,6 +100,10 @@ func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 func (_m *PoolMock) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
 	ret := _m.Called(ctx, address)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonce")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (uint64, error)); ok {
@@ -107,10 +124,44 @@ func (_m *PoolMock) GetNonce(ctx context.Context, address common.Address) (uint6
 	return r0, r1
 }
 
+// GetPendingBalance provides a mock function with given fields: ctx, address
+func (_m *PoolMock) GetPendingBalance(ctx context.Context, address common.Address) (*big.Int, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingBalance")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*big.Int, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *big.Int); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPendingTxHashesSince provides a mock function with given fields: ctx, since
 func (_m *PoolMock) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
 	ret := _m.Called(ctx, since)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTxHashesSince")
+	}
+
 	var r0 []common.Hash
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]common.Hash, error)); ok {
@@ -137,6 +188,10 @@ func (_m *PoolMock) GetPendingTxHashesSince(ctx context.Context, since time.Time
 func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
 	ret := _m.Called(ctx, limit)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTxs")
+	}
+
 	var r0 []pool.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]pool.Transaction, error)); ok {
@@ -163,6 +218,10 @@ func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Tra
 func (_m *PoolMock) GetTxByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
 	ret := _m.Called(ctx, hash)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxByHash")
+	}
+
 	var r0 *pool.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*pool.Transaction, error)); ok {
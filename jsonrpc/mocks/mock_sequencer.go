@@ -0,0 +1,174 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	state "github.com/0xPolygonHermez/zkevm-node/state"
+
+	time "time"
+)
+
+// SequencerMock is an autogenerated mock type for the SequencerInterface type
+type SequencerMock struct {
+	mock.Mock
+}
+
+// ForceBatchProcessing provides a mock function with given fields: ctx, rawTxsData, globalExitRoot, forcedAt
+func (_m *SequencerMock) ForceBatchProcessing(ctx context.Context, rawTxsData []byte, globalExitRoot common.Hash, forcedAt time.Time) (uint64, error) {
+	ret := _m.Called(ctx, rawTxsData, globalExitRoot, forcedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceBatchProcessing")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, common.Hash, time.Time) (uint64, error)); ok {
+		return rf(ctx, rawTxsData, globalExitRoot, forcedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, common.Hash, time.Time) uint64); ok {
+		r0 = rf(ctx, rawTxsData, globalExitRoot, forcedAt)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, common.Hash, time.Time) error); ok {
+		r1 = rf(ctx, rawTxsData, globalExitRoot, forcedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingNonce provides a mock function with given fields: address
+func (_m *SequencerMock) GetPendingNonce(address common.Address) uint64 {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingNonce")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Address) uint64); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetSequencerState provides a mock function with given fields:
+func (_m *SequencerMock) GetSequencerState() (state.SequencerState, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequencerState")
+	}
+
+	var r0 state.SequencerState
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (state.SequencerState, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() state.SequencerState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(state.SequencerState)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InspectWorker provides a mock function with given fields:
+func (_m *SequencerMock) InspectWorker() ([]state.PendingTxSummary, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for InspectWorker")
+	}
+
+	var r0 []state.PendingTxSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]state.PendingTxSummary, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []state.PendingTxSummary); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.PendingTxSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PauseForcedBatchProcessing provides a mock function with given fields: duration
+func (_m *SequencerMock) PauseForcedBatchProcessing(duration time.Duration) error {
+	ret := _m.Called(duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseForcedBatchProcessing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Duration) error); ok {
+		r0 = rf(duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDynamicConfig provides a mock function with given fields: field, value
+func (_m *SequencerMock) SetDynamicConfig(field string, value string) error {
+	ret := _m.Called(field, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDynamicConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(field, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSequencerMock creates a new instance of SequencerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSequencerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SequencerMock {
+	mock := &SequencerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package mocks
 
@@ -30,6 +30,10 @@ type StateMock struct {
 func (_m *StateMock) BatchNumberByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BatchNumberByL2BlockNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
@@ -54,6 +58,10 @@ func (_m *StateMock) BatchNumberByL2BlockNumber(ctx context.Context, blockNumber
 func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BeginStateTransaction")
+	}
+
 	var r0 pgx.Tx
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (pgx.Tx, error)); ok {
@@ -76,10 +84,42 @@ func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 	return r0, r1
 }
 
+// CountPrunableBatches provides a mock function with given fields: ctx, beforeBatchNumber, dbTx
+func (_m *StateMock) CountPrunableBatches(ctx context.Context, beforeBatchNumber uint64, dbTx pgx.Tx) (int64, error) {
+	ret := _m.Called(ctx, beforeBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPrunableBatches")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (int64, error)); ok {
+		return rf(ctx, beforeBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) int64); ok {
+		r0 = rf(ctx, beforeBatchNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, beforeBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DebugTransaction provides a mock function with given fields: ctx, transactionHash, traceConfig, dbTx
 func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash common.Hash, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	ret := _m.Called(ctx, transactionHash, traceConfig, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DebugTransaction")
+	}
+
 	var r0 *runtime.ExecutionResult
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, state.TraceConfig, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
@@ -106,6 +146,10 @@ func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash commo
 func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
 	ret := _m.Called(transaction, senderAddress, l2BlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGas")
+	}
+
 	var r0 uint64
 	var r1 []byte
 	var r2 error
@@ -139,6 +183,10 @@ func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddre
 func (_m *StateMock) GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash) (*big.Int, error)); ok {
@@ -161,10 +209,44 @@ func (_m *StateMock) GetBalance(ctx context.Context, address common.Address, roo
 	return r0, r1
 }
 
+// GetBatchByForcedBatchNum provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
+func (_m *StateMock) GetBatchByForcedBatchNum(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByForcedBatchNum")
+	}
+
+	var r0 *state.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
+		return rf(ctx, forcedBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
+		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBatchByNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByNumber")
+	}
+
 	var r0 *state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
@@ -191,6 +273,10 @@ func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, d
 func (_m *StateMock) GetBatchTimestamp(ctx context.Context, batchNumber uint64, forcedForkId *uint64, dbTx pgx.Tx) (*time.Time, error) {
 	ret := _m.Called(ctx, batchNumber, forcedForkId, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchTimestamp")
+	}
+
 	var r0 *time.Time
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, *uint64, pgx.Tx) (*time.Time, error)); ok {
@@ -217,6 +303,10 @@ func (_m *StateMock) GetBatchTimestamp(ctx context.Context, batchNumber uint64,
 func (_m *StateMock) GetCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error) {
 	ret := _m.Called(ctx, address, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetCode")
+	}
+
 	var r0 []byte
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash) ([]byte, error)); ok {
@@ -243,6 +333,10 @@ func (_m *StateMock) GetCode(ctx context.Context, address common.Address, root c
 func (_m *StateMock) GetExitRootByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (*state.GlobalExitRoot, error) {
 	ret := _m.Called(ctx, ger, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetExitRootByGlobalExitRoot")
+	}
+
 	var r0 *state.GlobalExitRoot
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*state.GlobalExitRoot, error)); ok {
@@ -265,10 +359,100 @@ func (_m *StateMock) GetExitRootByGlobalExitRoot(ctx context.Context, ger common
 	return r0, r1
 }
 
+// GetForcedBatch provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
+func (_m *StateMock) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error) {
+	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForcedBatch")
+	}
+
+	var r0 *state.ForcedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.ForcedBatch, error)); ok {
+		return rf(ctx, forcedBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.ForcedBatch); ok {
+		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.ForcedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForkIDActivationBatchNumber provides a mock function with given fields: ctx, forkID
+func (_m *StateMock) GetForkIDActivationBatchNumber(ctx context.Context, forkID uint64) (uint64, error) {
+	ret := _m.Called(ctx, forkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForkIDActivationBatchNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (uint64, error)); ok {
+		return rf(ctx, forkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) uint64); ok {
+		r0 = rf(ctx, forkID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, forkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetL1InfoRootLeafByIndex provides a mock function with given fields: ctx, l1InfoTreeIndex, dbTx
+func (_m *StateMock) GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error) {
+	ret := _m.Called(ctx, l1InfoTreeIndex, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoRootLeafByIndex")
+	}
+
+	var r0 state.L1InfoTreeExitRootStorageEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)); ok {
+		return rf(ctx, l1InfoTreeIndex, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, pgx.Tx) state.L1InfoTreeExitRootStorageEntry); ok {
+		r0 = rf(ctx, l1InfoTreeIndex, dbTx)
+	} else {
+		r0 = ret.Get(0).(state.L1InfoTreeExitRootStorageEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, pgx.Tx) error); ok {
+		r1 = rf(ctx, l1InfoTreeIndex, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetL2BlockByHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StateMock) GetL2BlockByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, hash, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockByHash")
+	}
+
 	var r0 *state.L2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*state.L2Block, error)); ok {
@@ -295,6 +479,10 @@ func (_m *StateMock) GetL2BlockByHash(ctx context.Context, hash common.Hash, dbT
 func (_m *StateMock) GetL2BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockByNumber")
+	}
+
 	var r0 *state.L2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.L2Block, error)); ok {
@@ -317,10 +505,44 @@ func (_m *StateMock) GetL2BlockByNumber(ctx context.Context, blockNumber uint64,
 	return r0, r1
 }
 
+// GetL2BlockHashesByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetL2BlockHashesByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]common.Hash, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockHashesByBatchNumber")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]common.Hash, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []common.Hash); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetL2BlockHashesSince provides a mock function with given fields: ctx, since, dbTx
 func (_m *StateMock) GetL2BlockHashesSince(ctx context.Context, since time.Time, dbTx pgx.Tx) ([]common.Hash, error) {
 	ret := _m.Called(ctx, since, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockHashesSince")
+	}
+
 	var r0 []common.Hash
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, time.Time, pgx.Tx) ([]common.Hash, error)); ok {
@@ -347,6 +569,10 @@ func (_m *StateMock) GetL2BlockHashesSince(ctx context.Context, since time.Time,
 func (_m *StateMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Header, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockHeaderByNumber")
+	}
+
 	var r0 *state.L2Header
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.L2Header, error)); ok {
@@ -373,6 +599,10 @@ func (_m *StateMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber u
 func (_m *StateMock) GetL2BlockTransactionCountByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, hash, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockTransactionCountByHash")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (uint64, error)); ok {
@@ -397,6 +627,10 @@ func (_m *StateMock) GetL2BlockTransactionCountByHash(ctx context.Context, hash
 func (_m *StateMock) GetL2BlockTransactionCountByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockTransactionCountByNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
@@ -421,6 +655,10 @@ func (_m *StateMock) GetL2BlockTransactionCountByNumber(ctx context.Context, blo
 func (_m *StateMock) GetL2BlocksByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.L2Block, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlocksByBatchNumber")
+	}
+
 	var r0 []state.L2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.L2Block, error)); ok {
@@ -447,6 +685,10 @@ func (_m *StateMock) GetL2BlocksByBatchNumber(ctx context.Context, batchNumber u
 func (_m *StateMock) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastBatchNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -471,6 +713,10 @@ func (_m *StateMock) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint6
 func (_m *StateMock) GetLastClosedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastClosedBatchNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -495,6 +741,10 @@ func (_m *StateMock) GetLastClosedBatchNumber(ctx context.Context, dbTx pgx.Tx)
 func (_m *StateMock) GetLastConsolidatedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastConsolidatedL2BlockNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -519,6 +769,10 @@ func (_m *StateMock) GetLastConsolidatedL2BlockNumber(ctx context.Context, dbTx
 func (_m *StateMock) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastL2Block")
+	}
+
 	var r0 *state.L2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.L2Block, error)); ok {
@@ -545,6 +799,10 @@ func (_m *StateMock) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2
 func (_m *StateMock) GetLastL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastL2BlockNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -569,6 +827,10 @@ func (_m *StateMock) GetLastL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uin
 func (_m *StateMock) GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVerifiedBatch")
+	}
+
 	var r0 *state.VerifiedBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.VerifiedBatch, error)); ok {
@@ -595,6 +857,10 @@ func (_m *StateMock) GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*st
 func (_m *StateMock) GetLastVerifiedBatchNumberUntilL1Block(ctx context.Context, l1BlockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, l1BlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVerifiedBatchNumberUntilL1Block")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
@@ -619,6 +885,10 @@ func (_m *StateMock) GetLastVerifiedBatchNumberUntilL1Block(ctx context.Context,
 func (_m *StateMock) GetLastVerifiedL2BlockNumberUntilL1Block(ctx context.Context, l1FinalizedBlockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, l1FinalizedBlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVerifiedL2BlockNumberUntilL1Block")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
@@ -643,6 +913,10 @@ func (_m *StateMock) GetLastVerifiedL2BlockNumberUntilL1Block(ctx context.Contex
 func (_m *StateMock) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVirtualBatchNum")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -667,6 +941,10 @@ func (_m *StateMock) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (u
 func (_m *StateMock) GetLastVirtualizedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVirtualizedL2BlockNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -687,10 +965,42 @@ func (_m *StateMock) GetLastVirtualizedL2BlockNumber(ctx context.Context, dbTx p
 	return r0, r1
 }
 
+// GetLatestIndex provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetLatestIndex(ctx context.Context, dbTx pgx.Tx) (uint32, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestIndex")
+	}
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint32, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint32); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLogs provides a mock function with given fields: ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx
 func (_m *StateMock) GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*coretypes.Log, error) {
 	ret := _m.Called(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogs")
+	}
+
 	var r0 []*coretypes.Log
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, []common.Address, [][]common.Hash, *common.Hash, *time.Time, pgx.Tx) ([]*coretypes.Log, error)); ok {
@@ -717,6 +1027,10 @@ func (_m *StateMock) GetLogs(ctx context.Context, fromBlock uint64, toBlock uint
 func (_m *StateMock) GetNativeBlockHashesInRange(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx) ([]common.Hash, error) {
 	ret := _m.Called(ctx, fromBlockNumber, toBlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetNativeBlockHashesInRange")
+	}
+
 	var r0 []common.Hash
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]common.Hash, error)); ok {
@@ -743,6 +1057,10 @@ func (_m *StateMock) GetNativeBlockHashesInRange(ctx context.Context, fromBlockN
 func (_m *StateMock) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
 	ret := _m.Called(ctx, address, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonce")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash) (uint64, error)); ok {
@@ -767,6 +1085,10 @@ func (_m *StateMock) GetNonce(ctx context.Context, address common.Address, root
 func (_m *StateMock) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, position, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageAt")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int, common.Hash) (*big.Int, error)); ok {
@@ -793,6 +1115,10 @@ func (_m *StateMock) GetStorageAt(ctx context.Context, address common.Address, p
 func (_m *StateMock) GetSyncingInfo(ctx context.Context, dbTx pgx.Tx) (state.SyncingInfo, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncingInfo")
+	}
+
 	var r0 state.SyncingInfo
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (state.SyncingInfo, error)); ok {
@@ -817,6 +1143,10 @@ func (_m *StateMock) GetSyncingInfo(ctx context.Context, dbTx pgx.Tx) (state.Syn
 func (_m *StateMock) GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*coretypes.Transaction, error) {
 	ret := _m.Called(ctx, transactionHash, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByHash")
+	}
+
 	var r0 *coretypes.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*coretypes.Transaction, error)); ok {
@@ -843,6 +1173,10 @@ func (_m *StateMock) GetTransactionByHash(ctx context.Context, transactionHash c
 func (_m *StateMock) GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*coretypes.Transaction, error) {
 	ret := _m.Called(ctx, blockHash, index, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByL2BlockHashAndIndex")
+	}
+
 	var r0 *coretypes.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint64, pgx.Tx) (*coretypes.Transaction, error)); ok {
@@ -869,6 +1203,10 @@ func (_m *StateMock) GetTransactionByL2BlockHashAndIndex(ctx context.Context, bl
 func (_m *StateMock) GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*coretypes.Transaction, error) {
 	ret := _m.Called(ctx, blockNumber, index, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByL2BlockNumberAndIndex")
+	}
+
 	var r0 *coretypes.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) (*coretypes.Transaction, error)); ok {
@@ -891,10 +1229,44 @@ func (_m *StateMock) GetTransactionByL2BlockNumberAndIndex(ctx context.Context,
 	return r0, r1
 }
 
+// GetTransactionByL2Hash provides a mock function with given fields: ctx, l2TxHash, dbTx
+func (_m *StateMock) GetTransactionByL2Hash(ctx context.Context, l2TxHash common.Hash, dbTx pgx.Tx) (*coretypes.Transaction, error) {
+	ret := _m.Called(ctx, l2TxHash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByL2Hash")
+	}
+
+	var r0 *coretypes.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*coretypes.Transaction, error)); ok {
+		return rf(ctx, l2TxHash, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) *coretypes.Transaction); ok {
+		r0 = rf(ctx, l2TxHash, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, l2TxHash, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionReceipt provides a mock function with given fields: ctx, transactionHash, dbTx
 func (_m *StateMock) GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*coretypes.Receipt, error) {
 	ret := _m.Called(ctx, transactionHash, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceipt")
+	}
+
 	var r0 *coretypes.Receipt
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*coretypes.Receipt, error)); ok {
@@ -917,10 +1289,113 @@ func (_m *StateMock) GetTransactionReceipt(ctx context.Context, transactionHash
 	return r0, r1
 }
 
+// GetTransactionReceiptsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetTransactionReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]coretypes.Receipt, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceiptsByBatchNumber")
+	}
+
+	var r0 []coretypes.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]coretypes.Receipt, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []coretypes.Receipt); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]coretypes.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionReceiptsByL2BlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetTransactionReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]coretypes.Receipt, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceiptsByL2BlockNumber")
+	}
+
+	var r0 []coretypes.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]coretypes.Receipt, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []coretypes.Receipt); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]coretypes.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionWithReceipt provides a mock function with given fields: ctx, transactionHash, dbTx
+func (_m *StateMock) GetTransactionWithReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*coretypes.Transaction, *coretypes.Receipt, error) {
+	ret := _m.Called(ctx, transactionHash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionWithReceipt")
+	}
+
+	var r0 *coretypes.Transaction
+	var r1 *coretypes.Receipt
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*coretypes.Transaction, *coretypes.Receipt, error)); ok {
+		return rf(ctx, transactionHash, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) *coretypes.Transaction); ok {
+		r0 = rf(ctx, transactionHash, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) *coretypes.Receipt); ok {
+		r1 = rf(ctx, transactionHash, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*coretypes.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r2 = rf(ctx, transactionHash, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]coretypes.Transaction, []uint8, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByBatchNumber")
+	}
+
 	var r0 []coretypes.Transaction
 	var r1 []uint8
 	var r2 error
@@ -956,6 +1431,10 @@ func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumb
 func (_m *StateMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedBatch")
+	}
+
 	var r0 *state.VerifiedBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, error)); ok {
@@ -982,6 +1461,10 @@ func (_m *StateMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, d
 func (_m *StateMock) GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetVirtualBatch")
+	}
+
 	var r0 *state.VirtualBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VirtualBatch, error)); ok {
@@ -1008,6 +1491,10 @@ func (_m *StateMock) GetVirtualBatch(ctx context.Context, batchNumber uint64, db
 func (_m *StateMock) IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for IsL2BlockConsolidated")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
@@ -1032,6 +1519,10 @@ func (_m *StateMock) IsL2BlockConsolidated(ctx context.Context, blockNumber uint
 func (_m *StateMock) IsL2BlockVirtualized(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for IsL2BlockVirtualized")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
@@ -1056,6 +1547,10 @@ func (_m *StateMock) IsL2BlockVirtualized(ctx context.Context, blockNumber uint6
 func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessUnsignedTransaction")
+	}
+
 	var r0 *runtime.ExecutionResult
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
@@ -1078,11 +1573,69 @@ func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretyp
 	return r0, r1
 }
 
+// PruneBatches provides a mock function with given fields: ctx, beforeBatchNumber, maxBatches, dbTx
+func (_m *StateMock) PruneBatches(ctx context.Context, beforeBatchNumber uint64, maxBatches uint64, dbTx pgx.Tx) (int64, error) {
+	ret := _m.Called(ctx, beforeBatchNumber, maxBatches, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneBatches")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) (int64, error)); ok {
+		return rf(ctx, beforeBatchNumber, maxBatches, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) int64); ok {
+		r0 = rf(ctx, beforeBatchNumber, maxBatches, dbTx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, beforeBatchNumber, maxBatches, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RegisterNewL2BlockEventHandler provides a mock function with given fields: h
 func (_m *StateMock) RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandler) {
 	_m.Called(h)
 }
 
+// RotateAuditLog provides a mock function with given fields:
+func (_m *StateMock) RotateAuditLog() (*state.AuditLogRotation, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateAuditLog")
+	}
+
+	var r0 *state.AuditLogRotation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*state.AuditLogRotation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *state.AuditLogRotation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.AuditLogRotation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StartToMonitorNewL2Blocks provides a mock function with given fields:
 func (_m *StateMock) StartToMonitorNewL2Blocks() {
 	_m.Called()
@@ -23,6 +23,8 @@ const (
 	FilterTypeBlock = "block"
 	// FilterTypePendingTx represent a filter of type pending Tx.
 	FilterTypePendingTx = "pendingTx"
+	// FilterTypeSyncing represents a filter of type syncing.
+	FilterTypeSyncing = "syncing"
 )
 
 // Filter represents a filter.
@@ -267,6 +269,11 @@ func (f *LogFilter) UnmarshalJSON(data []byte) error {
 				res := []string{}
 
 				for _, i := range raw {
+					if i == nil {
+						// a null in the list matches any topic in this position
+						res = []string{}
+						break
+					}
 					if item, ok := i.(string); ok {
 						res = append(res, item)
 					} else {
@@ -355,6 +362,9 @@ func (f *LogFilter) Validate() error {
 	if f.ShouldFilterByBlockHash() && f.ShouldFilterByBlockRange() {
 		return ErrFilterInvalidPayload
 	}
+	if len(f.Topics) > maxTopics {
+		return ErrFilterTooManyTopics
+	}
 	return nil
 }
 
@@ -394,8 +404,7 @@ func getNumericBlockNumbers(ctx context.Context, s types.StateInterface, e types
 
 	blockRange := toBlockNumber - fromBlockNumber
 	if maxBlockRange > 0 && blockRange > maxBlockRange {
-		errMsg := fmt.Sprintf(maxBlockRangeErr.Error(), maxBlockRange)
-		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, maxBlockRangeErr.Error(), nil, false, maxBlockRange)
 		return 0, 0, rpcErr
 	}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/didip/tollbooth/v6"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
@@ -33,10 +34,24 @@ const (
 	APITxPool = "txpool"
 	// APIWeb3 represents the web3 API prefix.
 	APIWeb3 = "web3"
+	// APIAdmin represents the admin API prefix.
+	APIAdmin = "admin"
+	// APIRPC represents the rpc API prefix.
+	APIRPC = "rpc"
+	// APITrace represents the trace API prefix.
+	APITrace = "trace"
 
 	wsBufferSizeLimitInBytes = 1024
 	maxRequestContentLength  = 1024 * 1024 * 5
 	contentType              = "application/json"
+
+	// correlationIDHeader is the response header with the correlation id of the request
+	correlationIDHeader = "X-Request-Id"
+	// correlationIDLogField is the log field with the correlation id of the request
+	correlationIDLogField = "correlationId"
+	// callGasCappedHeader is the response header with the max call gas the eth_call and
+	// eth_estimateGas requests asking for more gas are processed with
+	callGasCappedHeader = "X-Call-Gas-Capped"
 )
 
 // https://www.jsonrpc.org/historical/json-rpc-over-http.html#http-header
@@ -44,12 +59,20 @@ var acceptedContentTypes = []string{contentType, "application/json-rpc", "applic
 
 // Server is an API backend to handle RPC requests
 type Server struct {
-	config     Config
-	chainID    uint64
-	handler    *Handler
-	srv        *http.Server
-	wsSrv      *http.Server
-	wsUpgrader websocket.Upgrader
+	config      Config
+	chainID     uint64
+	handler     *Handler
+	srv         *http.Server
+	wsSrv       *http.Server
+	wsUpgrader  websocket.Upgrader
+	idempotency *idempotencyCache
+	services    []Service
+}
+
+// stopper is implemented by the services with background work to stop when the server stops,
+// the method is unexported so it's not registered as an endpoint
+type stopper interface {
+	stop()
 }
 
 // Service defines a struct that will provide public methods to be exposed
@@ -79,15 +102,25 @@ func NewServer(
 	}
 
 	handler := newJSONRpcHandler()
+	adminAllowedIPs, err := newIPAllowlist(cfg.AdminAllowedIPs)
+	if err != nil {
+		// the config is validated when it's loaded, the admin methods are refused to all the IPs
+		// instead of allowing them if it wasn't
+		log.Errorf("invalid AdminAllowedIPs, the admin methods are not allowed to any IP: %v", err)
+	}
+	handler.adminAllowedIPs = adminAllowedIPs
 
 	for _, service := range services {
 		handler.registerService(service)
 	}
+	handler.registerService(Service{Name: APIRPC, Service: NewRPCEndpoints(handler)})
 
 	srv := &Server{
-		config:  cfg,
-		handler: handler,
-		chainID: chainID,
+		config:      cfg,
+		handler:     handler,
+		chainID:     chainID,
+		idempotency: newIdempotencyCache(cfg.IdempotencyKeyTTL.Duration),
+		services:    services,
 	}
 	return srv
 }
@@ -111,11 +144,12 @@ func (s *Server) startHTTP() error {
 
 	address := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 
-	lis, err := net.Listen("tcp", address)
+	tcpLis, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Errorf("failed to create tcp listener: %v", err)
 		return err
 	}
+	lis := newConnLimitListener(tcpLis, s.config.MaxConnections)
 
 	mux := http.NewServeMux()
 
@@ -127,6 +161,7 @@ func (s *Server) startHTTP() error {
 		ReadHeaderTimeout: s.config.ReadTimeout.Duration,
 		ReadTimeout:       s.config.ReadTimeout.Duration,
 		WriteTimeout:      s.config.WriteTimeout.Duration,
+		IdleTimeout:       s.config.IdleTimeout.Duration,
 	}
 	log.Infof("http server started: %s", address)
 	if err := s.srv.Serve(lis); err != nil {
@@ -183,6 +218,12 @@ func (s *Server) startWS() {
 
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
+	for _, service := range s.services {
+		if svc, ok := service.Service.(stopper); ok {
+			svc.stop()
+		}
+	}
+
 	if s.srv != nil {
 		if err := s.srv.Shutdown(context.Background()); err != nil {
 			return err
@@ -212,7 +253,8 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+idempotencyKeyHeader)
+	w.Header().Set("Access-Control-Expose-Headers", correlationIDHeader+", "+idempotencyReplayedHeader+", "+callGasCappedHeader)
 
 	if req.Method == http.MethodOptions {
 		return
@@ -226,6 +268,11 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	correlationID := uuid.NewString()
+	w.Header().Set(correlationIDHeader, correlationID)
+	req = withCorrelationID(req, correlationID)
+	req = withResponseHeader(req, w.Header())
+
 	if code, err := validateRequest(req); err != nil {
 		handleInvalidRequest(w, err, code)
 		return
@@ -244,9 +291,34 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	start := time.Now()
+	if key := req.Header.Get(idempotencyKeyHeader); key != "" && s.idempotency != nil {
+		scopedKey := idempotencyScopedKey(req, key)
+		entry, first, err := s.idempotency.begin(scopedKey, data)
+		if err != nil {
+			handleInvalidRequest(w, err, http.StatusUnprocessableEntity)
+			return
+		}
+		if first && entry != nil {
+			iw := &idempotentResponseWriter{ResponseWriter: w}
+			defer s.idempotency.finish(scopedKey, entry, iw)
+			w = iw
+		} else if !first {
+			// a retry waits for the first request, if it failed the retry is handled again
+			select {
+			case <-entry.done:
+			case <-req.Context().Done():
+				return
+			}
+			if entry.cached {
+				s.writeIdempotentResponse(req, w, entry, start)
+				return
+			}
+		}
+	}
+
 	s.increaseHttpConnCounter()
 
-	start := time.Now()
 	var respLen int
 	if single {
 		respLen = s.handleSingleRequest(req, w, data)
@@ -257,6 +329,41 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	s.combinedLog(req, start, http.StatusOK, respLen)
 }
 
+// writeIdempotentResponse writes the response of the first request sent with the idempotency key
+func (s *Server) writeIdempotentResponse(req *http.Request, w http.ResponseWriter, entry *idempotencyEntry, start time.Time) {
+	log.Ctx(req.Context()).Debugf("request with idempotency key %s replayed", req.Header.Get(idempotencyKeyHeader))
+	w.Header().Set(idempotencyReplayedHeader, "true")
+	if _, err := w.Write(entry.response); err != nil {
+		handleError(w, err)
+		return
+	}
+	s.combinedLog(req, start, http.StatusOK, len(entry.response))
+}
+
+// withCorrelationID returns a copy of the request whose context carries a logger with the
+// correlation id, the logs of the request handling can be found with the id
+func withCorrelationID(req *http.Request, correlationID string) *http.Request {
+	l := log.WithFields(correlationIDLogField, correlationID)
+	return req.WithContext(log.CtxWithLogger(req.Context(), l))
+}
+
+// responseHeaderKey is the context key of the headers of the HTTP response of the request
+type responseHeaderKey struct{}
+
+// withResponseHeader returns a copy of the request whose context carries the headers of its
+// response, the endpoints use them to report what doesn't fit in the response body
+func withResponseHeader(req *http.Request, header http.Header) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), responseHeaderKey{}, header))
+}
+
+// setResponseHeader sets the header in the HTTP response of the request of the context, the
+// WS requests have no HTTP response so it's ignored for them
+func setResponseHeader(ctx context.Context, key, value string) {
+	if header, ok := ctx.Value(responseHeaderKey{}).(http.Header); ok {
+		header.Set(key, value)
+	}
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func validateRequest(req *http.Request) (int, error) {
@@ -465,13 +572,18 @@ func handleError(w http.ResponseWriter, err error) {
 	http.Error(w, err.Error(), http.StatusInternalServerError)
 }
 
-// RPCErrorResponse formats error to be returned through RPC
-func RPCErrorResponse(code int, message string, err error, logError bool) (interface{}, types.Error) {
-	return RPCErrorResponseWithData(code, message, nil, err, logError)
+// RPCErrorResponse formats error to be returned through RPC, the message is formatted with the args
+// like in types.NewRPCError
+func RPCErrorResponse(code int, message string, err error, logError bool, args ...interface{}) (interface{}, types.Error) {
+	return RPCErrorResponseWithData(code, message, nil, err, logError, args...)
 }
 
-// RPCErrorResponseWithData formats error to be returned through RPC
-func RPCErrorResponseWithData(code int, message string, data []byte, err error, logError bool) (interface{}, types.Error) {
+// RPCErrorResponseWithData formats error to be returned through RPC, the message is formatted with the
+// args like in types.NewRPCError
+func RPCErrorResponseWithData(code int, message string, data []byte, err error, logError bool, args ...interface{}) (interface{}, types.Error) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
 	if logError {
 		if err != nil {
 			log.Debugf("%v: %v", message, err.Error())
@@ -2,16 +2,21 @@ package jsonrpc
 
 import (
 	"bytes"
-	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/gasprice"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
@@ -38,17 +43,19 @@ type mockedServer struct {
 }
 
 type mocksWrapper struct {
-	Pool     *mocks.PoolMock
-	State    *mocks.StateMock
-	Etherman *mocks.EthermanMock
-	Storage  *storageMock
-	DbTx     *mocks.DBTxMock
+	Pool      *mocks.PoolMock
+	State     *mocks.StateMock
+	Etherman  *mocks.EthermanMock
+	Sequencer *mocks.SequencerMock
+	Storage   *storageMock
+	DbTx      *mocks.DBTxMock
 }
 
 func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *ethclient.Client) {
 	pool := mocks.NewPoolMock(t)
 	st := mocks.NewStateMock(t)
 	etherman := mocks.NewEthermanMock(t)
+	sequencer := mocks.NewSequencerMock(t)
 	storage := newStorageMock(t)
 	dbTx := mocks.NewDBTxMock(t)
 	apis := map[string]bool{
@@ -58,6 +65,7 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		APIZKEVM:  true,
 		APITxPool: true,
 		APIWeb3:   true,
+		APITrace:  true,
 	}
 
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
@@ -68,7 +76,7 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APIEth]; ok {
 		services = append(services, Service{
 			Name:    APIEth,
-			Service: NewEthEndpoints(cfg, chainID, pool, st, etherman, storage),
+			Service: NewEthEndpoints(cfg, chainID, pool, st, etherman, sequencer, storage),
 		})
 	}
 
@@ -82,14 +90,14 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APIZKEVM]; ok {
 		services = append(services, Service{
 			Name:    APIZKEVM,
-			Service: NewZKEVMEndpoints(cfg, st, etherman),
+			Service: NewZKEVMEndpoints(cfg, pool, st, etherman, gasprice.FollowerType.Source()),
 		})
 	}
 
 	if _, ok := apis[APITxPool]; ok {
 		services = append(services, Service{
 			Name:    APITxPool,
-			Service: &TxPoolEndpoints{},
+			Service: NewTxPoolEndpoints(sequencer),
 		})
 	}
 
@@ -100,6 +108,13 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		})
 	}
 
+	if _, ok := apis[APITrace]; ok {
+		services = append(services, Service{
+			Name:    APITrace,
+			Service: NewTraceEndpoints(cfg, st, etherman),
+		})
+	}
+
 	if _, ok := apis[APIWeb3]; ok {
 		services = append(services, Service{
 			Name:    APIWeb3,
@@ -139,11 +154,12 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	}
 
 	mks := &mocksWrapper{
-		Pool:     pool,
-		State:    st,
-		Etherman: etherman,
-		Storage:  storage,
-		DbTx:     dbTx,
+		Pool:      pool,
+		State:     st,
+		Etherman:  etherman,
+		Sequencer: sequencer,
+		Storage:   storage,
+		DbTx:      dbTx,
 	}
 
 	return msv, mks, ethClient
@@ -159,6 +175,7 @@ func getSequencerDefaultConfig() Config {
 		MaxLogsCount:                 10000,
 		MaxLogsBlockRange:            10000,
 		MaxNativeBlockHashBlockRange: 60000,
+		ProtocolVersion:              "0x41",
 		WebSockets: WebSocketsConfig{
 			Enabled:   true,
 			Host:      "0.0.0.0",
@@ -261,11 +278,11 @@ func TestBatchRequests(t *testing.T) {
 			NumberOfRequests:     100,
 			ExpectedError:        nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
-				m.DbTx.On("Commit", context.Background()).Return(nil).Times(tc.NumberOfRequests)
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
-				m.State.On("GetL2BlockByNumber", context.Background(), block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetTransactionReceipt", context.Background(), mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Times(tc.NumberOfRequests)
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
+				m.State.On("GetL2BlockByNumber", mock.Anything, block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetTransactionReceipt", mock.Anything, mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
 			},
 		},
 		{
@@ -275,11 +292,11 @@ func TestBatchRequests(t *testing.T) {
 			NumberOfRequests:     5,
 			ExpectedError:        nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
-				m.DbTx.On("Commit", context.Background()).Return(nil).Times(tc.NumberOfRequests)
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
-				m.State.On("GetL2BlockByNumber", context.Background(), block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetTransactionReceipt", context.Background(), mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Times(tc.NumberOfRequests)
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
+				m.State.On("GetL2BlockByNumber", mock.Anything, block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetTransactionReceipt", mock.Anything, mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
 			},
 		},
 		{
@@ -289,11 +306,11 @@ func TestBatchRequests(t *testing.T) {
 			NumberOfRequests:     4,
 			ExpectedError:        nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
-				m.DbTx.On("Commit", context.Background()).Return(nil).Times(tc.NumberOfRequests)
-				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
-				m.State.On("GetL2BlockByNumber", context.Background(), block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
-				m.State.On("GetTransactionReceipt", context.Background(), mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
+				m.DbTx.On("Commit", mock.Anything).Return(nil).Times(tc.NumberOfRequests)
+				m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(block.Number().Uint64(), nil).Times(tc.NumberOfRequests)
+				m.State.On("GetL2BlockByNumber", mock.Anything, block.Number().Uint64(), m.DbTx).Return(block, nil).Times(tc.NumberOfRequests)
+				m.State.On("GetTransactionReceipt", mock.Anything, mock.Anything, m.DbTx).Return(ethTypes.NewReceipt([]byte{}, false, uint64(0)), nil)
 			},
 		},
 	}
@@ -351,7 +368,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -363,7 +380,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "zkEVM JSON RPC Server",
 		},
@@ -375,7 +392,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -387,7 +404,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "method PUT not allowed\n",
 		},
@@ -399,7 +416,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "method PATCH not allowed\n",
 		},
@@ -411,7 +428,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "method DELETE not allowed\n",
 		},
@@ -432,7 +449,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "method TRACE not allowed\n",
 		},
@@ -445,7 +462,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "content length too large (5242881>5242880)\n",
 		},
@@ -458,7 +475,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "invalid content type, only application/json is supported\n",
 		},
@@ -472,7 +489,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "empty request body\n",
 		},
@@ -486,7 +503,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -500,7 +517,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -514,7 +531,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Idempotency-Key"},
 			},
 			ExpectedMessage: "invalid json array request body\n",
 		},
@@ -588,9 +605,9 @@ func TestMaxRequestPerIPPerSec(t *testing.T) {
 	// this makes us sure the code is calling these methods only for
 	// allowed requests
 	times := int(cfg.MaxRequestsPerIPAndSecond)
-	m.DbTx.On("Commit", context.Background()).Return(nil).Times(times)
-	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Times(times)
-	m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(1), nil).Times(times)
+	m.DbTx.On("Commit", mock.Anything).Return(nil).Times(times)
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Times(times)
+	m.State.On("GetLastL2BlockNumber", mock.Anything, m.DbTx).Return(uint64(1), nil).Times(times)
 
 	// prepare the workers to process the requests as long as a job is available
 	requestsLimitedCount := uint64(0)
@@ -638,3 +655,212 @@ func TestMaxRequestPerIPPerSec(t *testing.T) {
 	// connection abruptly
 	time.Sleep(time.Second)
 }
+
+// fuzzEndpoints echoes the arguments it receives, it's used to fuzz the decoding of
+// the argument types used by the implemented endpoints without needing a backend
+type fuzzEndpoints struct{}
+
+func (e *fuzzEndpoints) Uint64(arg types.ArgUint64) (interface{}, types.Error) { return arg, nil }
+func (e *fuzzEndpoints) Big(arg types.ArgBig) (interface{}, types.Error)       { return arg, nil }
+func (e *fuzzEndpoints) Bytes(arg types.ArgBytes) (interface{}, types.Error)   { return arg, nil }
+func (e *fuzzEndpoints) Hash(arg types.ArgHash) (interface{}, types.Error)     { return arg, nil }
+func (e *fuzzEndpoints) Address(arg types.ArgAddress) (interface{}, types.Error) {
+	return arg, nil
+}
+func (e *fuzzEndpoints) Index(arg types.Index) (interface{}, types.Error) { return arg, nil }
+func (e *fuzzEndpoints) BatchNumber(arg types.BatchNumber) (interface{}, types.Error) {
+	return arg, nil
+}
+func (e *fuzzEndpoints) BlockNumber(arg types.BlockNumber, fullTx bool) (interface{}, types.Error) {
+	return arg, nil
+}
+func (e *fuzzEndpoints) BlockNumberOrHash(arg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return arg, nil
+}
+func (e *fuzzEndpoints) TxArgs(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return arg, nil
+}
+func (e *fuzzEndpoints) LogFilter(arg LogFilter) (interface{}, types.Error) { return arg, nil }
+
+var errFuzzBackend = errors.New("fuzz backend error")
+
+// expectAnyCall makes the mock accept any call to the methods of iface, the methods
+// return the zero value of their results and errFuzzBackend as the error. The mock
+// is created without a testing.T so it can be shared by all the fuzz iterations
+func expectAnyCall(m *mock.Mock, iface interface{}) {
+	ifaceType := reflect.TypeOf(iface).Elem()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		method := ifaceType.Method(i)
+		args := make([]interface{}, method.Type.NumIn())
+		for j := range args {
+			args[j] = mock.Anything
+		}
+		results := make([]interface{}, method.Type.NumOut())
+		for j := range results {
+			if out := method.Type.Out(j); out == errType {
+				results[j] = errFuzzBackend
+			} else {
+				results[j] = reflect.Zero(out).Interface()
+			}
+		}
+		m.On(method.Name, args...).Return(results...)
+	}
+}
+
+func FuzzJSONRPCDispatch(f *testing.F) {
+	cfg := getSequencerDefaultConfig()
+	cfg.WebSockets.Enabled = false
+
+	pool := &mocks.PoolMock{}
+	expectAnyCall(&pool.Mock, (*types.PoolInterface)(nil))
+	st := &mocks.StateMock{}
+	expectAnyCall(&st.Mock, (*types.StateInterface)(nil))
+	etherman := &mocks.EthermanMock{}
+	expectAnyCall(&etherman.Mock, (*types.EthermanInterface)(nil))
+	sequencer := &mocks.SequencerMock{}
+	expectAnyCall(&sequencer.Mock, (*types.SequencerInterface)(nil))
+
+	// The seeded requests reach the registered endpoints, which fail on the first backend call
+	services := []Service{
+		{Name: APIEth, Service: NewEthEndpoints(cfg, chainID, pool, st, etherman, sequencer, NewStorage())},
+		{Name: APINet, Service: NewNetEndpoints(cfg, chainID)},
+		{Name: APIZKEVM, Service: NewZKEVMEndpoints(cfg, pool, st, etherman, gasprice.FollowerType.Source())},
+		{Name: APITxPool, Service: NewTxPoolEndpoints(sequencer)},
+		{Name: APIDebug, Service: NewDebugEndpoints(cfg, st, etherman)},
+		{Name: APITrace, Service: NewTraceEndpoints(cfg, st, etherman)},
+		{Name: APIWeb3, Service: &Web3Endpoints{}},
+		{Name: APIAdmin, Service: NewAdminEndpoints(cfg, st, sequencer)},
+		{Name: "fuzz", Service: &fuzzEndpoints{}},
+	}
+	s := NewServer(cfg, chainID, nil, nil, nil, services)
+	f.Cleanup(func() { require.NoError(f, s.Stop()) })
+
+	// Known request shapes of the implemented endpoints
+	seeds := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"admin_forceBatchProcessing","params":["0x","0x0000000000000000000000000000000000000000000000000000000000000001","0x65"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"admin_getSequencerState","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"admin_setDynamicConfig","params":["L2BlockTime","5s"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"admin_pauseForcedBatchProcessing","params":["30m"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"admin_pruneBatches","params":["0x64",true]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"admin_rotateAuditLog","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"debug_traceBlockByHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001",{"tracer":"callTracer"}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"debug_traceBlockByNumber","params":["0x1",{"disableStorage":true}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"debug_traceTransaction","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"debug_traceBatchByNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_accounts","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_call","params":[{"from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","data":"0x01"},"latest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_estimateGas","params":[{"to":"0x0000000000000000000000000000000000000002","value":"0x1"}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_gasPrice","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x0000000000000000000000000000000000000001","latest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001",true]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["pending",false]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockReceipts","params":["latest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockTransactionCountByHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockTransactionCountByNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getCode","params":["0x0000000000000000000000000000000000000001","earliest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getCompilers","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getFilterChanges","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getFilterLogs","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[{"fromBlock":"0x1","toBlock":"latest","address":"0x0000000000000000000000000000000000000001","topics":[null,["0x0000000000000000000000000000000000000000000000000000000000000001"]]}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getStorageAt","params":["0x0000000000000000000000000000000000000001","0x0","latest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByBlockHashAndIndex","params":["0x0000000000000000000000000000000000000000000000000000000000000001","0x0"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByBlockNumberAndIndex","params":["0x1","0x0"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionCount","params":["0x0000000000000000000000000000000000000001","pending"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionReceipt","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getUncleByBlockHashAndIndex","params":["0x0000000000000000000000000000000000000000000000000000000000000001","0x0"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getUncleByBlockNumberAndIndex","params":["0x1","0x0"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getUncleCountByBlockHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getUncleCountByBlockNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_hashrate","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_mining","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_newBlockFilter","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_newFilter","params":[{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001"}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_protocolVersion","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0xf86c808504a817c800825208940000000000000000000000000000000000000001880de0b6b3a76400008025a0"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_syncing","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_uninstallFilter","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_unsubscribe","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"net_version","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"trace_block","params":["latest"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"trace_transaction","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"txpool_content","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"txpool_inspect","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"web3_sha3","params":["0x68656c6c6f20776f726c64"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_batchNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_batchNumberByBlockNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_consolidatedBlockNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getBatchByNumber","params":["latest",true]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getBroadcastURI","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getFullBlockByHash","params":["0x0000000000000000000000000000000000000000000000000000000000000001",false]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getFullBlockByNumber","params":["0x1",true]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getNativeBlockHashesInRange","params":[{"fromBlock":"0x1","toBlock":"0x2"}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_isBlockConsolidated","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_isBlockVirtualized","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_verifiedBatchNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_virtualBatchNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getExitRootsByGER","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getL2BlockHashesByBatchNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getL2GasPrice","params":[]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getL1InfoTreeRootByIndex","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getL2ToL1MessageProof","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getForcedBatchByNumber","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getTransactionByL2Hash","params":["0x0000000000000000000000000000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"zkevm_getForkIDActivationBatchNumber","params":["0x7"]}`,
+		// Argument types decoded by the implemented endpoints
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_uint64","params":["0xffffffffffffffff"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_big","params":["0x` + strings.Repeat("f", 1024) + `"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_bytes","params":["0x0102"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_hash","params":["0x00"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_address","params":["0x0000000000000000000000000000000000000001"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_index","params":["0x1"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_batchNumber","params":["virtual"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_blockNumber","params":["finalized",true]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_blockNumberOrHash","params":[{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001","requireCanonical":true}]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_txArgs","params":[{"gas":"0x1","gasPrice":"0x1","value":"0x1","input":"0x01","nonce":"0x1"},"safe"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_logFilter","params":[{"fromBlock":"earliest","toBlock":"pending","address":["0x0000000000000000000000000000000000000001"],"topics":[["0x0000000000000000000000000000000000000000000000000000000000000001"]]}]}`,
+		// Malformed and batch requests
+		`[{"jsonrpc":"2.0","id":1,"method":"net_version","params":[]},{"jsonrpc":"2.0","id":"2","method":"fuzz_big","params":["0x1"]}]`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_uint64","params":["0x1"`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_logFilter","params":[[[[[[[[[[[[[[[[[[[[[[[[[[[[]]]]]]]]]]]]]]]]]]]]]]]]]]]]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"fuzz_uint64","params":["0x1","0x2"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"unknown_method"}`,
+		`{}`,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+		req.Header.Set("Content-Type", contentType)
+		res := httptest.NewRecorder()
+
+		s.handle(res, req)
+
+		// Invalid requests are answered with an HTTP error, the rest with a JSON-RPC response
+		if res.Code != http.StatusOK {
+			assert.Equal(t, http.StatusBadRequest, res.Code)
+			return
+		}
+		assert.True(t, json.Valid(res.Body.Bytes()), "invalid JSON response %q for request %q", res.Body.String(), string(data))
+	})
+}
+
+func TestRPCErrorResponse(t *testing.T) {
+	// The message is formatted only with args, a message without args is kept as it is
+	result, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, "invalid block %d, the last block is %d", nil, false, 2, 1)
+	assert.Nil(t, result)
+	assert.Equal(t, types.InvalidParamsErrorCode, rpcErr.ErrorCode())
+	assert.Equal(t, "invalid block 2, the last block is 1", rpcErr.Error())
+
+	_, rpcErr = RPCErrorResponseWithData(types.RevertedErrorCode, "execution reverted: 100%", []byte{1}, errors.New("reverted"), true)
+	assert.Equal(t, "execution reverted: 100%", rpcErr.Error())
+	assert.Equal(t, []byte{1}, rpcErr.ErrorData())
+}
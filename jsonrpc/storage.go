@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/google/uuid"
 )
@@ -17,6 +18,9 @@ var ErrNotFound = errors.New("object not found")
 // ErrFilterInvalidPayload indicates there is an invalid payload when creating a filter
 var ErrFilterInvalidPayload = errors.New("invalid argument 0: cannot specify both BlockHash and FromBlock/ToBlock, choose one or the other")
 
+// ErrFilterTooManyTopics indicates the filter has more topic positions than a log can have
+var ErrFilterTooManyTopics = errors.New("invalid argument 0: exceed max topics")
+
 // Storage uses memory to store the data
 // related to the json rpc server
 type Storage struct {
@@ -25,10 +29,12 @@ type Storage struct {
 	blockFiltersWithWSConn     map[string]*Filter
 	logFiltersWithWSConn       map[string]*Filter
 	pendingTxFiltersWithWSConn map[string]*Filter
+	syncingFiltersWithWSConn   map[string]*Filter
 
 	blockMutex     *sync.Mutex
 	logMutex       *sync.Mutex
 	pendingTxMutex *sync.Mutex
+	syncingMutex   *sync.Mutex
 }
 
 // NewStorage creates and initializes an instance of Storage
@@ -39,9 +45,11 @@ func NewStorage() *Storage {
 		blockFiltersWithWSConn:     make(map[string]*Filter),
 		logFiltersWithWSConn:       make(map[string]*Filter),
 		pendingTxFiltersWithWSConn: make(map[string]*Filter),
+		syncingFiltersWithWSConn:   make(map[string]*Filter),
 		blockMutex:                 &sync.Mutex{},
 		logMutex:                   &sync.Mutex{},
 		pendingTxMutex:             &sync.Mutex{},
+		syncingMutex:               &sync.Mutex{},
 	}
 }
 
@@ -64,6 +72,11 @@ func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string,
 	return s.createFilter(FilterTypePendingTx, nil, wsConn)
 }
 
+// NewSyncingFilter persists a new syncing filter
+func (s *Storage) NewSyncingFilter(wsConn *concurrentWsConn) (string, error) {
+	return s.createFilter(FilterTypeSyncing, nil, wsConn)
+}
+
 // create persists the filter to the memory and provides the filter id
 func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn) (string, error) {
 	lastPoll := time.Now().UTC()
@@ -75,9 +88,11 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
 
 	f := &Filter{
 		ID:            id,
@@ -104,6 +119,8 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else if t == FilterTypeSyncing {
+			s.syncingFiltersWithWSConn[id] = f
 		}
 	}
 	return id, nil
@@ -152,14 +169,30 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllSyncingFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by syncing status changes
+func (s *Storage) GetAllSyncingFiltersWithWSConn() []*Filter {
+	s.syncingMutex.Lock()
+	defer s.syncingMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.syncingFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -174,9 +207,11 @@ func (s *Storage) UpdateFilterLastPoll(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -192,9 +227,11 @@ func (s *Storage) UninstallFilter(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -210,9 +247,11 @@ func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
 
 	filters, found := s.allFiltersWithWSConn[wsConn]
 	if !found {
@@ -226,6 +265,42 @@ func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	return nil
 }
 
+// StartToExpireFilters uninstalls periodically the filters without a web socket connection that
+// were not polled during the ttl, so the filters of the clients that don't uninstall them don't
+// pile up. The filters with a web socket connection are uninstalled when the connection is closed
+func (s *Storage) StartToExpireFilters(ttl time.Duration) {
+	ticker := time.NewTicker(halfTTLInterval(ttl))
+	defer ticker.Stop()
+	for range ticker.C {
+		if expired := s.expireFilters(ttl); expired > 0 {
+			log.Debugf("%d filters not polled in the last %v uninstalled", expired, ttl)
+		}
+	}
+}
+
+// expireFilters uninstalls the filters without a web socket connection not polled during the
+// ttl, it returns the number of filters uninstalled
+func (s *Storage) expireFilters(ttl time.Duration) int {
+	s.blockMutex.Lock()
+	s.logMutex.Lock()
+	s.pendingTxMutex.Lock()
+	s.syncingMutex.Lock()
+	defer s.blockMutex.Unlock()
+	defer s.logMutex.Unlock()
+	defer s.pendingTxMutex.Unlock()
+	defer s.syncingMutex.Unlock()
+
+	expiredBefore := time.Now().UTC().Add(-ttl)
+	expired := 0
+	for _, filter := range s.allFilters {
+		if filter.WsConn == nil && filter.LastPoll.Before(expiredBefore) {
+			s.deleteFilter(filter)
+			expired++
+		}
+	}
+	return expired
+}
+
 // deleteFilter deletes a filter from all the maps
 func (s *Storage) deleteFilter(filter *Filter) {
 	if filter.Type == FilterTypeBlock {
@@ -234,6 +309,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else if filter.Type == FilterTypeSyncing {
+		delete(s.syncingFiltersWithWSConn, filter.ID)
 	}
 
 	if filter.WsConn != nil {
@@ -0,0 +1,44 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireFilters(t *testing.T) {
+	s := NewStorage()
+	wsConn := &concurrentWsConn{}
+
+	pendingTxFilterID, err := s.NewPendingTransactionFilter(nil)
+	require.NoError(t, err)
+	blockFilterID, err := s.NewBlockFilter(nil)
+	require.NoError(t, err)
+	wsFilterID, err := s.NewBlockFilter(wsConn)
+	require.NoError(t, err)
+
+	// The filters polled during the ttl are kept
+	assert.Equal(t, 0, s.expireFilters(time.Minute))
+
+	// The filters not polled during the ttl are uninstalled, except the web socket ones
+	for _, id := range []string{pendingTxFilterID, blockFilterID, wsFilterID} {
+		s.allFilters[id].LastPoll = time.Now().UTC().Add(-2 * time.Minute)
+	}
+	require.NoError(t, s.UpdateFilterLastPoll(blockFilterID))
+	assert.Equal(t, 1, s.expireFilters(time.Minute))
+
+	_, err = s.GetFilter(pendingTxFilterID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.GetFilter(blockFilterID)
+	assert.NoError(t, err)
+	_, err = s.GetFilter(wsFilterID)
+	assert.NoError(t, err)
+}
+
+func TestHalfTTLInterval(t *testing.T) {
+	// A ttl of 1ns is refreshed every 1ns instead of making the ticker panic
+	assert.Equal(t, time.Nanosecond, halfTTLInterval(time.Nanosecond))
+	assert.Equal(t, 30*time.Second, halfTTLInterval(time.Minute))
+}
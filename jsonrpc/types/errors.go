@@ -2,18 +2,24 @@ package types
 
 import "fmt"
 
+// The error codes from -32768 to -32000 are reserved by the JSON-RPC 2.0 specification,
+// the codes of the ethereum clients are used for the rest
 const (
 	// DefaultErrorCode rpc default error code
 	DefaultErrorCode = -32000
 	// RevertedErrorCode error code for reverted txs
 	RevertedErrorCode = 3
+	// ResourceUnavailableErrorCode error code for resources not available yet
+	ResourceUnavailableErrorCode = -32002
 	// InvalidRequestErrorCode error code for invalid requests
 	InvalidRequestErrorCode = -32600
-	// NotFoundErrorCode error code for not found objects
+	// NotFoundErrorCode error code for not found or not available methods
 	NotFoundErrorCode = -32601
 	// InvalidParamsErrorCode error code for invalid parameters
 	InvalidParamsErrorCode = -32602
-	// ParserErrorCode error code for parsing errors
+	// InternalErrorCode error code for internal errors
+	InternalErrorCode = -32603
+	// ParserErrorCode error code for requests that are not valid JSON
 	ParserErrorCode = -32700
 )
 
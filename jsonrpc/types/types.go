@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -60,7 +61,7 @@ func (b ArgBytes) MarshalText() ([]byte, error) {
 func (b *ArgBytes) UnmarshalText(input []byte) error {
 	hh, err := decodeToHex(input)
 	if err != nil {
-		return nil
+		return err
 	}
 	aux := make([]byte, len(hh))
 	copy(aux[:], hh[:])
@@ -267,37 +268,30 @@ type Block struct {
 func NewBlock(hash *common.Hash, b *state.L2Block, receipts []types.Receipt, fullTx, includeReceipts bool) (*Block, error) {
 	h := b.Header()
 
-	var miner *common.Address
-	if h.Coinbase.String() != state.ZeroAddress.String() {
-		cb := h.Coinbase
-		miner = &cb
-	}
+	// miner, nonce and totalDifficulty are always returned, some clients fail to decode
+	// the block when they are null
+	miner := h.Coinbase
 
-	var nonce *ArgBytes
-	if h.Nonce.Uint64() > 0 {
-		nBig := big.NewInt(0).SetUint64(h.Nonce.Uint64())
-		nBytes := common.LeftPadBytes(nBig.Bytes(), 8) //nolint:gomnd
-		n := ArgBytes(nBytes)
-		nonce = &n
-	}
+	nBig := big.NewInt(0).SetUint64(h.Nonce.Uint64())
+	nonce := ArgBytes(common.LeftPadBytes(nBig.Bytes(), 8)) //nolint:gomnd
 
+	// there is no proof of work, so the total difficulty is the difficulty of the block
 	difficulty := ArgUint64(0)
-	var totalDifficulty *ArgUint64
-	if h.Difficulty != nil && h.Difficulty.Uint64() > 0 {
+	if h.Difficulty != nil {
 		difficulty = ArgUint64(h.Difficulty.Uint64())
-		totalDifficulty = &difficulty
 	}
+	totalDifficulty := difficulty
 
 	res := &Block{
 		ParentHash:      h.ParentHash,
 		Sha3Uncles:      h.UncleHash,
-		Miner:           miner,
+		Miner:           &miner,
 		StateRoot:       h.Root,
 		TxRoot:          h.TxHash,
 		ReceiptsRoot:    h.ReceiptHash,
 		LogsBloom:       h.Bloom,
-		Difficulty:      ArgUint64(difficulty),
-		TotalDifficulty: totalDifficulty,
+		Difficulty:      difficulty,
+		TotalDifficulty: &totalDifficulty,
 		Size:            ArgUint64(b.Size()),
 		Number:          ArgUint64(b.Number().Uint64()),
 		GasLimit:        ArgUint64(h.GasLimit),
@@ -305,7 +299,7 @@ func NewBlock(hash *common.Hash, b *state.L2Block, receipts []types.Receipt, ful
 		Timestamp:       ArgUint64(h.Time),
 		ExtraData:       ArgBytes(h.Extra),
 		MixHash:         h.MixDigest,
-		Nonce:           nonce,
+		Nonce:           &nonce,
 		Hash:            hash,
 		Transactions:    []TransactionOrHash{},
 		Uncles:          []common.Hash{},
@@ -530,6 +524,7 @@ type Transaction struct {
 	ChainID     ArgBig          `json:"chainId"`
 	Type        ArgUint64       `json:"type"`
 	Receipt     *Receipt        `json:"receipt,omitempty"`
+	L2Hash      *common.Hash    `json:"l2Hash,omitempty"`
 }
 
 // CoreTx returns a geth core type Transaction
@@ -686,6 +681,51 @@ func NewLog(l types.Log) Log {
 
 // ExitRoots structure
 type ExitRoots struct {
+	GlobalExitRoot  common.Hash `json:"globalExitRoot"`
 	MainnetExitRoot common.Hash `json:"mainnetExitRoot"`
 	RollupExitRoot  common.Hash `json:"rollupExitRoot"`
+	BlockNumber     ArgUint64   `json:"blockNumber"`
+	Timestamp       ArgUint64   `json:"timestamp"`
+}
+
+// L2GasPrice structure
+type L2GasPrice struct {
+	GasPrice    ArgUint64 `json:"gasPrice"`
+	Source      string    `json:"source"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// L2ToL1MessageProof is the merkle proof of a leaf of the local exit tree against the local exit
+// root of a verified batch, the tree has LeafCount leaves
+type L2ToL1MessageProof struct {
+	LeafIndex     ArgUint64     `json:"leafIndex"`
+	LeafCount     ArgUint64     `json:"leafCount"`
+	BatchNumber   ArgUint64     `json:"batchNumber"`
+	LocalExitRoot common.Hash   `json:"localExitRoot"`
+	Proof         []common.Hash `json:"proof"`
+}
+
+// ForcedBatch structure
+type ForcedBatch struct {
+	ForcedBatchNumber ArgUint64   `json:"forcedBatchNumber"`
+	GlobalExitRoot    common.Hash `json:"globalExitRoot"`
+	RawTxsData        ArgBytes    `json:"rawTxsData"`
+	ForcedAt          time.Time   `json:"forcedAt"`
+	BatchNumber       *ArgUint64  `json:"batchNumber"`
+}
+
+// NewForcedBatch creates a ForcedBatch instance, batchNumber is the number of the
+// batch in which the forced batch was processed, nil if not processed yet
+func NewForcedBatch(forcedBatch *state.ForcedBatch, batchNumber *uint64) *ForcedBatch {
+	res := &ForcedBatch{
+		ForcedBatchNumber: ArgUint64(forcedBatch.ForcedBatchNumber),
+		GlobalExitRoot:    forcedBatch.GlobalExitRoot,
+		RawTxsData:        forcedBatch.RawTxsData,
+		ForcedAt:          forcedBatch.ForcedAt,
+	}
+	if batchNumber != nil {
+		bn := ArgUint64(*batchNumber)
+		res.BatchNumber = &bn
+	}
+	return res
 }
@@ -62,6 +62,35 @@ func TestArgHashUnmarshalFromShortString(t *testing.T) {
 	}
 }
 
+func TestArgBytesUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedResult []byte
+		expectedError  bool
+	}{
+		{"bytes with leading zeros", "0x0001", []byte{0x00, 0x01}, false},
+		{"odd length bytes", "0x123", []byte{0x01, 0x23}, false},
+		{"bytes without 0x", "0102", []byte{0x01, 0x02}, false},
+		{"empty bytes", "0x", []byte{}, false},
+		{"invalid hex value", "0xzz", nil, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var arg ArgBytes
+			err := arg.UnmarshalText([]byte(testCase.input))
+			if testCase.expectedError {
+				require.Error(t, err)
+				assert.Nil(t, arg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedResult, []byte(arg))
+		})
+	}
+}
+
 func TestArgAddressUnmarshalFromShortString(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -121,6 +122,24 @@ func (l *Logger) WithFields(keyValuePairs ...interface{}) *Logger {
 	}
 }
 
+// loggerCtxKey is the context key of the logger carried by a context
+type loggerCtxKey struct{}
+
+// CtxWithLogger returns a copy of ctx carrying the logger, so the code receiving the
+// context logs with the same fields, see Ctx
+func CtxWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// Ctx returns the logger carried by ctx, a Logger derived from the root one if ctx
+// doesn't carry any
+func Ctx(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok {
+		return l
+	}
+	return WithFields()
+}
+
 func sprintStackTrace(st []tracerr.Frame) string {
 	builder := strings.Builder{}
 	// Skip deepest frame because it belongs to the go runtime and we don't
@@ -1,7 +1,10 @@
 package log
 
 import (
+	"context"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLogNotInitialized(t *testing.T) {
@@ -35,3 +38,12 @@ func TestLog(t *testing.T) {
 	Warnf("Test log.Warnf %d", 10)
 	Warnw("Test log.Warnw", "value", 10)
 }
+
+func TestCtx(t *testing.T) {
+	ctx := context.Background()
+	assert.NotNil(t, Ctx(ctx))
+
+	l := WithFields("correlationId", "test")
+	assert.Same(t, l, Ctx(CtxWithLogger(ctx, l)))
+	Ctx(CtxWithLogger(ctx, l)).Info("Test log.Ctx")
+}
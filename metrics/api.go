@@ -3,6 +3,10 @@ package metrics
 const (
 	//Endpoint the endpoint for exposing the metrics
 	Endpoint = "/metrics"
+	// ReadinessEndpoint the endpoint for the readiness health check
+	ReadinessEndpoint = "/readyz"
+	// LivenessEndpoint the endpoint for the liveness health check
+	LivenessEndpoint = "/livez"
 	// ProfilingIndexEndpoint the endpoint for exposing the profiling metrics
 	ProfilingIndexEndpoint = "/debug/pprof/"
 	// ProfileEndpoint the endpoint for exposing the profile of the profiling metrics
@@ -34,6 +34,10 @@ var (
 	// maximum allowance of the current block.
 	ErrGasLimit = errors.New("exceeds block gas limit")
 
+	// ErrGasLimitTooHigh is returned if a transaction's gas limit exceeds the gas
+	// limit of a batch, so it can never be included in a batch.
+	ErrGasLimitTooHigh = errors.New("gas limit too high")
+
 	// ErrTxPoolAccountOverflow is returned if the account sending the transaction
 	// has already reached the limit of transactions in the pool set by the config
 	// AccountQueue and can't accept another remote transaction.
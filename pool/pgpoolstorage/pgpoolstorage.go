@@ -441,29 +441,28 @@ func (p *PostgresPoolStorage) SetGasPrices(ctx context.Context, l2GasPrice, l1Ga
 	return nil
 }
 
-// GetGasPrices returns the latest l2 and l1 gas prices
-func (p *PostgresPoolStorage) GetGasPrices(ctx context.Context) (uint64, uint64, error) {
-	sql := "SELECT price, l1_price FROM pool.gas_price ORDER BY item_id DESC LIMIT 1"
+// GetGasPrices returns the latest l2 and l1 gas prices with the time they were set
+func (p *PostgresPoolStorage) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
+	sql := "SELECT price, l1_price, timestamp FROM pool.gas_price ORDER BY item_id DESC LIMIT 1"
 	rows, err := p.db.Query(ctx, sql)
 	if errors.Is(err, pgx.ErrNoRows) {
-		return 0, 0, state.ErrNotFound
+		return pool.GasPrices{}, state.ErrNotFound
 	} else if err != nil {
-		return 0, 0, err
+		return pool.GasPrices{}, err
 	}
 
 	defer rows.Close()
 
-	l2GasPrice := uint64(0)
-	l1GasPrice := uint64(0)
+	gasPrices := pool.GasPrices{}
 
 	for rows.Next() {
-		err := rows.Scan(&l2GasPrice, &l1GasPrice)
+		err := rows.Scan(&gasPrices.L2GasPrice, &gasPrices.L1GasPrice, &gasPrices.UpdatedAt)
 		if err != nil {
-			return 0, 0, err
+			return pool.GasPrices{}, err
 		}
 	}
 
-	return l2GasPrice, l1GasPrice, nil
+	return gasPrices, nil
 }
 
 // DeleteGasPricesHistoryOlderThan deletes all gas prices older than the given date except the last one
@@ -535,6 +534,33 @@ func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from com
 	return txs, nil
 }
 
+// GetTxsByFromAndStatus get all the transactions from the pool with the same from address and any of the provided statuses
+func (p *PostgresPoolStorage) GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, 
+				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason
+	          FROM pool.transaction
+			 WHERE from_address = $1
+			   AND status = ANY ($2)`
+	rows, err := p.db.Query(ctx, sql, from.String(), status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
 // GetTxFromAddressFromByHash gets tx from address by hash
 func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
 	query := `SELECT from_address, nonce
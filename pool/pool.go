@@ -65,6 +65,8 @@ type preExecutionResponse struct {
 type GasPrices struct {
 	L2GasPrice uint64
 	L1GasPrice uint64
+	// UpdatedAt is the time the gas prices were set, zero if they were never set
+	UpdatedAt time.Time
 }
 
 // NewPool creates and initializes an instance of Pool
@@ -81,7 +83,7 @@ func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storag
 		minSuggestedGasPriceMux: new(sync.RWMutex),
 		minSuggestedGasPrice:    big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
 		eventLog:                eventLog,
-		gasPrices:               GasPrices{0, 0},
+		gasPrices:               GasPrices{},
 		gasPricesMux:            new(sync.RWMutex),
 		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice, cfg.DefaultMinGasPriceAllowed),
 	}
@@ -387,8 +389,7 @@ func (p *Pool) DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Ti
 
 // GetGasPrices returns the current L2 Gas Price and L1 Gas Price
 func (p *Pool) GetGasPrices(ctx context.Context) (GasPrices, error) {
-	l2GasPrice, l1GasPrice, err := p.storage.GetGasPrices(ctx)
-	return GasPrices{L1GasPrice: l1GasPrice, L2GasPrice: l2GasPrice}, err
+	return p.storage.GetGasPrices(ctx)
 }
 
 // CountPendingTransactions get number of pending transactions
@@ -397,6 +398,35 @@ func (p *Pool) CountPendingTransactions(ctx context.Context) (uint64, error) {
 	return p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
 }
 
+// GetPendingBalance returns the balance of the address at the last L2 block minus
+// the cost (value + gasPrice * gas) of its pending txs in the pool
+func (p *Pool) GetPendingBalance(ctx context.Context, address common.Address) (*big.Int, error) {
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := p.state.GetBalance(ctx, address, lastL2Block.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	pendingTxs, err := p.storage.GetTxsByFromAndStatus(ctx, address, TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingBalance := new(big.Int).Set(balance)
+	for _, tx := range pendingTxs {
+		pendingBalance.Sub(pendingBalance, tx.Cost())
+	}
+	if pendingBalance.Sign() < 0 {
+		pendingBalance.SetUint64(0)
+	}
+
+	return pendingBalance, nil
+}
+
 // IsTxPending check if tx is still pending
 func (p *Pool) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
 	return p.storage.IsTxPending(ctx, hash)
@@ -459,6 +489,22 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrBlockedSender
 	}
 
+	// try to get a transaction from the pool with the same nonce to check
+	// if the new one is already known or has a price bump
+	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
+	if err != nil {
+		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
+		return err
+	}
+
+	// check if the transaction is already in the pool, so duplicates are
+	// reported as known instead of failing any of the following validations
+	for _, oldTx := range oldTxs {
+		if oldTx.Hash() == poolTx.Hash() && oldTx.Status != TxStatusInvalid && oldTx.Status != TxStatusFailed {
+			return ErrAlreadyKnown
+		}
+	}
+
 	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
 	if err != nil {
 		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
@@ -472,7 +518,8 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 	}
 	// Ensure the transaction adheres to nonce ordering
 	if poolTx.Nonce() < currentNonce {
-		return ErrNonceTooLow
+		// include the expected nonce so clients can correct it without querying the account
+		return fmt.Errorf("%w: next nonce is %d", ErrNonceTooLow, currentNonce)
 	}
 
 	// check if sender has reached the limit of transactions in the pool
@@ -515,6 +562,11 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrGasPrice
 	}
 
+	// Reject transactions that don't fit in a batch
+	if err := CheckBatchGasLimit(poolTx.Gas(), p.batchConstraintsCfg.MaxCumulativeGasUsed); err != nil {
+		return err
+	}
+
 	// Transactor should have enough funds to cover the costs
 	// cost == V + GP * GL
 	balance, err := p.state.GetBalance(ctx, from, lastL2Block.Root())
@@ -536,14 +588,6 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrIntrinsicGas
 	}
 
-	// try to get a transaction from the pool with the same nonce to check
-	// if the new one has a price bump
-	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
-	if err != nil {
-		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
-		return err
-	}
-
 	// check if the new transaction has more gas than all the other txs in the pool
 	// with the same from and nonce to be able to replace the current txs by the new
 	// when being selected
@@ -556,10 +600,6 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
 		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
 
-		if oldTx.Hash() == poolTx.Hash() {
-			return ErrAlreadyKnown
-		}
-
 		// if old Tx Price is higher than the new poolTx price, it returns an error
 		if oldTxPrice.Cmp(txPrice) > 0 {
 			return ErrReplaceUnderpriced
@@ -254,6 +254,9 @@ func Test_AddTx(t *testing.T) {
 	}
 
 	assert.Equal(t, 1, c, "invalid number of txs in the pool")
+
+	err = p.AddTx(ctx, *tx, ip)
+	assert.ErrorIs(t, err, pool.ErrAlreadyKnown)
 }
 
 func Test_AddTx_OversizedData(t *testing.T) {
@@ -756,7 +759,7 @@ func Test_SetAndGetGasPrice(t *testing.T) {
 
 	nBig, err := rand.Int(rand.Reader, big.NewInt(0).SetUint64(math.MaxUint64))
 	require.NoError(t, err)
-	expectedGasPrice := pool.GasPrices{nBig.Uint64(), nBig.Uint64()}
+	expectedGasPrice := pool.GasPrices{L2GasPrice: nBig.Uint64(), L1GasPrice: nBig.Uint64()}
 	ctx := context.Background()
 	err = p.SetGasPrices(ctx, expectedGasPrice.L2GasPrice, expectedGasPrice.L1GasPrice)
 	require.NoError(t, err)
@@ -764,7 +767,9 @@ func Test_SetAndGetGasPrice(t *testing.T) {
 	gasPrice, err := p.GetGasPrices(ctx)
 	require.NoError(t, err)
 
-	assert.Equal(t, expectedGasPrice, gasPrice)
+	assert.Equal(t, expectedGasPrice.L2GasPrice, gasPrice.L2GasPrice)
+	assert.Equal(t, expectedGasPrice.L1GasPrice, gasPrice.L1GasPrice)
+	assert.WithinDuration(t, time.Now(), gasPrice.UpdatedAt, time.Minute)
 }
 
 func TestDeleteGasPricesHistoryOlderThan(t *testing.T) {
@@ -1895,6 +1900,86 @@ func Test_AddTx_NonceTooHigh(t *testing.T) {
 	require.Error(t, err, pool.ErrNonceTooHigh)
 }
 
+func Test_GetPendingBalance(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	st := newState(stateSqlDB, eventLog)
+
+	// generate accounts
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	p := setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	value := big.NewInt(1000000000000000000)
+	txs := make([]*ethTypes.Transaction, 0, 3)
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := ethTypes.NewTransaction(nonce, common.HexToAddress(senderAddress), value, gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		err = s.AddTx(ctx, *pool.NewTransaction(*signedTx, ip, false))
+		require.NoError(t, err)
+		txs = append(txs, signedTx)
+	}
+
+	// failed txs are not taken into account
+	failedReason := "failed"
+	err = p.UpdateTxStatus(ctx, txs[2].Hash(), pool.TxStatusFailed, false, &failedReason)
+	require.NoError(t, err)
+
+	balance, err := p.GetPendingBalance(ctx, common.HexToAddress(senderAddress))
+	require.NoError(t, err)
+
+	expectedBalance, _ := new(big.Int).SetString("1000000000000000000000", encoding.Base10)
+	expectedBalance.Sub(expectedBalance, txs[0].Cost())
+	expectedBalance.Sub(expectedBalance, txs[1].Cost())
+	assert.Equal(t, expectedBalance.String(), balance.String())
+}
+
 func Test_AddTx_IPValidation(t *testing.T) {
 	var tests = []struct {
 		name     string
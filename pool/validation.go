@@ -1,8 +1,20 @@
 package pool
 
-import "net"
+import (
+	"fmt"
+	"net"
+)
 
 // IsValidIP returns true if the given string is a valid IP address
 func IsValidIP(ip string) bool {
 	return ip != "" && net.ParseIP(ip) != nil
 }
+
+// CheckBatchGasLimit returns ErrGasLimitTooHigh with the batch gas limit if the
+// transaction gas limit exceeds it.
+func CheckBatchGasLimit(txGasLimit, batchGasLimit uint64) error {
+	if txGasLimit > batchGasLimit {
+		return fmt.Errorf("%w: tx gas limit %d exceeds the batch gas limit %d", ErrGasLimitTooHigh, txGasLimit, batchGasLimit)
+	}
+	return nil
+}
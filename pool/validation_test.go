@@ -25,3 +25,10 @@ func Test_IsValidIP(t *testing.T) {
 		})
 	}
 }
+
+func Test_CheckBatchGasLimit(t *testing.T) {
+	assert.NoError(t, CheckBatchGasLimit(30000000, 30000000))
+	err := CheckBatchGasLimit(30000001, 30000000)
+	assert.ErrorIs(t, err, ErrGasLimitTooHigh)
+	assert.Equal(t, "gas limit too high: tx gas limit 30000001 exceeds the batch gas limit 30000000", err.Error())
+}
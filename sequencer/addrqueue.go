@@ -90,6 +90,23 @@ func (a *addrQueue) addPendingTxToStore(txHash common.Hash) {
 	a.pendingTxsToStore[txHash] = struct{}{}
 }
 
+// hasTx returns true if the tx is in the addrQueue as a ready, notReady, forced or pending to store tx
+func (a *addrQueue) hasTx(txHash common.Hash) bool {
+	if a.readyTx != nil && a.readyTx.Hash == txHash {
+		return true
+	}
+	for _, txTracker := range a.notReadyTxs {
+		if txTracker.Hash == txHash {
+			return true
+		}
+	}
+	if _, found := a.forcedTxs[txHash]; found {
+		return true
+	}
+	_, found := a.pendingTxsToStore[txHash]
+	return found
+}
+
 // ExpireTransactions removes the txs that have been in the queue for more than maxTime
 func (a *addrQueue) ExpireTransactions(maxTime time.Duration) ([]*TxTracker, *TxTracker) {
 	var (
@@ -115,11 +132,45 @@ func (a *addrQueue) ExpireTransactions(maxTime time.Duration) ([]*TxTracker, *Tx
 	return txs, prevReadyTx
 }
 
+// txCount returns the number of ready and not ready txs of the addrQueue
+func (a *addrQueue) txCount() int {
+	count := len(a.notReadyTxs)
+	if a.readyTx != nil {
+		count++
+	}
+	return count
+}
+
+// hasNonce returns true if the addrQueue has a ready or not ready tx with the nonce
+func (a *addrQueue) hasNonce(nonce uint64) bool {
+	if a.readyTx != nil && a.readyTx.Nonce == nonce {
+		return true
+	}
+	_, found := a.notReadyTxs[nonce]
+	return found
+}
+
 // IsEmpty returns true if the addrQueue is empty
 func (a *addrQueue) IsEmpty() bool {
 	return a.readyTx == nil && len(a.notReadyTxs) == 0 && len(a.forcedTxs) == 0 && len(a.pendingTxsToStore) == 0
 }
 
+// getPendingNonce returns the next nonce of the addrQueue after the ready tx and the
+// not ready txs with consecutive nonces
+func (a *addrQueue) getPendingNonce() uint64 {
+	if a.readyTx == nil {
+		return a.currentNonce
+	}
+
+	nonce := a.currentNonce + 1
+	for {
+		if _, found := a.notReadyTxs[nonce]; !found {
+			return nonce
+		}
+		nonce++
+	}
+}
+
 // deleteTx deletes the tx from the addrQueue
 func (a *addrQueue) deleteTx(txHash common.Hash) (deletedReadyTx *TxTracker) {
 	txHashStr := txHash.String()
@@ -30,6 +30,7 @@ type Batch struct {
 	countOfTxs          int
 	remainingResources  state.BatchResources
 	closingReason       state.ClosingReason
+	fees                BatchFeeAccumulator // fees of the txs processed in the batch, since the batch was opened or the sequencer started
 }
 
 func (w *Batch) isEmpty() bool {
@@ -146,6 +147,8 @@ func (f *finalizer) initWIPBatch(ctx context.Context) {
 	log.Infof("initial batch: %d, initialStateRoot: %s, stateRoot: %s, coinbase: %s, GER: %s, LER: %s",
 		f.wipBatch.batchNumber, f.wipBatch.initialStateRoot, f.wipBatch.finalStateRoot, f.wipBatch.coinbase,
 		f.wipBatch.globalExitRoot, f.wipBatch.localExitRoot)
+
+	f.wipBatchNumber.Store(f.wipBatch.batchNumber)
 }
 
 // finalizeBatch retries until successful closes the current batch and opens a new one, potentially processing forced batches between the batch is closed and the resulting new empty batch
@@ -162,6 +165,7 @@ func (f *finalizer) finalizeBatch(ctx context.Context) {
 	}
 
 	log.Infof("new WIP batch %d", f.wipBatch.batchNumber)
+	f.wipBatchNumber.Store(f.wipBatch.batchNumber)
 }
 
 // closeAndOpenNewWIPBatch closes the current batch and opens a new one, potentially processing forced batches between the batch is closed and the resulting new empty batch
@@ -217,34 +221,8 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context) (*Batch, error)
 
 	log.Infof("batch %d closed", f.wipBatch.batchNumber)
 
-	//TODO: Call DSUpdateGER function
 	// Check if the batch is empty and sending a GER Update to the stream is needed
-	//TODO: is this UpdateGER still needed?
-	/*if f.streamServer != nil && f.wipBatch.isEmpty() && f.currentGERHash != f.previousGERHash {
-		updateGer := state.DSUpdateGER{
-			BatchNumber:    f.wipBatch.batchNumber,
-			Timestamp:      f.wipBatch.timestamp.Unix(),
-			GlobalExitRoot: f.wipBatch.globalExitRoot,
-			Coinbase:       f.sequencerAddress,
-			ForkID:         uint16(f.state.GetForkIDByBatchNumber(f.wipBatch.batchNumber)),
-			StateRoot:      f.wipBatch.finalStateRoot,
-		}
-
-		err = f.streamServer.StartAtomicOp()
-		if err != nil {
-			log.Errorf("failed to start atomic op for Update GER on batch %v: %v", f.wipBatch.batchNumber, err)
-		}
-
-		_, err = f.streamServer.AddStreamEntry(state.EntryTypeUpdateGER, updateGer.Encode())
-		if err != nil {
-			log.Errorf("failed to add stream entry for Update GER on batch %v: %v", f.wipBatch.batchNumber, err)
-		}
-
-		err = f.streamServer.CommitAtomicOp()
-		if err != nil {
-			log.Errorf("failed to commit atomic op for Update GER on batch  %v: %v", f.wipBatch.batchNumber, err)
-		}
-	}*/
+	f.checkAndSendUpdateGER(f.wipBatch)
 
 	// Metadata for the next batch
 	stateRoot := f.wipBatch.finalStateRoot
@@ -252,7 +230,7 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context) (*Batch, error)
 	lastBatchNumber := f.wipBatch.batchNumber
 
 	// Process Forced Batches
-	if len(f.nextForcedBatches) > 0 {
+	if f.pendingForcedBatchCount() > 0 {
 		lastBatchNumber, stateRoot, accInputHash = f.processForcedBatches(ctx, lastBatchNumber, stateRoot, accInputHash)
 		//TODO: how to reset wip L2 block after forced batch processing
 	}
@@ -364,9 +342,32 @@ func (f *finalizer) closeWIPBatch(ctx context.Context) error {
 		}
 	}
 
+	log.Infof("batch %d closed, closing reason: %s", receipt.BatchNumber, receipt.ClosingReason)
+	metrics.BatchClosed(string(receipt.ClosingReason))
+	metrics.BatchFees(f.wipBatch.fees.Total())
+	metrics.LastBatchClosed(now())
+
 	return nil
 }
 
+// checkAndSendUpdateGER updates the current GER with the GER of the closed batch. If the batch is empty
+// there is no L2 block to carry the GER to the data stream, so a GER update is sent when the GER changes
+func (f *finalizer) checkAndSendUpdateGER(batch *Batch) {
+	// ZeroHash means the batch doesn't update the GER
+	if batch.globalExitRoot == state.ZeroHash {
+		return
+	}
+
+	f.currentGERHashMux.Lock()
+	newGER := f.currentGERHash != batch.globalExitRoot
+	f.currentGERHash = batch.globalExitRoot
+	f.currentGERHashMux.Unlock()
+
+	if batch.isEmpty() && f.streamServer != nil && newGER {
+		f.DSSendUpdateGER(batch.batchNumber, batch.timestamp.Unix(), batch.globalExitRoot, batch.finalStateRoot)
+	}
+}
+
 // maxTxsPerBatchReached checks if the batch has reached the maximum number of txs per batch
 func (f *finalizer) maxTxsPerBatchReached() bool {
 	if f.wipBatch.countOfTxs >= int(f.batchConstraints.MaxTxsPerBatch) {
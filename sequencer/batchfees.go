@@ -0,0 +1,37 @@
+package sequencer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// BatchFeeAccumulator sums the fees (gasUsed * effectiveGasPrice) of the txs processed in a batch,
+// the zero value is an empty accumulator
+type BatchFeeAccumulator struct {
+	fees big.Int
+}
+
+// Add adds the fees of the txs of the batch response
+func (a *BatchFeeAccumulator) Add(batchResponse *state.ProcessBatchResponse) {
+	for _, blockResponse := range batchResponse.BlockResponses {
+		for _, txResponse := range blockResponse.TransactionResponses {
+			if txResponse.EffectiveGasPrice == "" {
+				continue
+			}
+			effectiveGasPrice, ok := new(big.Int).SetString(txResponse.EffectiveGasPrice, 0)
+			if !ok {
+				log.Errorf("error converting effective gas price %s of tx %s to big.Int", txResponse.EffectiveGasPrice, txResponse.TxHash.String())
+				continue
+			}
+			fee := effectiveGasPrice.Mul(effectiveGasPrice, new(big.Int).SetUint64(txResponse.GasUsed))
+			a.fees.Add(&a.fees, fee)
+		}
+	}
+}
+
+// Total returns the fees accumulated in wei
+func (a *BatchFeeAccumulator) Total() *big.Int {
+	return new(big.Int).Set(&a.fees)
+}
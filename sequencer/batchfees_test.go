@@ -0,0 +1,44 @@
+package sequencer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchFeeAccumulator(t *testing.T) {
+	var fees BatchFeeAccumulator
+	assert.Equal(t, big.NewInt(0), fees.Total())
+
+	fees.Add(&state.ProcessBatchResponse{
+		BlockResponses: []*state.ProcessBlockResponse{
+			{
+				TransactionResponses: []*state.ProcessTransactionResponse{
+					{GasUsed: 21000, EffectiveGasPrice: "0x3b9aca00"},
+					{GasUsed: 50000, EffectiveGasPrice: "2000000000"},
+				},
+			},
+			{
+				TransactionResponses: []*state.ProcessTransactionResponse{
+					// the txs without effective gas price or with an invalid one are skipped
+					{GasUsed: 21000},
+					{GasUsed: 21000, EffectiveGasPrice: "invalid"},
+				},
+			},
+		},
+	})
+	fees.Add(&state.ProcessBatchResponse{
+		BlockResponses: []*state.ProcessBlockResponse{
+			{TransactionResponses: []*state.ProcessTransactionResponse{{GasUsed: 1, EffectiveGasPrice: "7"}}},
+		},
+	})
+
+	expected := big.NewInt(21000*1000000000 + 50000*2000000000 + 7)
+	assert.Equal(t, expected, fees.Total())
+
+	// The total can't be modified by the caller
+	fees.Total().SetInt64(0)
+	assert.Equal(t, expected, fees.Total())
+}
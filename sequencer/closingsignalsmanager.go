@@ -2,9 +2,16 @@ package sequencer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // TODO: closingSignalsManager now is only used to notifiy new forced batches to process, maybe it's better to remove this struct and add the
@@ -16,6 +23,7 @@ type closingSignalsManager struct {
 	cfg                    FinalizerCfg
 	lastForcedBatchNumSent uint64
 	etherman               etherman
+	l1ReorgDetected        atomic.Bool
 }
 
 func newClosingSignalsManager(ctx context.Context, state stateInterface, closingSignalCh ClosingSignalCh, cfg FinalizerCfg, etherman etherman) *closingSignalsManager {
@@ -24,12 +32,18 @@ func newClosingSignalsManager(ctx context.Context, state stateInterface, closing
 
 func (c *closingSignalsManager) Start() {
 	go c.checkForcedBatches()
+	go c.checkL1Reorgs()
 }
 
 func (c *closingSignalsManager) checkForcedBatches() {
 	for {
 		time.Sleep(c.cfg.ClosingSignalsManagerWaitForCheckingForcedBatches.Duration)
 
+		// If there was a L1 reorg the forced batches sent could have been flushed, we need to send them again
+		if c.l1ReorgDetected.Swap(false) {
+			c.lastForcedBatchNumSent = 0
+		}
+
 		if c.lastForcedBatchNumSent == 0 {
 			lastTrustedForcedBatchNum, err := c.state.GetLastTrustedForcedBatchNumber(c.ctx, nil)
 			if err != nil {
@@ -41,21 +55,12 @@ func (c *closingSignalsManager) checkForcedBatches() {
 			}
 		}
 		// Take into account L1 finality
-		lastBlock, err := c.state.GetLastBlock(c.ctx, nil)
+		maxBlockNumber, err := c.getForcedBatchesMaxBlockNumber()
 		if err != nil {
-			log.Errorf("failed to get latest eth block number, err: %v", err)
+			log.Errorf("failed to get max L1 block number for forced batches, err: %v", err)
 			continue
 		}
 
-		blockNumber := lastBlock.BlockNumber
-
-		maxBlockNumber := uint64(0)
-		finalityNumberOfBlocks := c.cfg.ForcedBatchesFinalityNumberOfBlocks
-
-		if finalityNumberOfBlocks <= blockNumber {
-			maxBlockNumber = blockNumber - finalityNumberOfBlocks
-		}
-
 		forcedBatches, err := c.state.GetForcedBatchesSince(c.ctx, c.lastForcedBatchNumSent, maxBlockNumber, nil)
 		if err != nil {
 			log.Errorf("error checking forced batches: %v", err)
@@ -69,3 +74,122 @@ func (c *closingSignalsManager) checkForcedBatches() {
 		}
 	}
 }
+
+// getForcedBatchesMaxBlockNumber returns the max L1 block number from which forced batches can be ingested. The L1 block
+// must be ForcedBatchesFinalityNumberOfBlocks behind the last synced L1 block and ForcedBatchConfirmations behind the latest L1 block
+func (c *closingSignalsManager) getForcedBatchesMaxBlockNumber() (uint64, error) {
+	lastBlock, err := c.state.GetLastBlock(c.ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last synced L1 block, err: %w", err)
+	}
+
+	maxBlockNumber := uint64(0)
+	if c.cfg.ForcedBatchesFinalityNumberOfBlocks <= lastBlock.BlockNumber {
+		maxBlockNumber = lastBlock.BlockNumber - c.cfg.ForcedBatchesFinalityNumberOfBlocks
+	}
+
+	latestBlockNumber, err := c.etherman.GetLatestBlockNumber(c.ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest L1 block number, err: %w", err)
+	}
+
+	maxConfirmedBlockNumber := uint64(0)
+	if c.cfg.ForcedBatchConfirmations <= latestBlockNumber {
+		maxConfirmedBlockNumber = latestBlockNumber - c.cfg.ForcedBatchConfirmations
+	}
+
+	if maxConfirmedBlockNumber < maxBlockNumber {
+		maxBlockNumber = maxConfirmedBlockNumber
+	}
+
+	return maxBlockNumber, nil
+}
+
+// checkL1Reorgs subscribes to the new L1 headers and sends a L1 reorg signal when a L1 reorg is detected. If the L1 node
+// doesn't support subscriptions (HTTP endpoint) the latest L1 header is polled instead
+func (c *closingSignalsManager) checkL1Reorgs() {
+	l1ReorgDetector := newL1ReorgDetector(c.etherman)
+
+	for {
+		headersCh := make(chan *types.Header)
+		sub, err := c.etherman.SubscribeNewHead(c.ctx, headersCh)
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			log.Infof("L1 node doesn't support subscriptions, polling the latest L1 header every %v to detect L1 reorgs", c.cfg.ClosingSignalsManagerWaitForCheckingL1Timeout.Duration)
+			c.pollL1Headers(l1ReorgDetector)
+			return
+		}
+		if err != nil {
+			log.Errorf("failed to subscribe to new L1 headers, err: %v", err)
+			time.Sleep(c.cfg.ClosingSignalsManagerWaitForCheckingL1Timeout.Duration)
+			continue
+		}
+
+	subscriptionLoop:
+		for {
+			select {
+			case <-c.ctx.Done():
+				sub.Unsubscribe()
+				return
+			case err := <-sub.Err():
+				log.Errorf("L1 headers subscription error, err: %v", err)
+				break subscriptionLoop
+			case header := <-headersCh:
+				c.checkL1Header(l1ReorgDetector, header)
+			}
+		}
+
+		sub.Unsubscribe()
+		time.Sleep(c.cfg.ClosingSignalsManagerWaitForCheckingL1Timeout.Duration)
+	}
+}
+
+// pollL1Headers gets the latest L1 header every ClosingSignalsManagerWaitForCheckingL1Timeout and checks it for L1 reorgs.
+// The L1 headers between the last header checked and the latest one are also checked, so the parent hash of each header
+// can be compared with the previous one
+func (c *closingSignalsManager) pollL1Headers(l1ReorgDetector *l1ReorgDetector) {
+	ticker := time.NewTicker(c.cfg.ClosingSignalsManagerWaitForCheckingL1Timeout.Duration)
+	defer ticker.Stop()
+
+	for {
+		latestHeader, err := c.etherman.HeaderByNumber(c.ctx, nil)
+		if err != nil {
+			log.Errorf("failed to get latest L1 header, err: %v", err)
+		} else {
+			latestBlockNumber := latestHeader.Number.Uint64()
+			fromBlockNumber := l1ReorgDetector.lastBlockNumber + 1
+			if l1ReorgDetector.lastBlockNumber == 0 || latestBlockNumber <= fromBlockNumber || latestBlockNumber-fromBlockNumber > l1ReorgDetectorMaxHeaders {
+				fromBlockNumber = latestBlockNumber
+			}
+			for blockNumber := fromBlockNumber; blockNumber < latestBlockNumber; blockNumber++ {
+				header, err := c.etherman.HeaderByNumber(c.ctx, new(big.Int).SetUint64(blockNumber))
+				if err != nil {
+					log.Errorf("failed to get L1 header %d, err: %v", blockNumber, err)
+					break
+				}
+				c.checkL1Header(l1ReorgDetector, header)
+			}
+			c.checkL1Header(l1ReorgDetector, latestHeader)
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkL1Header checks the L1 header with the L1 reorg detector and sends the L1 reorg signal if a L1 reorg is detected
+func (c *closingSignalsManager) checkL1Header(l1ReorgDetector *l1ReorgDetector, header *types.Header) {
+	reorgEvent, err := l1ReorgDetector.checkHeader(c.ctx, header)
+	if err != nil {
+		log.Errorf("failed to check L1 header %d for reorgs, err: %v", header.Number.Uint64(), err)
+		return
+	}
+	if reorgEvent != nil {
+		log.Warnf("L1 reorg detected, first reorged L1 block: %d", reorgEvent.FirstReorgedBlockNumber)
+		metrics.L1ReorgDetected()
+		c.closingSignalCh.L1ReorgCh <- *reorgEvent
+		c.l1ReorgDetected.Store(true)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
@@ -20,8 +22,12 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/test/dbutils"
 	"github.com/0xPolygonHermez/zkevm-node/test/testutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethEvent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
@@ -131,6 +137,9 @@ func TestClosingSignalsManager(t *testing.T) {
 		ForcedBatchCh: make(chan state.ForcedBatch),
 	}
 
+	m.Etherman.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("subscription not supported")).Maybe()
+	m.Etherman.On("GetLatestBlockNumber", mock.Anything).Return(uint64(math.MaxUint64), nil).Maybe()
+
 	prepareForcedBatches(t)
 	closingSignalsManager := newClosingSignalsManager(localCtx, localState, channels, cfg, m.Etherman)
 	closingSignalsManager.Start()
@@ -164,3 +173,117 @@ func TestClosingSignalsManager(t *testing.T) {
 
 	cleanup(t)
 }
+
+func TestClosingSignalsManager_getForcedBatchesMaxBlockNumber(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		lastSyncedBlockNumber  uint64
+		latestL1BlockNumber    uint64
+		finalityNumberOfBlocks uint64
+		confirmations          uint64
+		expectedMaxBlockNumber uint64
+	}{
+		{
+			name:                   "Synced block finality is the limit",
+			lastSyncedBlockNumber:  100,
+			latestL1BlockNumber:    110,
+			finalityNumberOfBlocks: 10,
+			confirmations:          5,
+			expectedMaxBlockNumber: 90,
+		},
+		{
+			name:                   "Shallow L1 blocks are not confirmed",
+			lastSyncedBlockNumber:  100,
+			latestL1BlockNumber:    102,
+			finalityNumberOfBlocks: 0,
+			confirmations:          12,
+			expectedMaxBlockNumber: 90,
+		},
+		{
+			name:                   "Not enough L1 blocks for confirmations",
+			lastSyncedBlockNumber:  5,
+			latestL1BlockNumber:    5,
+			finalityNumberOfBlocks: 0,
+			confirmations:          12,
+			expectedMaxBlockNumber: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			stMock := NewStateMock(t)
+			ethMock := NewEthermanMock(t)
+
+			stMock.On("GetLastBlock", ctx, nil).Return(&state.Block{BlockNumber: tc.lastSyncedBlockNumber}, nil).Once()
+			ethMock.On("GetLatestBlockNumber", ctx).Return(tc.latestL1BlockNumber, nil).Once()
+
+			finalizerCfg := FinalizerCfg{
+				ForcedBatchesFinalityNumberOfBlocks: tc.finalityNumberOfBlocks,
+				ForcedBatchConfirmations:            tc.confirmations,
+			}
+			manager := newClosingSignalsManager(ctx, stMock, ClosingSignalCh{}, finalizerCfg, ethMock)
+
+			maxBlockNumber, err := manager.getForcedBatchesMaxBlockNumber()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMaxBlockNumber, maxBlockNumber)
+		})
+	}
+}
+
+func TestClosingSignalsManager_checkL1Reorgs(t *testing.T) {
+	header1 := &types.Header{Number: big.NewInt(1)}
+	header2 := &types.Header{Number: big.NewInt(2), ParentHash: header1.Hash()}
+	reorgedHeader2 := &types.Header{Number: big.NewInt(2), ParentHash: header1.Hash(), Extra: []byte("reorged")}
+	reorgedHeader3 := &types.Header{Number: big.NewInt(3), ParentHash: reorgedHeader2.Hash()}
+
+	finalizerCfg := FinalizerCfg{ClosingSignalsManagerWaitForCheckingL1Timeout: cfgTypes.Duration{Duration: 10 * time.Millisecond}}
+
+	t.Run("L1 reorg detected with the new L1 headers subscription", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ethMock := NewEthermanMock(t)
+
+		ethMock.On("SubscribeNewHead", ctx, mock.Anything).Return(ethEvent.NewSubscription(func(quit <-chan struct{}) error {
+			<-quit
+			return nil
+		}), nil).Run(func(args mock.Arguments) {
+			headersCh := args.Get(1).(chan<- *types.Header)
+			go func() {
+				for _, header := range []*types.Header{header1, header2, reorgedHeader3} {
+					headersCh <- header
+				}
+			}()
+		}).Once()
+		ethMock.On("HeaderByNumber", ctx, big.NewInt(2)).Return(reorgedHeader2, nil).Once()
+		ethMock.On("HeaderByNumber", ctx, big.NewInt(1)).Return(header1, nil).Once()
+
+		closingSignalCh := ClosingSignalCh{L1ReorgCh: make(chan L1ReorgEvent)}
+		manager := newClosingSignalsManager(ctx, nil, closingSignalCh, finalizerCfg, ethMock)
+		go manager.checkL1Reorgs()
+
+		reorgEvent := <-closingSignalCh.L1ReorgCh
+		assert.Equal(t, uint64(2), reorgEvent.FirstReorgedBlockNumber)
+	})
+
+	t.Run("L1 reorg detected polling the latest L1 header", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ethMock := NewEthermanMock(t)
+
+		ethMock.On("SubscribeNewHead", ctx, mock.Anything).Return(nil, rpc.ErrNotificationsUnsupported).Once()
+		ethMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header1, nil).Once()
+		// the latest L1 header skips the block 2, which is checked before the latest one
+		ethMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(reorgedHeader3, nil)
+		ethMock.On("HeaderByNumber", ctx, big.NewInt(2)).Return(header2, nil).Once()
+		ethMock.On("HeaderByNumber", ctx, big.NewInt(2)).Return(reorgedHeader2, nil).Once()
+		ethMock.On("HeaderByNumber", ctx, big.NewInt(1)).Return(header1, nil).Once()
+
+		closingSignalCh := ClosingSignalCh{L1ReorgCh: make(chan L1ReorgEvent)}
+		manager := newClosingSignalsManager(ctx, nil, closingSignalCh, finalizerCfg, ethMock)
+		go manager.checkL1Reorgs()
+
+		reorgEvent := <-closingSignalCh.L1ReorgCh
+		assert.Equal(t, uint64(2), reorgEvent.FirstReorgedBlockNumber)
+	})
+}
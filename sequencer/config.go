@@ -1,10 +1,15 @@
 package sequencer
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/0xPolygonHermez/zkevm-data-streamer/log"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 )
 
+const maxResourcePercentageToCloseBatch = 100
+
 // Config represents the configuration of a sequencer
 type Config struct {
 	// WaitPeriodPoolIsEmpty is the time the sequencer waits until
@@ -23,6 +28,10 @@ type Config struct {
 	// MaxTxLifetime is the time a tx can be in the sequencer/worker memory
 	MaxTxLifetime types.Duration `mapstructure:"MaxTxLifetime"`
 
+	// MaxTxsPerAddress is the max number of ready and not ready txs of an address in the worker, the txs
+	// over the limit are set as WIP in the pool until the address has room for them. 0 means no limit
+	MaxTxsPerAddress uint `mapstructure:"MaxTxsPerAddress"`
+
 	// Finalizer's specific config properties
 	Finalizer FinalizerCfg `mapstructure:"Finalizer"`
 
@@ -31,10 +40,17 @@ type Config struct {
 
 	// StreamServerCfg is the config for the stream server
 	StreamServer StreamServerCfg `mapstructure:"StreamServer"`
+
+	// DynamicConfigFile is the path of a JSON file with the finalizer timing parameters to change
+	// (TimestampResolution, L2BlockTime, ForcedBatchDeadlineTimeout), it's re-read on SIGHUP
+	DynamicConfigFile string `mapstructure:"DynamicConfigFile"`
 }
 
 // StreamServerCfg contains the data streamer's configuration properties
 type StreamServerCfg struct {
+	// Host is the address the data stream is reachable at by the clients, it is advertised by the RPC
+	// in zkevm_getBroadcastURI and it's required when the stream server is enabled
+	Host string `mapstructure:"Host"`
 	// Port to listen on
 	Port uint16 `mapstructure:"Port"`
 	// Filename of the binary data file
@@ -53,9 +69,18 @@ type FinalizerCfg struct {
 	// ForcedBatchDeadlineTimeout is the time the finalizer waits after receiving closing signal to process Forced Batches
 	ForcedBatchDeadlineTimeout types.Duration `mapstructure:"ForcedBatchDeadlineTimeout"`
 
+	// MinForcedBatchProcessingInterval is the time the finalizer waits to process again the pending forced batches when
+	// some of them could not be processed
+	MinForcedBatchProcessingInterval types.Duration `mapstructure:"MinForcedBatchProcessingInterval"`
+
 	// SleepDuration is the time the finalizer sleeps between each iteration, if there are no transactions to be processed
 	SleepDuration types.Duration `mapstructure:"SleepDuration"`
 
+	// LivenessTimeout is the max time the finalizer main loop can take to complete an iteration, once
+	// it's reached the liveness check of the metrics server fails so the instance can be restarted.
+	// 0 disables the check, then the liveness check only fails when the finalizer is halted
+	LivenessTimeout types.Duration `mapstructure:"LivenessTimeout"`
+
 	// ResourcePercentageToCloseBatch is the percentage window of the resource left out for the batch to be closed
 	ResourcePercentageToCloseBatch uint32 `mapstructure:"ResourcePercentageToCloseBatch"`
 
@@ -65,10 +90,14 @@ type FinalizerCfg struct {
 	// ForcedBatchesFinalityNumberOfBlocks is number of blocks to consider GER final
 	ForcedBatchesFinalityNumberOfBlocks uint64 `mapstructure:"ForcedBatchesFinalityNumberOfBlocks"`
 
+	// ForcedBatchConfirmations is the number of L1 blocks a forced batch must be behind the latest L1 block to be processed
+	ForcedBatchConfirmations uint64 `mapstructure:"ForcedBatchConfirmations"`
+
 	// L1InfoRootFinalityNumberOfBlocks is number of blocks to consider L1InfoRoot final
 	L1InfoRootFinalityNumberOfBlocks uint64 `mapstructure:"L1InfoRootFinalityNumberOfBlocks"`
 
-	// ClosingSignalsManagerWaitForCheckingL1Timeout is used by the closing signals manager to wait for its operation
+	// ClosingSignalsManagerWaitForCheckingL1Timeout is used by the closing signals manager to wait for its operation. When the
+	// L1 node doesn't support subscriptions it's also the interval to poll the latest L1 header to detect L1 reorgs
 	ClosingSignalsManagerWaitForCheckingL1Timeout types.Duration `mapstructure:"ClosingSignalsManagerWaitForCheckingL1Timeout"`
 
 	// ClosingSignalsManagerWaitForCheckingGER is used by the closing signals manager to wait for its operation
@@ -99,3 +128,67 @@ type DBManagerCfg struct {
 	PoolRetrievalInterval    types.Duration `mapstructure:"PoolRetrievalInterval"`
 	L2ReorgRetrievalInterval types.Duration `mapstructure:"L2ReorgRetrievalInterval"`
 }
+
+// Validate checks the sequencer config, it returns an error listing all the invalid fields
+func (c Config) Validate() error {
+	var errs []error
+	positive := func(field string, d types.Duration) {
+		if d.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%w: %s must be greater than 0, got %v", ErrInvalidConfig, field, d.Duration))
+		}
+	}
+	nonNegative := func(field string, d types.Duration) {
+		if d.Duration < 0 {
+			errs = append(errs, fmt.Errorf("%w: %s must not be negative, got %v", ErrInvalidConfig, field, d.Duration))
+		}
+	}
+
+	positive("WaitPeriodPoolIsEmpty", c.WaitPeriodPoolIsEmpty)
+	positive("FrequencyToCheckTxsForDelete", c.FrequencyToCheckTxsForDelete)
+	positive("TxLifetimeCheckTimeout", c.TxLifetimeCheckTimeout)
+	positive("MaxTxLifetime", c.MaxTxLifetime)
+
+	nonNegative("Finalizer.GERDeadlineTimeout", c.Finalizer.GERDeadlineTimeout)
+	nonNegative("Finalizer.ForcedBatchDeadlineTimeout", c.Finalizer.ForcedBatchDeadlineTimeout)
+	nonNegative("Finalizer.MinForcedBatchProcessingInterval", c.Finalizer.MinForcedBatchProcessingInterval)
+	nonNegative("Finalizer.SleepDuration", c.Finalizer.SleepDuration)
+	nonNegative("Finalizer.LivenessTimeout", c.Finalizer.LivenessTimeout)
+	if c.Finalizer.ResourcePercentageToCloseBatch > maxResourcePercentageToCloseBatch {
+		errs = append(errs, fmt.Errorf("%w: Finalizer.ResourcePercentageToCloseBatch must not be greater than %d, got %d",
+			ErrInvalidConfig, maxResourcePercentageToCloseBatch, c.Finalizer.ResourcePercentageToCloseBatch))
+	}
+	positive("Finalizer.ClosingSignalsManagerWaitForCheckingL1Timeout", c.Finalizer.ClosingSignalsManagerWaitForCheckingL1Timeout)
+	positive("Finalizer.ClosingSignalsManagerWaitForCheckingGER", c.Finalizer.ClosingSignalsManagerWaitForCheckingGER)
+	positive("Finalizer.ClosingSignalsManagerWaitForCheckingForcedBatches", c.Finalizer.ClosingSignalsManagerWaitForCheckingForcedBatches)
+	positive("Finalizer.WaitForCheckingL1InfoRoot", c.Finalizer.WaitForCheckingL1InfoRoot)
+	positive("Finalizer.TimestampResolution", c.Finalizer.TimestampResolution)
+	positive("Finalizer.L2BlockTime", c.Finalizer.L2BlockTime)
+
+	positive("DBManager.PoolRetrievalInterval", c.DBManager.PoolRetrievalInterval)
+	positive("DBManager.L2ReorgRetrievalInterval", c.DBManager.L2ReorgRetrievalInterval)
+
+	if err := c.StreamServer.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the stream server config, the host is required since it's advertised to the
+// clients by the RPC and there is no listen address to derive it from
+func (c StreamServerCfg) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Host == "" {
+		errs = append(errs, fmt.Errorf("%w: StreamServer.Host must be set when the stream server is enabled", ErrInvalidConfig))
+	}
+	if c.Port == 0 {
+		errs = append(errs, fmt.Errorf("%w: StreamServer.Port must be set when the stream server is enabled", ErrInvalidConfig))
+	}
+	if c.Filename == "" {
+		errs = append(errs, fmt.Errorf("%w: StreamServer.Filename must be set when the stream server is enabled", ErrInvalidConfig))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+package sequencer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() Config {
+	duration := types.NewDuration(time.Second)
+	return Config{
+		WaitPeriodPoolIsEmpty:         duration,
+		BlocksAmountForTxsToBeDeleted: 100,
+		FrequencyToCheckTxsForDelete:  duration,
+		TxLifetimeCheckTimeout:        duration,
+		MaxTxLifetime:                 duration,
+		Finalizer: FinalizerCfg{
+			GERDeadlineTimeout:                                duration,
+			ForcedBatchDeadlineTimeout:                        duration,
+			MinForcedBatchProcessingInterval:                  duration,
+			SleepDuration:                                     duration,
+			ResourcePercentageToCloseBatch:                    10,
+			ClosingSignalsManagerWaitForCheckingL1Timeout:     duration,
+			ClosingSignalsManagerWaitForCheckingGER:           duration,
+			ClosingSignalsManagerWaitForCheckingForcedBatches: duration,
+			WaitForCheckingL1InfoRoot:                         duration,
+			TimestampResolution:                               duration,
+			L2BlockTime:                                       duration,
+		},
+		DBManager: DBManagerCfg{
+			PoolRetrievalInterval:    duration,
+			L2ReorgRetrievalInterval: duration,
+		},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := validConfig()
+	require.NoError(t, cfg.Validate())
+
+	// Zero durations are allowed for the deadlines and the finalizer sleep
+	cfg.Finalizer.GERDeadlineTimeout = types.NewDuration(0)
+	cfg.Finalizer.SleepDuration = types.NewDuration(0)
+	require.NoError(t, cfg.Validate())
+
+	// All the invalid fields are reported at once
+	cfg.WaitPeriodPoolIsEmpty = types.NewDuration(0)
+	cfg.Finalizer.ForcedBatchDeadlineTimeout = types.NewDuration(-time.Second)
+	cfg.Finalizer.ResourcePercentageToCloseBatch = 101
+	cfg.Finalizer.L2BlockTime = types.NewDuration(-time.Second)
+	cfg.StreamServer.Enabled = true
+
+	err := cfg.Validate()
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	for _, field := range []string{
+		"WaitPeriodPoolIsEmpty",
+		"Finalizer.ForcedBatchDeadlineTimeout",
+		"Finalizer.ResourcePercentageToCloseBatch",
+		"Finalizer.L2BlockTime",
+		"StreamServer.Host",
+		"StreamServer.Port",
+		"StreamServer.Filename",
+	} {
+		assert.Contains(t, err.Error(), field)
+	}
+	assert.NotContains(t, err.Error(), "Finalizer.GERDeadlineTimeout")
+	assert.NotContains(t, err.Error(), "DBManager")
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	batchCfg := state.BatchConfig{}
+
+	// The config is validated before getting the sequencer address
+	cfg := validConfig()
+	cfg.MaxTxLifetime = types.NewDuration(0)
+	_, err := New(cfg, batchCfg, pool.Config{}, nil, nil, NewEthermanMock(t), nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	ethermanMock := NewEthermanMock(t)
+	ethermanMock.On("TrustedSequencer").Return(common.Address{}, nil).Once()
+	_, err = New(validConfig(), batchCfg, pool.Config{}, nil, nil, ethermanMock, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	ethermanMock.On("TrustedSequencer").Return(common.HexToAddress("0x1"), nil).Once()
+	seq, err := New(validConfig(), batchCfg, pool.Config{}, nil, nil, ethermanMock, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x1"), seq.address)
+}
@@ -9,51 +9,62 @@ import (
 //TODO: create interface to access datastreamer functions
 
 func (f *finalizer) DSSendL2Block(batchNumber uint64, blockResponse *state.ProcessBlockResponse) error {
-	forkID := f.state.GetForkIDByBatchNumber(batchNumber)
-
 	// Send data to streamer
 	if f.streamServer != nil {
-		l2Block := state.DSL2Block{
-			BatchNumber:    batchNumber,
-			L2BlockNumber:  blockResponse.BlockNumber,
-			Timestamp:      int64(blockResponse.Timestamp),
-			GlobalExitRoot: blockResponse.BlockInfoRoot, //TODO: is it ok?
-			Coinbase:       f.sequencerAddress,
-			ForkID:         uint16(forkID),
-			BlockHash:      blockResponse.BlockHash,
-			StateRoot:      blockResponse.BlockHash, //TODO: in etrog the blockhash is the block root
+		l2FullBlock, err := f.dsL2FullBlock(batchNumber, blockResponse)
+		if err != nil {
+			return err
 		}
 
-		l2Transactions := []state.DSL2Transaction{}
+		f.dataToStream <- l2FullBlock
+	}
 
-		for _, txResponse := range blockResponse.TransactionResponses {
-			binaryTxData, err := txResponse.Tx.MarshalBinary()
-			if err != nil {
-				return err
-			}
+	return nil
+}
 
-			l2Transaction := state.DSL2Transaction{
-				L2BlockNumber:               blockResponse.BlockNumber,
-				EffectiveGasPricePercentage: uint8(txResponse.EffectivePercentage),
-				IsValid:                     1,
-				EncodedLength:               uint32(len(binaryTxData)),
-				Encoded:                     binaryTxData,
-			}
+// dsL2FullBlock builds the data stream L2 block with its txs from the L2 block response
+func (f *finalizer) dsL2FullBlock(batchNumber uint64, blockResponse *state.ProcessBlockResponse) (state.DSL2FullBlock, error) {
+	forkID := f.state.GetForkIDByBatchNumber(batchNumber)
 
-			l2Transactions = append(l2Transactions, l2Transaction)
+	l2Block := state.DSL2Block{
+		BatchNumber:    batchNumber,
+		L2BlockNumber:  blockResponse.BlockNumber,
+		Timestamp:      int64(blockResponse.Timestamp),
+		GlobalExitRoot: blockResponse.BlockInfoRoot, //TODO: is it ok?
+		Coinbase:       f.sequencerAddress,
+		ForkID:         uint16(forkID),
+		BlockHash:      blockResponse.BlockHash,
+		StateRoot:      blockResponse.BlockHash, //TODO: in etrog the blockhash is the block root
+	}
+
+	l2Transactions := []state.DSL2Transaction{}
+
+	for _, txResponse := range blockResponse.TransactionResponses {
+		binaryTxData, err := txResponse.Tx.MarshalBinary()
+		if err != nil {
+			return state.DSL2FullBlock{}, err
 		}
 
-		f.dataToStream <- state.DSL2FullBlock{
-			DSL2Block: l2Block,
-			Txs:       l2Transactions,
+		l2Transaction := state.DSL2Transaction{
+			L2BlockNumber:               blockResponse.BlockNumber,
+			EffectiveGasPricePercentage: uint8(txResponse.EffectivePercentage),
+			IsValid:                     1,
+			EncodedLength:               uint32(len(binaryTxData)),
+			Encoded:                     binaryTxData,
 		}
+
+		l2Transactions = append(l2Transactions, l2Transaction)
 	}
 
-	return nil
+	return state.DSL2FullBlock{
+		DSL2Block: l2Block,
+		Txs:       l2Transactions,
+	}, nil
 }
 
+// DSSendUpdateGER sends a GER update entry to the data stream, it is used for the batches that
+// change the GER without L2 blocks, so the data stream consumers get the new GER
 func (f *finalizer) DSSendUpdateGER(batchNumber uint64, timestamp int64, GER common.Hash, stateRoot common.Hash) {
-	//TODO: review this datastream event
 	updateGer := state.DSUpdateGER{
 		BatchNumber:    batchNumber,
 		Timestamp:      timestamp,
@@ -0,0 +1,122 @@
+package sequencer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+// DynamicConfig contains the finalizer timing parameters that can be changed without restarting the node
+type DynamicConfig struct {
+	// TimestampResolution is the resolution of the timestamp used to close a batch
+	TimestampResolution types.Duration `json:"TimestampResolution"`
+	// L2BlockTime is the resolution of the timestamp used to close a L2 block
+	L2BlockTime types.Duration `json:"L2BlockTime"`
+	// ForcedBatchDeadlineTimeout is the time the finalizer waits after receiving closing signal to process Forced Batches
+	ForcedBatchDeadlineTimeout types.Duration `json:"ForcedBatchDeadlineTimeout"`
+}
+
+// validate checks the dynamic config values using the same rules as the sequencer config
+func (c DynamicConfig) validate() error {
+	if c.TimestampResolution.Duration <= 0 {
+		return fmt.Errorf("%w: TimestampResolution must be greater than 0, got %v", ErrInvalidConfig, c.TimestampResolution.Duration)
+	}
+	if c.L2BlockTime.Duration <= 0 {
+		return fmt.Errorf("%w: L2BlockTime must be greater than 0, got %v", ErrInvalidConfig, c.L2BlockTime.Duration)
+	}
+	if c.ForcedBatchDeadlineTimeout.Duration < 0 {
+		return fmt.Errorf("%w: ForcedBatchDeadlineTimeout must not be negative, got %v", ErrInvalidConfig, c.ForcedBatchDeadlineTimeout.Duration)
+	}
+	return nil
+}
+
+// dynamicConfig holds the current DynamicConfig of the finalizer, it's safe for concurrent use
+type dynamicConfig struct {
+	value atomic.Value
+	// updateMux serializes the updates, as each one is built from the current value
+	updateMux sync.Mutex
+}
+
+// newDynamicConfig creates a dynamicConfig with the initial values of the finalizer config
+func newDynamicConfig(cfg FinalizerCfg) *dynamicConfig {
+	d := &dynamicConfig{}
+	d.value.Store(DynamicConfig{
+		TimestampResolution:        cfg.TimestampResolution,
+		L2BlockTime:                cfg.L2BlockTime,
+		ForcedBatchDeadlineTimeout: cfg.ForcedBatchDeadlineTimeout,
+	})
+	return d
+}
+
+// load returns the current dynamic config
+func (d *dynamicConfig) load() DynamicConfig {
+	return d.value.Load().(DynamicConfig)
+}
+
+// set changes the value of a single field, the value is a duration expressed in units (e.g. "3s")
+func (d *dynamicConfig) set(field string, value string) error {
+	d.updateMux.Lock()
+	defer d.updateMux.Unlock()
+
+	cfg := d.load()
+	var duration *types.Duration
+	switch field {
+	case "TimestampResolution":
+		duration = &cfg.TimestampResolution
+	case "L2BlockTime":
+		duration = &cfg.L2BlockTime
+	case "ForcedBatchDeadlineTimeout":
+		duration = &cfg.ForcedBatchDeadlineTimeout
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownDynamicConfigField, field)
+	}
+	err := duration.UnmarshalText([]byte(value))
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s value %s, err: %v", ErrInvalidConfig, field, value, err)
+	}
+
+	return d.store(cfg)
+}
+
+// applyPatch changes the fields present in the JSON patch, e.g. {"L2BlockTime": "5s"}, the
+// rest of the fields keep their current value
+func (d *dynamicConfig) applyPatch(patch []byte) error {
+	d.updateMux.Lock()
+	defer d.updateMux.Unlock()
+
+	cfg := d.load()
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&cfg)
+	if err != nil {
+		return fmt.Errorf("%w: invalid dynamic config patch, err: %v", ErrInvalidConfig, err)
+	}
+
+	return d.store(cfg)
+}
+
+// applyPatchFile reads the JSON patch from the file and applies it
+func (d *dynamicConfig) applyPatchFile(path string) error {
+	patch, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return d.applyPatch(patch)
+}
+
+func (d *dynamicConfig) store(cfg DynamicConfig) error {
+	err := cfg.validate()
+	if err != nil {
+		return err
+	}
+	d.value.Store(cfg)
+	log.Infof("dynamic config updated, TimestampResolution: %v, L2BlockTime: %v, ForcedBatchDeadlineTimeout: %v",
+		cfg.TimestampResolution.Duration, cfg.L2BlockTime.Duration, cfg.ForcedBatchDeadlineTimeout.Duration)
+	return nil
+}
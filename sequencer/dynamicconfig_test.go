@@ -0,0 +1,131 @@
+package sequencer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicConfigSet(t *testing.T) {
+	d := newDynamicConfig(FinalizerCfg{
+		TimestampResolution:        cfgTypes.NewDuration(10 * time.Second),
+		L2BlockTime:                cfgTypes.NewDuration(3 * time.Second),
+		ForcedBatchDeadlineTimeout: cfgTypes.NewDuration(60 * time.Second),
+	})
+
+	require.NoError(t, d.set("L2BlockTime", "5s"))
+	assert.Equal(t, DynamicConfig{
+		TimestampResolution:        cfgTypes.NewDuration(10 * time.Second),
+		L2BlockTime:                cfgTypes.NewDuration(5 * time.Second),
+		ForcedBatchDeadlineTimeout: cfgTypes.NewDuration(60 * time.Second),
+	}, d.load())
+
+	assert.ErrorIs(t, d.set("MaxTxLifetime", "5s"), ErrUnknownDynamicConfigField)
+	assert.ErrorIs(t, d.set("L2BlockTime", "five seconds"), ErrInvalidConfig)
+	assert.ErrorIs(t, d.set("TimestampResolution", "0s"), ErrInvalidConfig)
+	assert.ErrorIs(t, d.set("ForcedBatchDeadlineTimeout", "-1s"), ErrInvalidConfig)
+
+	// The invalid values are not stored
+	assert.Equal(t, cfgTypes.NewDuration(5*time.Second), d.load().L2BlockTime)
+	assert.Equal(t, cfgTypes.NewDuration(10*time.Second), d.load().TimestampResolution)
+
+	// The forced batch deadline can be disabled
+	require.NoError(t, d.set("ForcedBatchDeadlineTimeout", "0s"))
+	assert.Equal(t, cfgTypes.NewDuration(0), d.load().ForcedBatchDeadlineTimeout)
+}
+
+func TestDynamicConfigApplyPatch(t *testing.T) {
+	d := newDynamicConfig(FinalizerCfg{
+		TimestampResolution:        cfgTypes.NewDuration(10 * time.Second),
+		L2BlockTime:                cfgTypes.NewDuration(3 * time.Second),
+		ForcedBatchDeadlineTimeout: cfgTypes.NewDuration(60 * time.Second),
+	})
+
+	// Only the fields in the patch are changed
+	require.NoError(t, d.applyPatch([]byte(`{"TimestampResolution": "20s", "ForcedBatchDeadlineTimeout": "30s"}`)))
+	assert.Equal(t, DynamicConfig{
+		TimestampResolution:        cfgTypes.NewDuration(20 * time.Second),
+		L2BlockTime:                cfgTypes.NewDuration(3 * time.Second),
+		ForcedBatchDeadlineTimeout: cfgTypes.NewDuration(30 * time.Second),
+	}, d.load())
+
+	// Invalid patches are rejected as a whole
+	assert.ErrorIs(t, d.applyPatch([]byte(`{"L2BlockTime": "1s", "MaxTxLifetime": "1h"}`)), ErrInvalidConfig)
+	assert.ErrorIs(t, d.applyPatch([]byte(`{"L2BlockTime": "1s", "TimestampResolution": "0s"}`)), ErrInvalidConfig)
+	assert.ErrorIs(t, d.applyPatch([]byte(`{"L2BlockTime":`)), ErrInvalidConfig)
+	assert.Equal(t, cfgTypes.NewDuration(3*time.Second), d.load().L2BlockTime)
+}
+
+func TestFinalizerPicksUpDynamicConfig(t *testing.T) {
+	f = setupFinalizer(true)
+	now = testNow
+	defer func() {
+		now = time.Now
+	}()
+	f.dynamicCfg = newDynamicConfig(FinalizerCfg{
+		TimestampResolution:        cfgTypes.NewDuration(time.Hour),
+		L2BlockTime:                cfgTypes.NewDuration(3 * time.Second),
+		ForcedBatchDeadlineTimeout: cfgTypes.NewDuration(60 * time.Second),
+	})
+
+	// The wip batch was opened a minute ago, it's not closed with a 1h timestamp resolution
+	f.wipBatch.timestamp = time.Now().Add(-time.Minute)
+	f.wipBatch.countOfTxs = 1
+	f.nextForcedBatchDeadline = 0
+	assert.False(t, f.isDeadlineEncountered())
+	f.setNextForcedBatchDeadline()
+	assert.Equal(t, testNow().Unix()+60, f.nextForcedBatchDeadline)
+
+	// The new values are used without creating the finalizer again
+	require.NoError(t, f.dynamicCfg.set("TimestampResolution", "30s"))
+	require.NoError(t, f.dynamicCfg.set("ForcedBatchDeadlineTimeout", "10s"))
+	f.nextForcedBatchDeadline = 0
+	assert.True(t, f.isDeadlineEncountered())
+	f.setNextForcedBatchDeadline()
+	assert.Equal(t, testNow().Unix()+10, f.nextForcedBatchDeadline)
+}
+
+func TestReloadDynamicConfigOnSignal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dynamic.json")
+	f := &finalizer{dynamicCfg: newDynamicConfig(FinalizerCfg{
+		TimestampResolution: cfgTypes.NewDuration(10 * time.Second),
+		L2BlockTime:         cfgTypes.NewDuration(3 * time.Second),
+	})}
+	s := &Sequencer{cfg: Config{DynamicConfigFile: path}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal)
+	go s.reloadDynamicConfigOnSignal(ctx, signals, f)
+
+	// An invalid patch keeps the current values
+	require.NoError(t, os.WriteFile(path, []byte(`{"L2BlockTime": "0s"}`), 0600))
+	signals <- syscall.SIGHUP
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"L2BlockTime": "6s"}`), 0600))
+	signals <- syscall.SIGHUP
+	// The unbuffered send only returns once the previous reload has finished
+	signals <- syscall.SIGHUP
+	assert.Equal(t, cfgTypes.NewDuration(6*time.Second), f.dynamicCfg.load().L2BlockTime)
+	assert.Equal(t, cfgTypes.NewDuration(10*time.Second), f.dynamicCfg.load().TimestampResolution)
+}
+
+func TestSetDynamicConfigSequencerNotStarted(t *testing.T) {
+	s := &Sequencer{}
+	assert.ErrorIs(t, s.SetDynamicConfig("L2BlockTime", "5s"), ErrSequencerNotStarted)
+
+	f := &finalizer{dynamicCfg: newDynamicConfig(FinalizerCfg{
+		TimestampResolution: cfgTypes.NewDuration(10 * time.Second),
+		L2BlockTime:         cfgTypes.NewDuration(3 * time.Second),
+	})}
+	s.finalizer.Store(f)
+	require.NoError(t, s.SetDynamicConfig("L2BlockTime", "5s"))
+	assert.Equal(t, cfgTypes.NewDuration(5*time.Second), f.dynamicCfg.load().L2BlockTime)
+}
@@ -10,6 +10,9 @@ var (
 	// ErrDuplicatedNonce is returned when adding a new tx to the worker and there is an existing tx
 	// with the same nonce and higher gasPrice (in this case we keep the existing tx)
 	ErrDuplicatedNonce = errors.New("duplicated nonce")
+	// ErrAddressPoolFull happens when the address already has the max number of txs allowed in the worker,
+	// the tx is not failed, it stays pending in the pool to be added later
+	ErrAddressPoolFull = errors.New("address pool full")
 	// ErrReplacedTransaction is returned when an existing tx is replaced by a new tx with the same nonce and higher gasPrice
 	ErrReplacedTransaction = errors.New("replaced transaction")
 	// ErrGetBatchByNumber happens when we get an error trying to get a batch by number (GetBatchByNumber)
@@ -29,4 +32,10 @@ var (
 	ErrNoFittingTransaction = errors.New("no fit transaction")
 	// ErrTransactionsListEmpty happens when txSortedList is empty
 	ErrTransactionsListEmpty = errors.New("transactions list empty")
+	// ErrInvalidConfig happens when a sequencer config field has an invalid value
+	ErrInvalidConfig = errors.New("invalid sequencer config")
+	// ErrUnknownDynamicConfigField happens when setting a field that is not part of the dynamic config
+	ErrUnknownDynamicConfigField = errors.New("unknown dynamic config field")
+	// ErrForcedBatchPauseTooLong happens when the forced batch processing pause is not shorter than the L1 force batch timeout
+	ErrForcedBatchPauseTooLong = errors.New("forced batch processing pause is not shorter than the force batch timeout")
 )
@@ -45,6 +45,7 @@ var (
 // finalizer represents the finalizer component of the sequencer.
 type finalizer struct {
 	cfg              FinalizerCfg
+	dynamicCfg       *dynamicConfig
 	isSynced         func(ctx context.Context) bool
 	sequencerAddress common.Address
 	worker           workerInterface
@@ -52,16 +53,28 @@ type finalizer struct {
 	state            stateInterface
 	etherman         etherman
 	wipBatch         *Batch
+	wipBatchNumber   atomic.Uint64
 	wipL2Block       *L2Block
 	batchConstraints statePackage.BatchConstraintsCfg
 	haltFinalizer    atomic.Bool
 	haltError        error
+	// running is true while Start runs, it's false once the main loop exits, even by a panic
+	running atomic.Bool
+	// lastHeartbeat is the unix time in nanoseconds of the last iteration of the main loop
+	lastHeartbeat atomic.Int64
 	// closing signals
 	closingSignalCh ClosingSignalCh
 	// forced batches
+	forcedBatchState        forcedBatchStateInterface
 	nextForcedBatches       []statePackage.ForcedBatch
 	nextForcedBatchDeadline int64
-	nextForcedBatchesMux    *sync.Mutex
+	// nextForcedBatchesNotFromL1 are the forced batches added with addForcedBatchNotFromL1, they are numbered
+	// apart from the L1 forced batches, lastForcedBatchNotFromL1Number is the last number assigned
+	nextForcedBatchesNotFromL1     []statePackage.ForcedBatch
+	lastForcedBatchNotFromL1Number uint64
+	nextForcedBatchesMux           *sync.Mutex
+	// forcedBatchesPausedUntil is the end of the forced batch processing pause, zero when not paused
+	forcedBatchesPausedUntil time.Time
 	// L1InfoTree
 	lastL1InfoTreeValid bool
 	lastL1InfoTree      statePackage.L1InfoTreeExitRootStorageEntry
@@ -88,6 +101,9 @@ type finalizer struct {
 	// stream server
 	streamServer *datastreamer.StreamServer
 	dataToStream chan statePackage.DSL2FullBlock
+	// last GER sent to the data stream
+	currentGERHash    common.Hash
+	currentGERHashMux *sync.Mutex
 }
 
 // newFinalizer returns a new instance of Finalizer.
@@ -108,6 +124,7 @@ func newFinalizer(
 ) *finalizer {
 	f := finalizer{
 		cfg:              cfg,
+		dynamicCfg:       newDynamicConfig(cfg),
 		isSynced:         isSynced,
 		sequencerAddress: sequencerAddr,
 		worker:           worker,
@@ -118,6 +135,7 @@ func newFinalizer(
 		// closing signals
 		closingSignalCh: closingSignalCh,
 		// forced batches
+		forcedBatchState:        state,
 		nextForcedBatches:       make([]statePackage.ForcedBatch, 0),
 		nextForcedBatchDeadline: 0,
 		nextForcedBatchesMux:    new(sync.Mutex),
@@ -144,8 +162,9 @@ func newFinalizer(
 		lastPendingFlushID: 0,
 		pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
 		// stream server
-		streamServer: streamServer,
-		dataToStream: dataToStream,
+		streamServer:      streamServer,
+		dataToStream:      dataToStream,
+		currentGERHashMux: new(sync.Mutex),
 	}
 
 	f.haltFinalizer.Store(false)
@@ -155,11 +174,18 @@ func newFinalizer(
 
 // Start starts the finalizer.
 func (f *finalizer) Start(ctx context.Context) {
+	f.running.Store(true)
+	defer f.running.Store(false)
+	f.lastHeartbeat.Store(now().UnixNano())
+
 	// Init mockL1InfoRoot to a mock value since it must be different to {0,0,...,0}
 	for i := 0; i < len(mockL1InfoRoot); i++ {
 		mockL1InfoRoot[i] = byte(i)
 	}
 
+	// Restore the forced txs of the forced batches being processed before the restart
+	f.restoreForcedTxsToWorker(ctx)
+
 	// Update L1InfoRoot
 	go f.checkL1InfoTreeUpdate(ctx)
 
@@ -185,6 +211,29 @@ func (f *finalizer) Start(ctx context.Context) {
 	f.finalizeBatches(ctx)
 }
 
+// Running returns if the finalizer main loop is running and processing txs, a halted finalizer keeps
+// looping in halt but it's not running
+func (f *finalizer) Running() bool {
+	return f.running.Load() && !f.haltFinalizer.Load()
+}
+
+// Halted returns if the finalizer is halted due to a fatal error
+func (f *finalizer) Halted() bool {
+	return f.haltFinalizer.Load()
+}
+
+// Alive returns if the finalizer is not halted and its main loop completed an iteration within
+// the LivenessTimeout, the heartbeat stops when the main loop exits or gets stuck
+func (f *finalizer) Alive() bool {
+	if f.Halted() {
+		return false
+	}
+	if f.cfg.LivenessTimeout.Duration <= 0 {
+		return true
+	}
+	return now().Sub(time.Unix(0, f.lastHeartbeat.Load())) < f.cfg.LivenessTimeout.Duration
+}
+
 // updateProverIdAndFlushId updates the prover id and flush id
 func (f *finalizer) updateProverIdAndFlushId(ctx context.Context) {
 	for {
@@ -281,6 +330,16 @@ func (f *finalizer) listenForClosingSignals(ctx context.Context) {
 				f.setNextForcedBatchDeadline()
 			}
 			f.nextForcedBatchesMux.Unlock()
+		// L1Reorg ch
+		case l1Reorg := <-f.closingSignalCh.L1ReorgCh:
+			log.Debugf("finalizer received L1 reorg event, first reorged L1 block number: %v", l1Reorg.FirstReorgedBlockNumber)
+
+			f.nextForcedBatchesMux.Lock()
+			f.nextForcedBatches = f.flushReorgedForcedBatches(f.nextForcedBatches, l1Reorg.FirstReorgedBlockNumber)
+			if f.pendingForcedBatchCount() == 0 {
+				f.nextForcedBatchDeadline = 0
+			}
+			f.nextForcedBatchesMux.Unlock()
 		// L2Reorg ch
 		case <-f.closingSignalCh.L2ReorgCh:
 			log.Debug("finalizer received L2 reorg event")
@@ -300,18 +359,51 @@ func (f *finalizer) updateLastPendingFlushID(newFlushID uint64) {
 	}
 }
 
+// getSequencerState returns a snapshot of the finalizer internal state
+func (f *finalizer) getSequencerState() statePackage.SequencerState {
+	seqState := statePackage.SequencerState{
+		BatchNumber:      f.wipBatchNumber.Load(),
+		SequencerAddress: f.sequencerAddress,
+		Halted:           f.haltFinalizer.Load(),
+	}
+
+	f.nextForcedBatchesMux.Lock()
+	seqState.PendingForcedBatches = f.pendingForcedBatchCount()
+	f.nextForcedBatchesMux.Unlock()
+
+	f.storedFlushIDCond.L.Lock()
+	seqState.StoredFlushID = f.storedFlushID
+	f.storedFlushIDCond.L.Unlock()
+
+	f.pendingFlushIDCond.L.Lock()
+	seqState.LastPendingFlushID = f.lastPendingFlushID
+	f.pendingFlushIDCond.L.Unlock()
+
+	f.currentGERHashMux.Lock()
+	seqState.CurrentGERHash = f.currentGERHash
+	f.currentGERHashMux.Unlock()
+
+	// haltError is set before haltFinalizer is stored, so it can be read once the finalizer is halted
+	if seqState.Halted && f.haltError != nil {
+		seqState.HaltError = f.haltError.Error()
+	}
+
+	return seqState
+}
+
 // finalizeBatches runs the endless loop for processing transactions finalizing batches.
 func (f *finalizer) finalizeBatches(ctx context.Context) {
 	log.Debug("finalizer init loop")
 	showNotFoundTxLog := true // used to log debug only the first message when there is no txs to process
 	for {
 		start := now()
+		f.lastHeartbeat.Store(start.UnixNano())
 		if f.wipBatch.batchNumber == f.cfg.StopSequencerOnBatchNum {
 			f.halt(ctx, fmt.Errorf("finalizer reached stop sequencer batch number: %v", f.cfg.StopSequencerOnBatchNum))
 		}
 
 		// We have reached the L2 block time, we need to close the current L2 block and open a new one
-		if !f.wipL2Block.timestamp.Add(f.cfg.L2BlockTime.Duration).After(time.Now()) {
+		if !f.wipL2Block.timestamp.Add(f.dynamicCfg.load().L2BlockTime.Duration).After(time.Now()) {
 			f.finalizeL2Block(ctx)
 		}
 
@@ -392,6 +484,20 @@ func (f *finalizer) sortForcedBatches(fb []state.ForcedBatch) []state.ForcedBatc
 	return fb
 }
 
+// flushReorgedForcedBatches removes the forced batches included in L1 blocks equal or greater than firstReorgedBlockNumber
+func (f *finalizer) flushReorgedForcedBatches(fb []state.ForcedBatch, firstReorgedBlockNumber uint64) []state.ForcedBatch {
+	validForcedBatches := make([]state.ForcedBatch, 0, len(fb))
+	for _, forcedBatch := range fb {
+		if forcedBatch.BlockNumber >= firstReorgedBlockNumber {
+			log.Infof("flushing forced batch %d included in reorged L1 block %d", forcedBatch.ForcedBatchNumber, forcedBatch.BlockNumber)
+			continue
+		}
+		validForcedBatches = append(validForcedBatches, forcedBatch)
+	}
+
+	return validForcedBatches
+}
+
 // checkIfProverRestarted checks if the proverID changed
 func (f *finalizer) checkIfProverRestarted(proverID string) {
 	if f.proverID != "" && f.proverID != proverID {
@@ -654,6 +760,7 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 	f.updateLastPendingFlushID(result.FlushID)
 
 	f.wipBatch.countOfTxs++
+	f.wipBatch.fees.Add(result)
 
 	f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, result)
 
@@ -705,6 +812,13 @@ func (f *finalizer) compareTxEffectiveGasPrice(ctx context.Context, tx *TxTracke
 }
 
 func (f *finalizer) updateWorkerAfterSuccessfulProcessing(ctx context.Context, txHash common.Hash, txFrom common.Address, isForced bool, result *state.ProcessBatchResponse) {
+	// Don't update the worker partially when stopping, it's rebuilt from the pool and the state after the restart
+	if err := ctx.Err(); err != nil {
+		log.Warnf("worker not updated after processing tx: %s, from: %s, forced: %t, the worker update is incomplete, err: %v",
+			txHash.String(), txFrom.Hex(), isForced, err)
+		return
+	}
+
 	// Delete the transaction from the worker
 	if isForced {
 		f.worker.DeleteForcedTx(txHash, txFrom)
@@ -807,7 +921,7 @@ func (f *finalizer) isDeadlineEncountered() bool {
 	}
 	//TODO: rename f.cfg.TimestampResolution to BatchTime or BatchMaxTime
 	// Timestamp resolution deadline
-	if !f.wipBatch.isEmpty() && f.wipBatch.timestamp.Add(f.cfg.TimestampResolution.Duration).Before(time.Now()) {
+	if !f.wipBatch.isEmpty() && f.wipBatch.timestamp.Add(f.dynamicCfg.load().TimestampResolution.Duration).Before(time.Now()) {
 		log.Infof("closing batch %d, because of timestamp resolution.", f.wipBatch.batchNumber)
 		f.wipBatch.closingReason = state.TimeoutResolutionDeadlineClosingReason
 		return true
@@ -817,7 +931,7 @@ func (f *finalizer) isDeadlineEncountered() bool {
 
 // setNextForcedBatchDeadline sets the next forced batch deadline
 func (f *finalizer) setNextForcedBatchDeadline() {
-	f.nextForcedBatchDeadline = now().Unix() + int64(f.cfg.ForcedBatchDeadlineTimeout.Duration.Seconds())
+	f.nextForcedBatchDeadline = now().Unix() + int64(f.dynamicCfg.load().ForcedBatchDeadlineTimeout.Duration.Seconds())
 }
 
 // halt halts the finalizer
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
 	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
@@ -17,6 +19,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -110,7 +113,6 @@ var (
 	}
 	testErrStr              = "some err"
 	testErr                 = fmt.Errorf(testErrStr)
-	openBatchError          = fmt.Errorf("failed to open new batch, err: %w", testErr)
 	cumulativeGasErr        = state.GetZKCounterError("CumulativeGasUsed")
 	testBatchL2DataAsString = "0xee80843b9aca00830186a0944d5cf5032b2a844602278b01199ed191a86c93ff88016345785d8a0000808203e980801186622d03b6b8da7cf111d1ccba5bb185c56deae6a322cebc6dda0556f3cb9700910c26408b64b51c5da36ba2f38ef55ba1cee719d5a6c012259687999074321bff"
 	decodedBatchL2Data      []byte
@@ -879,13 +881,19 @@ func TestFinalizer_openWIPBatch(t *testing.T) {
 	}()
 	batchNum := f.wipBatch.batchNumber + 1
 	expectedWipBatch := &Batch{
-		batchNumber:        batchNum,
-		coinbase:           f.sequencerAddress,
-		initialStateRoot:   oldHash,
-		imStateRoot:        oldHash,
-		timestamp:          now(),
-		globalExitRoot:     oldHash,
-		remainingResources: getMaxRemainingResources(f.batchConstraints),
+		batchNumber:         batchNum,
+		coinbase:            f.sequencerAddress,
+		initialStateRoot:    oldHash,
+		imStateRoot:         oldHash,
+		finalStateRoot:      oldHash,
+		initialAccInputHash: oldHash,
+		imAccInputHash:      oldHash,
+		finalAccInputHash:   oldHash,
+		timestamp:           now(),
+		globalExitRoot:      oldHash,
+		localExitRoot:       oldHash,
+		remainingResources:  getMaxRemainingResources(f.batchConstraints),
+		closingReason:       state.EmptyClosingReason,
 	}
 	testCases := []struct {
 		name         string
@@ -906,22 +914,22 @@ func TestFinalizer_openWIPBatch(t *testing.T) {
 			expectedErr: fmt.Errorf("failed to begin state transaction to open batch, err: %w", testErr),
 		},
 		{
-			name:         "Error OpenBatch",
+			name:         "Error OpenWIPBatch",
 			openBatchErr: testErr,
-			expectedErr:  fmt.Errorf("failed to open new batch, err: %w", testErr),
+			expectedErr:  fmt.Errorf("failed to open new wip batch. Error: %w", testErr),
 		},
 		{
 			name:        "Error Commit",
 			commitErr:   testErr,
-			expectedErr: fmt.Errorf("failed to commit database transaction for opening a batch, err: %w", testErr),
+			expectedErr: fmt.Errorf("failed to commit database transaction for opening a wip batch. Error: %w", testErr),
 		},
 		{
 			name:         "Error Rollback",
 			openBatchErr: testErr,
 			rollbackErr:  testErr,
 			expectedErr: fmt.Errorf(
-				"failed to rollback dbTx: %s. Rollback err: %w",
-				testErr.Error(), openBatchError,
+				"failed to rollback dbTx: %s. Error: %w",
+				testErr.Error(), testErr,
 			),
 		},
 	}
@@ -931,7 +939,9 @@ func TestFinalizer_openWIPBatch(t *testing.T) {
 			// arrange
 			stateMock.On("BeginStateTransaction", ctx).Return(dbTxMock, tc.beginTxErr).Once()
 			if tc.beginTxErr == nil {
-				stateMock.On("OpenBatch", ctx, mock.Anything, dbTxMock).Return(tc.openBatchErr).Once()
+				stateMock.On("OpenWIPBatch", ctx, mock.MatchedBy(func(batch state.Batch) bool {
+					return batch.BatchNumber == batchNum && batch.StateRoot == oldHash
+				}), dbTxMock).Return(tc.openBatchErr).Once()
 			}
 
 			if tc.expectedErr != nil && (tc.rollbackErr != nil || tc.openBatchErr != nil) {
@@ -1701,6 +1711,7 @@ func TestFinalizer_handleTransactionError(t *testing.T) {
 }*/
 
 func TestFinalizer_updateWorkerAfterSuccessfulProcessing(t *testing.T) {
+	ctx = context.Background()
 	testCases := []struct {
 		name                  string
 		txTracker             *TxTracker
@@ -1792,6 +1803,23 @@ func TestFinalizer_updateWorkerAfterSuccessfulProcessing(t *testing.T) {
 	}
 }
 
+func TestFinalizer_updateWorkerAfterSuccessfulProcessingCancelled(t *testing.T) {
+	finalizerInstance := setupFinalizer(false)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	finalizerInstance.updateWorkerAfterSuccessfulProcessing(cancelledCtx, oldHash, senderAddr, false, &state.ProcessBatchResponse{
+		ReadWriteAddresses: map[common.Address]*state.InfoReadWrite{
+			senderAddr: {Address: senderAddr, Nonce: &nonce1},
+		},
+	})
+
+	// The worker is not updated
+	workerMock.AssertNotCalled(t, "DeleteTx", mock.Anything, mock.Anything)
+	workerMock.AssertNotCalled(t, "UpdateAfterSingleSuccessfulTxExecution", mock.Anything, mock.Anything)
+	poolMock.AssertNotCalled(t, "UpdateTxStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestFinalizer_reprocessFullBatch(t *testing.T) {
 	successfulResult := &state.ProcessBatchResponse{
 		NewStateRoot: newHash,
@@ -2262,6 +2290,324 @@ func Test_sortForcedBatches(t *testing.T) {
 	}
 }
 
+func Test_flushReorgedForcedBatches(t *testing.T) {
+	f = setupFinalizer(false)
+
+	testCases := []struct {
+		name                    string
+		input                   []state.ForcedBatch
+		firstReorgedBlockNumber uint64
+		expected                []state.ForcedBatch
+	}{
+		{
+			name:                    "Empty slice",
+			input:                   []state.ForcedBatch{},
+			firstReorgedBlockNumber: 10,
+			expected:                []state.ForcedBatch{},
+		},
+		{
+			name:                    "No reorged forced batches",
+			input:                   []state.ForcedBatch{{ForcedBatchNumber: 1, BlockNumber: 5}, {ForcedBatchNumber: 2, BlockNumber: 9}},
+			firstReorgedBlockNumber: 10,
+			expected:                []state.ForcedBatch{{ForcedBatchNumber: 1, BlockNumber: 5}, {ForcedBatchNumber: 2, BlockNumber: 9}},
+		},
+		{
+			name:                    "Some reorged forced batches",
+			input:                   []state.ForcedBatch{{ForcedBatchNumber: 1, BlockNumber: 5}, {ForcedBatchNumber: 2, BlockNumber: 10}, {ForcedBatchNumber: 3, BlockNumber: 11}},
+			firstReorgedBlockNumber: 10,
+			expected:                []state.ForcedBatch{{ForcedBatchNumber: 1, BlockNumber: 5}},
+		},
+		{
+			name:                    "All reorged forced batches",
+			input:                   []state.ForcedBatch{{ForcedBatchNumber: 1, BlockNumber: 10}, {ForcedBatchNumber: 2, BlockNumber: 11}},
+			firstReorgedBlockNumber: 10,
+			expected:                []state.ForcedBatch{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := f.flushReorgedForcedBatches(testCase.input, testCase.firstReorgedBlockNumber)
+			assert.Equal(t, testCase.expected, result, "They should be equal")
+		})
+	}
+}
+
+func Test_addForcedBatchNotFromL1(t *testing.T) {
+	rawTxsData := []byte{0x1, 0x2}
+	forcedAt := time.Unix(1700000000, 0)
+
+	f = setupFinalizer(false)
+	// The L1 forced batches pending to be processed don't change the numbers of the forced batches not from L1
+	f.nextForcedBatches = []state.ForcedBatch{{ForcedBatchNumber: 4}, {ForcedBatchNumber: 5}}
+
+	for expectedForcedBatchNum := uint64(1); expectedForcedBatchNum <= 2; expectedForcedBatchNum++ {
+		forcedBatchNum := f.addForcedBatchNotFromL1(rawTxsData, oldHash, forcedAt)
+		assert.Equal(t, expectedForcedBatchNum, forcedBatchNum)
+
+		lastForcedBatch := f.nextForcedBatchesNotFromL1[len(f.nextForcedBatchesNotFromL1)-1]
+		assert.Equal(t, expectedForcedBatchNum, lastForcedBatch.ForcedBatchNumber)
+		assert.Equal(t, oldHash, lastForcedBatch.GlobalExitRoot)
+		assert.Equal(t, rawTxsData, lastForcedBatch.RawTxsData)
+		assert.Equal(t, forcedAt, lastForcedBatch.ForcedAt)
+	}
+	assert.Equal(t, []state.ForcedBatch{{ForcedBatchNumber: 4}, {ForcedBatchNumber: 5}}, f.nextForcedBatches)
+	assert.Equal(t, 4, f.pendingForcedBatchCount())
+	assert.NotZero(t, f.nextForcedBatchDeadline)
+}
+
+func Test_processForcedBatchSendsUpdateGER(t *testing.T) {
+	f = setupFinalizer(false)
+	ctx = context.Background()
+
+	streamServer, err := datastreamer.NewServer(0, state.StreamTypeSequencer, filepath.Join(t.TempDir(), "datastream.bin"), nil)
+	require.NoError(t, err)
+	require.NoError(t, streamServer.Start())
+	f.streamServer = streamServer
+	f.currentGERHash = oldHash
+
+	forcedBatch := state.ForcedBatch{
+		BlockNumber:       1,
+		ForcedBatchNumber: 1,
+		Sequencer:         seqAddr,
+		GlobalExitRoot:    newHash,
+		RawTxsData:        nil,
+		ForcedAt:          time.Unix(1700000000, 0),
+	}
+	batchResponse := &state.ProcessBatchResponse{
+		NewStateRoot:     newHash,
+		NewAccInputHash:  newHash,
+		NewLocalExitRoot: newHash,
+	}
+
+	testCases := []struct {
+		name                 string
+		lastBatchNumber      uint64
+		expectedTotalEntries uint64
+	}{
+		{
+			name:                 "Forced batch with new GER",
+			lastBatchNumber:      1,
+			expectedTotalEntries: 1,
+		},
+		{
+			name:                 "Forced batch with same GER",
+			lastBatchNumber:      2,
+			expectedTotalEntries: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stateMock.On("BeginStateTransaction", ctx).Return(dbTxMock, nilErr).Once()
+			l1ParentHeader := &types.Header{Number: big.NewInt(int64(forcedBatch.BlockNumber - 1))}
+			stateMock.On("GetL1BlockByNumber", ctx, forcedBatch.BlockNumber, nil).Return(&state.Block{BlockNumber: forcedBatch.BlockNumber, ParentHash: l1ParentHeader.Hash()}, nilErr).Once()
+			ethermanMock.On("HeaderByNumber", mock.Anything, l1ParentHeader.Number).Return(l1ParentHeader, nilErr).Once()
+			stateMock.On("OpenBatch", ctx, mock.Anything, dbTxMock).Return(nilErr).Once()
+			stateMock.On("GetForkIDByBatchNumber", mock.Anything).Return(uint64(7))
+			stateMock.On("ProcessBatchV2", ctx, mock.Anything, true).Return(batchResponse, nilErr).Once()
+			stateMock.On("CloseBatch", ctx, mock.Anything, dbTxMock).Return(nilErr).Once()
+			dbTxMock.On("Commit", ctx).Return(nilErr).Once()
+
+			newBatchNumber, _, _, err := f.processForcedBatch(ctx, forcedBatch, true, tc.lastBatchNumber, oldHash, oldHash)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lastBatchNumber+1, newBatchNumber)
+			assert.Equal(t, forcedBatch.GlobalExitRoot, f.currentGERHash)
+			assert.Equal(t, tc.expectedTotalEntries, f.streamServer.GetHeader().TotalEntries)
+
+			entry, err := f.streamServer.GetEntry(0)
+			require.NoError(t, err)
+			assert.Equal(t, state.EntryTypeUpdateGER, entry.Type)
+			updateGER := state.DSUpdateGER{}.Decode(entry.Data)
+			assert.Equal(t, uint64(2), updateGER.BatchNumber)
+			assert.Equal(t, forcedBatch.GlobalExitRoot, updateGER.GlobalExitRoot)
+			assert.Equal(t, batchResponse.NewStateRoot, updateGER.StateRoot)
+
+			stateMock.AssertExpectations(t)
+			dbTxMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_checkAndSendUpdateGER(t *testing.T) {
+	f = setupFinalizer(false)
+
+	streamServer, err := datastreamer.NewServer(0, state.StreamTypeSequencer, filepath.Join(t.TempDir(), "datastream.bin"), nil)
+	require.NoError(t, err)
+	require.NoError(t, streamServer.Start())
+	f.streamServer = streamServer
+	f.currentGERHash = oldHash
+	stateMock.On("GetForkIDByBatchNumber", mock.Anything).Return(uint64(7))
+
+	testCases := []struct {
+		name                 string
+		batch                *Batch
+		expectedCurrentGER   common.Hash
+		expectedTotalEntries uint64
+	}{
+		{
+			name:                 "Non empty batch with new GER",
+			batch:                &Batch{batchNumber: 1, globalExitRoot: newHash, countOfTxs: 1, finalStateRoot: newHash, timestamp: time.Unix(1700000000, 0)},
+			expectedCurrentGER:   newHash,
+			expectedTotalEntries: 0,
+		},
+		{
+			name:                 "Empty batch with same GER",
+			batch:                &Batch{batchNumber: 2, globalExitRoot: newHash, finalStateRoot: newHash, timestamp: time.Unix(1700000000, 0)},
+			expectedCurrentGER:   newHash,
+			expectedTotalEntries: 0,
+		},
+		{
+			name:                 "Empty batch without GER update",
+			batch:                &Batch{batchNumber: 3, globalExitRoot: state.ZeroHash, finalStateRoot: newHash, timestamp: time.Unix(1700000000, 0)},
+			expectedCurrentGER:   newHash,
+			expectedTotalEntries: 0,
+		},
+		{
+			name:                 "Empty batch with new GER",
+			batch:                &Batch{batchNumber: 4, globalExitRoot: oldHash, finalStateRoot: newHash, timestamp: time.Unix(1700000000, 0)},
+			expectedCurrentGER:   oldHash,
+			expectedTotalEntries: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f.checkAndSendUpdateGER(tc.batch)
+			assert.Equal(t, tc.expectedCurrentGER, f.currentGERHash)
+			assert.Equal(t, tc.expectedTotalEntries, f.streamServer.GetHeader().TotalEntries)
+		})
+	}
+
+	entry, err := f.streamServer.GetEntry(0)
+	require.NoError(t, err)
+	assert.Equal(t, state.EntryTypeUpdateGER, entry.Type)
+	updateGER := state.DSUpdateGER{}.Decode(entry.Data)
+	assert.Equal(t, uint64(4), updateGER.BatchNumber)
+	assert.Equal(t, int64(1700000000), updateGER.Timestamp)
+	assert.Equal(t, oldHash, updateGER.GlobalExitRoot)
+	assert.Equal(t, newHash, updateGER.StateRoot)
+	assert.Equal(t, uint16(7), updateGER.ForkID)
+}
+
+func Test_processForcedBatchesGapFilling(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		lastTrustedForcedBatchNum uint64
+		nextForcedBatches         []uint64
+		missingForcedBatches      []uint64
+		getForcedBatchErr         error
+		expectedProcessed         []uint64
+	}{
+		{
+			name:                      "Single gap",
+			lastTrustedForcedBatchNum: 0,
+			nextForcedBatches:         []uint64{1, 3},
+			missingForcedBatches:      []uint64{2},
+			expectedProcessed:         []uint64{1, 2, 3},
+		},
+		{
+			name:                      "Multiple gaps",
+			lastTrustedForcedBatchNum: 0,
+			nextForcedBatches:         []uint64{2, 5},
+			missingForcedBatches:      []uint64{1, 3, 4},
+			expectedProcessed:         []uint64{1, 2, 3, 4, 5},
+		},
+		{
+			name:                      "Gap at the start",
+			lastTrustedForcedBatchNum: 2,
+			nextForcedBatches:         []uint64{5, 6},
+			missingForcedBatches:      []uint64{3, 4},
+			expectedProcessed:         []uint64{3, 4, 5, 6},
+		},
+		{
+			name:                      "GetForcedBatch error during gap fill",
+			lastTrustedForcedBatchNum: 0,
+			nextForcedBatches:         []uint64{1, 3},
+			missingForcedBatches:      []uint64{2},
+			getForcedBatchErr:         testErr,
+			expectedProcessed:         []uint64{1},
+		},
+		{
+			name:                      "Missing forced batch not synced yet",
+			lastTrustedForcedBatchNum: 0,
+			nextForcedBatches:         []uint64{1, 3},
+			missingForcedBatches:      []uint64{2},
+			getForcedBatchErr:         state.ErrNotFound,
+			expectedProcessed:         []uint64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f = setupFinalizer(false)
+			lastBatchNumber := uint64(10)
+			batchResponse := &state.ProcessBatchResponse{
+				NewStateRoot:     newHash,
+				NewAccInputHash:  newHash,
+				NewLocalExitRoot: newHash,
+			}
+
+			for _, forcedBatchNum := range tc.nextForcedBatches {
+				f.nextForcedBatches = append(f.nextForcedBatches, state.ForcedBatch{ForcedBatchNumber: forcedBatchNum, GlobalExitRoot: oldHash})
+			}
+
+			stateMock.On("GetLastTrustedForcedBatchNumber", ctx, nil).Return(tc.lastTrustedForcedBatchNum, nilErr).Once()
+			for _, forcedBatchNum := range tc.missingForcedBatches {
+				if tc.getForcedBatchErr != nil {
+					stateMock.On("GetForcedBatch", ctx, forcedBatchNum, nil).Return(nil, tc.getForcedBatchErr).Once()
+					continue
+				}
+				stateMock.On("GetForcedBatch", ctx, forcedBatchNum, nil).Return(&state.ForcedBatch{ForcedBatchNumber: forcedBatchNum, GlobalExitRoot: oldHash}, nilErr).Once()
+			}
+			stateMock.On("GetForkIDByBatchNumber", mock.Anything).Return(uint64(7))
+			for i, forcedBatchNum := range tc.expectedProcessed {
+				expectedBatchNumber := lastBatchNumber + uint64(i) + 1
+				expectedForcedBatchNum := forcedBatchNum
+				stateMock.On("BeginStateTransaction", ctx).Return(dbTxMock, nilErr).Once()
+				stateMock.On("GetL1BlockByNumber", ctx, mock.Anything, nil).Return(&state.Block{}, nilErr).Once()
+				stateMock.On("OpenBatch", ctx, mock.MatchedBy(func(processingCtx state.ProcessingContext) bool {
+					return processingCtx.BatchNumber == expectedBatchNumber && *processingCtx.ForcedBatchNum == expectedForcedBatchNum
+				}), dbTxMock).Return(nilErr).Once()
+				stateMock.On("ProcessBatchV2", ctx, mock.Anything, true).Return(batchResponse, nilErr).Once()
+				stateMock.On("CloseBatch", ctx, mock.Anything, dbTxMock).Return(nilErr).Once()
+				dbTxMock.On("Commit", ctx).Return(nilErr).Once()
+			}
+
+			newLastBatchNumber, _, _ := f.processForcedBatches(ctx, lastBatchNumber, oldHash, oldHash)
+
+			assert.Equal(t, lastBatchNumber+uint64(len(tc.expectedProcessed)), newLastBatchNumber)
+			stateMock.AssertExpectations(t)
+			dbTxMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_getSequencerState(t *testing.T) {
+	f = setupFinalizer(true)
+	f.wipBatchNumber.Store(f.wipBatch.batchNumber)
+	f.nextForcedBatches = []state.ForcedBatch{{ForcedBatchNumber: 1}, {ForcedBatchNumber: 2}}
+	f.storedFlushID = 5
+	f.lastPendingFlushID = 7
+	f.currentGERHash = newHash
+
+	expected := state.SequencerState{
+		BatchNumber:          1,
+		PendingForcedBatches: 2,
+		StoredFlushID:        5,
+		LastPendingFlushID:   7,
+		CurrentGERHash:       newHash,
+		SequencerAddress:     seqAddr,
+	}
+	assert.Equal(t, expected, f.getSequencerState())
+
+	f.haltError = errors.New("executor error")
+	f.haltFinalizer.Store(true)
+	expected.Halted = true
+	expected.HaltError = "executor error"
+	assert.Equal(t, expected, f.getSequencerState())
+}
+
 func setupFinalizer(withWipBatch bool) *finalizer {
 	wipBatch := new(Batch)
 	poolMock = new(PoolMock)
@@ -2292,12 +2638,15 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 	eventLog := event.NewEventLog(event.Config{}, eventStorage)
 	return &finalizer{
 		cfg:                        cfg,
+		dynamicCfg:                 newDynamicConfig(cfg),
 		closingSignalCh:            closingSignalCh,
 		isSynced:                   isSynced,
 		sequencerAddress:           seqAddr,
 		worker:                     workerMock,
 		pool:                       poolMock,
 		state:                      stateMock,
+		etherman:                   ethermanMock,
+		forcedBatchState:           stateMock,
 		wipBatch:                   wipBatch,
 		batchConstraints:           bc,
 		nextForcedBatches:          make([]state.ForcedBatch, 0),
@@ -2315,5 +2664,53 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 		proverID:                   "",
 		lastPendingFlushID:         0,
 		pendingFlushIDCond:         sync.NewCond(new(sync.Mutex)),
+		currentGERHashMux:          new(sync.Mutex),
 	}
 }
+
+func TestSequencerRunning(t *testing.T) {
+	s := &Sequencer{}
+	assert.False(t, s.Running())
+
+	f := &finalizer{}
+	s.finalizer.Store(f)
+	assert.False(t, s.Running())
+
+	f.running.Store(true)
+	assert.True(t, s.Running())
+	assert.False(t, s.Halted())
+
+	// A halted finalizer loops forever in halt, it's not running
+	f.haltFinalizer.Store(true)
+	assert.False(t, s.Running())
+	assert.True(t, s.Halted())
+
+	f.haltFinalizer.Store(false)
+	f.running.Store(false)
+	assert.False(t, s.Running())
+}
+
+func TestSequencerAlive(t *testing.T) {
+	now = testNow
+	defer func() {
+		now = time.Now
+	}()
+
+	s := &Sequencer{}
+	assert.True(t, s.Alive())
+
+	f := &finalizer{cfg: FinalizerCfg{LivenessTimeout: cfgTypes.NewDuration(time.Minute)}}
+	s.finalizer.Store(f)
+	f.lastHeartbeat.Store(testNow().UnixNano())
+	assert.True(t, s.Alive())
+
+	// The main loop stopped sending heartbeats for longer than the liveness timeout
+	f.lastHeartbeat.Store(testNow().Add(-time.Minute).UnixNano())
+	assert.False(t, s.Alive())
+
+	// Without liveness timeout only a halted finalizer is not alive
+	f.cfg.LivenessTimeout = cfgTypes.NewDuration(0)
+	assert.True(t, s.Alive())
+	f.haltFinalizer.Store(true)
+	assert.False(t, s.Alive())
+}
@@ -2,23 +2,50 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v4"
 )
 
+const (
+	// checkForcedBlockHashL1Timeout bounds the L1 request of the forced block hash check, the check is only logged
+	// so processing the forced batch doesn't wait for a slow L1 node
+	checkForcedBlockHashL1Timeout = 5 * time.Second
+)
+
 // processForcedBatches processes all the forced batches that are pending to be processed
 func (f *finalizer) processForcedBatches(ctx context.Context, lastBatchNumber uint64, stateRoot, accInputHash common.Hash) (newLastBatchNumber uint64, newStateRoot, newAccInputHash common.Hash) {
 	f.nextForcedBatchesMux.Lock()
 	defer f.nextForcedBatchesMux.Unlock()
+
+	if f.isForcedBatchProcessingPaused() {
+		log.Warnf("[processForcedBatches] forced batch processing paused until %v, %d forced batches pending to be processed",
+			f.forcedBatchesPausedUntil, f.pendingForcedBatchCount())
+		// The deadline is moved to the end of the pause so the wip batches are not closed meanwhile to process the forced batches
+		f.nextForcedBatchDeadline = f.forcedBatchesPausedUntil.Unix()
+		return lastBatchNumber, stateRoot, accInputHash
+	}
+
 	f.nextForcedBatchDeadline = 0
 
-	lastForcedBatchNumber, err := f.state.GetLastTrustedForcedBatchNumber(ctx, nil)
+	// If some forced batches could not be processed we set a new deadline to process them again soon
+	defer func() {
+		if f.pendingForcedBatchCount() > 0 {
+			f.nextForcedBatchDeadline = now().Add(f.cfg.MinForcedBatchProcessingInterval.Duration).Unix()
+		}
+	}()
+
+	lastForcedBatchNumber, err := f.forcedBatchState.GetLastTrustedForcedBatchNumber(ctx, nil)
 	if err != nil {
 		log.Errorf("[processForcedBatches] failed to get last trusted forced batch number. Error: %w", err)
 		return lastBatchNumber, stateRoot, accInputHash
@@ -26,39 +53,178 @@ func (f *finalizer) processForcedBatches(ctx context.Context, lastBatchNumber ui
 	nextForcedBatchNumber := lastForcedBatchNumber + 1
 
 	for _, forcedBatch := range f.nextForcedBatches {
-		forcedBatchToProcess := forcedBatch
 		// Skip already processed forced batches
-		if forcedBatchToProcess.ForcedBatchNumber < nextForcedBatchNumber {
+		if forcedBatch.ForcedBatchNumber < nextForcedBatchNumber {
 			continue
-		} else if forcedBatch.ForcedBatchNumber > nextForcedBatchNumber {
-			// We have a gap in the f.nextForcedBatches slice, we get the missing forced batch from the state
-			missingForcedBatch, err := f.state.GetForcedBatch(ctx, nextForcedBatchNumber, nil)
-			if err != nil {
-				log.Errorf("[processForcedBatches] failed to get missing forced batch %d. Error: %w", nextForcedBatchNumber, err)
+		}
+
+		// If we have a gap in the f.nextForcedBatches slice, we get the missing forced batches from the state
+		forcedBatchesToProcess := []state.ForcedBatch{}
+		for missingForcedBatchNumber := nextForcedBatchNumber; missingForcedBatchNumber < forcedBatch.ForcedBatchNumber; missingForcedBatchNumber++ {
+			missingForcedBatch, err := f.forcedBatchState.GetForcedBatch(ctx, missingForcedBatchNumber, nil)
+			if errors.Is(err, state.ErrNotFound) {
+				// The synchronizer has not stored the missing forced batch yet, we wait for the next deadline to process it
+				log.Infof("[processForcedBatches] missing forced batch %d not synced yet, waiting to process it", missingForcedBatchNumber)
+				return lastBatchNumber, stateRoot, accInputHash
+			} else if err != nil {
+				log.Errorf("[processForcedBatches] failed to get missing forced batch %d. Error: %w", missingForcedBatchNumber, err)
 				return lastBatchNumber, stateRoot, accInputHash
 			}
-			forcedBatchToProcess = *missingForcedBatch
+			forcedBatchesToProcess = append(forcedBatchesToProcess, *missingForcedBatch)
 		}
+		forcedBatchesToProcess = append(forcedBatchesToProcess, forcedBatch)
+
+		for _, forcedBatchToProcess := range forcedBatchesToProcess {
+			log.Infof("processing forced batch %d, LastBatchNumber: %d, StateRoot: %s, AccInputHash: %s", forcedBatchToProcess.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
+			lastBatchNumber, stateRoot, accInputHash, err = f.processForcedBatch(ctx, forcedBatchToProcess, true, lastBatchNumber, stateRoot, accInputHash)
+
+			if err != nil {
+				log.Errorf("[processForcedBatches] error when processing forced batch %d. Error: %w", forcedBatchToProcess.ForcedBatchNumber, err)
+				return lastBatchNumber, stateRoot, accInputHash
+			}
+
+			log.Infof("processed forced batch %d, BatchNumber: %d, NewStateRoot: %s, NewAccInputHash: %s", forcedBatchToProcess.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
 
-		log.Infof("processing forced batch %d, LastBatchNumber: %d, StateRoot: %s, AccInputHash: %s", forcedBatchToProcess.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
-		lastBatchNumber, stateRoot, accInputHash, err = f.processForcedBatch(ctx, forcedBatchToProcess, lastBatchNumber, stateRoot, accInputHash)
+			nextForcedBatchNumber += 1
+		}
+	}
+	f.nextForcedBatches = make([]state.ForcedBatch, 0)
 
+	// The forced batches not from L1 are processed after the L1 ones, in the order they were added
+	for len(f.nextForcedBatchesNotFromL1) > 0 {
+		forcedBatch := f.nextForcedBatchesNotFromL1[0]
+		log.Infof("processing forced batch %d not from L1, LastBatchNumber: %d, StateRoot: %s, AccInputHash: %s", forcedBatch.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
+		lastBatchNumber, stateRoot, accInputHash, err = f.processForcedBatch(ctx, forcedBatch, false, lastBatchNumber, stateRoot, accInputHash)
 		if err != nil {
-			log.Errorf("[processForcedBatches] error when processing forced batch %d. Error: %w", forcedBatchToProcess.ForcedBatchNumber, err)
+			log.Errorf("[processForcedBatches] error when processing forced batch %d not from L1. Error: %w", forcedBatch.ForcedBatchNumber, err)
 			return lastBatchNumber, stateRoot, accInputHash
 		}
+		log.Infof("processed forced batch %d not from L1, BatchNumber: %d, NewStateRoot: %s, NewAccInputHash: %s", forcedBatch.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
+		f.nextForcedBatchesNotFromL1 = f.nextForcedBatchesNotFromL1[1:]
+	}
+
+	return lastBatchNumber, stateRoot, accInputHash
+}
+
+// pendingForcedBatchCount returns the number of forced batches pending to be processed, including the ones
+// not from L1. nextForcedBatchesMux must be held when it's called outside the finalizer loop
+func (f *finalizer) pendingForcedBatchCount() int {
+	return len(f.nextForcedBatches) + len(f.nextForcedBatchesNotFromL1)
+}
+
+// pauseForcedBatchProcessing pauses the processing of the forced batches for the duration, the regular batches
+// are still built. A duration <= 0 resumes the processing
+func (f *finalizer) pauseForcedBatchProcessing(duration time.Duration) {
+	f.nextForcedBatchesMux.Lock()
+	defer f.nextForcedBatchesMux.Unlock()
+
+	if duration <= 0 {
+		log.Warn("forced batch processing resumed")
+		f.forcedBatchesPausedUntil = time.Time{}
+		if f.pendingForcedBatchCount() > 0 {
+			f.nextForcedBatchDeadline = now().Unix()
+		}
+		return
+	}
+
+	f.forcedBatchesPausedUntil = now().Add(duration)
+	log.Warnf("forced batch processing paused until %v", f.forcedBatchesPausedUntil)
+	if f.nextForcedBatchDeadline != 0 {
+		f.nextForcedBatchDeadline = f.forcedBatchesPausedUntil.Unix()
+	}
+}
 
-		log.Infof("processed forced batch %d, BatchNumber: %d, NewStateRoot: %s, NewAccInputHash: %s", forcedBatchToProcess.ForcedBatchNumber, lastBatchNumber, stateRoot.String(), accInputHash.String())
+// isForcedBatchProcessingPaused returns true while the forced batch processing pause has not expired,
+// nextForcedBatchesMux must be held
+func (f *finalizer) isForcedBatchProcessingPaused() bool {
+	return !f.forcedBatchesPausedUntil.IsZero() && now().Before(f.forcedBatchesPausedUntil)
+}
 
-		nextForcedBatchNumber += 1
+// addForcedBatchNotFromL1 adds a forced batch that has not been sent to L1 to the forced batches pending to be processed.
+// It's used to test the forced batches without going through L1, so the forced batch has its own number space and it's
+// stored in the state as a batch without forced batch number, the next L1 forced batch number is not used
+func (f *finalizer) addForcedBatchNotFromL1(rawTxsData []byte, globalExitRoot common.Hash, forcedAt time.Time) uint64 {
+	f.nextForcedBatchesMux.Lock()
+	defer f.nextForcedBatchesMux.Unlock()
+
+	f.lastForcedBatchNotFromL1Number++
+	forcedBatch := state.ForcedBatch{
+		ForcedBatchNumber: f.lastForcedBatchNotFromL1Number,
+		Sequencer:         f.sequencerAddress,
+		GlobalExitRoot:    globalExitRoot,
+		RawTxsData:        rawTxsData,
+		ForcedAt:          forcedAt,
 	}
-	f.nextForcedBatches = make([]state.ForcedBatch, 0)
+	log.Warnf("adding forced batch %d not sent to L1, GER: %s, forcedAt: %v", forcedBatch.ForcedBatchNumber, globalExitRoot.String(), forcedAt)
 
-	return lastBatchNumber, stateRoot, accInputHash
+	f.nextForcedBatchesNotFromL1 = append(f.nextForcedBatchesNotFromL1, forcedBatch)
+	if f.nextForcedBatchDeadline == 0 {
+		f.setNextForcedBatchDeadline()
+	}
+
+	return forcedBatch.ForcedBatchNumber
 }
 
-func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.ForcedBatch, lastBatchNumber uint64, stateRoot, accInputHash common.Hash) (newLastBatchNumber uint64, newStateRoot, newAccInputHash common.Hash, retErr error) {
-	dbTx, err := f.state.BeginStateTransaction(ctx)
+// checkForcedBlockHashL1 checks that the parent hash of the forced batch L1 block, used as ForcedBlockHashL1, is the
+// hash of the parent block in L1. A mismatch means an L1 reorg not synced yet or a data integrity issue
+func (f *finalizer) checkForcedBlockHashL1(ctx context.Context, forcedBatch state.ForcedBatch, fbL1Block *state.Block) {
+	if fbL1Block.BlockNumber == 0 {
+		return
+	}
+
+	parentBlockNumber := fbL1Block.BlockNumber - 1
+	headerCtx, cancel := context.WithTimeout(ctx, checkForcedBlockHashL1Timeout)
+	defer cancel()
+	parentHeader, err := f.etherman.HeaderByNumber(headerCtx, new(big.Int).SetUint64(parentBlockNumber))
+	if err != nil {
+		log.Warnf("[processForcedBatch] failed to get L1 block %d to check the forced block hash of forced batch %d. Error: %v", parentBlockNumber, forcedBatch.ForcedBatchNumber, err)
+		return
+	}
+	if parentHeader.Hash() == fbL1Block.ParentHash {
+		return
+	}
+
+	description := fmt.Sprintf("forced block hash %s of forced batch %d doesn't match the hash %s of L1 block %d, there is an L1 reorg or a data integrity issue",
+		fbL1Block.ParentHash, forcedBatch.ForcedBatchNumber, parentHeader.Hash(), parentBlockNumber)
+	log.Warnf("[processForcedBatch] CRITICAL: %s", description)
+
+	event := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequencer,
+		Level:       event.Level_Critical,
+		EventID:     event.EventID_ForcedBlockHashMismatch,
+		Description: description,
+	}
+	err = f.eventLog.LogEvent(ctx, event)
+	if err != nil {
+		log.Errorf("error storing forced block hash mismatch event: %v", err)
+	}
+}
+
+// processForcedBatch processes the forced batch in a new batch, fromL1 is false for the forced batches added with
+// addForcedBatchNotFromL1, which use the last synced L1 block as forced block and have no forced batch row in the state
+func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.ForcedBatch, fromL1 bool, lastBatchNumber uint64, stateRoot, accInputHash common.Hash) (newLastBatchNumber uint64, newStateRoot, newAccInputHash common.Hash, retErr error) {
+	var (
+		fbL1Block *state.Block
+		err       error
+	)
+	if fromL1 {
+		// Get the L1 block where the forced batch was forced, its parent hash is the forced block hash of the batch.
+		// The L1 block is checked before the dbTx is open so the L1 request doesn't hold the db connection
+		fbL1Block, err = f.forcedBatchState.GetL1BlockByNumber(ctx, forcedBatch.BlockNumber, nil)
+		if err != nil {
+			return lastBatchNumber, stateRoot, accInputHash, fmt.Errorf("[processForcedBatch] error getting L1 block number %d for forced batch %d. Error: %w", forcedBatch.BlockNumber, forcedBatch.ForcedBatchNumber, err)
+		}
+		f.checkForcedBlockHashL1(ctx, forcedBatch, fbL1Block)
+	} else {
+		fbL1Block, err = f.forcedBatchState.GetLastBlock(ctx, nil)
+		if err != nil {
+			return lastBatchNumber, stateRoot, accInputHash, fmt.Errorf("[processForcedBatch] error getting last L1 block for forced batch %d not from L1. Error: %w", forcedBatch.ForcedBatchNumber, err)
+		}
+	}
+
+	dbTx, err := f.forcedBatchState.BeginStateTransaction(ctx)
 	if err != nil {
 		log.Errorf("failed to begin state transaction for process forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, err)
 		return lastBatchNumber, stateRoot, accInputHash, err
@@ -73,23 +239,19 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 		return lastBatchNumber, stateRoot, accInputHash, retError
 	}
 
-	// Get L1 block for the forced batch
-	fbL1Block, err := f.state.GetBlockByNumber(ctx, forcedBatch.ForcedBatchNumber, dbTx)
-	if err != nil {
-		return lastBatchNumber, stateRoot, accInputHash, fmt.Errorf("[processForcedBatch] error getting L1 block number %d for forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, forcedBatch.ForcedBatchNumber, err)
-	}
-
 	newBatchNumber := lastBatchNumber + 1
 
-	// Open new batch on state for the forced batch
+	// Open new batch on state for the forced batch, the forced batches not from L1 have no forced batch row to reference
 	processingCtx := state.ProcessingContext{
 		BatchNumber:    newBatchNumber,
 		Coinbase:       f.sequencerAddress,
 		Timestamp:      time.Now(),
 		GlobalExitRoot: forcedBatch.GlobalExitRoot,
-		ForcedBatchNum: &forcedBatch.ForcedBatchNumber,
 	}
-	err = f.state.OpenBatch(ctx, processingCtx, dbTx)
+	if fromL1 {
+		processingCtx.ForcedBatchNum = &forcedBatch.ForcedBatchNumber
+	}
+	err = f.forcedBatchState.OpenBatch(ctx, processingCtx, dbTx)
 	if err != nil {
 		return rollbackOnError(fmt.Errorf("[processForcedBatch] error opening state batch %d for forced batch %d. Error: %w", newBatchNumber, forcedBatch.ForcedBatchNumber, err))
 	}
@@ -103,7 +265,7 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 		Transactions:            forcedBatch.RawTxsData,
 		Coinbase:                f.sequencerAddress,
 		TimestampLimit_V2:       uint64(forcedBatch.ForcedAt.Unix()),
-		ForkID:                  f.state.GetForkIDByBatchNumber(lastBatchNumber),
+		ForkID:                  f.forcedBatchState.GetForkIDByBatchNumber(lastBatchNumber),
 		SkipVerifyL1InfoRoot_V2: true,
 		Caller:                  stateMetrics.SequencerCallerLabel,
 	}
@@ -112,7 +274,7 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 	// L1InfoRoot = fb.GER
 	// forced_blockhash_l1 = table.forced_batch.block_num.parent_hash
 	// l1_info_tree_data  vacio
-	batchResponse, err := f.state.ProcessBatchV2(ctx, executorBatchRequest, true)
+	batchResponse, err := f.forcedBatchState.ProcessBatchV2(ctx, executorBatchRequest, true)
 	if err != nil {
 		return rollbackOnError(fmt.Errorf("[processForcedBatch] failed to process/execute forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, err))
 	}
@@ -125,37 +287,79 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 		AccInputHash:  batchResponse.NewAccInputHash,
 		BatchL2Data:   forcedBatch.RawTxsData,
 		BatchResources: state.BatchResources{
-			ZKCounters: batchResponse.UsedZkCounters,
-			Bytes:      uint64(len(forcedBatch.RawTxsData)),
+			ZKCounters:      batchResponse.UsedZkCounters,
+			Bytes:           uint64(len(forcedBatch.RawTxsData)),
+			CompressedBytes: state.CompressedBatchL2DataSize(forcedBatch.RawTxsData),
 		},
 		ClosingReason: state.ForcedBatchClosingReason,
 	}
-	err = f.state.CloseBatch(ctx, processingReceipt, dbTx)
+	err = f.forcedBatchState.CloseBatch(ctx, processingReceipt, dbTx)
 	if err != nil {
 		return rollbackOnError(fmt.Errorf("[processForcedBatch] error closing state batch %d for forced batch %d. Error: %w", newBatchNumber, forcedBatch.ForcedBatchNumber, err))
 	}
 
+	if batchResponse.IsRomOOCError {
+		if len(batchResponse.BlockResponses) == 0 {
+			log.Warnf("[processForcedBatch] forced batch %d produced ROM OOC error with no block responses; committing empty batch", forcedBatch.ForcedBatchNumber)
+		} else {
+			log.Warnf("[processForcedBatch] forced batch %d produced ROM OOC error, discarding its %d block responses; committing empty batch",
+				forcedBatch.ForcedBatchNumber, len(batchResponse.BlockResponses))
+		}
+	}
+
+	hasL2Blocks := len(batchResponse.BlockResponses) > 0 && !batchResponse.IsRomOOCError
+	var dsL2Blocks []state.DSL2FullBlock
+	if hasL2Blocks {
+		dsL2Blocks, err = f.handleProcessForcedBatchResponse(ctx, forcedBatch.ForcedBatchNumber, fromL1, batchResponse, dbTx)
+		if err != nil {
+			return rollbackOnError(fmt.Errorf("[processForcedBatch] error when handling batch response for forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, err))
+		}
+	}
+
 	err = dbTx.Commit(ctx)
 	if err != nil {
 		return rollbackOnError(fmt.Errorf("[processForcedBatch] error when commit dbTx when processing forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, err))
 	}
+	metrics.BatchClosed(string(processingReceipt.ClosingReason))
+	metrics.LastBatchClosed(now())
+	// The forced batch metrics are updated once the forced batch is committed, so the retries of a
+	// forced batch whose processing fails are not counted
+	if batchResponse.IsRomOOCError {
+		metrics.ForcedBatchRomOOC()
+	}
+	if hasL2Blocks {
+		var fees BatchFeeAccumulator
+		fees.Add(batchResponse)
+		metrics.BatchFees(fees.Total())
+	}
+
+	// Update the current GER once the forced batch is stored, checking if the forced batch introduces a new GER
+	f.currentGERHashMux.Lock()
+	newGER := f.currentGERHash != forcedBatch.GlobalExitRoot
+	f.currentGERHash = forcedBatch.GlobalExitRoot
+	f.currentGERHashMux.Unlock()
 
-	if len(batchResponse.BlockResponses) > 0 && !batchResponse.IsRomOOCError {
-		err = f.handleProcessForcedBatchResponse(ctx, batchResponse, dbTx)
-		return rollbackOnError(fmt.Errorf("[processForcedBatch] error when handling batch response for forced batch %d. Error: %w", forcedBatch.ForcedBatchNumber, err))
-	} //else {
-	//TODO: review if this is still needed
-	/*if f.streamServer != nil && f.currentGERHash != forcedBatch.GlobalExitRoot {
-		//TODO: review this datastream parameters
+	if hasL2Blocks {
+		f.streamForcedL2Blocks(dsL2Blocks)
+	} else if f.streamServer != nil && newGER {
+		// The forced batch has no L2 blocks to send to the data stream, we send the GER update
 		f.DSSendUpdateGER(newBatchNumber, forcedBatch.ForcedAt.Unix(), forcedBatch.GlobalExitRoot, batchResponse.NewStateRoot)
-	}*/
-	//}
+	}
 
 	return newBatchNumber, batchResponse.NewStateRoot, batchResponse.NewAccInputHash, nil
 }
 
-// addForcedTxToWorker adds the txs of the forced batch to the worker
-func (f *finalizer) addForcedTxToWorker(forcedBatchResponse *state.ProcessBatchResponse) {
+// streamForcedL2Blocks sends the L2 blocks of the forced batch to the data streamer, it's called once the
+// forced batch is committed so the data stream never has L2 blocks that are not in the state
+func (f *finalizer) streamForcedL2Blocks(dsL2Blocks []state.DSL2FullBlock) {
+	for _, dsL2Block := range dsL2Blocks {
+		f.dataToStream <- dsL2Block
+	}
+}
+
+// forcedBatchTxs returns the hashes of the txs of the forced batch with their senders
+func forcedBatchTxs(forcedBatchResponse *state.ProcessBatchResponse) map[common.Hash]common.Address {
+	forcedTxs := make(map[common.Hash]common.Address)
 	for _, blockResponse := range forcedBatchResponse.BlockResponses {
 		for _, txResponse := range blockResponse.TransactionResponses {
 			from, err := state.GetSender(txResponse.Tx)
@@ -163,14 +367,65 @@ func (f *finalizer) addForcedTxToWorker(forcedBatchResponse *state.ProcessBatchR
 				log.Warnf("failed trying to add forced tx (%s) to worker. Error getting sender from tx, Error: %w", txResponse.TxHash, err)
 				continue
 			}
-			f.worker.AddForcedTx(txResponse.TxHash, from)
+			forcedTxs[txResponse.TxHash] = from
 		}
 	}
+	return forcedTxs
+}
+
+// addForcedTxToWorker adds the txs of the forced batch to the worker
+func (f *finalizer) addForcedTxToWorker(forcedTxs map[common.Hash]common.Address) {
+	for txHash, from := range forcedTxs {
+		f.worker.AddForcedTx(txHash, from)
+	}
+}
+
+// restoreForcedTxsToWorker adds to the worker the txs of the forced batches whose processing
+// was interrupted by a restart, so they are known as forced when the forced batch is processed again
+func (f *finalizer) restoreForcedTxsToWorker(ctx context.Context) {
+	forcedTxs, err := f.forcedBatchState.GetPendingForcedTxHashes(ctx, nil)
+	if err != nil {
+		log.Errorf("failed to get the pending forced txs to restore them to the worker, error: %v", err)
+		return
+	}
+	if len(forcedTxs) > 0 {
+		log.Infof("restoring %d forced txs to the worker", len(forcedTxs))
+		f.addForcedTxToWorker(forcedTxs)
+	}
 }
 
 // handleProcessForcedTxsResponse handles the block/transactions responses for the processed forced batch.
-func (f *finalizer) handleProcessForcedBatchResponse(ctx context.Context, batchResponse *state.ProcessBatchResponse, dbTx pgx.Tx) error {
-	f.addForcedTxToWorker(batchResponse)
+// The forced txs of the forced batches not from L1 are not stored to be restored, as the forced batch is lost on a restart.
+// The data stream L2 blocks are built while the next L2 blocks are stored and returned to be sent once dbTx is committed
+func (f *finalizer) handleProcessForcedBatchResponse(ctx context.Context, forcedBatchNumber uint64, fromL1 bool, batchResponse *state.ProcessBatchResponse, dbTx pgx.Tx) ([]state.DSL2FullBlock, error) {
+	forcedTxs := forcedBatchTxs(batchResponse)
+	// The txs the worker knows about are checked before adding the forced txs, HasTx is true for the forced txs
+	workerTxs := make(map[common.Hash]bool, len(forcedTxs))
+	for txHash, from := range forcedTxs {
+		workerTxs[txHash] = f.worker.HasTx(txHash, from)
+	}
+	storeForcedTxs := fromL1 && len(forcedTxs) > 0
+	if storeForcedTxs {
+		// The forced txs are stored without dbTx so they are kept if the node restarts before dbTx is committed,
+		// they are deleted in dbTx once the forced batch is stored
+		err := f.forcedBatchState.StoreForcedTxHashes(ctx, forcedBatchNumber, forcedTxs, nil)
+		if err != nil {
+			return nil, fmt.Errorf("[handleProcessForcedBatchResponse] database error on storing the txs of forced batch %d. Error: %w", forcedBatchNumber, err)
+		}
+	}
+	f.addForcedTxToWorker(forcedTxs)
+
+	// The forced txs added to the worker are deleted if the forced batch fails, they are added again when it's retried
+	pendingWorkerTxs := make(map[common.Hash]common.Address, len(forcedTxs))
+	for txHash, from := range forcedTxs {
+		pendingWorkerTxs[txHash] = from
+	}
+	deleteForcedTxsOnError := func(retError error) error {
+		for txHash, from := range pendingWorkerTxs {
+			f.worker.DeleteForcedTx(txHash, from)
+		}
+		return retError
+	}
 
 	f.updateLastPendingFlushID(batchResponse.FlushID)
 
@@ -178,20 +433,34 @@ func (f *finalizer) handleProcessForcedBatchResponse(ctx context.Context, batchR
 	f.storedFlushIDCond.L.Lock()
 	for f.storedFlushID < batchResponse.FlushID {
 		f.storedFlushIDCond.Wait()
-		// check if context is done after waking up
-		if ctx.Err() != nil {
+		// check if context is done after waking up, the forced batch is rolled back as it's not flushed
+		if err := ctx.Err(); err != nil {
 			f.storedFlushIDCond.L.Unlock()
-			return nil
+			return nil, deleteForcedTxsOnError(err)
 		}
 	}
 	f.storedFlushIDCond.L.Unlock()
 
+	// The data stream L2 block of each forced L2 block is built while the next one is stored
+	var dsWG sync.WaitGroup
+	defer dsWG.Wait()
+	dsL2Blocks := make([]state.DSL2FullBlock, len(batchResponse.BlockResponses))
+	dsErrors := make([]error, len(batchResponse.BlockResponses))
+
 	// process L2 blocks responses for the forced batch
-	for _, forcedL2BlockResponse := range batchResponse.BlockResponses {
+	for i, forcedL2BlockResponse := range batchResponse.BlockResponses {
 		// Store forced L2 blocks in the state
-		err := f.state.StoreL2Block(ctx, batchResponse.NewBatchNumber, forcedL2BlockResponse, nil, dbTx)
+		err := f.forcedBatchState.StoreL2Block(ctx, batchResponse.NewBatchNumber, forcedL2BlockResponse, nil, dbTx)
 		if err != nil {
-			return fmt.Errorf("[handleProcessForcedBatchResponse] database error on storing L2 block %d. Error: %w", forcedL2BlockResponse.BlockNumber, err)
+			return nil, deleteForcedTxsOnError(fmt.Errorf("[handleProcessForcedBatchResponse] database error on storing L2 block %d. Error: %w", forcedL2BlockResponse.BlockNumber, err))
+		}
+
+		if f.streamServer != nil {
+			dsWG.Add(1)
+			go func(i int, blockResponse *state.ProcessBlockResponse) {
+				defer dsWG.Done()
+				dsL2Blocks[i], dsErrors[i] = f.dsL2FullBlock(batchResponse.NewBatchNumber, blockResponse)
+			}(i, forcedL2BlockResponse)
 		}
 
 		// Update worker with info from the transaction responses
@@ -201,18 +470,40 @@ func (f *finalizer) handleProcessForcedBatchResponse(ctx context.Context, batchR
 				log.Warnf("[handleForcedTxsProcessResp] failed to get sender for tx (%s): %v", txResponse.TxHash, err)
 			}
 
-			if err == nil {
+			if err != nil {
+				continue
+			}
+			if workerTxs[txResponse.TxHash] {
 				f.updateWorkerAfterSuccessfulProcessing(ctx, txResponse.TxHash, from, true, batchResponse)
+			} else {
+				// The tx is only in the forced batch, the worker only has the forced tx added above
+				f.worker.DeleteForcedTx(txResponse.TxHash, from)
 			}
+			delete(pendingWorkerTxs, txResponse.TxHash)
 		}
+	}
 
-		// Send L2 block to data streamer
-		err = f.DSSendL2Block(batchResponse.NewBatchNumber, forcedL2BlockResponse)
+	if storeForcedTxs {
+		err := f.forcedBatchState.DeleteForcedTxHashes(ctx, forcedBatchNumber, dbTx)
 		if err != nil {
+			return nil, deleteForcedTxsOnError(fmt.Errorf("[handleProcessForcedBatchResponse] database error on deleting the txs of forced batch %d. Error: %w", forcedBatchNumber, err))
+		}
+	}
+
+	if f.streamServer == nil {
+		return nil, nil
+	}
+
+	dsWG.Wait()
+	streamL2Blocks := make([]state.DSL2FullBlock, 0, len(dsL2Blocks))
+	for i, dsL2Block := range dsL2Blocks {
+		if dsErrors[i] != nil {
 			//TODO: we need to halt/rollback the L2 block if we had an error sending to the data streamer?
-			log.Errorf("[storeL2Block] error sending L2 block %d to data streamer", forcedL2BlockResponse.BlockNumber)
+			log.Errorf("[handleProcessForcedBatchResponse] error building data stream L2 block %d. Error: %v", batchResponse.BlockResponses[i].BlockNumber, dsErrors[i])
+			continue
 		}
+		streamL2Blocks = append(streamL2Blocks, dsL2Block)
 	}
 
-	return nil
+	return streamL2Blocks, nil
 }
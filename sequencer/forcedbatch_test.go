@@ -0,0 +1,723 @@
+package sequencer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const benchForcedBatchesPerIteration = 10
+
+// benchL1ParentHeader is the L1 parent header of every forced batch of the benchmark
+var benchL1ParentHeader = &types.Header{}
+
+func Test_processForcedBatch(t *testing.T) {
+	// the L1 block number and the forced batch number differ so the L1 block can't be read by batch number
+	forcedBatch := state.ForcedBatch{
+		BlockNumber:       15,
+		ForcedBatchNumber: 1,
+		Sequencer:         seqAddr,
+		GlobalExitRoot:    newHash,
+		RawTxsData:        []byte{},
+		ForcedAt:          time.Unix(1700000000, 0),
+	}
+	fbL1ParentHeader := &types.Header{Number: big.NewInt(int64(forcedBatch.BlockNumber - 1))}
+	fbL1Block := &state.Block{BlockNumber: forcedBatch.BlockNumber, ParentHash: fbL1ParentHeader.Hash()}
+	l2BlockResponse := &state.ProcessBlockResponse{BlockNumber: 1}
+	batchResponse := &state.ProcessBatchResponse{
+		NewStateRoot:    newHash,
+		NewAccInputHash: newHash,
+		NewBatchNumber:  2,
+		BlockResponses:  []*state.ProcessBlockResponse{l2BlockResponse},
+	}
+
+	testCases := []struct {
+		name                 string
+		getL1BlockErr        error
+		l1ParentHeader       *types.Header
+		processBatchErr      error
+		storeL2BlockErr      error
+		expectedBatchNumber  uint64
+		expectedStateRoot    common.Hash
+		expectedAccInputHash common.Hash
+		expectedErr          error
+	}{
+		{
+			name:                 "Forced batch processed",
+			expectedBatchNumber:  2,
+			expectedStateRoot:    newHash,
+			expectedAccInputHash: newHash,
+		},
+		{
+			name:                 "Forced block hash mismatch",
+			l1ParentHeader:       &types.Header{Number: big.NewInt(int64(forcedBatch.BlockNumber - 1)), Extra: []byte("reorged")},
+			expectedBatchNumber:  2,
+			expectedStateRoot:    newHash,
+			expectedAccInputHash: newHash,
+		},
+		{
+			name:                 "Get L1 block error",
+			getL1BlockErr:        testErr,
+			expectedBatchNumber:  1,
+			expectedStateRoot:    oldHash,
+			expectedAccInputHash: oldHash,
+			expectedErr:          testErr,
+		},
+		{
+			name:                 "Executor error",
+			processBatchErr:      testErr,
+			expectedBatchNumber:  1,
+			expectedStateRoot:    oldHash,
+			expectedAccInputHash: oldHash,
+			expectedErr:          testErr,
+		},
+		{
+			name:                 "Store L2 block error",
+			storeL2BlockErr:      testErr,
+			expectedBatchNumber:  1,
+			expectedStateRoot:    oldHash,
+			expectedAccInputHash: oldHash,
+			expectedErr:          testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			stMock := NewForcedBatchStateMock(t)
+			ethMock := NewEthermanMock(t)
+			dbTx := NewDbTxMock(t)
+			eventStorage, err := nileventstorage.NewNilEventStorage()
+			require.NoError(t, err)
+			seqStMock := NewStateMock(t)
+			fin := &finalizer{
+				sequencerAddress:   seqAddr,
+				worker:             NewWorkerMock(t),
+				state:              seqStMock,
+				forcedBatchState:   stMock,
+				etherman:           ethMock,
+				eventLog:           event.NewEventLog(event.Config{}, eventStorage),
+				storedFlushIDCond:  sync.NewCond(&sync.Mutex{}),
+				pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
+				currentGERHashMux:  new(sync.Mutex),
+				streamServer:       &datastreamer.StreamServer{},
+				dataToStream:       make(chan state.DSL2FullBlock, len(batchResponse.BlockResponses)),
+			}
+
+			if tc.getL1BlockErr != nil {
+				// The dbTx is not open when the L1 block can't be read
+				stMock.On("GetL1BlockByNumber", ctx, forcedBatch.BlockNumber, nil).Return(nil, tc.getL1BlockErr).Once()
+				batchNumber, stateRoot, accInputHash, err := fin.processForcedBatch(ctx, forcedBatch, true, 1, oldHash, oldHash)
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, tc.expectedBatchNumber, batchNumber)
+				assert.Equal(t, tc.expectedStateRoot, stateRoot)
+				assert.Equal(t, tc.expectedAccInputHash, accInputHash)
+				return
+			}
+			stMock.On("GetL1BlockByNumber", ctx, forcedBatch.BlockNumber, nil).Return(fbL1Block, nil).Once()
+			l1ParentHeader := fbL1ParentHeader
+			if tc.l1ParentHeader != nil {
+				l1ParentHeader = tc.l1ParentHeader
+			}
+			// The L1 request has a deadline
+			ethMock.On("HeaderByNumber", mock.MatchedBy(func(headerCtx context.Context) bool {
+				_, ok := headerCtx.Deadline()
+				return ok
+			}), big.NewInt(int64(forcedBatch.BlockNumber-1))).Return(l1ParentHeader, nil).Once()
+			stMock.On("BeginStateTransaction", ctx).Return(dbTx, nil).Once()
+			const newForkID = uint64(7)
+			stMock.On("OpenBatch", ctx, mock.MatchedBy(func(processingCtx state.ProcessingContext) bool {
+				return processingCtx.BatchNumber == 2 && *processingCtx.ForcedBatchNum == forcedBatch.ForcedBatchNumber
+			}), dbTx).Return(nil).Once()
+			stMock.On("GetForkIDByBatchNumber", uint64(1)).Return(newForkID).Once()
+			stMock.On("ProcessBatchV2", ctx, mock.MatchedBy(func(request state.ProcessRequest) bool {
+				return request.ForcedBlockHashL1 == fbL1Block.ParentHash
+			}), true).Return(batchResponse, tc.processBatchErr).Once()
+			if tc.processBatchErr == nil {
+				stMock.On("CloseBatch", ctx, mock.MatchedBy(func(receipt state.ProcessingReceipt) bool {
+					return receipt.BatchNumber == 2 && receipt.ClosingReason == state.ForcedBatchClosingReason
+				}), dbTx).Return(nil).Once()
+				stMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(tc.storeL2BlockErr).Once()
+			}
+			if tc.expectedErr == nil {
+				dbTx.On("Commit", ctx).Return(nil).Once()
+				seqStMock.On("GetForkIDByBatchNumber", batchResponse.NewBatchNumber).Return(newForkID).Once()
+			} else {
+				dbTx.On("Rollback", ctx).Return(nil).Once()
+			}
+
+			batchNumber, stateRoot, accInputHash, err := fin.processForcedBatch(ctx, forcedBatch, true, 1, oldHash, oldHash)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedBatchNumber, batchNumber)
+			assert.Equal(t, tc.expectedStateRoot, stateRoot)
+			assert.Equal(t, tc.expectedAccInputHash, accInputHash)
+
+			// The L2 blocks are only sent to the data streamer once the forced batch is committed
+			if tc.expectedErr == nil {
+				assert.Len(t, fin.dataToStream, len(batchResponse.BlockResponses))
+			} else {
+				assert.Empty(t, fin.dataToStream)
+			}
+		})
+	}
+}
+
+func Test_processForcedBatchesNotFromL1(t *testing.T) {
+	ctx := context.Background()
+	batchResponse, rawTxsData := newBenchForcedBatchResponse(t, 2)
+	batchResponse.NewBatchNumber = 2
+	l2BlockResponse := batchResponse.BlockResponses[0]
+	lastL1Block := &state.Block{BlockNumber: 100, ParentHash: common.HexToHash("0x99")}
+
+	stMock := NewForcedBatchStateMock(t)
+	dbTx := NewDbTxMock(t)
+	fin := &finalizer{
+		sequencerAddress:     seqAddr,
+		worker:               NewWorker(nil, bc, 0),
+		forcedBatchState:     stMock,
+		nextForcedBatches:    make([]state.ForcedBatch, 0),
+		nextForcedBatchesMux: new(sync.Mutex),
+		storedFlushIDCond:    sync.NewCond(&sync.Mutex{}),
+		pendingFlushIDCond:   sync.NewCond(&sync.Mutex{}),
+		currentGERHashMux:    new(sync.Mutex),
+		dynamicCfg:           newDynamicConfig(FinalizerCfg{}),
+	}
+	forcedBatchNumber := fin.addForcedBatchNotFromL1(rawTxsData, newHash, time.Unix(1700000000, 0))
+
+	// The last trusted L1 forced batch number doesn't change the number of the forced batch not from L1
+	stMock.On("GetLastTrustedForcedBatchNumber", ctx, nil).Return(uint64(5), nil).Once()
+	// The last synced L1 block is used as forced block, there is no L1 block for the forced batch
+	stMock.On("GetLastBlock", ctx, nil).Return(lastL1Block, nil).Once()
+	stMock.On("BeginStateTransaction", ctx).Return(dbTx, nil).Once()
+	// The batch has no forced batch number, there is no forced batch row in the state to reference
+	stMock.On("OpenBatch", ctx, mock.MatchedBy(func(processingCtx state.ProcessingContext) bool {
+		return processingCtx.BatchNumber == 2 && processingCtx.ForcedBatchNum == nil
+	}), dbTx).Return(nil).Once()
+	stMock.On("GetForkIDByBatchNumber", uint64(1)).Return(uint64(7)).Once()
+	stMock.On("ProcessBatchV2", ctx, mock.MatchedBy(func(request state.ProcessRequest) bool {
+		return request.ForcedBlockHashL1 == lastL1Block.ParentHash && bytes.Equal(request.Transactions, rawTxsData)
+	}), true).Return(batchResponse, nil).Once()
+	stMock.On("CloseBatch", ctx, mock.MatchedBy(func(receipt state.ProcessingReceipt) bool {
+		return receipt.BatchNumber == 2 && receipt.ClosingReason == state.ForcedBatchClosingReason
+	}), dbTx).Return(nil).Once()
+	// The forced txs are not stored to be restored, StoreForcedTxHashes and DeleteForcedTxHashes are not called
+	stMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(nil).Once()
+	dbTx.On("Commit", ctx).Return(nil).Once()
+
+	batchNumber, stateRoot, accInputHash := fin.processForcedBatches(ctx, 1, oldHash, oldHash)
+	assert.Equal(t, uint64(1), forcedBatchNumber)
+	assert.Equal(t, uint64(2), batchNumber)
+	assert.Equal(t, newHash, stateRoot)
+	assert.Equal(t, newHash, accInputHash)
+	assert.Empty(t, fin.nextForcedBatchesNotFromL1)
+	assert.Zero(t, fin.nextForcedBatchDeadline)
+	assert.Empty(t, fin.worker.(*Worker).queuelessForcedTxs)
+}
+
+func Test_handleProcessForcedBatchResponse(t *testing.T) {
+	l2BlockResponses := []*state.ProcessBlockResponse{{BlockNumber: 1}, {BlockNumber: 2}, {BlockNumber: 3}}
+	batchResponse := &state.ProcessBatchResponse{
+		NewBatchNumber: 2,
+		BlockResponses: l2BlockResponses,
+	}
+
+	testCases := []struct {
+		name                 string
+		storeL2BlockErrBlock uint64
+		expectedErr          error
+	}{
+		{
+			name: "All L2 blocks stored in order",
+		},
+		{
+			name:                 "Store L2 block error",
+			storeL2BlockErrBlock: 2,
+			expectedErr:          testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fbStMock := NewForcedBatchStateMock(t)
+			dbTx := NewDbTxMock(t)
+			stMock := NewStateMock(t)
+			fin := &finalizer{
+				sequencerAddress:   seqAddr,
+				worker:             NewWorkerMock(t),
+				state:              stMock,
+				forcedBatchState:   fbStMock,
+				storedFlushIDCond:  sync.NewCond(&sync.Mutex{}),
+				pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
+				streamServer:       &datastreamer.StreamServer{},
+				dataToStream:       make(chan state.DSL2FullBlock, len(l2BlockResponses)),
+			}
+
+			expectedDSL2Blocks := []uint64{}
+			for _, l2BlockResponse := range l2BlockResponses {
+				if tc.storeL2BlockErrBlock == l2BlockResponse.BlockNumber {
+					fbStMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(testErr).Once()
+					break
+				}
+				fbStMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(nil).Once()
+				// The data stream L2 block is built once the L2 block is stored
+				stMock.On("GetForkIDByBatchNumber", batchResponse.NewBatchNumber).Return(uint64(7)).Once()
+				expectedDSL2Blocks = append(expectedDSL2Blocks, l2BlockResponse.BlockNumber)
+			}
+
+			dsL2Blocks, err := fin.handleProcessForcedBatchResponse(ctx, 1, true, batchResponse, dbTx)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, dsL2Blocks)
+			} else {
+				assert.NoError(t, err)
+				dsL2BlockNumbers := []uint64{}
+				for _, dsL2Block := range dsL2Blocks {
+					dsL2BlockNumbers = append(dsL2BlockNumbers, dsL2Block.L2BlockNumber)
+				}
+				assert.Equal(t, expectedDSL2Blocks, dsL2BlockNumbers)
+			}
+
+			// The stored L2 blocks are not committed yet, so none of them is sent to the data streamer
+			assert.Empty(t, fin.dataToStream)
+		})
+	}
+}
+
+func Test_handleProcessForcedBatchResponseCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	batchResponse := &state.ProcessBatchResponse{
+		NewBatchNumber: 2,
+		FlushID:        1,
+		BlockResponses: []*state.ProcessBlockResponse{{BlockNumber: 1}},
+	}
+	fin := &finalizer{
+		sequencerAddress:   seqAddr,
+		forcedBatchState:   NewForcedBatchStateMock(t),
+		storedFlushIDCond:  sync.NewCond(&sync.Mutex{}),
+		pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := fin.handleProcessForcedBatchResponse(ctx, 1, true, batchResponse, NewDbTxMock(t))
+		done <- err
+	}()
+	cancel()
+
+	// The flush id is never stored, the wait ends when the context is done
+	for {
+		fin.storedFlushIDCond.Broadcast()
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func Test_streamForcedL2Blocks(t *testing.T) {
+	dsL2Blocks := []state.DSL2FullBlock{
+		{DSL2Block: state.DSL2Block{BatchNumber: 2, L2BlockNumber: 1}},
+		{DSL2Block: state.DSL2Block{BatchNumber: 2, L2BlockNumber: 2}},
+		{DSL2Block: state.DSL2Block{BatchNumber: 2, L2BlockNumber: 3}},
+	}
+	fin := &finalizer{
+		streamServer: &datastreamer.StreamServer{},
+		dataToStream: make(chan state.DSL2FullBlock, len(dsL2Blocks)),
+	}
+
+	fin.streamForcedL2Blocks(dsL2Blocks)
+
+	close(fin.dataToStream)
+	streamedBlocks := []uint64{}
+	for l2Block := range fin.dataToStream {
+		streamedBlocks = append(streamedBlocks, l2Block.L2BlockNumber)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, streamedBlocks)
+}
+
+func Test_handleProcessForcedBatchResponseWorkerUpdate(t *testing.T) {
+	ctx := context.Background()
+	batchResponse, _ := newBenchForcedBatchResponse(t, 2)
+	batchResponse.NewBatchNumber = 2
+	l2BlockResponse := batchResponse.BlockResponses[0]
+	knownTx := l2BlockResponse.TransactionResponses[0]
+	unknownTx := l2BlockResponse.TransactionResponses[1]
+	from, err := state.GetSender(knownTx.Tx)
+	require.NoError(t, err)
+
+	// The worker knows only about the first tx, the second one is only in the forced batch
+	worker := NewWorker(nil, bc, 0)
+	addr := newAddrQueue(from, 0, big.NewInt(0))
+	addr.readyTx = &TxTracker{Hash: knownTx.TxHash, From: from}
+	worker.pool[from.String()] = addr
+
+	fbStMock := NewForcedBatchStateMock(t)
+	dbTx := NewDbTxMock(t)
+	fin := &finalizer{
+		sequencerAddress:   seqAddr,
+		worker:             worker,
+		forcedBatchState:   fbStMock,
+		storedFlushIDCond:  sync.NewCond(&sync.Mutex{}),
+		pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
+	}
+
+	forcedTxs := map[common.Hash]common.Address{knownTx.TxHash: from, unknownTx.TxHash: from}
+	// The forced txs are stored without dbTx and deleted in dbTx
+	fbStMock.On("StoreForcedTxHashes", ctx, uint64(1), forcedTxs, nil).Return(nil).Once()
+	fbStMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(nil).Once()
+	fbStMock.On("DeleteForcedTxHashes", ctx, uint64(1), dbTx).Return(nil).Once()
+
+	_, err = fin.handleProcessForcedBatchResponse(ctx, 1, true, batchResponse, dbTx)
+	require.NoError(t, err)
+
+	// The forced txs added to the worker are deleted and the worker keeps the tx it knew about
+	assert.Empty(t, addr.forcedTxs)
+	assert.Empty(t, worker.queuelessForcedTxs)
+	assert.True(t, worker.HasTx(knownTx.TxHash, from))
+	assert.False(t, worker.HasTx(unknownTx.TxHash, from))
+}
+
+func Test_handleProcessForcedBatchResponseWorkerCleanupOnError(t *testing.T) {
+	ctx := context.Background()
+	batchResponse, _ := newBenchForcedBatchResponse(t, 2)
+	batchResponse.NewBatchNumber = 2
+	l2BlockResponse := batchResponse.BlockResponses[0]
+	from, err := state.GetSender(l2BlockResponse.TransactionResponses[0].Tx)
+	require.NoError(t, err)
+
+	// The sender has no addrQueue, so the forced txs are kept as queueless forced txs
+	worker := NewWorker(nil, bc, 0)
+	fbStMock := NewForcedBatchStateMock(t)
+	dbTx := NewDbTxMock(t)
+	fin := &finalizer{
+		sequencerAddress:   seqAddr,
+		worker:             worker,
+		forcedBatchState:   fbStMock,
+		storedFlushIDCond:  sync.NewCond(&sync.Mutex{}),
+		pendingFlushIDCond: sync.NewCond(&sync.Mutex{}),
+	}
+
+	storeErr := errors.New("failed to store L2 block")
+	fbStMock.On("StoreForcedTxHashes", ctx, uint64(1), mock.Anything, nil).Return(nil).Once()
+	fbStMock.On("StoreL2Block", ctx, batchResponse.NewBatchNumber, l2BlockResponse, mock.Anything, dbTx).Return(storeErr).Once()
+
+	_, err = fin.handleProcessForcedBatchResponse(ctx, 1, true, batchResponse, dbTx)
+	require.ErrorIs(t, err, storeErr)
+
+	// The forced txs of the failed forced batch are deleted from the worker
+	assert.Empty(t, worker.queuelessForcedTxs)
+	for _, txResponse := range l2BlockResponse.TransactionResponses {
+		assert.False(t, worker.HasTx(txResponse.TxHash, from))
+	}
+}
+
+func Test_restoreForcedTxsToWorker(t *testing.T) {
+	ctx := context.Background()
+	forcedTxs := map[common.Hash]common.Address{{1}: {2}, {3}: {4}}
+
+	wMock := NewWorkerMock(t)
+	fbStMock := NewForcedBatchStateMock(t)
+	fin := &finalizer{
+		worker:           wMock,
+		forcedBatchState: fbStMock,
+	}
+
+	fbStMock.On("GetPendingForcedTxHashes", ctx, nil).Return(forcedTxs, nil).Once()
+	for txHash, from := range forcedTxs {
+		wMock.On("AddForcedTx", txHash, from).Once()
+	}
+	fin.restoreForcedTxsToWorker(ctx)
+
+	// The worker is not updated when the forced txs can't be read
+	fbStMock.On("GetPendingForcedTxHashes", ctx, nil).Return(nil, testErr).Once()
+	fin.restoreForcedTxsToWorker(ctx)
+}
+
+func Test_processForcedBatchesDeadline(t *testing.T) {
+	now = testNow
+	defer func() {
+		now = time.Now
+	}()
+
+	testCases := []struct {
+		name                      string
+		lastTrustedForcedBatchNum uint64
+		lastTrustedErr            error
+		expectedPendingBatches    int
+		expectedDeadline          int64
+	}{
+		{
+			name:                      "Pending forced batches processed",
+			lastTrustedForcedBatchNum: 1,
+			expectedPendingBatches:    0,
+			expectedDeadline:          0,
+		},
+		{
+			name:                   "Pending forced batches not processed",
+			lastTrustedErr:         testErr,
+			expectedPendingBatches: 1,
+			expectedDeadline:       testNow().Add(10 * time.Second).Unix(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			stMock := NewForcedBatchStateMock(t)
+			fin := &finalizer{
+				cfg:                     FinalizerCfg{MinForcedBatchProcessingInterval: cfgTypes.NewDuration(10 * time.Second)},
+				forcedBatchState:        stMock,
+				nextForcedBatches:       []state.ForcedBatch{{ForcedBatchNumber: 1}},
+				nextForcedBatchDeadline: 100,
+				nextForcedBatchesMux:    new(sync.Mutex),
+			}
+
+			stMock.On("GetLastTrustedForcedBatchNumber", ctx, nil).Return(tc.lastTrustedForcedBatchNum, tc.lastTrustedErr).Once()
+
+			lastBatchNumber, _, _ := fin.processForcedBatches(ctx, 1, oldHash, oldHash)
+			assert.Equal(t, uint64(1), lastBatchNumber)
+			assert.Len(t, fin.nextForcedBatches, tc.expectedPendingBatches)
+			assert.Equal(t, tc.expectedDeadline, fin.nextForcedBatchDeadline)
+		})
+	}
+}
+
+func Test_pauseForcedBatchProcessing(t *testing.T) {
+	now = testNow
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	stMock := NewForcedBatchStateMock(t)
+	fin := &finalizer{
+		cfg:                     FinalizerCfg{MinForcedBatchProcessingInterval: cfgTypes.NewDuration(10 * time.Second)},
+		forcedBatchState:        stMock,
+		nextForcedBatches:       []state.ForcedBatch{{ForcedBatchNumber: 1}},
+		nextForcedBatchDeadline: 100,
+		nextForcedBatchesMux:    new(sync.Mutex),
+	}
+
+	// The pending deadline is moved to the end of the pause
+	fin.pauseForcedBatchProcessing(time.Hour)
+	pausedUntil := testNow().Add(time.Hour).Unix()
+	assert.Equal(t, pausedUntil, fin.nextForcedBatchDeadline)
+
+	// The forced batches are not processed during the pause
+	lastBatchNumber, _, _ := fin.processForcedBatches(ctx, 1, oldHash, oldHash)
+	assert.Equal(t, uint64(1), lastBatchNumber)
+	assert.Len(t, fin.nextForcedBatches, 1)
+	assert.Equal(t, pausedUntil, fin.nextForcedBatchDeadline)
+
+	// The forced batches are processed once the pause expires
+	now = func() time.Time { return testNow().Add(time.Hour) }
+	stMock.On("GetLastTrustedForcedBatchNumber", ctx, nil).Return(uint64(1), nilErr).Once()
+	fin.processForcedBatches(ctx, 1, oldHash, oldHash)
+	assert.Empty(t, fin.nextForcedBatches)
+	assert.Equal(t, int64(0), fin.nextForcedBatchDeadline)
+
+	// Resuming sets the deadline to process the pending forced batches at once
+	now = testNow
+	fin.pauseForcedBatchProcessing(time.Hour)
+	fin.nextForcedBatches = []state.ForcedBatch{{ForcedBatchNumber: 2}}
+	fin.pauseForcedBatchProcessing(0)
+	assert.False(t, fin.isForcedBatchProcessingPaused())
+	assert.Equal(t, testNow().Unix(), fin.nextForcedBatchDeadline)
+}
+
+func TestSequencerPauseForcedBatchProcessing(t *testing.T) {
+	now = testNow
+	defer func() {
+		now = time.Now
+	}()
+
+	ethermanMock := NewEthermanMock(t)
+	s := &Sequencer{etherman: ethermanMock}
+	assert.ErrorIs(t, s.PauseForcedBatchProcessing(time.Hour), ErrSequencerNotStarted)
+
+	fin := &finalizer{nextForcedBatchesMux: new(sync.Mutex)}
+	s.finalizer.Store(fin)
+
+	// The pause must be shorter than the force batch timeout
+	ethermanMock.On("ForceBatchTimeout").Return(5*24*time.Hour, nilErr).Twice()
+	assert.ErrorIs(t, s.PauseForcedBatchProcessing(5*24*time.Hour), ErrForcedBatchPauseTooLong)
+	assert.False(t, fin.isForcedBatchProcessingPaused())
+	require.NoError(t, s.PauseForcedBatchProcessing(time.Hour))
+	assert.True(t, fin.isForcedBatchProcessingPaused())
+
+	// Without the force batch timeout the processing isn't paused
+	ethermanMock.On("ForceBatchTimeout").Return(time.Duration(0), testErr).Once()
+	require.NoError(t, s.PauseForcedBatchProcessing(0))
+	assert.ErrorIs(t, s.PauseForcedBatchProcessing(time.Hour), testErr)
+	assert.False(t, fin.isForcedBatchProcessingPaused())
+}
+
+// benchForcedBatchState implements the state methods used to process forced batches, the executor
+// returns immediately the same response for all the forced batches
+type benchForcedBatchState struct {
+	stateInterface
+	batchResponse *state.ProcessBatchResponse
+}
+
+func (s *benchForcedBatchState) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
+	return &benchDbTx{}, nil
+}
+
+func (s *benchForcedBatchState) GetLastTrustedForcedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return 0, nil
+}
+
+func (s *benchForcedBatchState) GetL1BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	return &state.Block{BlockNumber: blockNumber, ParentHash: benchL1ParentHeader.Hash()}, nil
+}
+
+func (s *benchForcedBatchState) OpenBatch(ctx context.Context, processingContext state.ProcessingContext, dbTx pgx.Tx) error {
+	return nil
+}
+
+func (s *benchForcedBatchState) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	return 7
+}
+
+func (s *benchForcedBatchState) ProcessBatchV2(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
+	return s.batchResponse, nil
+}
+
+func (s *benchForcedBatchState) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
+	return nil
+}
+
+func (s *benchForcedBatchState) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error {
+	return nil
+}
+
+func (s *benchForcedBatchState) StoreForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, hashes map[common.Hash]common.Address, dbTx pgx.Tx) error {
+	return nil
+}
+
+func (s *benchForcedBatchState) DeleteForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) error {
+	return nil
+}
+
+type benchDbTx struct {
+	pgx.Tx
+}
+
+func (tx *benchDbTx) Commit(ctx context.Context) error {
+	return nil
+}
+
+func (tx *benchDbTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+type benchForcedBatchWorker struct {
+	workerInterface
+}
+
+func (w *benchForcedBatchWorker) AddForcedTx(txHash common.Hash, addr common.Address) {}
+
+func (w *benchForcedBatchWorker) DeleteForcedTx(txHash common.Hash, addr common.Address) {}
+
+func (w *benchForcedBatchWorker) HasTx(txHash common.Hash, addr common.Address) bool {
+	return true
+}
+
+// newBenchForcedBatchResponse returns a batch response with a L2 block containing txCount signed transactions
+func newBenchForcedBatchResponse(tb testing.TB, txCount int) (*state.ProcessBatchResponse, []byte) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix("0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e", "0x"))
+	require.NoError(tb, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1000))
+	require.NoError(tb, err)
+
+	txs := make([]types.Transaction, 0, txCount)
+	effectivePercentages := make([]uint8, 0, txCount)
+	txResponses := make([]*state.ProcessTransactionResponse, 0, txCount)
+	for i := 0; i < txCount; i++ {
+		tx := types.NewTransaction(uint64(i), common.HexToAddress("0x1"), big.NewInt(1), 100000, big.NewInt(1), nil)
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(tb, err)
+		txs = append(txs, *signedTx)
+		effectivePercentages = append(effectivePercentages, state.MaxEffectivePercentage)
+		txResponses = append(txResponses, &state.ProcessTransactionResponse{TxHash: signedTx.Hash(), Tx: *signedTx})
+	}
+
+	rawTxsData, err := state.EncodeTransactions(txs, effectivePercentages, 7)
+	require.NoError(tb, err)
+
+	batchResponse := &state.ProcessBatchResponse{
+		NewStateRoot:    newHash,
+		NewAccInputHash: newHash,
+		BlockResponses: []*state.ProcessBlockResponse{
+			{
+				BlockNumber:          1,
+				TransactionResponses: txResponses,
+			},
+		},
+	}
+
+	return batchResponse, rawTxsData
+}
+
+func BenchmarkProcessForcedBatches(b *testing.B) {
+	for _, txCount := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("txs=%d", txCount), func(b *testing.B) {
+			batchResponse, rawTxsData := newBenchForcedBatchResponse(b, txCount)
+
+			st := &benchForcedBatchState{batchResponse: batchResponse}
+			ethMock := NewEthermanMock(b)
+			ethMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(benchL1ParentHeader, nil)
+			fin := newFinalizer(cfg, poolCfg, &benchForcedBatchWorker{}, nil, st, ethMock, seqAddr, nil, closingSignalCh, bc, nil, nil, nil)
+
+			forcedBatches := make([]state.ForcedBatch, 0, benchForcedBatchesPerIteration)
+			for i := 1; i <= benchForcedBatchesPerIteration; i++ {
+				forcedBatches = append(forcedBatches, state.ForcedBatch{
+					BlockNumber:       uint64(i),
+					ForcedBatchNumber: uint64(i),
+					Sequencer:         seqAddr,
+					GlobalExitRoot:    newHash,
+					RawTxsData:        rawTxsData,
+					ForcedAt:          time.Unix(1700000000, 0),
+				})
+			}
+
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				fin.nextForcedBatches = append(fin.nextForcedBatches, forcedBatches...)
+				lastBatchNumber, _, _ := fin.processForcedBatches(context.Background(), 0, oldHash, oldHash)
+				if lastBatchNumber != benchForcedBatchesPerIteration {
+					b.Fatalf("processed %d forced batches, expected %d", lastBatchNumber, benchForcedBatchesPerIteration)
+				}
+			}
+			b.ReportMetric(float64(b.N*benchForcedBatchesPerIteration)/time.Since(start).Seconds(), "forcedbatches/s")
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	integrationStateRoot    = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	integrationAccInputHash = common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	integrationBlockRoot    = common.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	integrationGER          = common.HexToHash("0x4444444444444444444444444444444444444444444444444444444444444444")
+)
+
+// executorClientFake is an in-process executor that returns a single empty L2 block for every batch
+type executorClientFake struct {
+	requests []*executor.ProcessBatchRequestV2
+}
+
+func (e *executorClientFake) ProcessBatch(ctx context.Context, in *executor.ProcessBatchRequest, opts ...grpc.CallOption) (*executor.ProcessBatchResponse, error) {
+	return nil, errors.New("ProcessBatch is not supported, use ProcessBatchV2")
+}
+
+func (e *executorClientFake) ProcessBatchV2(ctx context.Context, in *executor.ProcessBatchRequestV2, opts ...grpc.CallOption) (*executor.ProcessBatchResponseV2, error) {
+	e.requests = append(e.requests, in)
+	return &executor.ProcessBatchResponseV2{
+		NewStateRoot:    integrationStateRoot.Bytes(),
+		NewAccInputHash: integrationAccInputHash.Bytes(),
+		NewBatchNum:     in.OldBatchNum + 1,
+		Error:           executor.ExecutorError_EXECUTOR_ERROR_NO_ERROR,
+		ErrorRom:        executor.RomError_ROM_ERROR_NO_ERROR,
+		ForkId:          in.ForkId,
+		BlockResponses: []*executor.ProcessBlockResponseV2{
+			{
+				Coinbase:      in.Coinbase,
+				BlockNumber:   in.OldBatchNum + 1,
+				Timestamp:     in.TimestampLimit,
+				Ger:           in.L1InfoRoot,
+				BlockInfoRoot: integrationGER.Bytes(),
+				BlockHash:     integrationBlockRoot.Bytes(),
+				Error:         executor.RomError_ROM_ERROR_NO_ERROR,
+			},
+		},
+	}, nil
+}
+
+func (e *executorClientFake) GetFlushStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*executor.GetFlushStatusResponse, error) {
+	return &executor.GetFlushStatusResponse{}, nil
+}
+
+// setupIntegrationState creates a state backed by the test state DB and the fake executor, storing the
+// L1 block and the genesis batch needed to process forced batches
+func setupIntegrationState(t *testing.T, executorClient executor.ExecutorServiceClient) *state.State {
+	initOrResetDB()
+
+	stateDb, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	t.Cleanup(stateDb.Close)
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := state.NewState(stateCfg, pgstatestorage.NewPostgresStorage(stateCfg, stateDb), executorClient, nil, eventLog, nil)
+
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	err = st.AddBlock(ctx, &state.Block{BlockNumber: 1, BlockHash: common.HexToHash("0x1"), ReceivedAt: time.Now()}, dbTx)
+	require.NoError(t, err)
+	const addGenesisBatchSQL = "INSERT INTO state.batch (batch_num, global_exit_root, timestamp, state_root, acc_input_hash, wip) VALUES (0, $1, $2, $3, $4, false)"
+	_, err = dbTx.Exec(ctx, addGenesisBatchSQL, common.Hash{}.String(), time.Unix(0, 0), common.Hash{}.String(), common.Hash{}.String())
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	return st
+}
+
+func TestForcedBatchIntegration(t *testing.T) {
+	ctx := context.Background()
+	executorClient := &executorClientFake{}
+	st := setupIntegrationState(t, executorClient)
+
+	streamServer, err := datastreamer.NewServer(0, state.StreamTypeSequencer, filepath.Join(t.TempDir(), "datastream.bin"), nil)
+	require.NoError(t, err)
+	require.NoError(t, streamServer.Start())
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	// Build the sequencer with a finalizer using the real state
+	dataToStream := make(chan state.DSL2FullBlock, 1)
+	isSynced := func(ctx context.Context) bool { return true }
+	fin := newFinalizer(cfg, pool.Config{}, NewWorkerMock(t), NewPoolMock(t), st, NewEthermanMock(t), seqAddr, isSynced, ClosingSignalCh{}, bc, eventLog, streamServer, dataToStream)
+	seq := &Sequencer{stateI: st, eventLog: eventLog, streamServer: streamServer, dataToStream: dataToStream}
+	seq.finalizer.Store(fin)
+	go seq.sendDataToStreamer()
+
+	// Inject the forced batch through admin_forceBatchProcessing
+	forcedAt := time.Now().Unix()
+	admin := jsonrpc.NewAdminEndpoints(jsonrpc.Config{EnableAdminForceBatchProcessing: true}, nil, seq)
+	res, rpcErr := admin.ForceBatchProcessing(ctx, types.ArgBytes{}, integrationGER, types.ArgUint64(forcedAt))
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "0x1", res)
+
+	// Process the pending forced batches from the genesis batch
+	lastBatchNumber, stateRoot, accInputHash := fin.processForcedBatches(ctx, 0, common.Hash{}, common.Hash{})
+	assert.Equal(t, uint64(1), lastBatchNumber)
+	assert.Equal(t, integrationStateRoot, stateRoot)
+	assert.Equal(t, integrationAccInputHash, accInputHash)
+	require.Len(t, executorClient.requests, 1)
+	assert.Equal(t, integrationGER.Bytes(), executorClient.requests[0].L1InfoRoot)
+	assert.Equal(t, uint64(forcedAt), executorClient.requests[0].TimestampLimit)
+
+	// The forced batch not from L1 is stored without forced batch number, the L1 forced batch numbers are not used
+	batch, err := st.GetBatchByNumber(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Nil(t, batch.ForcedBatchNum)
+	assert.Equal(t, state.ForcedBatchClosingReason, batch.ClosingReason)
+	lastForcedBatchNumber, err := st.GetLastTrustedForcedBatchNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Zero(t, lastForcedBatchNumber)
+
+	// The L2 block of the forced batch is available through eth_getBlockByNumber
+	eth := jsonrpc.NewEthEndpoints(jsonrpc.Config{}, stateCfg.ChainID, nil, st, nil, seq, nil)
+	blockRes, rpcErr := eth.GetBlockByNumber(ctx, types.LatestBlockNumber, false)
+	require.Nil(t, rpcErr)
+	block, ok := blockRes.(*types.Block)
+	require.True(t, ok)
+	assert.Equal(t, types.ArgUint64(1), block.Number)
+	// In etrog the block hash returned by the executor is the state root of the L2 block
+	assert.Equal(t, integrationBlockRoot, block.StateRoot)
+	l2Block, err := st.GetL2BlockByNumber(ctx, 1, nil)
+	require.NoError(t, err)
+	require.NotNil(t, block.Hash)
+	assert.Equal(t, l2Block.Hash(), *block.Hash)
+	assert.Equal(t, types.ArgUint64(forcedAt), block.Timestamp)
+
+	// The L2 block of the forced batch is sent to the data stream (bookmark, L2 block start and L2 block end)
+	require.Eventually(t, func() bool {
+		return streamServer.GetHeader().TotalEntries == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	entry, err := streamServer.GetEntry(1)
+	require.NoError(t, err)
+	assert.Equal(t, state.EntryTypeL2BlockStart, entry.Type)
+	blockStart := state.DSL2BlockStart{}.Decode(entry.Data)
+	assert.Equal(t, uint64(1), blockStart.BatchNumber)
+	assert.Equal(t, uint64(1), blockStart.L2BlockNumber)
+	assert.Equal(t, forcedAt, blockStart.Timestamp)
+
+	entry, err = streamServer.GetEntry(2)
+	require.NoError(t, err)
+	assert.Equal(t, state.EntryTypeL2BlockEnd, entry.Type)
+}
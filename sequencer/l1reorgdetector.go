@@ -0,0 +1,109 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// l1ReorgDetectorMaxHeaders is the number of L1 headers kept by the L1 reorg detector to find the first reorged L1 block
+	l1ReorgDetectorMaxHeaders = 256
+)
+
+// L1ReorgEvent is the event that is triggered when a reorg happens in the L1
+type L1ReorgEvent struct {
+	// FirstReorgedBlockNumber is the number of the first L1 block that is no longer part of the canonical chain
+	FirstReorgedBlockNumber uint64
+}
+
+// l1ReorgDetector compares the parent hash of each new L1 header with the hash of the previous header
+// to detect when the L1 chain has been reorganized
+type l1ReorgDetector struct {
+	etherman etherman
+	// headers contains the hashes of the last L1 headers received, indexed by block number
+	headers map[uint64]common.Hash
+	// lastBlockNumber is the number of the last L1 header received
+	lastBlockNumber uint64
+}
+
+func newL1ReorgDetector(etherman etherman) *l1ReorgDetector {
+	return &l1ReorgDetector{
+		etherman: etherman,
+		headers:  make(map[uint64]common.Hash),
+	}
+}
+
+// checkHeader adds the new L1 header to the detector. If the header doesn't follow the previous headers received it
+// returns the L1ReorgEvent with the first L1 block that has been reorged
+func (d *l1ReorgDetector) checkHeader(ctx context.Context, header *types.Header) (*L1ReorgEvent, error) {
+	blockNumber := header.Number.Uint64()
+
+	var reorgEvent *L1ReorgEvent
+	if blockNumber > 0 {
+		if parentHash, found := d.headers[blockNumber-1]; found && parentHash != header.ParentHash {
+			firstReorgedBlockNumber, err := d.getFirstReorgedBlockNumber(ctx, blockNumber-1)
+			if err != nil {
+				return nil, err
+			}
+			reorgEvent = &L1ReorgEvent{FirstReorgedBlockNumber: firstReorgedBlockNumber}
+		}
+	}
+	// If we receive a header with a number lower or equal than the last one, the blocks after it have been reorged
+	if reorgEvent == nil && d.lastBlockNumber >= blockNumber {
+		if hash, found := d.headers[blockNumber]; found && hash != header.Hash() {
+			reorgEvent = &L1ReorgEvent{FirstReorgedBlockNumber: blockNumber}
+		}
+	}
+
+	if reorgEvent != nil {
+		for number := range d.headers {
+			if number >= reorgEvent.FirstReorgedBlockNumber {
+				delete(d.headers, number)
+			}
+		}
+	}
+
+	d.headers[blockNumber] = header.Hash()
+	d.lastBlockNumber = blockNumber
+
+	// Remove the headers that are too old to be checked
+	if blockNumber >= l1ReorgDetectorMaxHeaders {
+		for number := range d.headers {
+			if number <= blockNumber-l1ReorgDetectorMaxHeaders {
+				delete(d.headers, number)
+			}
+		}
+	}
+
+	return reorgEvent, nil
+}
+
+// getFirstReorgedBlockNumber walks back the headers stored, starting with fromBlockNumber, until it finds a header that is
+// still in the canonical chain. It returns the block number of the next header
+func (d *l1ReorgDetector) getFirstReorgedBlockNumber(ctx context.Context, fromBlockNumber uint64) (uint64, error) {
+	blockNumber := fromBlockNumber
+	for {
+		hash, found := d.headers[blockNumber]
+		if !found {
+			// We don't have older headers to compare with, we consider reorged the oldest header we have checked
+			return blockNumber + 1, nil
+		}
+
+		header, err := d.etherman.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		if err != nil {
+			return 0, err
+		}
+
+		if header.Hash() == hash {
+			return blockNumber + 1, nil
+		}
+
+		if blockNumber == 0 {
+			return 0, nil
+		}
+		blockNumber--
+	}
+}
@@ -0,0 +1,83 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestL1Header(number uint64, parentHash common.Hash, extra byte) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		ParentHash: parentHash,
+		Extra:      []byte{extra},
+	}
+}
+
+func TestL1ReorgDetector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("No reorg", func(t *testing.T) {
+		ethermanMock := NewEthermanMock(t)
+		detector := newL1ReorgDetector(ethermanMock)
+
+		header1 := newTestL1Header(1, common.Hash{}, 0)
+		header2 := newTestL1Header(2, header1.Hash(), 0)
+		header3 := newTestL1Header(3, header2.Hash(), 0)
+
+		for _, header := range []*types.Header{header1, header2, header3} {
+			reorgEvent, err := detector.checkHeader(ctx, header)
+			require.NoError(t, err)
+			assert.Nil(t, reorgEvent)
+		}
+	})
+
+	t.Run("Parent hash mismatch", func(t *testing.T) {
+		ethermanMock := NewEthermanMock(t)
+		detector := newL1ReorgDetector(ethermanMock)
+
+		header1 := newTestL1Header(1, common.Hash{}, 0)
+		header2 := newTestL1Header(2, header1.Hash(), 0)
+		header3 := newTestL1Header(3, header2.Hash(), 0)
+		for _, header := range []*types.Header{header1, header2, header3} {
+			_, err := detector.checkHeader(ctx, header)
+			require.NoError(t, err)
+		}
+
+		// Blocks 2 and 3 have been reorged
+		reorgedHeader2 := newTestL1Header(2, header1.Hash(), 1)
+		reorgedHeader3 := newTestL1Header(3, reorgedHeader2.Hash(), 1)
+		header4 := newTestL1Header(4, reorgedHeader3.Hash(), 1)
+		ethermanMock.On("HeaderByNumber", ctx, big.NewInt(3)).Return(reorgedHeader3, nil).Once()
+		ethermanMock.On("HeaderByNumber", ctx, big.NewInt(2)).Return(reorgedHeader2, nil).Once()
+		ethermanMock.On("HeaderByNumber", ctx, big.NewInt(1)).Return(header1, nil).Once()
+
+		reorgEvent, err := detector.checkHeader(ctx, header4)
+		require.NoError(t, err)
+		require.NotNil(t, reorgEvent)
+		assert.Equal(t, uint64(2), reorgEvent.FirstReorgedBlockNumber)
+	})
+
+	t.Run("Same height header", func(t *testing.T) {
+		ethermanMock := NewEthermanMock(t)
+		detector := newL1ReorgDetector(ethermanMock)
+
+		header1 := newTestL1Header(1, common.Hash{}, 0)
+		header2 := newTestL1Header(2, header1.Hash(), 0)
+		for _, header := range []*types.Header{header1, header2} {
+			_, err := detector.checkHeader(ctx, header)
+			require.NoError(t, err)
+		}
+
+		reorgedHeader2 := newTestL1Header(2, header1.Hash(), 1)
+		reorgEvent, err := detector.checkHeader(ctx, reorgedHeader2)
+		require.NoError(t, err)
+		require.NotNil(t, reorgEvent)
+		assert.Equal(t, uint64(2), reorgEvent.FirstReorgedBlockNumber)
+	})
+}
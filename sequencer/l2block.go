@@ -332,6 +332,7 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 
 	batch.BatchL2Data = append(batch.BatchL2Data, blockL2Data...)
 	batch.Resources.SumUp(state.BatchResources{ZKCounters: l2Block.batchResponse.UsedZkCounters, Bytes: uint64(len(blockL2Data))})
+	batch.Resources.CompressedBytes = state.CompressedBatchL2DataSize(batch.BatchL2Data)
 
 	receipt := state.ProcessingReceipt{
 		BatchNumber:    f.wipBatch.batchNumber,
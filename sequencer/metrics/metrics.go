@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
@@ -18,18 +20,61 @@ const (
 	TxProcessedName = Prefix + "transaction_processed"
 	// SequencesOversizedDataErrorName is the name of the metric that counts the sequences with oversized data error.
 	SequencesOversizedDataErrorName = Prefix + "sequences_oversized_data_error"
+	// L1ReorgDetectedName is the name of the metric that counts the L1 reorgs detected.
+	L1ReorgDetectedName = Prefix + "l1_reorg_detected_total"
+	// ForcedBatchRomOOCName is the name of the metric that counts the forced batches with ROM out of counters error.
+	ForcedBatchRomOOCName = Prefix + "forced_batch_rom_ooc_total"
 	// EthToPolPriceName is the name of the metric that shows the Ethereum to Pol price.
 	EthToPolPriceName = Prefix + "eth_to_pol_price"
 	// SequenceRewardInPolName is the name of the metric that shows the reward in Pol of a sequence.
 	SequenceRewardInPolName = Prefix + "sequence_reward_in_pol"
 	// ProcessingTimeName is the name of the metric that shows the processing time.
 	ProcessingTimeName = Prefix + "processing_time"
+	// BatchFeesName is the name of the metric that shows the fees of the txs of the closed batches.
+	BatchFeesName = Prefix + "batch_fees_wei"
+	// LastBatchClosedName is the name of the metric that shows the Unix timestamp of the last batch closed by the finalizer.
+	LastBatchClosedName = Prefix + "finalizer_last_batch_timestamp_seconds"
+	// BatchCloseReasonName is the name of the metric that counts the closed batches by closing reason.
+	BatchCloseReasonName = Prefix + "batch_close_reason_total"
 	// WorkerPrefix is the prefix for the metrics of the worker.
 	WorkerPrefix = Prefix + "worker_"
 	// WorkerProcessingTimeName is the name of the metric that shows the worker processing time.
 	WorkerProcessingTimeName = WorkerPrefix + "processing_time"
+	// WorkerAddressQueueDepthName is the name of the metric that shows the number of txs of an address in the worker.
+	WorkerAddressQueueDepthName = WorkerPrefix + "address_queue_depth"
+	// SequencesSkippedL1SubmissionName is the name of the metric that counts the sequences not sent to L1.
+	SequencesSkippedL1SubmissionName = Prefix + "sequences_skipped_l1_submission"
 	// TxProcessedLabelName is the name of the label for the processed transactions.
 	TxProcessedLabelName = "status"
+	// SequenceSkippedLabelName is the name of the label for the sequences not sent to L1.
+	SequenceSkippedLabelName = "reason"
+	// BatchCloseReasonLabelName is the name of the label for the closing reason of the closed batches.
+	BatchCloseReasonLabelName = "reason"
+	// AddressLabelName is the name of the label for the truncated address of the worker address queues.
+	AddressLabelName = "address"
+
+	// batchFeesBucketStart, batchFeesBucketFactor and batchFeesBucketCount are the buckets of the batch fees
+	// histogram, from 1e12 wei (1e-6 ETH) to 1e21 wei (1000 ETH)
+	batchFeesBucketStart  = 1e12
+	batchFeesBucketFactor = 10
+	batchFeesBucketCount  = 10
+
+	// batchCloseReasonUnknown is the reason label of the batches closed without closing reason
+	batchCloseReasonUnknown = "unknown"
+
+	// addressLabelPrefixLen and addressLabelSuffixLen are the characters of the address kept in the address label
+	addressLabelPrefixLen = 6
+	addressLabelSuffixLen = 4
+	// workerAddressQueueDepthMaxLabels is the max number of address labels of the worker address queue depth gauge,
+	// the addresses of new labels over the limit aren't reported until the queues of other labels are empty
+	workerAddressQueueDepthMaxLabels = 1000
+)
+
+var (
+	// workerAddressQueueDepths are the depths of the address queues reported on the worker address queue depth
+	// gauge, by address label and address. The addresses sharing a truncated label are added up on the label
+	workerAddressQueueDepths    = make(map[string]map[string]int)
+	workerAddressQueueDepthsMux sync.Mutex
 )
 
 // TxProcessedLabel represents the possible values for the
@@ -45,12 +90,24 @@ const (
 	TxProcessedLabelFailed TxProcessedLabel = "failed"
 )
 
+// SequenceSkippedLabel represents the possible values for the
+// `sequencer_sequences_skipped_l1_submission` metric `reason` label.
+type SequenceSkippedLabel string
+
+const (
+	// SequenceSkippedLabelLowTxCount represents a sequence with less txs than the minimum required
+	SequenceSkippedLabelLowTxCount SequenceSkippedLabel = "low_tx_count"
+	// SequenceSkippedLabelGasPriceCap represents a sequence not sent because the L1 gas price is over the cap
+	SequenceSkippedLabelGasPriceCap SequenceSkippedLabel = "gas_price_cap"
+)
+
 // Register the metrics for the sequencer package.
 func Register() {
 	var (
 		counters    []prometheus.CounterOpts
 		counterVecs []metrics.CounterVecOpts
 		gauges      []prometheus.GaugeOpts
+		gaugeVecs   []metrics.GaugeVecOpts
 		histograms  []prometheus.HistogramOpts
 	)
 
@@ -63,6 +120,14 @@ func Register() {
 			Name: SequencesOversizedDataErrorName,
 			Help: "[SEQUENCER] total count of sequences with oversized data error",
 		},
+		{
+			Name: L1ReorgDetectedName,
+			Help: "[SEQUENCER] total count of L1 reorgs detected",
+		},
+		{
+			Name: ForcedBatchRomOOCName,
+			Help: "[SEQUENCER] total count of forced batches with ROM out of counters error",
+		},
 	}
 
 	counterVecs = []metrics.CounterVecOpts{
@@ -73,6 +138,20 @@ func Register() {
 			},
 			Labels: []string{TxProcessedLabelName},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: SequencesSkippedL1SubmissionName,
+				Help: "[SEQUENCER] number of sequences not sent to L1",
+			},
+			Labels: []string{SequenceSkippedLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: BatchCloseReasonName,
+				Help: "[SEQUENCER] number of batches closed by closing reason",
+			},
+			Labels: []string{BatchCloseReasonLabelName},
+		},
 	}
 
 	gauges = []prometheus.GaugeOpts{
@@ -88,6 +167,20 @@ func Register() {
 			Name: SequenceRewardInPolName,
 			Help: "[SEQUENCER] reward for a sequence in pol",
 		},
+		{
+			Name: LastBatchClosedName,
+			Help: "[SEQUENCER] unix timestamp in seconds of the last batch closed by the finalizer",
+		},
+	}
+
+	gaugeVecs = []metrics.GaugeVecOpts{
+		{
+			GaugeOpts: prometheus.GaugeOpts{
+				Name: WorkerAddressQueueDepthName,
+				Help: "[SEQUENCER] number of ready and not ready txs of an address in the worker",
+			},
+			Labels: []string{AddressLabelName},
+		},
 	}
 
 	histograms = []prometheus.HistogramOpts{
@@ -99,11 +192,17 @@ func Register() {
 			Name: WorkerProcessingTimeName,
 			Help: "[SEQUENCER] worker processing time",
 		},
+		{
+			Name:    BatchFeesName,
+			Help:    "[SEQUENCER] fees in wei of the txs of the closed batches",
+			Buckets: prometheus.ExponentialBuckets(batchFeesBucketStart, batchFeesBucketFactor, batchFeesBucketCount),
+		},
 	}
 
 	metrics.RegisterCounters(counters...)
 	metrics.RegisterCounterVecs(counterVecs...)
 	metrics.RegisterGauges(gauges...)
+	metrics.RegisterGaugeVecs(gaugeVecs...)
 	metrics.RegisterHistograms(histograms...)
 }
 
@@ -124,12 +223,29 @@ func TxProcessed(status TxProcessedLabel, count float64) {
 	metrics.CounterVecAdd(TxProcessedName, string(status), count)
 }
 
+// SequencesSkippedL1Submission increases the counter vector for the sequences
+// not sent to L1 for the given label (reason).
+func SequencesSkippedL1Submission(reason SequenceSkippedLabel) {
+	metrics.CounterVecInc(SequencesSkippedL1SubmissionName, string(reason))
+}
+
 // SequencesOvesizedDataError increases the counter for sequences that
 // encounter a OversizedData error.
 func SequencesOvesizedDataError() {
 	metrics.CounterInc(SequencesOversizedDataErrorName)
 }
 
+// L1ReorgDetected increases the counter for L1 reorgs detected.
+func L1ReorgDetected() {
+	metrics.CounterInc(L1ReorgDetectedName)
+}
+
+// ForcedBatchRomOOC increases the counter for forced batches that
+// produce a ROM out of counters error.
+func ForcedBatchRomOOC() {
+	metrics.CounterInc(ForcedBatchRomOOCName)
+}
+
 // EthToPolPrice sets the gauge for the Ethereum to Pol price.
 func EthToPolPrice(price float64) {
 	metrics.GaugeSet(EthToPolPriceName, price)
@@ -140,6 +256,21 @@ func SequenceRewardInPol(reward float64) {
 	metrics.GaugeSet(SequenceRewardInPolName, reward)
 }
 
+// BatchClosed increases the counter vector for the batches closed with the given
+// closing reason, an empty reason is counted as unknown.
+func BatchClosed(reason string) {
+	if reason == "" {
+		reason = batchCloseReasonUnknown
+	}
+	metrics.CounterVecInc(BatchCloseReasonName, reason)
+}
+
+// LastBatchClosed sets the gauge to the time the finalizer closed the last batch, a stale
+// value means the finalizer is not making progress.
+func LastBatchClosed(closedAt time.Time) {
+	metrics.GaugeSet(LastBatchClosedName, float64(closedAt.Unix()))
+}
+
 // ProcessingTime observes the last processing time on the histogram.
 func ProcessingTime(lastProcessTime time.Duration) {
 	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
@@ -151,3 +282,54 @@ func WorkerProcessingTime(lastProcessTime time.Duration) {
 	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
 	metrics.HistogramObserve(WorkerProcessingTimeName, execTimeInSeconds)
 }
+
+// BatchFees observes the fees in wei of the txs of a closed batch on the histogram.
+func BatchFees(fees *big.Int) {
+	value, _ := new(big.Float).SetInt(fees).Float64()
+	metrics.HistogramObserve(BatchFeesName, value)
+}
+
+// WorkerAddressQueueDepth sets the number of ready and not ready txs of the address in the worker,
+// the address is truncated in the label to not expose the users, the gauge of the label is
+// removed when the depth of its addresses is 0. At most workerAddressQueueDepthMaxLabels labels
+// are reported.
+func WorkerAddressQueueDepth(address string, depth int) {
+	label := addressLabel(address)
+
+	workerAddressQueueDepthsMux.Lock()
+	defer workerAddressQueueDepthsMux.Unlock()
+
+	labelDepths, found := workerAddressQueueDepths[label]
+	if !found {
+		if depth == 0 || len(workerAddressQueueDepths) >= workerAddressQueueDepthMaxLabels {
+			return
+		}
+		labelDepths = make(map[string]int)
+		workerAddressQueueDepths[label] = labelDepths
+	}
+
+	if depth == 0 {
+		delete(labelDepths, address)
+	} else {
+		labelDepths[address] = depth
+	}
+	if len(labelDepths) == 0 {
+		delete(workerAddressQueueDepths, label)
+		metrics.GaugeVecDelete(WorkerAddressQueueDepthName, label)
+		return
+	}
+
+	labelDepth := 0
+	for _, addressDepth := range labelDepths {
+		labelDepth += addressDepth
+	}
+	metrics.GaugeVecSet(WorkerAddressQueueDepthName, label, float64(labelDepth))
+}
+
+// addressLabel returns the first and last characters of the address
+func addressLabel(address string) string {
+	if len(address) <= addressLabelPrefixLen+addressLabelSuffixLen {
+		return address
+	}
+	return address[:addressLabelPrefixLen] + "..." + address[len(address)-addressLabelSuffixLen:]
+}
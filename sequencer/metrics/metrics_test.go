@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerAddressQueueDepth(t *testing.T) {
+	metrics.Init()
+	Register()
+	gaugeVec, exist := metrics.GaugeVec(WorkerAddressQueueDepthName)
+	require.True(t, exist)
+
+	// The addresses sharing a truncated label are added up on the label
+	WorkerAddressQueueDepth("0x1234560000000000000000000000000000000001", 2)
+	WorkerAddressQueueDepth("0x1234561111111111111111111111111111110001", 3)
+	assert.Equal(t, float64(5), testutil.ToFloat64(gaugeVec.WithLabelValues("0x1234...0001")))
+
+	WorkerAddressQueueDepth("0x1234560000000000000000000000000000000001", 0)
+	assert.Equal(t, float64(3), testutil.ToFloat64(gaugeVec.WithLabelValues("0x1234...0001")))
+
+	// The label is removed when its addresses are empty
+	WorkerAddressQueueDepth("0x1234561111111111111111111111111111110001", 0)
+	assert.Equal(t, 0, testutil.CollectAndCount(gaugeVec))
+
+	// The new labels over the limit aren't reported
+	for i := 0; i < workerAddressQueueDepthMaxLabels+1; i++ {
+		WorkerAddressQueueDepth(fmt.Sprintf("0x%040x", i), 1)
+	}
+	assert.Equal(t, workerAddressQueueDepthMaxLabels, testutil.CollectAndCount(gaugeVec))
+
+	// A label is reported once there is room for it
+	WorkerAddressQueueDepth(fmt.Sprintf("0x%040x", 0), 0)
+	WorkerAddressQueueDepth(fmt.Sprintf("0x%040x", workerAddressQueueDepthMaxLabels), 1)
+	assert.Equal(t, float64(1), testutil.ToFloat64(gaugeVec.WithLabelValues(addressLabel(fmt.Sprintf("0x%040x", workerAddressQueueDepthMaxLabels)))))
+	assert.Equal(t, workerAddressQueueDepthMaxLabels, testutil.CollectAndCount(gaugeVec))
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package sequencer
 
@@ -10,8 +10,12 @@ import (
 
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 
+	ethereum "github.com/ethereum/go-ethereum"
+
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/0xPolygonHermez/zkevm-node/etherman/types"
 )
 
@@ -24,6 +28,10 @@ type EthermanMock struct {
 func (_m *EthermanMock) BuildSequenceBatchesTxData(sender common.Address, sequences []types.Sequence, l2CoinBase common.Address) (*common.Address, []byte, error) {
 	ret := _m.Called(sender, sequences, l2CoinBase)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BuildSequenceBatchesTxData")
+	}
+
 	var r0 *common.Address
 	var r1 []byte
 	var r2 error
@@ -59,6 +67,10 @@ func (_m *EthermanMock) BuildSequenceBatchesTxData(sender common.Address, sequen
 func (_m *EthermanMock) EstimateGasSequenceBatches(sender common.Address, sequences []types.Sequence, l2CoinBase common.Address) (*coretypes.Transaction, error) {
 	ret := _m.Called(sender, sequences, l2CoinBase)
 
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGasSequenceBatches")
+	}
+
 	var r0 *coretypes.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(common.Address, []types.Sequence, common.Address) (*coretypes.Transaction, error)); ok {
@@ -81,10 +93,42 @@ func (_m *EthermanMock) EstimateGasSequenceBatches(sender common.Address, sequen
 	return r0, r1
 }
 
+// ForceBatchTimeout provides a mock function with given fields:
+func (_m *EthermanMock) ForceBatchTimeout() (time.Duration, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceBatchTimeout")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (time.Duration, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLatestBatchNumber provides a mock function with given fields:
 func (_m *EthermanMock) GetLatestBatchNumber() (uint64, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBatchNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
@@ -109,6 +153,10 @@ func (_m *EthermanMock) GetLatestBatchNumber() (uint64, error) {
 func (_m *EthermanMock) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
@@ -133,6 +181,10 @@ func (_m *EthermanMock) GetLatestBlockNumber(ctx context.Context) (uint64, error
 func (_m *EthermanMock) GetLatestBlockTimestamp(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockTimestamp")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
@@ -157,6 +209,10 @@ func (_m *EthermanMock) GetLatestBlockTimestamp(ctx context.Context) (uint64, er
 func (_m *EthermanMock) GetSendSequenceFee(numBatches uint64) (*big.Int, error) {
 	ret := _m.Called(numBatches)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetSendSequenceFee")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(uint64) (*big.Int, error)); ok {
@@ -179,10 +235,74 @@ func (_m *EthermanMock) GetSendSequenceFee(numBatches uint64) (*big.Int, error)
 	return r0, r1
 }
 
+// HeaderByNumber provides a mock function with given fields: ctx, number
+func (_m *EthermanMock) HeaderByNumber(ctx context.Context, number *big.Int) (*coretypes.Header, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeaderByNumber")
+	}
+
+	var r0 *coretypes.Header
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) (*coretypes.Header, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) *coretypes.Header); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Header)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeNewHead provides a mock function with given fields: ctx, ch
+func (_m *EthermanMock) SubscribeNewHead(ctx context.Context, ch chan<- *coretypes.Header) (ethereum.Subscription, error) {
+	ret := _m.Called(ctx, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeNewHead")
+	}
+
+	var r0 ethereum.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, chan<- *coretypes.Header) (ethereum.Subscription, error)); ok {
+		return rf(ctx, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, chan<- *coretypes.Header) ethereum.Subscription); ok {
+		r0 = rf(ctx, ch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ethereum.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, chan<- *coretypes.Header) error); ok {
+		r1 = rf(ctx, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TrustedSequencer provides a mock function with given fields:
 func (_m *EthermanMock) TrustedSequencer() (common.Address, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for TrustedSequencer")
+	}
+
 	var r0 common.Address
 	var r1 error
 	if rf, ok := ret.Get(0).(func() (common.Address, error)); ok {
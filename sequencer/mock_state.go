@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package sequencer
 
@@ -30,6 +30,10 @@ type StateMock struct {
 func (_m *StateMock) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsEGPData []state.StoreTxEGPData, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, l2Block, receipts, txsEGPData, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AddL2Block")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, *state.L2Block, []*types.Receipt, []state.StoreTxEGPData, pgx.Tx) error); ok {
 		r0 = rf(ctx, batchNumber, l2Block, receipts, txsEGPData, dbTx)
@@ -44,6 +48,10 @@ func (_m *StateMock) AddL2Block(ctx context.Context, batchNumber uint64, l2Block
 func (_m *StateMock) Begin(ctx context.Context) (pgx.Tx, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
 	var r0 pgx.Tx
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (pgx.Tx, error)); ok {
@@ -70,6 +78,10 @@ func (_m *StateMock) Begin(ctx context.Context) (pgx.Tx, error) {
 func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BeginStateTransaction")
+	}
+
 	var r0 pgx.Tx
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) (pgx.Tx, error)); ok {
@@ -96,6 +108,10 @@ func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 func (_m *StateMock) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex uint32) []byte {
 	ret := _m.Called(deltaTimestamp, l1InfoTreeIndex)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BuildChangeL2Block")
+	}
+
 	var r0 []byte
 	if rf, ok := ret.Get(0).(func(uint32, uint32) []byte); ok {
 		r0 = rf(deltaTimestamp, l1InfoTreeIndex)
@@ -112,6 +128,10 @@ func (_m *StateMock) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex u
 func (_m *StateMock) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CloseBatch")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessingReceipt, pgx.Tx) error); ok {
 		r0 = rf(ctx, receipt, dbTx)
@@ -126,6 +146,10 @@ func (_m *StateMock) CloseBatch(ctx context.Context, receipt state.ProcessingRec
 func (_m *StateMock) CloseWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CloseWIPBatch")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessingReceipt, pgx.Tx) error); ok {
 		r0 = rf(ctx, receipt, dbTx)
@@ -140,6 +164,10 @@ func (_m *StateMock) CloseWIPBatch(ctx context.Context, receipt state.Processing
 func (_m *StateMock) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CountReorgs")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -160,10 +188,32 @@ func (_m *StateMock) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, erro
 	return r0, r1
 }
 
+// DeleteForcedTxHashes provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
+func (_m *StateMock) DeleteForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForcedTxHashes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ExecuteBatch provides a mock function with given fields: ctx, batch, updateMerkleTree, dbTx
 func (_m *StateMock) ExecuteBatch(ctx context.Context, batch state.Batch, updateMerkleTree bool, dbTx pgx.Tx) (*executor.ProcessBatchResponse, error) {
 	ret := _m.Called(ctx, batch, updateMerkleTree, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteBatch")
+	}
+
 	var r0 *executor.ProcessBatchResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.Batch, bool, pgx.Tx) (*executor.ProcessBatchResponse, error)); ok {
@@ -190,6 +240,10 @@ func (_m *StateMock) ExecuteBatch(ctx context.Context, batch state.Batch, update
 func (_m *StateMock) ExecuteBatchV2(ctx context.Context, batch state.Batch, l1InfoTree state.L1InfoTreeExitRootStorageEntry, timestampLimit time.Time, updateMerkleTree bool, skipVerifyL1InfoRoot uint32, forcedBlockHashL1 *common.Hash, dbTx pgx.Tx) (*executor.ProcessBatchResponseV2, error) {
 	ret := _m.Called(ctx, batch, l1InfoTree, timestampLimit, updateMerkleTree, skipVerifyL1InfoRoot, forcedBlockHashL1, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteBatchV2")
+	}
+
 	var r0 *executor.ProcessBatchResponseV2
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.Batch, state.L1InfoTreeExitRootStorageEntry, time.Time, bool, uint32, *common.Hash, pgx.Tx) (*executor.ProcessBatchResponseV2, error)); ok {
@@ -216,6 +270,10 @@ func (_m *StateMock) ExecuteBatchV2(ctx context.Context, batch state.Batch, l1In
 func (_m *StateMock) FlushMerkleTree(ctx context.Context, newStateRoot common.Hash) error {
 	ret := _m.Called(ctx, newStateRoot)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FlushMerkleTree")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) error); ok {
 		r0 = rf(ctx, newStateRoot)
@@ -230,6 +288,10 @@ func (_m *StateMock) FlushMerkleTree(ctx context.Context, newStateRoot common.Ha
 func (_m *StateMock) GetBalanceByStateRoot(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceByStateRoot")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash) (*big.Int, error)); ok {
@@ -256,6 +318,10 @@ func (_m *StateMock) GetBalanceByStateRoot(ctx context.Context, address common.A
 func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByNumber")
+	}
+
 	var r0 *state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
@@ -278,36 +344,14 @@ func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, d
 	return r0, r1
 }
 
-// GetBlockByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
-func (_m *StateMock) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
-	ret := _m.Called(ctx, blockNumber, dbTx)
-
-	var r0 *state.Block
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Block, error)); ok {
-		return rf(ctx, blockNumber, dbTx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Block); ok {
-		r0 = rf(ctx, blockNumber, dbTx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*state.Block)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
-		r1 = rf(ctx, blockNumber, dbTx)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetDSBatches provides a mock function with given fields: ctx, firstBatchNumber, lastBatchNumber, readWIPBatch, dbTx
 func (_m *StateMock) GetDSBatches(ctx context.Context, firstBatchNumber uint64, lastBatchNumber uint64, readWIPBatch bool, dbTx pgx.Tx) ([]*state.DSBatch, error) {
 	ret := _m.Called(ctx, firstBatchNumber, lastBatchNumber, readWIPBatch, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDSBatches")
+	}
+
 	var r0 []*state.DSBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, bool, pgx.Tx) ([]*state.DSBatch, error)); ok {
@@ -334,6 +378,10 @@ func (_m *StateMock) GetDSBatches(ctx context.Context, firstBatchNumber uint64,
 func (_m *StateMock) GetDSGenesisBlock(ctx context.Context, dbTx pgx.Tx) (*state.DSL2Block, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDSGenesisBlock")
+	}
+
 	var r0 *state.DSL2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.DSL2Block, error)); ok {
@@ -360,6 +408,10 @@ func (_m *StateMock) GetDSGenesisBlock(ctx context.Context, dbTx pgx.Tx) (*state
 func (_m *StateMock) GetDSL2Blocks(ctx context.Context, firstBatchNumber uint64, lastBatchNumber uint64, dbTx pgx.Tx) ([]*state.DSL2Block, error) {
 	ret := _m.Called(ctx, firstBatchNumber, lastBatchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDSL2Blocks")
+	}
+
 	var r0 []*state.DSL2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]*state.DSL2Block, error)); ok {
@@ -386,6 +438,10 @@ func (_m *StateMock) GetDSL2Blocks(ctx context.Context, firstBatchNumber uint64,
 func (_m *StateMock) GetDSL2Transactions(ctx context.Context, firstL2Block uint64, lastL2Block uint64, dbTx pgx.Tx) ([]*state.DSL2Transaction, error) {
 	ret := _m.Called(ctx, firstL2Block, lastL2Block, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDSL2Transactions")
+	}
+
 	var r0 []*state.DSL2Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]*state.DSL2Transaction, error)); ok {
@@ -412,6 +468,10 @@ func (_m *StateMock) GetDSL2Transactions(ctx context.Context, firstL2Block uint6
 func (_m *StateMock) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error) {
 	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetForcedBatch")
+	}
+
 	var r0 *state.ForcedBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.ForcedBatch, error)); ok {
@@ -438,6 +498,10 @@ func (_m *StateMock) GetForcedBatch(ctx context.Context, forcedBatchNumber uint6
 func (_m *StateMock) GetForcedBatchesSince(ctx context.Context, forcedBatchNumber uint64, maxBlockNumber uint64, dbTx pgx.Tx) ([]*state.ForcedBatch, error) {
 	ret := _m.Called(ctx, forcedBatchNumber, maxBlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetForcedBatchesSince")
+	}
+
 	var r0 []*state.ForcedBatch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]*state.ForcedBatch, error)); ok {
@@ -464,6 +528,10 @@ func (_m *StateMock) GetForcedBatchesSince(ctx context.Context, forcedBatchNumbe
 func (_m *StateMock) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
 	ret := _m.Called(batchNumber)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetForkIDByBatchNumber")
+	}
+
 	var r0 uint64
 	if rf, ok := ret.Get(0).(func(uint64) uint64); ok {
 		r0 = rf(batchNumber)
@@ -474,10 +542,44 @@ func (_m *StateMock) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
 	return r0
 }
 
+// GetL1BlockByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetL1BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1BlockByNumber")
+	}
+
+	var r0 *state.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Block, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Block); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetL1InfoTreeDataFromBatchL2Data provides a mock function with given fields: ctx, batchL2Data, dbTx
 func (_m *StateMock) GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, error) {
 	ret := _m.Called(ctx, batchL2Data, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoTreeDataFromBatchL2Data")
+	}
+
 	var r0 map[uint32]state.L1DataV2
 	var r1 common.Hash
 	var r2 error
@@ -513,6 +615,10 @@ func (_m *StateMock) GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batch
 func (_m *StateMock) GetLastBatch(ctx context.Context, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastBatch")
+	}
+
 	var r0 *state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.Batch, error)); ok {
@@ -539,6 +645,10 @@ func (_m *StateMock) GetLastBatch(ctx context.Context, dbTx pgx.Tx) (*state.Batc
 func (_m *StateMock) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastBatchNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -563,6 +673,10 @@ func (_m *StateMock) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint6
 func (_m *StateMock) GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastBlock")
+	}
+
 	var r0 *state.Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.Block, error)); ok {
@@ -589,6 +703,10 @@ func (_m *StateMock) GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Bloc
 func (_m *StateMock) GetLastClosedBatch(ctx context.Context, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastClosedBatch")
+	}
+
 	var r0 *state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.Batch, error)); ok {
@@ -615,6 +733,10 @@ func (_m *StateMock) GetLastClosedBatch(ctx context.Context, dbTx pgx.Tx) (*stat
 func (_m *StateMock) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastL2Block")
+	}
+
 	var r0 *state.L2Block
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.L2Block, error)); ok {
@@ -641,6 +763,10 @@ func (_m *StateMock) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2
 func (_m *StateMock) GetLastL2BlockHeader(ctx context.Context, dbTx pgx.Tx) (*state.L2Header, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastL2BlockHeader")
+	}
+
 	var r0 *state.L2Header
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.L2Header, error)); ok {
@@ -667,6 +793,10 @@ func (_m *StateMock) GetLastL2BlockHeader(ctx context.Context, dbTx pgx.Tx) (*st
 func (_m *StateMock) GetLastNBatches(ctx context.Context, numBatches uint, dbTx pgx.Tx) ([]*state.Batch, error) {
 	ret := _m.Called(ctx, numBatches, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastNBatches")
+	}
+
 	var r0 []*state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint, pgx.Tx) ([]*state.Batch, error)); ok {
@@ -693,6 +823,10 @@ func (_m *StateMock) GetLastNBatches(ctx context.Context, numBatches uint, dbTx
 func (_m *StateMock) GetLastStateRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastStateRoot")
+	}
+
 	var r0 common.Hash
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (common.Hash, error)); ok {
@@ -719,6 +853,10 @@ func (_m *StateMock) GetLastStateRoot(ctx context.Context, dbTx pgx.Tx) (common.
 func (_m *StateMock) GetLastTrustedForcedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastTrustedForcedBatchNumber")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -743,6 +881,10 @@ func (_m *StateMock) GetLastTrustedForcedBatchNumber(ctx context.Context, dbTx p
 func (_m *StateMock) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVirtualBatchNum")
+	}
+
 	var r0 uint64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
@@ -767,6 +909,10 @@ func (_m *StateMock) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (u
 func (_m *StateMock) GetLatestGer(ctx context.Context, maxBlockNumber uint64) (state.GlobalExitRoot, time.Time, error) {
 	ret := _m.Called(ctx, maxBlockNumber)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestGer")
+	}
+
 	var r0 state.GlobalExitRoot
 	var r1 time.Time
 	var r2 error
@@ -798,6 +944,10 @@ func (_m *StateMock) GetLatestGer(ctx context.Context, maxBlockNumber uint64) (s
 func (_m *StateMock) GetLatestGlobalExitRoot(ctx context.Context, maxBlockNumber uint64, dbTx pgx.Tx) (state.GlobalExitRoot, time.Time, error) {
 	ret := _m.Called(ctx, maxBlockNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestGlobalExitRoot")
+	}
+
 	var r0 state.GlobalExitRoot
 	var r1 time.Time
 	var r2 error
@@ -829,6 +979,10 @@ func (_m *StateMock) GetLatestGlobalExitRoot(ctx context.Context, maxBlockNumber
 func (_m *StateMock) GetLatestL1InfoRoot(ctx context.Context, maxBlockNumber uint64) (state.L1InfoTreeExitRootStorageEntry, error) {
 	ret := _m.Called(ctx, maxBlockNumber)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestL1InfoRoot")
+	}
+
 	var r0 state.L1InfoTreeExitRootStorageEntry
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64) (state.L1InfoTreeExitRootStorageEntry, error)); ok {
@@ -853,6 +1007,10 @@ func (_m *StateMock) GetLatestL1InfoRoot(ctx context.Context, maxBlockNumber uin
 func (_m *StateMock) GetLatestVirtualBatchTimestamp(ctx context.Context, dbTx pgx.Tx) (time.Time, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestVirtualBatchTimestamp")
+	}
+
 	var r0 time.Time
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (time.Time, error)); ok {
@@ -877,6 +1035,10 @@ func (_m *StateMock) GetLatestVirtualBatchTimestamp(ctx context.Context, dbTx pg
 func (_m *StateMock) GetNonceByStateRoot(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonceByStateRoot")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash) (*big.Int, error)); ok {
@@ -899,10 +1061,44 @@ func (_m *StateMock) GetNonceByStateRoot(ctx context.Context, address common.Add
 	return r0, r1
 }
 
+// GetPendingForcedTxHashes provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetPendingForcedTxHashes(ctx context.Context, dbTx pgx.Tx) (map[common.Hash]common.Address, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingForcedTxHashes")
+	}
+
+	var r0 map[common.Hash]common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (map[common.Hash]common.Address, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) map[common.Hash]common.Address); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Hash]common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageAt provides a mock function with given fields: ctx, address, position, root
 func (_m *StateMock) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, position, root)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageAt")
+	}
+
 	var r0 *big.Int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int, common.Hash) (*big.Int, error)); ok {
@@ -929,6 +1125,10 @@ func (_m *StateMock) GetStorageAt(ctx context.Context, address common.Address, p
 func (_m *StateMock) GetStoredFlushID(ctx context.Context) (uint64, string, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetStoredFlushID")
+	}
+
 	var r0 uint64
 	var r1 string
 	var r2 error
@@ -960,6 +1160,10 @@ func (_m *StateMock) GetStoredFlushID(ctx context.Context) (uint64, string, erro
 func (_m *StateMock) GetTimeForLatestBatchVirtualization(ctx context.Context, dbTx pgx.Tx) (time.Time, error) {
 	ret := _m.Called(ctx, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeForLatestBatchVirtualization")
+	}
+
 	var r0 time.Time
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (time.Time, error)); ok {
@@ -984,6 +1188,10 @@ func (_m *StateMock) GetTimeForLatestBatchVirtualization(ctx context.Context, db
 func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]types.Transaction, []uint8, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByBatchNumber")
+	}
+
 	var r0 []types.Transaction
 	var r1 []uint8
 	var r2 error
@@ -1019,6 +1227,10 @@ func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumb
 func (_m *StateMock) GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks uint64, dbTx pgx.Tx) ([]common.Hash, error) {
 	ret := _m.Called(ctx, nL1Blocks, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxsOlderThanNL1Blocks")
+	}
+
 	var r0 []common.Hash
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]common.Hash, error)); ok {
@@ -1045,6 +1257,10 @@ func (_m *StateMock) GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks uin
 func (_m *StateMock) GetWIPBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetWIPBatch")
+	}
+
 	var r0 *state.Batch
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
@@ -1071,6 +1287,10 @@ func (_m *StateMock) GetWIPBatch(ctx context.Context, batchNumber uint64, dbTx p
 func (_m *StateMock) IsBatchClosed(ctx context.Context, batchNum uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, batchNum, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for IsBatchClosed")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
@@ -1095,6 +1315,10 @@ func (_m *StateMock) IsBatchClosed(ctx context.Context, batchNum uint64, dbTx pg
 func (_m *StateMock) OpenBatch(ctx context.Context, processingContext state.ProcessingContext, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, processingContext, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBatch")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessingContext, pgx.Tx) error); ok {
 		r0 = rf(ctx, processingContext, dbTx)
@@ -1109,6 +1333,10 @@ func (_m *StateMock) OpenBatch(ctx context.Context, processingContext state.Proc
 func (_m *StateMock) OpenWIPBatch(ctx context.Context, batch state.Batch, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batch, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for OpenWIPBatch")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.Batch, pgx.Tx) error); ok {
 		r0 = rf(ctx, batch, dbTx)
@@ -1123,6 +1351,10 @@ func (_m *StateMock) OpenWIPBatch(ctx context.Context, batch state.Batch, dbTx p
 func (_m *StateMock) ProcessBatch(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
 	ret := _m.Called(ctx, request, updateMerkleTree)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessBatch")
+	}
+
 	var r0 *state.ProcessBatchResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessRequest, bool) (*state.ProcessBatchResponse, error)); ok {
@@ -1149,6 +1381,10 @@ func (_m *StateMock) ProcessBatch(ctx context.Context, request state.ProcessRequ
 func (_m *StateMock) ProcessBatchV2(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
 	ret := _m.Called(ctx, request, updateMerkleTree)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessBatchV2")
+	}
+
 	var r0 *state.ProcessBatchResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessRequest, bool) (*state.ProcessBatchResponse, error)); ok {
@@ -1171,10 +1407,32 @@ func (_m *StateMock) ProcessBatchV2(ctx context.Context, request state.ProcessRe
 	return r0, r1
 }
 
+// StoreForcedTxHashes provides a mock function with given fields: ctx, forcedBatchNumber, hashes, dbTx
+func (_m *StateMock) StoreForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, hashes map[common.Hash]common.Address, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, forcedBatchNumber, hashes, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreForcedTxHashes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, map[common.Hash]common.Address, pgx.Tx) error); ok {
+		r0 = rf(ctx, forcedBatchNumber, hashes, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, txsEGPLog, dbTx
 func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for StoreL2Block")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, *state.ProcessBlockResponse, []*state.EffectiveGasPriceLog, pgx.Tx) error); ok {
 		r0 = rf(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
@@ -1189,6 +1447,10 @@ func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Blo
 func (_m *StateMock) StoreTransaction(ctx context.Context, batchNumber uint64, processedTx *state.ProcessTransactionResponse, coinbase common.Address, timestamp uint64, egpLog *state.EffectiveGasPriceLog, dbTx pgx.Tx) (*state.L2Header, error) {
 	ret := _m.Called(ctx, batchNumber, processedTx, coinbase, timestamp, egpLog, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for StoreTransaction")
+	}
+
 	var r0 *state.L2Header
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint64, *state.ProcessTransactionResponse, common.Address, uint64, *state.EffectiveGasPriceLog, pgx.Tx) (*state.L2Header, error)); ok {
@@ -1215,6 +1477,10 @@ func (_m *StateMock) StoreTransaction(ctx context.Context, batchNumber uint64, p
 func (_m *StateMock) UpdateWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWIPBatch")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, state.ProcessingReceipt, pgx.Tx) error); ok {
 		r0 = rf(ctx, receipt, dbTx)
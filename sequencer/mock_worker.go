@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.39.0. DO NOT EDIT.
 
 package sequencer
 
@@ -34,6 +34,10 @@ func (_m *WorkerMock) AddPendingTxToStore(txHash common.Hash, addr common.Addres
 func (_m *WorkerMock) AddTxTracker(ctx context.Context, txTracker *TxTracker) (*TxTracker, error) {
 	ret := _m.Called(ctx, txTracker)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AddTxTracker")
+	}
+
 	var r0 *TxTracker
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *TxTracker) (*TxTracker, error)); ok {
@@ -75,6 +79,10 @@ func (_m *WorkerMock) DeleteTx(txHash common.Hash, from common.Address) {
 func (_m *WorkerMock) GetBestFittingTx(resources state.BatchResources) (*TxTracker, error) {
 	ret := _m.Called(resources)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetBestFittingTx")
+	}
+
 	var r0 *TxTracker
 	var r1 error
 	if rf, ok := ret.Get(0).(func(state.BatchResources) (*TxTracker, error)); ok {
@@ -97,15 +105,75 @@ func (_m *WorkerMock) GetBestFittingTx(resources state.BatchResources) (*TxTrack
 	return r0, r1
 }
 
+// GetPendingNonce provides a mock function with given fields: address
+func (_m *WorkerMock) GetPendingNonce(address common.Address) uint64 {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingNonce")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Address) uint64); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // HandleL2Reorg provides a mock function with given fields: txHashes
 func (_m *WorkerMock) HandleL2Reorg(txHashes []common.Hash) {
 	_m.Called(txHashes)
 }
 
+// HasTx provides a mock function with given fields: txHash, addr
+func (_m *WorkerMock) HasTx(txHash common.Hash, addr common.Address) bool {
+	ret := _m.Called(txHash, addr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasTx")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Hash, common.Address) bool); ok {
+		r0 = rf(txHash, addr)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Inspect provides a mock function with given fields:
+func (_m *WorkerMock) Inspect() []state.PendingTxSummary {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inspect")
+	}
+
+	var r0 []state.PendingTxSummary
+	if rf, ok := ret.Get(0).(func() []state.PendingTxSummary); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.PendingTxSummary)
+		}
+	}
+
+	return r0
+}
+
 // MoveTxToNotReady provides a mock function with given fields: txHash, from, actualNonce, actualBalance
 func (_m *WorkerMock) MoveTxToNotReady(txHash common.Hash, from common.Address, actualNonce *uint64, actualBalance *big.Int) []*TxTracker {
 	ret := _m.Called(txHash, from, actualNonce, actualBalance)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTxToNotReady")
+	}
+
 	var r0 []*TxTracker
 	if rf, ok := ret.Get(0).(func(common.Hash, common.Address, *uint64, *big.Int) []*TxTracker); ok {
 		r0 = rf(txHash, from, actualNonce, actualBalance)
@@ -122,6 +190,10 @@ func (_m *WorkerMock) MoveTxToNotReady(txHash common.Hash, from common.Address,
 func (_m *WorkerMock) NewTxTracker(tx types.Transaction, counters state.ZKCounters, ip string) (*TxTracker, error) {
 	ret := _m.Called(tx, counters, ip)
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewTxTracker")
+	}
+
 	var r0 *TxTracker
 	var r1 error
 	if rf, ok := ret.Get(0).(func(types.Transaction, state.ZKCounters, string) (*TxTracker, error)); ok {
@@ -148,6 +220,10 @@ func (_m *WorkerMock) NewTxTracker(tx types.Transaction, counters state.ZKCounte
 func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker {
 	ret := _m.Called(from, touchedAddresses)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAfterSingleSuccessfulTxExecution")
+	}
+
 	var r0 []*TxTracker
 	if rf, ok := ret.Get(0).(func(common.Address, map[common.Address]*state.InfoReadWrite) []*TxTracker); ok {
 		r0 = rf(from, touchedAddresses)
@@ -2,7 +2,12 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
@@ -18,6 +23,11 @@ const (
 	datastreamChannelMultiplier = 2
 )
 
+var (
+	// ErrSequencerNotStarted is returned when the sequencer is required to be running to perform an action
+	ErrSequencerNotStarted = errors.New("sequencer not started")
+)
+
 // Sequencer represents a sequencer
 type Sequencer struct {
 	cfg      Config
@@ -34,6 +44,7 @@ type Sequencer struct {
 	dataToStream chan state.DSL2FullBlock
 
 	closingSignalCh ClosingSignalCh
+	finalizer       atomic.Pointer[finalizer]
 
 	numberOfStateInconsistencies uint64
 	address                      common.Address
@@ -50,14 +61,23 @@ type ClosingSignalCh struct {
 	GERCh                chan common.Hash
 	L1InfoTreeExitRootCh chan state.L1InfoTreeExitRootStorageEntry
 	L2ReorgCh            chan L2ReorgEvent
+	L1ReorgCh            chan L1ReorgEvent
 }
 
 // New init sequencer
 func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txPool, stateI stateInterface, etherman etherman, eventLog *event.EventLog) (*Sequencer, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	addr, err := etherman.TrustedSequencer()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trusted sequencer address, err: %v", err)
 	}
+	if addr == (common.Address{}) {
+		return nil, fmt.Errorf("%w: the trusted sequencer address is zero", ErrInvalidConfig)
+	}
 
 	sequencer := &Sequencer{
 		cfg:      cfg,
@@ -88,6 +108,7 @@ func (s *Sequencer) Start(ctx context.Context) {
 		GERCh:                make(chan common.Hash),
 		L1InfoTreeExitRootCh: make(chan state.L1InfoTreeExitRootStorageEntry),
 		L2ReorgCh:            make(chan L2ReorgEvent),
+		L1ReorgCh:            make(chan L1ReorgEvent),
 	}
 
 	err := s.pool.MarkWIPTxsAsPending(ctx)
@@ -95,7 +116,7 @@ func (s *Sequencer) Start(ctx context.Context) {
 		log.Fatalf("failed to mark WIP txs as pending, err: %v", err)
 	}
 
-	s.worker = NewWorker(s.stateI, s.batchCfg.Constraints)
+	s.worker = NewWorker(s.stateI, s.batchCfg.Constraints, s.cfg.MaxTxsPerAddress)
 	//dbManager := newDBManager(ctx, s.cfg.DBManager, s.pool, s.state, worker, closingSignalCh, s.batchCfg.Constraints)
 
 	// Start stream server if enabled
@@ -127,8 +148,15 @@ func (s *Sequencer) Start(ctx context.Context) {
 	}
 
 	finalizer := newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateI, s.etherman, s.address, s.isSynced, s.closingSignalCh, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.dataToStream)
+	s.finalizer.Store(finalizer)
 	go finalizer.Start(ctx)
 
+	if s.cfg.DynamicConfigFile != "" {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGHUP)
+		go s.reloadDynamicConfigOnSignal(ctx, signals, finalizer)
+	}
+
 	closingSignalsManager := newClosingSignalsManager(ctx, s.stateI, s.closingSignalCh, finalizer.cfg, s.etherman)
 	go closingSignalsManager.Start()
 
@@ -210,6 +238,8 @@ func (s *Sequencer) loadFromPool(ctx context.Context) {
 	for {
 		time.Sleep(s.cfg.DBManager.PoolRetrievalInterval.Duration)
 
+		s.unparkPoolTxs(ctx)
+
 		poolTransactions, err := s.pool.GetNonWIPPendingTxs(ctx)
 		if err != nil && err != pool.ErrNotFound {
 			log.Errorf("load tx from pool: %v", err)
@@ -224,13 +254,30 @@ func (s *Sequencer) loadFromPool(ctx context.Context) {
 	}
 }
 
+// unparkPoolTxs sets as non WIP the parked txs whose address has room for them in the worker, so they are loaded
+// again from the pool. If the update fails the tx stays WIP until the sequencer restarts and marks the WIP txs as pending
+func (s *Sequencer) unparkPoolTxs(ctx context.Context) {
+	for _, txHash := range s.worker.unparkTxs() {
+		err := s.pool.UpdateTxWIPStatus(ctx, txHash, false)
+		if err != nil {
+			log.Errorf("failed to unpark tx %s in the pool, err: %v", txHash.String(), err)
+		}
+	}
+}
+
 func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) error {
 	txTracker, err := s.worker.NewTxTracker(tx.Transaction, tx.ZKCounters, tx.IP)
 	if err != nil {
 		return err
 	}
 	replacedTx, dropReason := s.worker.AddTxTracker(ctx, txTracker)
-	if dropReason != nil {
+	if errors.Is(dropReason, ErrAddressPoolFull) {
+		// the tx is parked as WIP in the pool, so it isn't loaded again until the address has room for it
+		return s.pool.UpdateTxWIPStatus(ctx, txTracker.Hash, true)
+	} else if errors.Is(dropReason, pool.ErrAlreadyKnown) {
+		// the tx is already in the worker, it must not be set as failed in the pool
+		return s.pool.UpdateTxWIPStatus(ctx, txTracker.Hash, true)
+	} else if dropReason != nil {
 		failedReason := dropReason.Error()
 		return s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
 	} else {
@@ -335,6 +382,114 @@ func waitTick(ctx context.Context, ticker *time.Ticker) {
 	}
 }
 
+// ForceBatchProcessing adds a forced batch that has not been sent to L1 to the forced batches pending to be processed.
+// It returns the forced batch number assigned, which is numbered apart from the L1 forced batches. It must only be used
+// for testing purposes
+func (s *Sequencer) ForceBatchProcessing(ctx context.Context, rawTxsData []byte, globalExitRoot common.Hash, forcedAt time.Time) (uint64, error) {
+	f := s.finalizer.Load()
+	if f == nil {
+		return 0, ErrSequencerNotStarted
+	}
+	return f.addForcedBatchNotFromL1(rawTxsData, globalExitRoot, forcedAt), nil
+}
+
+// GetPendingNonce returns the next nonce of the address including the txs queued in the worker.
+// It returns 0 if the sequencer is not started or the address has no txs in the worker
+func (s *Sequencer) GetPendingNonce(address common.Address) uint64 {
+	f := s.finalizer.Load()
+	if f == nil {
+		return 0
+	}
+	return f.worker.GetPendingNonce(address)
+}
+
+// GetSequencerState returns a snapshot of the sequencer internal state
+func (s *Sequencer) GetSequencerState() (state.SequencerState, error) {
+	f := s.finalizer.Load()
+	if f == nil {
+		return state.SequencerState{}, ErrSequencerNotStarted
+	}
+	return f.getSequencerState(), nil
+}
+
+// Running returns if the finalizer is running, it's false until the sequencer is synced and
+// starts the finalizer, while the finalizer is halted and after the finalizer main loop exits
+func (s *Sequencer) Running() bool {
+	f := s.finalizer.Load()
+	return f != nil && f.Running()
+}
+
+// Halted returns if the finalizer is halted due to a fatal error
+func (s *Sequencer) Halted() bool {
+	f := s.finalizer.Load()
+	return f != nil && f.Halted()
+}
+
+// Alive returns false if the finalizer is halted or its main loop stopped sending heartbeats for
+// more than Finalizer.LivenessTimeout, it's true until the sequencer starts the finalizer
+func (s *Sequencer) Alive() bool {
+	f := s.finalizer.Load()
+	return f == nil || f.Alive()
+}
+
+// InspectWorker returns a summary of the txs waiting in the worker
+func (s *Sequencer) InspectWorker() ([]state.PendingTxSummary, error) {
+	f := s.finalizer.Load()
+	if f == nil {
+		return nil, ErrSequencerNotStarted
+	}
+	return f.worker.Inspect(), nil
+}
+
+// PauseForcedBatchProcessing pauses the processing of the forced batches for the duration without stopping
+// the sequencer, the regular batches are still built. A duration <= 0 resumes the processing. The duration
+// must be shorter than the L1 force batch timeout, after it anyone can sequence the pending forced batches
+func (s *Sequencer) PauseForcedBatchProcessing(duration time.Duration) error {
+	f := s.finalizer.Load()
+	if f == nil {
+		return ErrSequencerNotStarted
+	}
+	if duration > 0 {
+		forceBatchTimeout, err := s.etherman.ForceBatchTimeout()
+		if err != nil {
+			return fmt.Errorf("failed to get the force batch timeout, err: %w", err)
+		}
+		if duration >= forceBatchTimeout {
+			return fmt.Errorf("%w, pause: %v, force batch timeout: %v", ErrForcedBatchPauseTooLong, duration, forceBatchTimeout)
+		}
+	}
+	f.pauseForcedBatchProcessing(duration)
+	return nil
+}
+
+// SetDynamicConfig changes the value of a finalizer timing parameter without restarting the node,
+// the value is a duration expressed in units (e.g. "3s")
+func (s *Sequencer) SetDynamicConfig(field string, value string) error {
+	f := s.finalizer.Load()
+	if f == nil {
+		return ErrSequencerNotStarted
+	}
+	return f.dynamicCfg.set(field, value)
+}
+
+// reloadDynamicConfigOnSignal applies the DynamicConfigFile JSON patch to the finalizer dynamic config
+// each time a signal is received
+func (s *Sequencer) reloadDynamicConfigOnSignal(ctx context.Context, signals chan os.Signal, f *finalizer) {
+	defer signal.Stop(signals)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			log.Infof("reloading dynamic config from %s", s.cfg.DynamicConfigFile)
+			err := f.dynamicCfg.applyPatchFile(s.cfg.DynamicConfigFile)
+			if err != nil {
+				log.Errorf("failed to reload dynamic config from %s, err: %v", s.cfg.DynamicConfigFile, err)
+			}
+		}
+	}
+}
+
 func (s *Sequencer) isSynced(ctx context.Context) bool {
 	lastSyncedBatchNum, err := s.stateI.GetLastVirtualBatchNum(ctx, nil)
 	if err != nil && err != state.ErrNotFound {
@@ -16,6 +16,8 @@ type TxTracker struct {
 	From              common.Address
 	FromStr           string
 	Nonce             uint64
+	To                *common.Address
+	Value             *big.Int
 	Gas               uint64 // To check if it fits into a batch
 	GasPrice          *big.Int
 	Cost              *big.Int             // Cost = Amount + Benefit
@@ -51,6 +53,8 @@ func newTxTracker(tx types.Transaction, counters state.ZKCounters, ip string) (*
 		From:     addr,
 		FromStr:  addr.String(),
 		Nonce:    tx.Nonce(),
+		To:       tx.To(),
+		Value:    tx.Value(),
 		Gas:      tx.Gas(),
 		GasPrice: tx.GasPrice(),
 		Cost:     tx.Cost(),
@@ -1,15 +1,18 @@
 package sequencer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -22,15 +25,26 @@ type Worker struct {
 	workerMutex      sync.Mutex
 	state            stateInterface
 	batchConstraints state.BatchConstraintsCfg
+	// maxTxsPerAddress is the max number of ready and not ready txs of an address, 0 means no limit
+	maxTxsPerAddress uint
+	// queuelessForcedTxs are the forced txs of the addresses without addrQueue, by address,
+	// they are moved to the addrQueue when it's created
+	queuelessForcedTxs map[string]map[common.Hash]struct{}
+	// parkedTxs are the nonces of the txs not added because their address had maxTxsPerAddress txs, by address and
+	// tx hash. They are set as WIP in the pool, so they are not loaded again until the address has room for them
+	parkedTxs map[string]map[common.Hash]uint64
 }
 
 // NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg) *Worker {
+func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, maxTxsPerAddress uint) *Worker {
 	w := Worker{
-		pool:             make(map[string]*addrQueue),
-		txSortedList:     newTxSortedList(),
-		state:            state,
-		batchConstraints: constraints,
+		pool:               make(map[string]*addrQueue),
+		txSortedList:       newTxSortedList(),
+		state:              state,
+		batchConstraints:   constraints,
+		maxTxsPerAddress:   maxTxsPerAddress,
+		queuelessForcedTxs: make(map[string]map[common.Hash]struct{}),
+		parkedTxs:          make(map[string]map[common.Hash]uint64),
 	}
 
 	return &w
@@ -58,6 +72,13 @@ func (w *Worker) AddTxTracker(ctx context.Context, tx *TxTracker) (replacedTx *T
 		return nil, pool.ErrOutOfCounters
 	}
 
+	// Make sure the transaction's gas limit fits in a batch.
+	if err := pool.CheckBatchGasLimit(tx.Gas, w.batchConstraints.MaxCumulativeGasUsed); err != nil {
+		log.Errorf("%v for tx: %s", err, tx.Hash.String())
+		w.workerMutex.Unlock()
+		return nil, err
+	}
+
 	addr, found := w.pool[tx.FromStr]
 	if !found {
 		// Unlock the worker to let execute other worker functions while creating the new AddrQueue
@@ -89,6 +110,29 @@ func (w *Worker) AddTxTracker(ctx context.Context, tx *TxTracker) (replacedTx *T
 
 		w.pool[tx.FromStr] = addr
 		log.Debugf("new addrQueue created for addr(%s) nonce(%d) balance(%s)", tx.FromStr, nonce.Uint64(), balance.String())
+
+		for forcedTxHash := range w.queuelessForcedTxs[tx.FromStr] {
+			addr.addForcedTx(forcedTxHash)
+		}
+		delete(w.queuelessForcedTxs, tx.FromStr)
+	}
+
+	// The tx can be loaded again from the pool while it's still in the worker
+	if addr.hasTx(tx.Hash) {
+		log.Debugf("tx(%s) not added to addrQueue(%s), reason: %s", tx.HashStr, tx.FromStr, pool.ErrAlreadyKnown.Error())
+		w.workerMutex.Unlock()
+		return nil, pool.ErrAlreadyKnown
+	}
+
+	// A tx replacing another one with the same nonce doesn't increase the txs of the address
+	if w.maxTxsPerAddress > 0 && !addr.hasNonce(tx.Nonce) && addr.txCount() >= int(w.maxTxsPerAddress) {
+		log.Debugf("tx(%s) not added to addrQueue(%s), reason: %s", tx.HashStr, tx.FromStr, ErrAddressPoolFull.Error())
+		if _, found := w.parkedTxs[tx.FromStr]; !found {
+			w.parkedTxs[tx.FromStr] = make(map[common.Hash]uint64)
+		}
+		w.parkedTxs[tx.FromStr][tx.Hash] = tx.Nonce
+		w.workerMutex.Unlock()
+		return nil, ErrAddressPoolFull
 	}
 
 	// Add the txTracker to Addr and get the newReadyTx and prevReadyTx
@@ -114,11 +158,49 @@ func (w *Worker) AddTxTracker(ctx context.Context, tx *TxTracker) (replacedTx *T
 	if repTx != nil {
 		log.Debugf("[AddTxTracker] replacedTx(%s) nonce(%d) gasPrice(%d) addr(%s) has been replaced", repTx.HashStr, repTx.Nonce, repTx.GasPrice, tx.FromStr)
 	}
+	metrics.WorkerAddressQueueDepth(addr.fromStr, addr.txCount())
 
 	w.workerMutex.Unlock()
 	return repTx, nil
 }
 
+// unparkTxs removes from the parked txs the ones whose address has room for them in the worker, with the lowest
+// nonces first, and returns their hashes so they can be loaded again from the pool
+func (w *Worker) unparkTxs() []common.Hash {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	unparkedTxs := []common.Hash{}
+	for fromStr, parkedTxs := range w.parkedTxs {
+		room := int(w.maxTxsPerAddress)
+		if addr, found := w.pool[fromStr]; found {
+			room -= addr.txCount()
+		}
+		if room <= 0 {
+			continue
+		}
+
+		txHashes := make([]common.Hash, 0, len(parkedTxs))
+		for txHash := range parkedTxs {
+			txHashes = append(txHashes, txHash)
+		}
+		sort.Slice(txHashes, func(i, j int) bool { return parkedTxs[txHashes[i]] < parkedTxs[txHashes[j]] })
+		if len(txHashes) > room {
+			txHashes = txHashes[:room]
+		}
+
+		for _, txHash := range txHashes {
+			delete(parkedTxs, txHash)
+		}
+		if len(parkedTxs) == 0 {
+			delete(w.parkedTxs, fromStr)
+		}
+		unparkedTxs = append(unparkedTxs, txHashes...)
+	}
+
+	return unparkedTxs
+}
+
 func (w *Worker) applyAddressUpdate(from common.Address, fromNonce *uint64, fromBalance *big.Int) (*TxTracker, *TxTracker, []*TxTracker) {
 	addrQueue, found := w.pool[from.String()]
 
@@ -134,6 +216,7 @@ func (w *Worker) applyAddressUpdate(from common.Address, fromNonce *uint64, from
 			log.Debugf("[applyAddressUpdate] newReadyTx(%s) nonce(%d) gasPrice(%d) added to TxSortedList", newReadyTx.Hash.String(), newReadyTx.Nonce, newReadyTx.GasPrice)
 			w.txSortedList.add(newReadyTx)
 		}
+		metrics.WorkerAddressQueueDepth(addrQueue.fromStr, addrQueue.txCount())
 
 		return newReadyTx, prevReadyTx, txsToDelete
 	}
@@ -200,6 +283,7 @@ func (w *Worker) DeleteTx(txHash common.Hash, addr common.Address) {
 			log.Debugf("[DeleteTx] tx(%s) deleted from TxSortedList", deletedReadyTx.Hash.String())
 			w.txSortedList.delete(deletedReadyTx)
 		}
+		metrics.WorkerAddressQueueDepth(addrQueue.fromStr, addrQueue.txCount())
 	} else {
 		log.Warnf("[DeleteTx] addrQueue(%s) not found", addr.String())
 	}
@@ -213,6 +297,11 @@ func (w *Worker) DeleteForcedTx(txHash common.Hash, addr common.Address) {
 	addrQueue, found := w.pool[addr.String()]
 	if found {
 		addrQueue.deleteForcedTx(txHash)
+	} else if forcedTxs, found := w.queuelessForcedTxs[addr.String()]; found {
+		delete(forcedTxs, txHash)
+		if len(forcedTxs) == 0 {
+			delete(w.queuelessForcedTxs, addr.String())
+		}
 	} else {
 		log.Warnf("[DeleteForcedTx] addrQueue(%s) not found", addr.String())
 	}
@@ -257,7 +346,8 @@ func (w *Worker) AddPendingTxToStore(txHash common.Hash, addr common.Address) {
 	}
 }
 
-// AddForcedTx adds a forced tx to the addrQueue
+// AddForcedTx adds a forced tx to the addrQueue, the forced txs of an address without addrQueue
+// are kept until its addrQueue is created
 func (w *Worker) AddForcedTx(txHash common.Hash, addr common.Address) {
 	w.workerMutex.Lock()
 	defer w.workerMutex.Unlock()
@@ -267,7 +357,13 @@ func (w *Worker) AddForcedTx(txHash common.Hash, addr common.Address) {
 	if found {
 		addrQueue.addForcedTx(txHash)
 	} else {
-		log.Warnf("[AddForcedTx] addrQueue(%s) not found", addr.String())
+		forcedTxs, found := w.queuelessForcedTxs[addr.String()]
+		if !found {
+			forcedTxs = make(map[common.Hash]struct{})
+			w.queuelessForcedTxs[addr.String()] = forcedTxs
+		}
+		forcedTxs[txHash] = struct{}{}
+		log.Debugf("[AddForcedTx] addrQueue(%s) not found, forced tx(%s) kept until it's created", addr.String(), txHash.String())
 	}
 }
 
@@ -285,6 +381,73 @@ func (w *Worker) DeletePendingTxToStore(txHash common.Hash, addr common.Address)
 	}
 }
 
+// HasTx returns true if the tx from the addr is known by the worker
+func (w *Worker) HasTx(txHash common.Hash, addr common.Address) bool {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	addrQueue, found := w.pool[addr.String()]
+	if !found {
+		_, found = w.queuelessForcedTxs[addr.String()][txHash]
+		return found
+	}
+
+	return addrQueue.hasTx(txHash)
+}
+
+// GetPendingNonce returns the next nonce of the address including the txs queued in the worker,
+// it returns 0 if the address has no txs in the worker
+func (w *Worker) GetPendingNonce(address common.Address) uint64 {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	addrQueue, found := w.pool[address.String()]
+	if !found {
+		return 0
+	}
+
+	return addrQueue.getPendingNonce()
+}
+
+// Inspect returns a summary of the ready and not ready txs in the worker, sorted by address and nonce
+func (w *Worker) Inspect() []state.PendingTxSummary {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	summaries := []state.PendingTxSummary{}
+	for _, addrQueue := range w.pool {
+		if addrQueue.readyTx != nil {
+			summaries = append(summaries, newPendingTxSummary(addrQueue.readyTx, false))
+		}
+		for _, tx := range addrQueue.notReadyTxs {
+			summaries = append(summaries, newPendingTxSummary(tx, true))
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].From != summaries[j].From {
+			return bytes.Compare(summaries[i].From.Bytes(), summaries[j].From.Bytes()) < 0
+		}
+		return summaries[i].Nonce < summaries[j].Nonce
+	})
+
+	return summaries
+}
+
+func newPendingTxSummary(tx *TxTracker, queued bool) state.PendingTxSummary {
+	return state.PendingTxSummary{
+		Hash:       tx.Hash,
+		From:       tx.From,
+		Nonce:      tx.Nonce,
+		GasPrice:   tx.GasPrice,
+		GasLimit:   tx.Gas,
+		ReceivedAt: tx.ReceivedAt,
+		To:         tx.To,
+		Value:      tx.Value,
+		Queued:     queued,
+	}
+}
+
 // GetBestFittingTx gets the most efficient tx that fits in the available batch resources
 func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, error) {
 	w.workerMutex.Lock()
@@ -360,6 +523,7 @@ func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 		if prevReadyTx != nil {
 			w.txSortedList.delete(prevReadyTx)
 		}
+		metrics.WorkerAddressQueueDepth(addrQueue.fromStr, addrQueue.txCount())
 
 		if addrQueue.IsEmpty() {
 			delete(w.pool, addrQueue.fromStr)
@@ -4,11 +4,15 @@ import (
 	"context"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -40,6 +44,7 @@ type workerAddTxTestCase struct {
 	counters             state.ZKCounters
 	usedBytes            uint64
 	gasPrice             *big.Int
+	gas                  uint64
 	expectedTxSortedList []common.Hash
 	ip                   string
 	expectedErr          error
@@ -64,6 +69,7 @@ func processWorkerAddTxTestCases(ctx context.Context, t *testing.T, worker *Work
 			tx.Cost = testCase.cost
 			tx.BatchResources.Bytes = testCase.usedBytes
 			tx.GasPrice = testCase.gasPrice
+			tx.Gas = testCase.gas
 			tx.updateZKCounters(testCase.counters)
 			if testCase.ip == "" {
 				// A random valid IP Address
@@ -134,12 +140,22 @@ func TestWorkerAddTx(t *testing.T) {
 			},
 		},
 		{
-			name: "Readding from:0x02, tx:0x02/gp:20", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
+			name: "Replacing from:0x02, tx:0x06/gp:20", from: common.Address{2}, txHash: common.Hash{6}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
 			cost:      new(big.Int).SetInt64(5),
 			counters:  state.ZKCounters{GasUsed: 5, UsedKeccakHashes: 5, UsedPoseidonHashes: 5, UsedPoseidonPaddings: 5, UsedMemAligns: 5, UsedArithmetics: 5, UsedBinaries: 5, UsedSteps: 5, UsedSha256Hashes_V2: 5},
 			usedBytes: 5,
 			expectedTxSortedList: []common.Hash{
-				{2}, {1},
+				{6}, {1},
+			},
+		},
+		{
+			name: "Readding from:0x02, tx:0x06/gp:20", from: common.Address{2}, txHash: common.Hash{6}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
+			cost:        new(big.Int).SetInt64(5),
+			counters:    state.ZKCounters{GasUsed: 5, UsedKeccakHashes: 5, UsedPoseidonHashes: 5, UsedPoseidonPaddings: 5, UsedMemAligns: 5, UsedArithmetics: 5, UsedBinaries: 5, UsedSteps: 5, UsedSha256Hashes_V2: 5},
+			usedBytes:   5,
+			expectedErr: pool.ErrAlreadyKnown,
+			expectedTxSortedList: []common.Hash{
+				{6}, {1},
 			},
 		},
 		{
@@ -148,7 +164,7 @@ func TestWorkerAddTx(t *testing.T) {
 			counters:  state.ZKCounters{GasUsed: 2, UsedKeccakHashes: 2, UsedPoseidonHashes: 2, UsedPoseidonPaddings: 2, UsedMemAligns: 2, UsedArithmetics: 2, UsedBinaries: 2, UsedSteps: 2, UsedSha256Hashes_V2: 2},
 			usedBytes: 2,
 			expectedTxSortedList: []common.Hash{
-				{3}, {2}, {1},
+				{3}, {6}, {1},
 			},
 		},
 		{
@@ -177,13 +193,21 @@ func TestWorkerAddTx(t *testing.T) {
 			usedBytes:   1,
 			expectedErr: pool.ErrOutOfCounters,
 		},
+		{
+			name: "Gas limit above the batch gas limit", from: common.Address{5}, txHash: common.Hash{5}, nonce: 1,
+			cost:        new(big.Int).SetInt64(5),
+			gas:         worker.batchConstraints.MaxCumulativeGasUsed + 1,
+			counters:    state.ZKCounters{GasUsed: 1, UsedKeccakHashes: 1, UsedPoseidonHashes: 1, UsedPoseidonPaddings: 1, UsedMemAligns: 1, UsedArithmetics: 1, UsedBinaries: 1, UsedSteps: 1, UsedSha256Hashes_V2: 1},
+			usedBytes:   1,
+			expectedErr: pool.ErrGasLimitTooHigh,
+		},
 		{
 			name: "Adding from:0x04, tx:0x04/gp:100", from: common.Address{4}, txHash: common.Hash{4}, nonce: 1, gasPrice: new(big.Int).SetInt64(100),
 			cost:      new(big.Int).SetInt64(5),
 			counters:  state.ZKCounters{GasUsed: 1, UsedKeccakHashes: 1, UsedPoseidonHashes: 1, UsedPoseidonPaddings: 1, UsedMemAligns: 1, UsedArithmetics: 1, UsedBinaries: 1, UsedSteps: 1, UsedSha256Hashes_V2: 1},
 			usedBytes: 1,
 			expectedTxSortedList: []common.Hash{
-				{4}, {3}, {2}, {1},
+				{4}, {3}, {6}, {1},
 			},
 		},
 	}
@@ -288,7 +312,277 @@ func TestWorkerGetBestTx(t *testing.T) {
 	}
 }
 
+func TestWorkerGetPendingNonce(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+	from := common.Address{1}
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	assert.Equal(t, uint64(0), worker.GetPendingNonce(from))
+
+	// txs with nonces 1, 2 and 4, the nonce 3 is missing
+	for _, nonce := range []uint64{2, 4, 1} {
+		tx := &TxTracker{
+			Hash:     common.Hash{byte(nonce)},
+			HashStr:  common.Hash{byte(nonce)}.String(),
+			From:     from,
+			FromStr:  from.String(),
+			Nonce:    nonce,
+			Cost:     new(big.Int).SetInt64(1),
+			GasPrice: new(big.Int).SetInt64(1),
+			IP:       validIP,
+		}
+		_, err := worker.AddTxTracker(ctx, tx)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, uint64(3), worker.GetPendingNonce(from))
+	assert.Equal(t, uint64(0), worker.GetPendingNonce(common.Address{2}))
+}
+
+func TestWorkerHasTx(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+	from := common.Address{1}
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	readyTxHash := common.Hash{1}
+	notReadyTxHash := common.Hash{3}
+	forcedTxHash := common.Hash{4}
+	pendingTxToStoreHash := common.Hash{5}
+
+	assert.False(t, worker.HasTx(readyTxHash, from))
+
+	for nonce, txHash := range map[uint64]common.Hash{1: readyTxHash, 3: notReadyTxHash} {
+		tx := &TxTracker{
+			Hash:     txHash,
+			HashStr:  txHash.String(),
+			From:     from,
+			FromStr:  from.String(),
+			Nonce:    nonce,
+			Cost:     new(big.Int).SetInt64(1),
+			GasPrice: new(big.Int).SetInt64(1),
+			IP:       validIP,
+		}
+		_, err := worker.AddTxTracker(ctx, tx)
+		assert.NoError(t, err)
+	}
+	worker.AddForcedTx(forcedTxHash, from)
+	worker.AddPendingTxToStore(pendingTxToStoreHash, from)
+
+	assert.True(t, worker.HasTx(readyTxHash, from))
+	assert.True(t, worker.HasTx(notReadyTxHash, from))
+	assert.True(t, worker.HasTx(forcedTxHash, from))
+	assert.True(t, worker.HasTx(pendingTxToStoreHash, from))
+	assert.False(t, worker.HasTx(common.Hash{6}, from))
+	assert.False(t, worker.HasTx(readyTxHash, common.Address{2}))
+
+	worker.DeleteForcedTx(forcedTxHash, from)
+	assert.False(t, worker.HasTx(forcedTxHash, from))
+}
+
+func TestWorkerForcedTxWithoutAddrQueue(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+	from := common.Address{1}
+	forcedTxHash := common.Hash{4}
+	deletedForcedTxHash := common.Hash{5}
+
+	// The forced txs of an address without addrQueue are kept by the worker
+	worker.AddForcedTx(forcedTxHash, from)
+	worker.AddForcedTx(deletedForcedTxHash, from)
+	assert.True(t, worker.HasTx(forcedTxHash, from))
+	assert.False(t, worker.HasTx(forcedTxHash, common.Address{2}))
+	worker.DeleteForcedTx(deletedForcedTxHash, from)
+	assert.False(t, worker.HasTx(deletedForcedTxHash, from))
+
+	// and moved to the addrQueue when it's created
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	txHash := common.Hash{1}
+	_, err := worker.AddTxTracker(ctx, &TxTracker{
+		Hash:     txHash,
+		HashStr:  txHash.String(),
+		From:     from,
+		FromStr:  from.String(),
+		Nonce:    1,
+		Cost:     new(big.Int).SetInt64(1),
+		GasPrice: new(big.Int).SetInt64(1),
+		IP:       validIP,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, worker.queuelessForcedTxs)
+	assert.True(t, worker.HasTx(forcedTxHash, from))
+
+	worker.DeleteForcedTx(forcedTxHash, from)
+	assert.False(t, worker.HasTx(forcedTxHash, from))
+}
+
+func TestWorkerMaxTxsPerAddress(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := NewWorker(stateMock, rcMax, 2)
+
+	ctx := context.Background()
+	from := common.Address{1}
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	newTx := func(nonce uint64, gasPrice int64) *TxTracker {
+		txHash := common.Hash{byte(nonce), byte(gasPrice)}
+		return &TxTracker{
+			Hash:     txHash,
+			HashStr:  txHash.String(),
+			From:     from,
+			FromStr:  from.String(),
+			Nonce:    nonce,
+			Cost:     new(big.Int).SetInt64(1),
+			GasPrice: new(big.Int).SetInt64(gasPrice),
+			IP:       validIP,
+		}
+	}
+
+	// The ready and the not ready txs count for the limit
+	_, err := worker.AddTxTracker(ctx, newTx(1, 1))
+	assert.NoError(t, err)
+	_, err = worker.AddTxTracker(ctx, newTx(3, 1))
+	assert.NoError(t, err)
+	_, err = worker.AddTxTracker(ctx, newTx(4, 1))
+	assert.ErrorIs(t, err, ErrAddressPoolFull)
+
+	// A tx replacing another one is accepted
+	replacedTx, err := worker.AddTxTracker(ctx, newTx(3, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, newTx(3, 1).Hash, replacedTx.Hash)
+
+	// The txs over the limit are parked until the address has room for them
+	_, err = worker.AddTxTracker(ctx, newTx(5, 1))
+	assert.ErrorIs(t, err, ErrAddressPoolFull)
+	assert.Empty(t, worker.unparkTxs())
+
+	// Deleting a tx makes room for the parked tx with the lowest nonce
+	worker.DeleteTx(newTx(1, 1).Hash, from)
+	assert.Equal(t, []common.Hash{newTx(4, 1).Hash}, worker.unparkTxs())
+	_, err = worker.AddTxTracker(ctx, newTx(4, 1))
+	assert.NoError(t, err)
+	assert.Empty(t, worker.unparkTxs())
+}
+
+func TestWorkerInspect(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+	fromA := common.Address{2}
+	fromB := common.Address{1}
+	to := common.Address{3}
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, from := range []common.Address{fromA, fromB} {
+		stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	newTx := func(from common.Address, nonce uint64) *TxTracker {
+		txHash := common.Hash{from[0], byte(nonce)}
+		return &TxTracker{
+			Hash:       txHash,
+			HashStr:    txHash.String(),
+			From:       from,
+			FromStr:    from.String(),
+			Nonce:      nonce,
+			To:         &to,
+			Value:      new(big.Int).SetInt64(1),
+			Gas:        5,
+			Cost:       new(big.Int).SetInt64(1),
+			GasPrice:   new(big.Int).SetInt64(2),
+			ReceivedAt: time.Unix(int64(nonce), 0),
+			IP:         validIP,
+		}
+	}
+
+	assert.Empty(t, worker.Inspect())
+
+	for _, tx := range []*TxTracker{newTx(fromA, 3), newTx(fromA, 1), newTx(fromB, 1)} {
+		_, err := worker.AddTxTracker(ctx, tx)
+		assert.NoError(t, err)
+	}
+
+	summaries := worker.Inspect()
+	assert.Equal(t, []state.PendingTxSummary{
+		{Hash: newTx(fromB, 1).Hash, From: fromB, Nonce: 1, GasPrice: big.NewInt(2), GasLimit: 5, ReceivedAt: time.Unix(1, 0), To: &to, Value: big.NewInt(1)},
+		{Hash: newTx(fromA, 1).Hash, From: fromA, Nonce: 1, GasPrice: big.NewInt(2), GasLimit: 5, ReceivedAt: time.Unix(1, 0), To: &to, Value: big.NewInt(1)},
+		{Hash: newTx(fromA, 3).Hash, From: fromA, Nonce: 3, GasPrice: big.NewInt(2), GasLimit: 5, ReceivedAt: time.Unix(3, 0), To: &to, Value: big.NewInt(1), Queued: true},
+	}, summaries)
+}
+
+func TestSequencerAddTxToWorkerAddressPoolFull(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	poolMock := NewPoolMock(t)
+	s := &Sequencer{pool: poolMock, worker: NewWorker(stateMock, rcMax, 1)}
+
+	ctx := context.Background()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(0), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	newPoolTx := func(nonce uint64) pool.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{2}, big.NewInt(0), 1, big.NewInt(1), nil), types.HomesteadSigner{}, privateKey)
+		require.NoError(t, err)
+		return pool.Transaction{Transaction: *tx, IP: validIP}
+	}
+
+	// The tx over the limit is parked as WIP in the pool, so it isn't loaded again
+	firstTx, secondTx := newPoolTx(0), newPoolTx(1)
+	poolMock.On("UpdateTxWIPStatus", ctx, firstTx.Hash(), true).Return(nilErr).Once()
+	require.NoError(t, s.addTxToWorker(ctx, firstTx))
+	poolMock.On("UpdateTxWIPStatus", ctx, secondTx.Hash(), true).Return(nilErr).Once()
+	require.NoError(t, s.addTxToWorker(ctx, secondTx))
+	assert.False(t, s.worker.HasTx(secondTx.Hash(), from))
+
+	// It isn't unparked while the address is full
+	s.unparkPoolTxs(ctx)
+
+	// It's unparked and added once the address has room for it
+	s.worker.DeleteTx(firstTx.Hash(), from)
+	poolMock.On("UpdateTxWIPStatus", ctx, secondTx.Hash(), false).Return(nilErr).Once()
+	s.unparkPoolTxs(ctx)
+	poolMock.On("UpdateTxWIPStatus", ctx, secondTx.Hash(), true).Return(nilErr).Once()
+	require.NoError(t, s.addTxToWorker(ctx, secondTx))
+	assert.True(t, s.worker.HasTx(secondTx.Hash(), from))
+}
+
 func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax)
+	worker := NewWorker(stateMock, rcMax, 0)
 	return worker
 }
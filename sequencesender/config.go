@@ -25,6 +25,10 @@ type Config struct {
 	// PrivateKey defines all the key store files that are going
 	// to be read in order to provide the private keys to sign the L1 txs
 	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+	// HSMKeyARN is the ARN of the AWS KMS key used to sign the L1 txs instead of
+	// PrivateKey, the sender address is derived from the public key of the HSM key. The AWS credentials
+	// are retrieved with the AWS default credential chain (environment, shared config files, IRSA, IAM role)
+	HSMKeyARN string `mapstructure:"HSMKeyARN"`
 	// Batch number where there is a forkid change (fork upgrade)
 	ForkUpgradeBatchNumber uint64
 	// GasOffset is the amount of gas to be added to the gas estimation in order
@@ -38,4 +42,20 @@ type Config struct {
 	// gas offset: 100
 	// final gas: 1100
 	GasOffset uint64 `mapstructure:"GasOffset"`
+	// MinTxCountForL1Submission is the minimum number of txs the batches of a sequence must contain
+	// to send the sequence to L1. This avoids paying gas to send sequences of empty batches. The
+	// sequences with a forced batch or the fork upgrade batch are always sent, and the rest are sent
+	// anyway once their first batch is older than MaxWaitForL1Submission
+	MinTxCountForL1Submission int `mapstructure:"MinTxCountForL1Submission"`
+	// MaxWaitForL1Submission is the max time the sequences wait to be sent to L1 when they have less
+	// than MinTxCountForL1Submission txs or the L1 gas price is over MaxL1GasPriceWei, counted from
+	// the opening of their first batch
+	MaxWaitForL1Submission types.Duration `mapstructure:"MaxWaitForL1Submission"`
+	// MaxL1GasPriceWei is the max L1 gas price, in wei, allowed to send a sequence to L1. If the current
+	// L1 gas price is greater than this value the sequence is not sent, unless its first batch is older
+	// than MaxWaitForL1Submission. Default value is 0, which means no limit.
+	//
+	// ex:
+	// 100 gwei: MaxL1GasPriceWei = 100000000000
+	MaxL1GasPriceWei uint64 `mapstructure:"MaxL1GasPriceWei"`
 }
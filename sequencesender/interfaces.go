@@ -22,6 +22,7 @@ type etherman interface {
 	// GetLastBatchTimestamp() (uint64, error)
 	GetLatestBlockTimestamp(ctx context.Context) (uint64, error)
 	GetLatestBatchNumber() (uint64, error)
+	GetL1GasPrice(ctx context.Context) *big.Int
 }
 
 // stateInterface gathers the methods required to interact with the state.
@@ -32,6 +33,7 @@ type stateInterface interface {
 	GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error)
 	GetTimeForLatestBatchVirtualization(ctx context.Context, dbTx pgx.Tx) (time.Time, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	CountTransactionsByBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error)
 }
 
 type ethTxManager interface {
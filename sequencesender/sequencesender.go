@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	ethman "github.com/0xPolygonHermez/zkevm-node/etherman"
@@ -92,6 +93,12 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context, ticker *time.Tic
 		return
 	}
 
+	// Check if the sequences are worth to be sent to L1
+	if !s.isWorthSendingSequences(ctx, sequences) {
+		waitTick(ctx, ticker)
+		return
+	}
+
 	lastVirtualBatchNum, err := s.state.GetLastVirtualBatchNum(ctx, nil)
 	if err != nil {
 		log.Errorf("failed to get last virtual batch num, err: %v", err)
@@ -231,6 +238,75 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 	return nil, nil
 }
 
+// isWorthSendingSequences checks if the sequences contain enough txs and if the current L1 gas price
+// is below the configured limit to send the sequences to L1
+func (s *SequenceSender) isWorthSendingSequences(ctx context.Context, sequences []types.Sequence) bool {
+	if s.cfg.MinTxCountForL1Submission > 0 && !s.mustSendSequences(sequences) {
+		var txCount uint64
+		for _, seq := range sequences {
+			count, err := s.state.CountTransactionsByBatch(ctx, seq.BatchNumber, nil)
+			if err != nil {
+				log.Warnf("failed to count txs of batch %d, sending sequences as a conservative approach, err: %v", seq.BatchNumber, err)
+				return true
+			}
+			txCount += count
+		}
+
+		if txCount < uint64(s.cfg.MinTxCountForL1Submission) {
+			log.Warnf("skipping L1 submission, sequences from batch %d to batch %d contain %d txs (min %d)",
+				sequences[0].BatchNumber, sequences[len(sequences)-1].BatchNumber, txCount, s.cfg.MinTxCountForL1Submission)
+			metrics.SequencesSkippedL1Submission(metrics.SequenceSkippedLabelLowTxCount)
+			return false
+		}
+	}
+
+	if s.cfg.MaxL1GasPriceWei > 0 {
+		// the sequences waiting for too long are sent whatever the L1 gas price, so a
+		// long L1 gas price spike doesn't stop the batches from being virtualized
+		if waiting := waitingForL1Submission(sequences); waiting >= s.cfg.MaxWaitForL1Submission.Duration {
+			log.Infof("sending sequences to L1 from batch %d regardless of the L1 gas price, they are waiting for %v", sequences[0].BatchNumber, waiting)
+			return true
+		}
+
+		maxL1GasPrice := new(big.Int).SetUint64(s.cfg.MaxL1GasPriceWei)
+		l1GasPrice := s.etherman.GetL1GasPrice(ctx)
+		if l1GasPrice != nil && l1GasPrice.Cmp(maxL1GasPrice) == 1 {
+			log.Warnf("skipping L1 submission, current L1 gas price %s is greater than the max L1 gas price %s",
+				l1GasPrice.String(), maxL1GasPrice.String())
+			metrics.SequencesSkippedL1Submission(metrics.SequenceSkippedLabelGasPriceCap)
+			return false
+		}
+	}
+
+	return true
+}
+
+// mustSendSequences checks if the sequences must be sent to L1 whatever their number of txs: they contain
+// a forced batch or the fork upgrade batch, or their first batch was opened more than MaxWaitForL1Submission ago
+func (s *SequenceSender) mustSendSequences(sequences []types.Sequence) bool {
+	for _, seq := range sequences {
+		if seq.ForcedBatchTimestamp > 0 {
+			log.Infof("sending sequences to L1 with forced batch %d", seq.BatchNumber)
+			return true
+		}
+		if s.cfg.ForkUpgradeBatchNumber != 0 && seq.BatchNumber == s.cfg.ForkUpgradeBatchNumber {
+			log.Infof("sending sequences to L1 with the fork upgrade batch %d", seq.BatchNumber)
+			return true
+		}
+	}
+	if waiting := waitingForL1Submission(sequences); waiting >= s.cfg.MaxWaitForL1Submission.Duration {
+		log.Infof("sending sequences to L1 from batch %d, they are waiting for %v", sequences[0].BatchNumber, waiting)
+		return true
+	}
+	return false
+}
+
+// waitingForL1Submission returns the time the sequences are waiting to be sent to L1, counted
+// from the opening of their first batch
+func waitingForL1Submission(sequences []types.Sequence) time.Duration {
+	return time.Since(time.Unix(sequences[0].Timestamp, 0))
+}
+
 // handleEstimateGasSendSequenceErr handles an error on the estimate gas. It will return:
 // nil, error: impossible to handle gracefully
 // sequence, nil: handled gracefully. Potentially manipulating the sequences
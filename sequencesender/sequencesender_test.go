@@ -0,0 +1,114 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWorthSendingSequences(t *testing.T) {
+	ctx := context.Background()
+	recentTimestamp := time.Now().Unix()
+	oldTimestamp := time.Now().Add(-time.Hour).Unix()
+
+	type testCase struct {
+		name             string
+		sequences        []ethmanTypes.Sequence
+		maxL1GasPriceWei uint64
+		txCounts         []uint64
+		countTxsErr      error
+		l1GasPrice       *big.Int
+		expectedWorth    bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "enough txs",
+			sequences:     []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp}, {BatchNumber: 2, Timestamp: recentTimestamp}},
+			txCounts:      []uint64{0, 1},
+			expectedWorth: true,
+		},
+		{
+			name:          "not enough txs",
+			sequences:     []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp}},
+			txCounts:      []uint64{0},
+			expectedWorth: false,
+		},
+		{
+			name:          "not enough txs in a forced batch",
+			sequences:     []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp, ForcedBatchTimestamp: recentTimestamp}},
+			expectedWorth: true,
+		},
+		{
+			name:          "not enough txs waiting for too long",
+			sequences:     []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: oldTimestamp}},
+			expectedWorth: true,
+		},
+		{
+			name:          "failed to count txs",
+			sequences:     []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp}},
+			txCounts:      []uint64{0},
+			countTxsErr:   errors.New("failed to count txs"),
+			expectedWorth: true,
+		},
+		{
+			name:             "L1 gas price below the cap",
+			sequences:        []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp}},
+			maxL1GasPriceWei: 100,
+			txCounts:         []uint64{1},
+			l1GasPrice:       big.NewInt(100),
+			expectedWorth:    true,
+		},
+		{
+			name:             "L1 gas price over the cap",
+			sequences:        []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp}},
+			maxL1GasPriceWei: 100,
+			txCounts:         []uint64{1},
+			l1GasPrice:       big.NewInt(101),
+			expectedWorth:    false,
+		},
+		{
+			name:             "L1 gas price over the cap in a forced batch",
+			sequences:        []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: recentTimestamp, ForcedBatchTimestamp: recentTimestamp}},
+			maxL1GasPriceWei: 100,
+			l1GasPrice:       big.NewInt(101),
+			expectedWorth:    false,
+		},
+		{
+			name:             "L1 gas price over the cap waiting for too long",
+			sequences:        []ethmanTypes.Sequence{{BatchNumber: 1, Timestamp: oldTimestamp}},
+			maxL1GasPriceWei: 100,
+			expectedWorth:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			etherman := newEthermanMock(t)
+			st := newStateMock(t)
+
+			for i, txCount := range tc.txCounts {
+				st.On("CountTransactionsByBatch", ctx, tc.sequences[i].BatchNumber, nil).Return(txCount, tc.countTxsErr).Once()
+			}
+			if tc.l1GasPrice != nil {
+				etherman.On("GetL1GasPrice", ctx).Return(tc.l1GasPrice).Once()
+			}
+
+			cfg := Config{
+				MinTxCountForL1Submission: 1,
+				MaxWaitForL1Submission:    types.NewDuration(10 * time.Minute),
+				MaxL1GasPriceWei:          tc.maxL1GasPriceWei,
+			}
+			s, err := New(cfg, st, etherman, nil, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedWorth, s.isWorthSendingSequences(ctx, tc.sequences))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package state
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// AuditLogOpenBatch is the audit log event of OpenBatch and OpenWIPBatch
+	AuditLogOpenBatch = "OpenBatch"
+	// AuditLogCloseBatch is the audit log event of CloseBatch and CloseWIPBatch
+	AuditLogCloseBatch = "CloseBatch"
+	// AuditLogStoreL2Block is the audit log event of StoreL2Block
+	AuditLogStoreL2Block = "StoreL2Block"
+
+	auditLogRotationTimeFormat = "20060102T150405.000000000Z"
+	auditLogHMACExtension      = ".hmac"
+)
+
+// AuditLogEntry is a line of the audit log
+type AuditLogEntry struct {
+	Event         string       `json:"event"`
+	BatchNumber   uint64       `json:"batchNumber"`
+	L2BlockNumber *uint64      `json:"l2BlockNumber,omitempty"`
+	StateRoot     *common.Hash `json:"stateRoot,omitempty"`
+	Caller        string       `json:"caller"`
+	Timestamp     time.Time    `json:"timestamp"`
+}
+
+// AuditLogRotation is the result of rotating the audit log
+type AuditLogRotation struct {
+	// File is the path of the closed audit log file
+	File string `json:"file"`
+	// HMAC is the hex encoded HMAC-SHA256 of the closed file, it's also stored in File + ".hmac"
+	HMAC string `json:"hmac"`
+}
+
+// AuditLog writes a JSON line for every state mutation to an append-only file. The closed
+// files are signed with HMAC-SHA256 when the log is rotated, so they can be checked for tampering
+type AuditLog struct {
+	mux     sync.Mutex
+	path    string
+	hmacKey []byte
+	file    *os.File
+}
+
+// NewAuditLog opens the audit log file in append mode, creating it if it doesn't exist
+func NewAuditLog(path string, hmacKey []byte) (*AuditLog, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: the audit log file is required", ErrInvalidAuditLogConfig)
+	}
+	if len(hmacKey) == 0 {
+		return nil, fmt.Errorf("%w: the audit log HMAC key is required", ErrInvalidAuditLogConfig)
+	}
+	a := &AuditLog{path: path, hmacKey: hmacKey}
+	err := a.open()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *AuditLog) open() error {
+	file, err := openAuditLogFile(a.path)
+	if err != nil {
+		return err
+	}
+	a.file = file
+	return nil
+}
+
+func openAuditLogFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// record writes the entry to the audit log
+func (a *AuditLog) record(entry AuditLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	_, err = a.file.Write(line)
+	if err != nil {
+		return fmt.Errorf("failed to write the audit log entry: %w", err)
+	}
+	return nil
+}
+
+// Rotate renames the current audit log file with the rotation time as suffix, closes and signs
+// it, the new entries are written to a new file in the configured path. The current file is
+// only closed once the new one is open, so a failed rotation keeps writing to the current file
+func (a *AuditLog) Rotate() (*AuditLogRotation, error) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	closedPath := a.path + "." + time.Now().UTC().Format(auditLogRotationTimeFormat)
+	err := os.Rename(a.path, closedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename the audit log file: %w", err)
+	}
+	file, err := openAuditLogFile(a.path)
+	if err != nil {
+		// keep writing to the current file under the configured path
+		if renameErr := os.Rename(closedPath, a.path); renameErr != nil {
+			log.Errorf("failed to restore the audit log file %s after a failed rotation: %v", closedPath, renameErr)
+		}
+		return nil, err
+	}
+	closedFile := a.file
+	a.file = file
+	err = closedFile.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close the rotated audit log file %s: %w", closedPath, err)
+	}
+
+	mac, err := a.sign(closedPath)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(closedPath+auditLogHMACExtension, []byte(mac+"\n"), 0600) //nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the audit log signature: %w", err)
+	}
+	log.Infof("audit log rotated, closed file %s signed with HMAC %s", closedPath, mac)
+
+	return &AuditLogRotation{File: closedPath, HMAC: mac}, nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the file
+func (a *AuditLog) sign(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open the audit log file to sign it: %w", err)
+	}
+	defer file.Close()
+
+	mac := hmac.New(sha256.New, a.hmacKey)
+	_, err = io.Copy(mac, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the audit log file to sign it: %w", err)
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Close closes the audit log file
+func (a *AuditLog) Close() error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.file.Close()
+}
+
+// auditLogCaller returns the name of the function that called the audited state method
+func auditLogCaller() string {
+	// skip runtime.Callers, auditLogCaller, State.audit and the state method
+	const skip = 4
+	pc := make([]uintptr, 1)
+	if runtime.Callers(skip, pc) == 0 {
+		return "unknown"
+	}
+	frame, _ := runtime.CallersFrames(pc).Next()
+	return frame.Function
+}
+
+// SetAuditLog enables the audit log of the state mutations
+func (s *State) SetAuditLog(auditLog *AuditLog) {
+	s.auditLog = auditLog
+}
+
+// RotateAuditLog rotates the audit log, see AuditLog.Rotate
+func (s *State) RotateAuditLog() (*AuditLogRotation, error) {
+	if s.auditLog == nil {
+		return nil, ErrAuditLogDisabled
+	}
+	return s.auditLog.Rotate()
+}
+
+// audit records the state mutation when the audit log is enabled. The mutations done in a
+// transaction started with BeginStateTransaction are recorded once it's committed, a failure to
+// record them is logged and doesn't fail the mutation
+func (s *State) audit(dbTx pgx.Tx, entry AuditLogEntry) {
+	if s.auditLog == nil {
+		return
+	}
+	entry.Caller = auditLogCaller()
+	entry.Timestamp = time.Now().UTC()
+	auditLog := s.auditLog
+	s.afterCommit(dbTx, func() {
+		if err := auditLog.record(entry); err != nil {
+			log.Errorf("failed to record the %s audit log entry of batch %d: %v", entry.Event, entry.BatchNumber, err)
+		}
+	})
+}
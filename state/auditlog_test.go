@@ -0,0 +1,121 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogStorage is an openBatchStorage that also closes wip batches
+type auditLogStorage struct {
+	openBatchStorage
+}
+
+func (s *auditLogStorage) CloseWIPBatchInStorage(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error {
+	s.closedBatches = append(s.closedBatches, receipt.BatchNumber)
+	return nil
+}
+
+func readAuditLog(t *testing.T, path string) []AuditLogEntry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	entries := []AuditLogEntry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestNewAuditLogConfig(t *testing.T) {
+	_, err := NewAuditLog("", []byte("key"))
+	assert.ErrorIs(t, err, ErrInvalidAuditLogConfig)
+	_, err = NewAuditLog(filepath.Join(t.TempDir(), "audit.log"), nil)
+	assert.ErrorIs(t, err, ErrInvalidAuditLogConfig)
+
+	_, err = (&State{}).RotateAuditLog()
+	assert.ErrorIs(t, err, ErrAuditLogDisabled)
+}
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	key := []byte("audit log key")
+	auditLog, err := NewAuditLog(path, key)
+	require.NoError(t, err)
+	defer auditLog.Close()
+
+	ctx := context.Background()
+	stateRoot := common.HexToHash("0x1")
+	s := &State{storage: &auditLogStorage{openBatchStorage{lastBatchNumber: 1, lastBatchIsClosed: true}}}
+	s.SetAuditLog(auditLog)
+
+	// The entries of a transaction are recorded when it's committed
+	dbTx := &stateTx{Tx: &committableDbTx{}}
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, dbTx))
+	require.NoError(t, s.CloseWIPBatch(ctx, ProcessingReceipt{BatchNumber: 2, StateRoot: stateRoot}, dbTx))
+	assert.Empty(t, readAuditLog(t, path))
+	require.NoError(t, dbTx.Commit(ctx))
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, AuditLogOpenBatch, entries[0].Event)
+	assert.Equal(t, uint64(2), entries[0].BatchNumber)
+	assert.Nil(t, entries[0].StateRoot)
+	assert.Equal(t, AuditLogCloseBatch, entries[1].Event)
+	assert.Equal(t, &stateRoot, entries[1].StateRoot)
+	for _, entry := range entries {
+		// the caller is the function calling the state, not the state itself
+		assert.True(t, strings.HasSuffix(entry.Caller, ".TestAuditLog"), entry.Caller)
+		assert.WithinDuration(t, time.Now(), entry.Timestamp, time.Minute)
+	}
+
+	// The entries of a rolled back or failed transaction are dropped
+	dbTx = &stateTx{Tx: &committableDbTx{}}
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, dbTx))
+	require.NoError(t, dbTx.Rollback(ctx))
+	dbTx = &stateTx{Tx: &committableDbTx{commitErr: errors.New("commit failed")}}
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, dbTx))
+	require.Error(t, dbTx.Commit(ctx))
+	require.Len(t, readAuditLog(t, path), 2)
+
+	// The closed file is signed and the next entries go to a new file
+	rotation, err := s.RotateAuditLog()
+	require.NoError(t, err)
+	closed, err := os.ReadFile(rotation.File)
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(closed)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), rotation.HMAC)
+	signature, err := os.ReadFile(rotation.File + auditLogHMACExtension)
+	require.NoError(t, err)
+	assert.Equal(t, rotation.HMAC+"\n", string(signature))
+	assert.Empty(t, readAuditLog(t, path))
+
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, &openBatchDbTx{}))
+	assert.Len(t, readAuditLog(t, path), 1)
+	assert.Len(t, readAuditLog(t, rotation.File), 2)
+
+	// A failure to write the entry doesn't fail the state mutation
+	require.NoError(t, auditLog.Close())
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, &openBatchDbTx{}))
+	assert.Len(t, readAuditLog(t, path), 1)
+}
@@ -38,10 +38,20 @@ type Batch struct {
 	GlobalExitRoot common.Hash
 	ForcedBatchNum *uint64
 	Resources      BatchResources
+	// ClosingReason is the reason why the batch was closed, it's empty for WIP batches
+	ClosingReason ClosingReason
 	// WIP: if WIP == true is a openBatch
 	WIP bool
 }
 
+// BatchTimestamp is the time a batch was opened and closed
+type BatchTimestamp struct {
+	BatchNumber uint64
+	OpenedAt    time.Time
+	// ClosedAt is nil for the WIP batch and the batches closed before the closing time was stored
+	ClosedAt *time.Time
+}
+
 // ProcessingContext is the necessary data that a batch needs to provide to the runtime,
 // without the historical state data (processing receipt from previous batch)
 type ProcessingContext struct {
@@ -127,7 +137,7 @@ func (s *State) OpenBatch(ctx context.Context, processingContext ProcessingConte
 		return err
 	}
 	if lastBatchNum+1 != processingContext.BatchNumber {
-		return fmt.Errorf("%w number %d, should be %d", ErrUnexpectedBatch, processingContext.BatchNumber, lastBatchNum+1)
+		return fmt.Errorf("%w. Got %d, should be %d", ErrNonMonotonicBatchNumber, processingContext.BatchNumber, lastBatchNum+1)
 	}
 	// Check if last batch is closed
 	isLastBatchClosed, err := s.IsBatchClosed(ctx, lastBatchNum, dbTx)
@@ -145,7 +155,16 @@ func (s *State) OpenBatch(ctx context.Context, processingContext ProcessingConte
 	if prevTimestamp.Unix() > processingContext.Timestamp.Unix() {
 		return ErrTimestampGE
 	}
-	return s.OpenBatchInStorage(ctx, processingContext, dbTx)
+	err = s.applyForkIDMigrationsOnOpenBatch(ctx, processingContext.BatchNumber, dbTx)
+	if err != nil {
+		return err
+	}
+	err = s.OpenBatchInStorage(ctx, processingContext, dbTx)
+	if err != nil {
+		return err
+	}
+	s.audit(dbTx, AuditLogEntry{Event: AuditLogOpenBatch, BatchNumber: processingContext.BatchNumber})
+	return nil
 }
 
 // OpenWIPBatch adds a new WIP batch into the state
@@ -161,7 +180,7 @@ func (s *State) OpenWIPBatch(ctx context.Context, batch Batch, dbTx pgx.Tx) erro
 		return err
 	}
 	if lastBatchNum+1 != batch.BatchNumber {
-		return fmt.Errorf("%w number %d, should be %d", ErrUnexpectedBatch, batch.BatchNumber, lastBatchNum+1)
+		return fmt.Errorf("%w. Got %d, should be %d", ErrNonMonotonicBatchNumber, batch.BatchNumber, lastBatchNum+1)
 	}
 	// Check if last batch is closed
 	isLastBatchClosed, err := s.IsBatchClosed(ctx, lastBatchNum, dbTx)
@@ -179,7 +198,16 @@ func (s *State) OpenWIPBatch(ctx context.Context, batch Batch, dbTx pgx.Tx) erro
 	if prevTimestamp.Unix() > batch.Timestamp.Unix() {
 		return ErrTimestampGE
 	}
-	return s.OpenWIPBatchInStorage(ctx, batch, dbTx)
+	err = s.applyForkIDMigrationsOnOpenBatch(ctx, batch.BatchNumber, dbTx)
+	if err != nil {
+		return err
+	}
+	err = s.OpenWIPBatchInStorage(ctx, batch, dbTx)
+	if err != nil {
+		return err
+	}
+	s.audit(dbTx, AuditLogEntry{Event: AuditLogOpenBatch, BatchNumber: batch.BatchNumber, StateRoot: &batch.StateRoot})
+	return nil
 }
 
 // GetWIPBatch returns the wip batch in the state
@@ -404,13 +432,13 @@ func (s *State) sendBatchRequestToExecutor(ctx context.Context, processBatchRequ
 }
 
 func (s *State) isBatchClosable(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error {
-	// Check if the batch that is being closed is the last batch
+	// Check if the batch that is being closed is the last (open) batch
 	lastBatchNum, err := s.GetLastBatchNumber(ctx, dbTx)
 	if err != nil {
 		return err
 	}
 	if lastBatchNum != receipt.BatchNumber {
-		return fmt.Errorf("%w number %d, should be %d", ErrUnexpectedBatch, receipt.BatchNumber, lastBatchNum)
+		return fmt.Errorf("%w. Got %d, should be %d", ErrBatchNumberMismatch, receipt.BatchNumber, lastBatchNum)
 	}
 	// Check if last batch is closed
 	isLastBatchClosed, err := s.IsBatchClosed(ctx, lastBatchNum, dbTx)
@@ -435,12 +463,22 @@ func (s *State) CloseBatch(ctx context.Context, receipt ProcessingReceipt, dbTx
 		return err
 	}
 
-	return s.CloseBatchInStorage(ctx, receipt, dbTx)
+	err = s.CloseBatchInStorage(ctx, receipt, dbTx)
+	if err != nil {
+		return err
+	}
+	s.audit(dbTx, AuditLogEntry{Event: AuditLogCloseBatch, BatchNumber: receipt.BatchNumber, StateRoot: &receipt.StateRoot})
+	return nil
 }
 
 // CloseWIPBatch is used by sequencer to close the wip batch
 func (s *State) CloseWIPBatch(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error {
-	return s.CloseWIPBatchInStorage(ctx, receipt, dbTx)
+	err := s.CloseWIPBatchInStorage(ctx, receipt, dbTx)
+	if err != nil {
+		return err
+	}
+	s.audit(dbTx, AuditLogEntry{Event: AuditLogCloseBatch, BatchNumber: receipt.BatchNumber, StateRoot: &receipt.StateRoot})
+	return nil
 }
 
 // ProcessAndStoreClosedBatch is used by the Synchronizer to add a closed batch into the data base. Values returned are the new stateRoot,
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openBatchStorage is a storage that only implements the methods used to open and close a batch
+type openBatchStorage struct {
+	storage
+	lastBatchNumber   uint64
+	lastBatchTime     time.Time
+	lastBatchIsClosed bool
+	openedBatches     []uint64
+	closedBatches     []uint64
+}
+
+func (s *openBatchStorage) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return s.lastBatchNumber, nil
+}
+
+func (s *openBatchStorage) IsBatchClosed(ctx context.Context, batchNum uint64, dbTx pgx.Tx) (bool, error) {
+	return s.lastBatchIsClosed, nil
+}
+
+func (s *openBatchStorage) GetLastBatchTime(ctx context.Context, dbTx pgx.Tx) (time.Time, error) {
+	return s.lastBatchTime, nil
+}
+
+func (s *openBatchStorage) OpenBatchInStorage(ctx context.Context, batchContext ProcessingContext, dbTx pgx.Tx) error {
+	s.openedBatches = append(s.openedBatches, batchContext.BatchNumber)
+	return nil
+}
+
+func (s *openBatchStorage) CloseBatchInStorage(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error {
+	s.closedBatches = append(s.closedBatches, receipt.BatchNumber)
+	return nil
+}
+
+type openBatchDbTx struct {
+	pgx.Tx
+}
+
+func TestOpenBatchBatchNumber(t *testing.T) {
+	testCases := []struct {
+		name          string
+		batchNumber   uint64
+		expectedError error
+	}{
+		{name: "next batch number", batchNumber: 6},
+		{name: "same batch number", batchNumber: 5, expectedError: ErrNonMonotonicBatchNumber},
+		{name: "previous batch number", batchNumber: 4, expectedError: ErrNonMonotonicBatchNumber},
+		{name: "skipped batch number", batchNumber: 7, expectedError: ErrNonMonotonicBatchNumber},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &openBatchStorage{lastBatchNumber: 5, lastBatchTime: time.Unix(1700000000, 0), lastBatchIsClosed: true}
+			s := &State{storage: st}
+
+			processingCtx := ProcessingContext{BatchNumber: tc.batchNumber, Timestamp: time.Unix(1700000001, 0)}
+			err := s.OpenBatch(context.Background(), processingCtx, &openBatchDbTx{})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.ErrorIs(t, err, ErrUnexpectedBatch)
+				assert.Empty(t, st.openedBatches)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{tc.batchNumber}, st.openedBatches)
+		})
+	}
+}
+
+func TestCloseBatchBatchNumber(t *testing.T) {
+	testCases := []struct {
+		name          string
+		batchNumber   uint64
+		expectedError error
+	}{
+		{name: "open batch number", batchNumber: 5},
+		{name: "previous batch number", batchNumber: 4, expectedError: ErrBatchNumberMismatch},
+		{name: "next batch number", batchNumber: 6, expectedError: ErrBatchNumberMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &openBatchStorage{lastBatchNumber: 5}
+			s := &State{storage: st}
+
+			err := s.CloseBatch(context.Background(), ProcessingReceipt{BatchNumber: tc.batchNumber}, &openBatchDbTx{})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.ErrorIs(t, err, ErrUnexpectedBatch)
+				assert.Empty(t, st.closedBatches)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{tc.batchNumber}, st.closedBatches)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/jackc/pgx/v4"
+)
+
+// batchNumCacheTTL is how long the last virtual and verified batch numbers are served from memory
+const batchNumCacheTTL = 500 * time.Millisecond
+
+// batchNumCache keeps the last virtual batch number and the last verified batch read from the
+// storage, the zero value is an empty cache
+type batchNumCache struct {
+	mux sync.RWMutex
+	// invalidatedAt is the time of the last invalidation, the values read before are not cached
+	invalidatedAt time.Time
+
+	lastVirtualBatchNum   *uint64
+	lastVirtualBatchNumAt time.Time
+
+	lastVerifiedBatch   *VerifiedBatch
+	lastVerifiedBatchAt time.Time
+}
+
+func (c *batchNumCache) getLastVirtualBatchNum() (uint64, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.lastVirtualBatchNum == nil || time.Since(c.lastVirtualBatchNumAt) >= batchNumCacheTTL {
+		return 0, false
+	}
+	return *c.lastVirtualBatchNum, true
+}
+
+func (c *batchNumCache) setLastVirtualBatchNum(batchNumber uint64, readAt time.Time) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	// a value read before the last invalidation could be older than the state
+	if !readAt.After(c.invalidatedAt) || readAt.Before(c.lastVirtualBatchNumAt) {
+		return
+	}
+	c.lastVirtualBatchNum = &batchNumber
+	c.lastVirtualBatchNumAt = readAt
+}
+
+func (c *batchNumCache) getLastVerifiedBatch() (*VerifiedBatch, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.lastVerifiedBatch == nil || time.Since(c.lastVerifiedBatchAt) >= batchNumCacheTTL {
+		return nil, false
+	}
+	verifiedBatch := *c.lastVerifiedBatch
+	return &verifiedBatch, true
+}
+
+func (c *batchNumCache) setLastVerifiedBatch(verifiedBatch *VerifiedBatch, readAt time.Time) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if !readAt.After(c.invalidatedAt) || readAt.Before(c.lastVerifiedBatchAt) {
+		return
+	}
+	cached := *verifiedBatch
+	c.lastVerifiedBatch = &cached
+	c.lastVerifiedBatchAt = readAt
+}
+
+// invalidate drops the cached values, the values read before the invalidation are not cached
+func (c *batchNumCache) invalidate() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.invalidatedAt = time.Now()
+	c.lastVirtualBatchNum = nil
+	c.lastVerifiedBatch = nil
+}
+
+// GetLastVirtualBatchNum gets the last virtual batch number. Without dbTx the number is
+// served from memory for up to batchNumCacheTTL, a dbTx always reads the storage so the
+// changes of the same dbTx are seen
+func (s *State) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	if dbTx != nil {
+		return s.storage.GetLastVirtualBatchNum(ctx, dbTx)
+	}
+	if batchNumber, ok := s.batchNumCache.getLastVirtualBatchNum(); ok {
+		return batchNumber, nil
+	}
+	return s.refreshLastVirtualBatchNum(ctx)
+}
+
+func (s *State) refreshLastVirtualBatchNum(ctx context.Context) (uint64, error) {
+	readAt := time.Now()
+	batchNumber, err := s.storage.GetLastVirtualBatchNum(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	s.batchNumCache.setLastVirtualBatchNum(batchNumber, readAt)
+	return batchNumber, nil
+}
+
+// GetLastVerifiedBatch gets the last verified batch. Without dbTx the batch is served from
+// memory for up to batchNumCacheTTL, a dbTx always reads the storage so the changes of the
+// same dbTx are seen
+func (s *State) GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*VerifiedBatch, error) {
+	if dbTx != nil {
+		return s.storage.GetLastVerifiedBatch(ctx, dbTx)
+	}
+	if verifiedBatch, ok := s.batchNumCache.getLastVerifiedBatch(); ok {
+		return verifiedBatch, nil
+	}
+	return s.refreshLastVerifiedBatch(ctx)
+}
+
+func (s *State) refreshLastVerifiedBatch(ctx context.Context) (*VerifiedBatch, error) {
+	readAt := time.Now()
+	verifiedBatch, err := s.storage.GetLastVerifiedBatch(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.batchNumCache.setLastVerifiedBatch(verifiedBatch, readAt)
+	return verifiedBatch, nil
+}
+
+// AddVirtualBatch stores the virtual batch and invalidates the cached last virtual batch number once
+// dbTx is committed, a value read before the commit is not cached
+func (s *State) AddVirtualBatch(ctx context.Context, virtualBatch *VirtualBatch, dbTx pgx.Tx) error {
+	err := s.storage.AddVirtualBatch(ctx, virtualBatch, dbTx)
+	if err != nil {
+		return err
+	}
+	s.afterCommit(dbTx, s.batchNumCache.invalidate)
+	return nil
+}
+
+// AddVerifiedBatch stores the verified batch and invalidates the cached last verified batch once
+// dbTx is committed
+func (s *State) AddVerifiedBatch(ctx context.Context, verifiedBatch *VerifiedBatch, dbTx pgx.Tx) error {
+	err := s.storage.AddVerifiedBatch(ctx, verifiedBatch, dbTx)
+	if err != nil {
+		return err
+	}
+	s.afterCommit(dbTx, s.batchNumCache.invalidate)
+	return nil
+}
+
+// Reset removes all the L1 blocks after the block number and invalidates the cached batch
+// numbers once dbTx is committed, the virtual and verified batches of the removed blocks are removed too
+func (s *State) Reset(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error {
+	err := s.storage.Reset(ctx, blockNumber, dbTx)
+	if err != nil {
+		return err
+	}
+	s.afterCommit(dbTx, s.batchNumCache.invalidate)
+	return nil
+}
+
+// StartToRefreshBatchNumCache refreshes the cached last virtual and verified batches every
+// batchNumCacheTTL until the context is done, so the readers without dbTx don't wait for
+// the storage
+func (s *State) StartToRefreshBatchNumCache(ctx context.Context) {
+	ticker := time.NewTicker(batchNumCacheTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.refreshLastVirtualBatchNum(ctx); err != nil {
+				log.Debugf("failed to refresh the cached last virtual batch number: %v", err)
+			}
+			if _, err := s.refreshLastVerifiedBatch(ctx); err != nil {
+				log.Debugf("failed to refresh the cached last verified batch: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchNumStorage counts the reads of the last virtual and verified batches
+type batchNumStorage struct {
+	storage
+	lastVirtualBatchNum   uint64
+	lastVerifiedBatchNum  uint64
+	virtualBatchNumReads  int
+	verifiedBatchNumReads int
+}
+
+func (s *batchNumStorage) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	s.virtualBatchNumReads++
+	return s.lastVirtualBatchNum, nil
+}
+
+func (s *batchNumStorage) GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*VerifiedBatch, error) {
+	s.verifiedBatchNumReads++
+	return &VerifiedBatch{BatchNumber: s.lastVerifiedBatchNum}, nil
+}
+
+func (s *batchNumStorage) AddVirtualBatch(ctx context.Context, virtualBatch *VirtualBatch, dbTx pgx.Tx) error {
+	s.lastVirtualBatchNum = virtualBatch.BatchNumber
+	return nil
+}
+
+func (s *batchNumStorage) AddVerifiedBatch(ctx context.Context, verifiedBatch *VerifiedBatch, dbTx pgx.Tx) error {
+	s.lastVerifiedBatchNum = verifiedBatch.BatchNumber
+	return nil
+}
+
+func TestBatchNumCache(t *testing.T) {
+	ctx := context.Background()
+	storage := &batchNumStorage{lastVirtualBatchNum: 5, lastVerifiedBatchNum: 3}
+	s := &State{storage: storage}
+
+	// The reads without dbTx are cached
+	for i := 0; i < 3; i++ {
+		batchNumber, err := s.GetLastVirtualBatchNum(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), batchNumber)
+		verifiedBatch, err := s.GetLastVerifiedBatch(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), verifiedBatch.BatchNumber)
+	}
+	assert.Equal(t, 1, storage.virtualBatchNumReads)
+	assert.Equal(t, 1, storage.verifiedBatchNumReads)
+
+	// The cached verified batch can't be modified by the callers
+	verifiedBatch, err := s.GetLastVerifiedBatch(ctx, nil)
+	require.NoError(t, err)
+	verifiedBatch.BatchNumber = 100
+	verifiedBatch, err = s.GetLastVerifiedBatch(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), verifiedBatch.BatchNumber)
+
+	// The reads with dbTx always go to the storage
+	_, err = s.GetLastVirtualBatchNum(ctx, &openBatchDbTx{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, storage.virtualBatchNumReads)
+
+	// Adding batches invalidates the cache
+	require.NoError(t, s.AddVirtualBatch(ctx, &VirtualBatch{BatchNumber: 6}, nil))
+	batchNumber, err := s.GetLastVirtualBatchNum(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), batchNumber)
+	require.NoError(t, s.AddVerifiedBatch(ctx, &VerifiedBatch{BatchNumber: 4}, nil))
+	verifiedBatch, err = s.GetLastVerifiedBatch(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), verifiedBatch.BatchNumber)
+
+	// A value read before the invalidation is not cached
+	readAt := time.Now()
+	s.batchNumCache.invalidate()
+	s.batchNumCache.setLastVirtualBatchNum(1, readAt)
+	_, ok := s.batchNumCache.getLastVirtualBatchNum()
+	assert.False(t, ok)
+
+	// The cached values expire after the TTL
+	_, err = s.GetLastVirtualBatchNum(ctx, nil)
+	require.NoError(t, err)
+	storage.lastVirtualBatchNum = 7
+	s.batchNumCache.lastVirtualBatchNumAt = time.Now().Add(-batchNumCacheTTL)
+	batchNumber, err = s.GetLastVirtualBatchNum(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), batchNumber)
+
+	// Adding batches in a transaction invalidates the cache once it's committed
+	dbTx := &stateTx{Tx: &committableDbTx{}}
+	require.NoError(t, s.AddVirtualBatch(ctx, &VirtualBatch{BatchNumber: 9}, dbTx))
+	batchNumber, err = s.GetLastVirtualBatchNum(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), batchNumber)
+	require.NoError(t, dbTx.Commit(ctx))
+	batchNumber, err = s.GetLastVirtualBatchNum(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), batchNumber)
+
+	// The background refresh keeps the cache up to date
+	storage.lastVerifiedBatchNum = 8
+	refreshCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.StartToRefreshBatchNumCache(refreshCtx)
+	assert.Eventually(t, func() bool {
+		verifiedBatch, ok := s.batchNumCache.getLastVerifiedBatch()
+		return ok && verifiedBatch.BatchNumber == 8
+	}, 5*batchNumCacheTTL, batchNumCacheTTL/10)
+}
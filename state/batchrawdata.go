@@ -0,0 +1,107 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// BatchDataCompressionNone stores the batch raw data uncompressed
+	BatchDataCompressionNone = "none"
+	// BatchDataCompressionZstd stores the batch raw data compressed with zstd
+	BatchDataCompressionZstd = "zstd"
+	// BatchDataCompressionGzip is the encoding of the batch raw data compressed with gzip, it can
+	// be read but the node doesn't store data with it
+	BatchDataCompressionGzip = "gzip"
+	// BatchDataCompressionZlib is the encoding of the batch raw data compressed with zlib, it can
+	// be read but the node doesn't store data with it
+	BatchDataCompressionZlib = "zlib"
+
+	// maxBatchRawDataSize is the max size of the decompressed batch raw data, far above the size
+	// of the batches allowed on L1, the larger data is rejected to protect from decompression bombs
+	maxBatchRawDataSize = 16 * 1024 * 1024
+)
+
+var (
+	// the encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll,
+	// creating them only fails with invalid options
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxBatchRawDataSize))
+)
+
+// IsValidBatchDataCompression checks if the batch data compression is supported, empty means none
+func IsValidBatchDataCompression(compression string) bool {
+	return compression == "" || compression == BatchDataCompressionNone || compression == BatchDataCompressionZstd
+}
+
+// CompressBatchRawData compresses the raw data of a batch to store it, it returns the compressed data
+// and the encoding to store with it, that GetBatchRawData needs to decompress the data
+func CompressBatchRawData(rawData []byte, compression string) ([]byte, string, error) {
+	switch compression {
+	case "", BatchDataCompressionNone:
+		return rawData, BatchDataCompressionNone, nil
+	case BatchDataCompressionZstd:
+		if len(rawData) == 0 {
+			return rawData, BatchDataCompressionNone, nil
+		}
+		return zstdEncoder.EncodeAll(rawData, make([]byte, 0, len(rawData))), BatchDataCompressionZstd, nil
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedBatchDataCompression, compression)
+	}
+}
+
+// CompressedBatchL2DataSize returns the size of the batch L2 data compressed with zstd, it's the
+// size the data would take in the L1 payload if it's posted compressed, whatever the storage compression
+func CompressedBatchL2DataSize(batchL2Data []byte) uint64 {
+	if len(batchL2Data) == 0 {
+		return 0
+	}
+	return uint64(len(zstdEncoder.EncodeAll(batchL2Data, make([]byte, 0, len(batchL2Data)))))
+}
+
+// GetBatchRawData returns the raw data of a batch stored with the given encoding, the compressed
+// data is decompressed and the uncompressed data is returned as is
+func GetBatchRawData(rawData []byte, encoding string) ([]byte, error) {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+
+	switch encoding {
+	case "", BatchDataCompressionNone:
+		return rawData, nil
+	case BatchDataCompressionZstd:
+		decompressed, err := zstdDecoder.DecodeAll(rawData, nil)
+		if errors.Is(err, zstd.ErrDecoderSizeExceeded) || len(decompressed) > maxBatchRawDataSize {
+			return nil, ErrBatchRawDataTooLarge
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decompress batch raw data, err: %w", err)
+		}
+		return decompressed, nil
+	case BatchDataCompressionGzip:
+		reader, err = gzip.NewReader(bytes.NewReader(rawData))
+	case BatchDataCompressionZlib:
+		reader, err = zlib.NewReader(bytes.NewReader(rawData))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBatchDataCompression, encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compressed batch raw data, err: %w", err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxBatchRawDataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress batch raw data, err: %w", err)
+	}
+	if len(decompressed) > maxBatchRawDataSize {
+		return nil, ErrBatchRawDataTooLarge
+	}
+	return decompressed, nil
+}
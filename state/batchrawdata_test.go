@@ -0,0 +1,126 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBatchRawData(t *testing.T) {
+	rawData := []byte{0x0b, 0x00, 0x00, 0x00, 0x7b, 0x00, 0x00, 0x00, 0x01, 0xee, 0x80, 0x84, 0x3b, 0x9a, 0xca, 0x00}
+
+	var zlibData bytes.Buffer
+	zlibWriter := zlib.NewWriter(&zlibData)
+	_, err := zlibWriter.Write(rawData)
+	require.NoError(t, err)
+	require.NoError(t, zlibWriter.Close())
+
+	var gzipData bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipData)
+	_, err = gzipWriter.Write(rawData)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		encoding      string
+		expectedData  []byte
+		expectedError error
+	}{
+		{name: "uncompressed", data: rawData, encoding: BatchDataCompressionNone, expectedData: rawData},
+		{name: "without encoding", data: rawData, encoding: "", expectedData: rawData},
+		{name: "empty", data: []byte{}, encoding: BatchDataCompressionNone, expectedData: []byte{}},
+		{name: "zlib compressed", data: zlibData.Bytes(), encoding: BatchDataCompressionZlib, expectedData: rawData},
+		{name: "gzip compressed", data: gzipData.Bytes(), encoding: BatchDataCompressionGzip, expectedData: rawData},
+		// the uncompressed data that starts with the magic bytes is not decompressed
+		{name: "uncompressed with the gzip magic bytes", data: gzipData.Bytes(), encoding: BatchDataCompressionNone, expectedData: gzipData.Bytes()},
+		{name: "corrupted gzip", data: gzipData.Bytes()[:len(gzipData.Bytes())-4], encoding: BatchDataCompressionGzip, expectedError: io.ErrUnexpectedEOF},
+		{name: "unsupported encoding", data: rawData, encoding: "lz4", expectedError: ErrUnsupportedBatchDataCompression},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := GetBatchRawData(tc.data, tc.encoding)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedData, data)
+		})
+	}
+}
+
+func TestGetBatchRawDataTooLarge(t *testing.T) {
+	var gzipData bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipData)
+	_, err := gzipWriter.Write(make([]byte, maxBatchRawDataSize+1))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	_, err = GetBatchRawData(gzipData.Bytes(), BatchDataCompressionGzip)
+	assert.ErrorIs(t, err, ErrBatchRawDataTooLarge)
+
+	zstdData, _, err := CompressBatchRawData(make([]byte, maxBatchRawDataSize+1), BatchDataCompressionZstd)
+	require.NoError(t, err)
+	_, err = GetBatchRawData(zstdData, BatchDataCompressionZstd)
+	assert.ErrorIs(t, err, ErrBatchRawDataTooLarge)
+}
+
+func TestCompressBatchRawData(t *testing.T) {
+	rawData := bytes.Repeat([]byte{0x0b, 0x00, 0x00, 0x00, 0x7b, 0x00, 0x00, 0x00, 0x01, 0xee, 0x80, 0x84, 0x3b, 0x9a, 0xca, 0x00}, 100)
+
+	for _, compression := range []string{"", BatchDataCompressionNone} {
+		data, encoding, err := CompressBatchRawData(rawData, compression)
+		require.NoError(t, err)
+		assert.Equal(t, rawData, data)
+		assert.Equal(t, BatchDataCompressionNone, encoding)
+	}
+
+	compressed, encoding, err := CompressBatchRawData(rawData, BatchDataCompressionZstd)
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(rawData))
+	assert.Equal(t, BatchDataCompressionZstd, encoding)
+
+	// The compressed data is decompressed with its encoding
+	data, err := GetBatchRawData(compressed, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, rawData, data)
+
+	// The data that starts with the zstd magic number is compressed too
+	compressedTwice, encoding, err := CompressBatchRawData(compressed, BatchDataCompressionZstd)
+	require.NoError(t, err)
+	assert.Equal(t, BatchDataCompressionZstd, encoding)
+	data, err = GetBatchRawData(compressedTwice, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, compressed, data)
+
+	// Empty batches are stored as is
+	data, _, err = CompressBatchRawData([]byte{}, BatchDataCompressionZstd)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	// Corrupted data fails to decompress
+	_, err = GetBatchRawData(compressed[:len(compressed)-4], BatchDataCompressionZstd)
+	assert.Error(t, err)
+
+	_, _, err = CompressBatchRawData(rawData, "lz4")
+	assert.ErrorIs(t, err, ErrUnsupportedBatchDataCompression)
+	assert.False(t, IsValidBatchDataCompression("lz4"))
+	assert.True(t, IsValidBatchDataCompression(BatchDataCompressionZstd))
+}
+
+func TestCompressedBatchL2DataSize(t *testing.T) {
+	rawData := bytes.Repeat([]byte{0x0b, 0x00, 0x00, 0x00, 0x7b, 0x00, 0x00, 0x00, 0x01, 0xee, 0x80, 0x84, 0x3b, 0x9a, 0xca, 0x00}, 100)
+
+	compressed, _, err := CompressBatchRawData(rawData, BatchDataCompressionZstd)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(compressed)), CompressedBatchL2DataSize(rawData))
+	assert.Equal(t, uint64(0), CompressedBatchL2DataSize(nil))
+}
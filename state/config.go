@@ -45,6 +45,28 @@ type Config struct {
 	// MaxNativeBlockHashBlockRange is a configuration to set the max range for block number when querying
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64
+
+	// GasEstimationTolerance is the max difference in gas between the estimation
+	// and the lowest gas that makes the tx succeed, if zero it means the exact value.
+	// The node sets it from RPC.GasEstimationTolerance, so the zero value only applies
+	// to the states created without the node config
+	GasEstimationTolerance uint64
+
+	// BatchDataCompression is the compression used to store the raw data of the closed batches
+	// (none or zstd), the batches stored with a different compression can still be read.
+	// The batches closed by the sequencer from a wip batch keep their raw data uncompressed
+	BatchDataCompression string `mapstructure:"BatchDataCompression"`
+
+	// AuditLog enables writing a JSON line to AuditLogFile for every batch opened or closed
+	// and every L2 block stored in the state
+	AuditLog bool `mapstructure:"AuditLog"`
+
+	// AuditLogFile is the path of the append-only audit log file, each component running in a
+	// different process must use its own file
+	AuditLogFile string `mapstructure:"AuditLogFile"`
+
+	// AuditLogHMACKey is the key used to sign the audit log files with HMAC-SHA256 when they are rotated
+	AuditLogHMACKey string `mapstructure:"AuditLogHMACKey"`
 }
 
 // BatchConfig represents the configuration of the batch constraints
@@ -12,6 +12,10 @@ var (
 	ErrInvalidBatchHeader = errors.New("invalid batch header")
 	// ErrUnexpectedBatch indicates that the batch is unexpected
 	ErrUnexpectedBatch = errors.New("unexpected batch")
+	// ErrNonMonotonicBatchNumber indicates that the batch being opened is not the next one after the last batch
+	ErrNonMonotonicBatchNumber = fmt.Errorf("%w: non monotonic batch number", ErrUnexpectedBatch)
+	// ErrBatchNumberMismatch indicates that the batch being closed is not the open batch
+	ErrBatchNumberMismatch = fmt.Errorf("%w: batch number doesn't match the open batch", ErrUnexpectedBatch)
 	// ErrStateNotSynchronized indicates the state database may be empty
 	ErrStateNotSynchronized = errors.New("state not synchronized")
 	// ErrNotFound indicates an object has not been found for the search criteria used
@@ -34,8 +38,22 @@ var (
 	ErrClosingBatchWithoutTxs = errors.New("can not close a batch without transactions")
 	// ErrTimestampGE indicates that timestamp needs to be greater or equal
 	ErrTimestampGE = errors.New("timestamp needs to be greater or equal")
+	// ErrUnsupportedBatchDataCompression indicates that the batch data compression is not supported
+	ErrUnsupportedBatchDataCompression = errors.New("unsupported batch data compression")
+	// ErrBatchRawDataTooLarge indicates that the decompressed batch raw data is over the max size allowed
+	ErrBatchRawDataTooLarge = errors.New("the decompressed batch raw data is too large")
+	// ErrForkIDNotFound indicates that the fork id is not in the fork id intervals
+	ErrForkIDNotFound = errors.New("fork id not found")
+	// ErrInvalidAuditLogConfig indicates that the audit log config is not valid
+	ErrInvalidAuditLogConfig = errors.New("invalid audit log config")
+	// ErrAuditLogDisabled indicates that the audit log is not enabled
+	ErrAuditLogDisabled = errors.New("audit log is disabled")
 	// ErrDBTxNil indicates that the method requires a dbTx that is not nil
 	ErrDBTxNil = errors.New("the method requires a dbTx that is not nil")
+	// ErrL2BlockConflict indicates that an L2 block with the same number and a different hash is already stored
+	ErrL2BlockConflict = errors.New("a different L2 block with the same number is already stored")
+	// ErrL2BlockAlreadyStored indicates that an L2 block with the same number is already stored
+	ErrL2BlockAlreadyStored = errors.New("an L2 block with the same number is already stored")
 	// ErrExistingTxGreaterThanProcessedTx indicates that we have more txs stored
 	// in db than the txs we want to process.
 	ErrExistingTxGreaterThanProcessedTx = errors.New("there are more transactions in the database than in the processed transaction set")
@@ -46,6 +64,9 @@ var (
 	// ErrInsufficientFundsForTransfer is returned if the transaction sender doesn't
 	// have enough funds for transfer(topmost call only).
 	ErrInsufficientFundsForTransfer = errors.New("insufficient funds for transfer")
+	// ErrGasRequiredExceedsAllowance is returned by the gas estimation when the
+	// transaction fails with the highest gas allowed
+	ErrGasRequiredExceedsAllowance = errors.New("gas required exceeds allowance")
 	// ErrExecutorNil indicates that the method requires an executor that is not nil
 	ErrExecutorNil = errors.New("the method requires an executor that is not nil")
 	// ErrStateTreeNil indicates that the method requires a state tree that is not nil
@@ -110,6 +131,26 @@ func NewBatchRemainingResourcesUnderflowError(err error, resourceName string) er
 	}
 }
 
+// GasRequiredExceedsAllowanceError happens when the gas estimation fails with the highest gas allowed,
+// it wraps ErrGasRequiredExceedsAllowance and the error of the execution with that gas
+type GasRequiredExceedsAllowanceError struct {
+	Gas uint64
+	Err error
+}
+
+// Error returns the error message
+func (e *GasRequiredExceedsAllowanceError) Error() string {
+	return fmt.Sprintf("%s (%d)", ErrGasRequiredExceedsAllowance.Error(), e.Gas)
+}
+
+// Unwrap returns ErrGasRequiredExceedsAllowance and the execution error
+func (e *GasRequiredExceedsAllowanceError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrGasRequiredExceedsAllowance}
+	}
+	return []error{ErrGasRequiredExceedsAllowance, e.Err}
+}
+
 func constructErrorMsg(resourceName string) string {
 	return fmt.Sprintf("underflow of remaining resources for current batch. Resource %s", resourceName)
 }
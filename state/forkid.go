@@ -36,6 +36,21 @@ func (s *State) AddForkIDInterval(ctx context.Context, newForkID ForkIDInterval,
 	return s.storage.AddForkIDInterval(ctx, newForkID, dbTx)
 }
 
+// GetForkIDActivationBatchNumber returns the batch number where the fork id was activated,
+// it returns ErrForkIDNotFound if the fork id is unknown
+func (s *State) GetForkIDActivationBatchNumber(ctx context.Context, forkID uint64) (uint64, error) {
+	forkIDs, err := s.storage.GetForkIDs(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, interval := range forkIDs {
+		if interval.ForkId == forkID {
+			return interval.FromBatchNumber, nil
+		}
+	}
+	return 0, ErrForkIDNotFound
+}
+
 // GetForkIDByBatchNumber returns the fork id for a given batch number
 func (s *State) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
 	return s.storage.GetForkIDByBatchNumber(batchNumber)
@@ -0,0 +1,39 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forkIDStorage is a storage that only implements the methods used to query the fork ids
+type forkIDStorage struct {
+	storage
+	forkIDs []ForkIDInterval
+}
+
+func (s *forkIDStorage) GetForkIDs(ctx context.Context, dbTx pgx.Tx) ([]ForkIDInterval, error) {
+	return s.forkIDs, nil
+}
+
+func TestGetForkIDActivationBatchNumber(t *testing.T) {
+	s := &State{storage: &forkIDStorage{forkIDs: []ForkIDInterval{
+		{FromBatchNumber: 0, ToBatchNumber: 99, ForkId: FORKID_INCABERRY},
+		{FromBatchNumber: 100, ToBatchNumber: 1<<64 - 1, ForkId: FORKID_ETROG},
+	}}}
+	ctx := context.Background()
+
+	batchNumber, err := s.GetForkIDActivationBatchNumber(ctx, FORKID_INCABERRY)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), batchNumber)
+
+	batchNumber, err = s.GetForkIDActivationBatchNumber(ctx, FORKID_ETROG)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), batchNumber)
+
+	_, err = s.GetForkIDActivationBatchNumber(ctx, FORKID_ETROG+1)
+	assert.ErrorIs(t, err, ErrForkIDNotFound)
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/jackc/pgx/v4"
+)
+
+// ForkIDMigration is a state mutation that must be applied when a new fork ID activates,
+// e.g. adding the columns or backfilling the data required by the new fork ID
+type ForkIDMigration interface {
+	// ApplyMigration applies the migration for the fork ID using the dbTx
+	ApplyMigration(ctx context.Context, dbTx pgx.Tx, forkID uint64) error
+}
+
+// ForkIDMigrationFunc is an adapter to use an ordinary function as a ForkIDMigration
+type ForkIDMigrationFunc func(ctx context.Context, dbTx pgx.Tx, forkID uint64) error
+
+// ApplyMigration calls f(ctx, dbTx, forkID)
+func (f ForkIDMigrationFunc) ApplyMigration(ctx context.Context, dbTx pgx.Tx, forkID uint64) error {
+	return f(ctx, dbTx, forkID)
+}
+
+var (
+	forkIDMigrationsMux sync.RWMutex
+	forkIDMigrations    = map[uint64][]ForkIDMigration{}
+)
+
+// RegisterForkIDMigration registers a migration to be applied when the fork ID activates,
+// the migrations of the same fork ID are applied in the order they were registered
+func RegisterForkIDMigration(forkID uint64, migration ForkIDMigration) {
+	forkIDMigrationsMux.Lock()
+	defer forkIDMigrationsMux.Unlock()
+	forkIDMigrations[forkID] = append(forkIDMigrations[forkID], migration)
+}
+
+// hasForkIDMigrations returns if any fork ID has migrations registered
+func hasForkIDMigrations() bool {
+	forkIDMigrationsMux.RLock()
+	defer forkIDMigrationsMux.RUnlock()
+	for _, migrations := range forkIDMigrations {
+		if len(migrations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applyForkIDMigrationsOnOpenBatch applies the migrations of the fork ID of the batch when it's the
+// first batch of a new fork ID. It's called by OpenBatch and OpenWIPBatch, so the migrations are
+// applied by every node type in the dbTx that opens the batch, and it does nothing while there are
+// no migrations registered
+func (s *State) applyForkIDMigrationsOnOpenBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	if batchNumber == 0 || !hasForkIDMigrations() {
+		return nil
+	}
+	forkID := s.GetForkIDByBatchNumber(batchNumber)
+	if forkID == s.GetForkIDByBatchNumber(batchNumber-1) {
+		return nil
+	}
+
+	log.Infof("batch %d is the first batch of fork id %d, applying fork id migrations", batchNumber, forkID)
+	err := s.ApplyForkIDMigrations(ctx, forkID, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to apply fork id %d migrations for batch %d, err: %w", forkID, batchNumber, err)
+	}
+	return nil
+}
+
+// ApplyForkIDMigrations applies the migrations registered for the fork ID, it's called
+// when the first batch of the fork ID is opened, in the same dbTx
+func (s *State) ApplyForkIDMigrations(ctx context.Context, forkID uint64, dbTx pgx.Tx) error {
+	forkIDMigrationsMux.RLock()
+	migrations := forkIDMigrations[forkID]
+	forkIDMigrationsMux.RUnlock()
+
+	if len(migrations) == 0 {
+		return nil
+	}
+	if dbTx == nil {
+		return ErrDBTxNil
+	}
+
+	for i, migration := range migrations {
+		err := migration.ApplyMigration(ctx, dbTx, forkID)
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d of fork id %d: %w", i, forkID, err)
+		}
+	}
+	log.Infof("applied %d migrations of fork id %d", len(migrations), forkID)
+
+	return nil
+}
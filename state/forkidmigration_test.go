@@ -0,0 +1,107 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyForkIDMigrations(t *testing.T) {
+	const forkID = 1000
+	defer func() {
+		forkIDMigrationsMux.Lock()
+		delete(forkIDMigrations, forkID)
+		forkIDMigrationsMux.Unlock()
+	}()
+
+	s := &State{}
+	ctx := context.Background()
+	dbTx := &openBatchDbTx{}
+
+	// Nothing to do for fork ids without migrations, even without dbTx
+	require.NoError(t, s.ApplyForkIDMigrations(ctx, forkID, nil))
+
+	var applied []int
+	migration := func(i int, err error) ForkIDMigration {
+		return ForkIDMigrationFunc(func(ctx context.Context, tx pgx.Tx, id uint64) error {
+			assert.Equal(t, dbTx, tx)
+			assert.Equal(t, uint64(forkID), id)
+			applied = append(applied, i)
+			return err
+		})
+	}
+	RegisterForkIDMigration(forkID, migration(1, nil))
+	RegisterForkIDMigration(forkID, migration(2, nil))
+
+	assert.ErrorIs(t, s.ApplyForkIDMigrations(ctx, forkID, nil), ErrDBTxNil)
+	assert.Empty(t, applied)
+
+	// The migrations are applied in the order they were registered
+	require.NoError(t, s.ApplyForkIDMigrations(ctx, forkID, dbTx))
+	assert.Equal(t, []int{1, 2}, applied)
+
+	// A failing migration stops the next ones
+	applied = nil
+	errMigration := errors.New("migration failed")
+	forkIDMigrationsMux.Lock()
+	forkIDMigrations[forkID] = nil
+	forkIDMigrationsMux.Unlock()
+	RegisterForkIDMigration(forkID, migration(1, errMigration))
+	RegisterForkIDMigration(forkID, migration(2, nil))
+
+	assert.ErrorIs(t, s.ApplyForkIDMigrations(ctx, forkID, dbTx), errMigration)
+	assert.Equal(t, []int{1}, applied)
+}
+
+// forkIDMigrationStorage is an openBatchStorage whose batches from forkIDBatch belong to a new fork id
+type forkIDMigrationStorage struct {
+	openBatchStorage
+	forkIDBatch uint64
+	forkID      uint64
+}
+
+func (s *forkIDMigrationStorage) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	if batchNumber >= s.forkIDBatch {
+		return s.forkID
+	}
+	return s.forkID - 1
+}
+
+func TestOpenBatchForkIDMigrations(t *testing.T) {
+	const forkID = 1000
+	defer func() {
+		forkIDMigrationsMux.Lock()
+		delete(forkIDMigrations, forkID)
+		forkIDMigrationsMux.Unlock()
+	}()
+
+	ctx := context.Background()
+	dbTx := &openBatchDbTx{}
+
+	// Without registered migrations the fork id of the batch isn't even read, the
+	// embedded nil storage would panic otherwise
+	s := &State{storage: &openBatchStorage{lastBatchNumber: 1, lastBatchIsClosed: true}}
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, dbTx))
+
+	var applied []uint64
+	RegisterForkIDMigration(forkID, ForkIDMigrationFunc(func(ctx context.Context, tx pgx.Tx, id uint64) error {
+		applied = append(applied, id)
+		return nil
+	}))
+
+	// The migrations are applied when opening the first batch of the fork id
+	storage := &forkIDMigrationStorage{openBatchStorage: openBatchStorage{lastBatchNumber: 1, lastBatchIsClosed: true}, forkIDBatch: 2, forkID: forkID}
+	s = &State{storage: storage}
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 2, Timestamp: time.Now()}, dbTx))
+	assert.Equal(t, []uint64{forkID}, applied)
+
+	// But not when opening the next ones
+	storage.lastBatchNumber = 2
+	require.NoError(t, s.OpenBatch(ctx, ProcessingContext{BatchNumber: 3, Timestamp: time.Now()}, dbTx))
+	assert.Equal(t, []uint64{forkID}, applied)
+}
@@ -15,6 +15,8 @@ const (
 	Prefix = "state_"
 	// ExecutorProcessingTimeName is the name of the metric that shows the processing time in the executor.
 	ExecutorProcessingTimeName = Prefix + "executor_processing_time"
+	// DBConnectionRecycledName is the name of the metric that counts the broken db connections replaced when beginning a state transaction.
+	DBConnectionRecycledName = Prefix + "db_connection_recycled_total"
 	// CallerLabelName is the name of the label for the caller.
 	CallerLabelName = "caller"
 
@@ -28,6 +30,13 @@ const (
 
 // Register the metrics for the sequencer package.
 func Register() {
+	counters := []prometheus.CounterOpts{
+		{
+			Name: DBConnectionRecycledName,
+			Help: "[STATE] total count of broken db connections replaced when beginning a state transaction",
+		},
+	}
+
 	histogramVecs := []metrics.HistogramVecOpts{
 		{
 			HistogramOpts: prometheus.HistogramOpts{
@@ -38,6 +47,7 @@ func Register() {
 		},
 	}
 
+	metrics.RegisterCounters(counters...)
 	metrics.RegisterHistogramVecs(histogramVecs...)
 }
 
@@ -47,3 +57,8 @@ func ExecutorProcessingTime(caller string, lastExecutionTime time.Duration) {
 	execTimeInSeconds := float64(lastExecutionTime) / float64(time.Second)
 	metrics.HistogramVecObserve(ExecutorProcessingTimeName, string(caller), execTimeInSeconds)
 }
+
+// DBConnectionRecycled increases the counter of the broken db connections replaced by a fresh one.
+func DBConnectionRecycled() {
+	metrics.CounterInc(DBConnectionRecycledName)
+}
@@ -83,7 +83,7 @@ func (p *PostgresStorage) GetVerifiedBatch(ctx context.Context, batchNumber uint
 
 // GetLastNBatches returns the last numBatches batches.
 func (p *PostgresStorage) GetLastNBatches(ctx context.Context, numBatches uint, dbTx pgx.Tx) ([]*state.Batch, error) {
-	const getLastNBatchesSQL = "SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, batch_resources, wip from state.batch ORDER BY batch_num DESC LIMIT $1"
+	const getLastNBatchesSQL = "SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, raw_txs_data_encoding, forced_batch_num, batch_resources, closing_reason, wip from state.batch ORDER BY batch_num DESC LIMIT $1"
 
 	e := p.getExecQuerier(dbTx)
 	rows, err := e.Query(ctx, getLastNBatchesSQL, numBatches)
@@ -119,6 +119,7 @@ func (p *PostgresStorage) GetLastNBatchesByL2BlockNumber(ctx context.Context, l2
                b.timestamp,
                b.coinbase,
                b.raw_txs_data,
+               b.raw_txs_data_encoding,
 			   b.wip,
                /* gets the state root of the l2 block with the highest number associated to the batch in the row */
                (SELECT l2b1.header->>'stateRoot'
@@ -256,7 +257,7 @@ func (p *PostgresStorage) SetInitSyncBatch(ctx context.Context, batchNumber uint
 // GetBatchByNumber returns the batch with the given number.
 func (p *PostgresStorage) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	const getBatchByNumberSQL = `
-		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, batch_resources, wip
+		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, raw_txs_data_encoding, forced_batch_num, batch_resources, closing_reason, wip
 		  FROM state.batch 
 		 WHERE batch_num = $1`
 
@@ -273,10 +274,42 @@ func (p *PostgresStorage) GetBatchByNumber(ctx context.Context, batchNumber uint
 	return &batch, nil
 }
 
+// GetBatchesByNumbers returns the batches with the given numbers using a single query,
+// the batches are sorted by batch number and the numbers not found are skipped
+func (p *PostgresStorage) GetBatchesByNumbers(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) ([]*state.Batch, error) {
+	const getBatchesByNumbersSQL = `
+		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, raw_txs_data_encoding, forced_batch_num, batch_resources, closing_reason, wip
+		  FROM state.batch
+		 WHERE batch_num = ANY($1)
+		 ORDER BY batch_num ASC`
+
+	if len(batchNumbers) == 0 {
+		return []*state.Batch{}, nil
+	}
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getBatchesByNumbersSQL, batchNumbers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := make([]*state.Batch, 0, len(batchNumbers))
+	for rows.Next() {
+		batch, err := scanBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, &batch)
+	}
+
+	return batches, rows.Err()
+}
+
 // GetBatchByTxHash returns the batch including the given tx
 func (p *PostgresStorage) GetBatchByTxHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*state.Batch, error) {
 	const getBatchByTxHashSQL = `
-		SELECT b.batch_num, b.global_exit_root, b.local_exit_root, b.acc_input_hash, b.state_root, b.timestamp, b.coinbase, b.raw_txs_data, b.forced_batch_num, b.batch_resources, b.wip
+		SELECT b.batch_num, b.global_exit_root, b.local_exit_root, b.acc_input_hash, b.state_root, b.timestamp, b.coinbase, b.raw_txs_data, b.raw_txs_data_encoding, b.forced_batch_num, b.batch_resources, b.closing_reason, b.wip
 		  FROM state.transaction t, state.batch b, state.l2block l 
 		  WHERE t.hash = $1 AND l.block_num = t.l2_block_num AND b.batch_num = l.batch_num`
 
@@ -295,7 +328,7 @@ func (p *PostgresStorage) GetBatchByTxHash(ctx context.Context, transactionHash
 // GetBatchByL2BlockNumber returns the batch related to the l2 block accordingly to the provided l2 block number.
 func (p *PostgresStorage) GetBatchByL2BlockNumber(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	const getBatchByL2BlockNumberSQL = `
-		SELECT bt.batch_num, bt.global_exit_root, bt.local_exit_root, bt.acc_input_hash, bt.state_root, bt.timestamp, bt.coinbase, bt.raw_txs_data, bt.forced_batch_num, bt.batch_resources, bt.wip
+		SELECT bt.batch_num, bt.global_exit_root, bt.local_exit_root, bt.acc_input_hash, bt.state_root, bt.timestamp, bt.coinbase, bt.raw_txs_data, bt.raw_txs_data_encoding, bt.forced_batch_num, bt.batch_resources, bt.closing_reason, bt.wip
 		  FROM state.batch bt
 		 INNER JOIN state.l2block bl
 		    ON bt.batch_num = bl.batch_num
@@ -326,8 +359,10 @@ func (p *PostgresStorage) GetVirtualBatchByNumber(ctx context.Context, batchNumb
 			timestamp,
 			coinbase,
 			raw_txs_data,
+			raw_txs_data_encoding,
 			forced_batch_num,
-			batch_resources, 
+			batch_resources,
+			closing_reason,
 			wip
 		FROM
 			state.batch
@@ -390,7 +425,9 @@ func scanBatch(row pgx.Row) (state.Batch, error) {
 		aihStr        *string
 		stateStr      *string
 		coinbaseStr   string
+		encoding      string
 		resourcesData []byte
+		closingReason *string
 		wip           bool
 	)
 	err := row.Scan(
@@ -402,13 +439,19 @@ func scanBatch(row pgx.Row) (state.Batch, error) {
 		&batch.Timestamp,
 		&coinbaseStr,
 		&batch.BatchL2Data,
+		&encoding,
 		&batch.ForcedBatchNum,
 		&resourcesData,
+		&closingReason,
 		&wip,
 	)
 	if err != nil {
 		return batch, err
 	}
+	batch.BatchL2Data, err = state.GetBatchRawData(batch.BatchL2Data, encoding)
+	if err != nil {
+		return batch, err
+	}
 	batch.GlobalExitRoot = common.HexToHash(gerStr)
 	if lerStr != nil {
 		batch.LocalExitRoot = common.HexToHash(*lerStr)
@@ -426,6 +469,9 @@ func scanBatch(row pgx.Row) (state.Batch, error) {
 			return batch, err
 		}
 	}
+	if closingReason != nil {
+		batch.ClosingReason = state.ClosingReason(*closingReason)
+	}
 	batch.WIP = wip
 
 	batch.Coinbase = common.HexToAddress(coinbaseStr)
@@ -440,6 +486,7 @@ func scanBatchWithL2BlockStateRoot(row pgx.Row) (state.Batch, *common.Hash, erro
 		aihStr              *string
 		stateStr            *string
 		coinbaseStr         string
+		encoding            string
 		l2BlockStateRootStr *string
 		wip                 bool
 	)
@@ -452,11 +499,17 @@ func scanBatchWithL2BlockStateRoot(row pgx.Row) (state.Batch, *common.Hash, erro
 		&batch.Timestamp,
 		&coinbaseStr,
 		&batch.BatchL2Data,
+		&encoding,
 		&wip,
 		&l2BlockStateRootStr,
 	); err != nil {
 		return batch, nil, err
 	}
+	var err error
+	batch.BatchL2Data, err = state.GetBatchRawData(batch.BatchL2Data, encoding)
+	if err != nil {
+		return batch, nil, err
+	}
 	batch.GlobalExitRoot = common.HexToHash(gerStr)
 	if lerStr != nil {
 		batch.LocalExitRoot = common.HexToHash(*lerStr)
@@ -482,17 +535,24 @@ func scanForcedBatch(row pgx.Row) (state.ForcedBatch, error) {
 	var (
 		gerStr      string
 		coinbaseStr string
+		encoding    string
 	)
 	if err := row.Scan(
 		&forcedBatch.ForcedBatchNumber,
 		&gerStr,
 		&forcedBatch.ForcedAt,
 		&forcedBatch.RawTxsData,
+		&encoding,
 		&coinbaseStr,
 		&forcedBatch.BlockNumber,
 	); err != nil {
 		return forcedBatch, err
 	}
+	var err error
+	forcedBatch.RawTxsData, err = state.GetBatchRawData(forcedBatch.RawTxsData, encoding)
+	if err != nil {
+		return forcedBatch, err
+	}
 	forcedBatch.GlobalExitRoot = common.HexToHash(gerStr)
 	forcedBatch.Sequencer = common.HexToAddress(coinbaseStr)
 	return forcedBatch, nil
@@ -615,23 +675,32 @@ func (p *PostgresStorage) OpenWIPBatchInStorage(ctx context.Context, batch state
 // CloseBatchInStorage closes a batch in the state storage
 func (p *PostgresStorage) CloseBatchInStorage(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	const closeBatchSQL = `UPDATE state.batch 
-		SET state_root = $1, local_exit_root = $2, acc_input_hash = $3, raw_txs_data = $4, batch_resources = $5, closing_reason = $6, wip = FALSE
-		  WHERE batch_num = $7`
+		SET state_root = $1, local_exit_root = $2, acc_input_hash = $3, raw_txs_data = $4, raw_txs_data_encoding = $5, batch_resources = $6, closing_reason = $7, wip = FALSE, closed_at = NOW()
+		  WHERE batch_num = $8`
 
 	e := p.getExecQuerier(dbTx)
+	batchL2Data, encoding, err := state.CompressBatchRawData(receipt.BatchL2Data, p.cfg.BatchDataCompression)
+	if err != nil {
+		return err
+	}
+	receipt.BatchResources.CompressedBytes = state.CompressedBatchL2DataSize(receipt.BatchL2Data)
 	batchResourcesJsonBytes, err := json.Marshal(receipt.BatchResources)
 	if err != nil {
 		return err
 	}
 	_, err = e.Exec(ctx, closeBatchSQL, receipt.StateRoot.String(), receipt.LocalExitRoot.String(),
-		receipt.AccInputHash.String(), receipt.BatchL2Data, string(batchResourcesJsonBytes), receipt.ClosingReason, receipt.BatchNumber)
+		receipt.AccInputHash.String(), batchL2Data, encoding, string(batchResourcesJsonBytes), receipt.ClosingReason, receipt.BatchNumber)
 
 	return err
 }
 
 // CloseWIPBatchInStorage is used by sequencer to close the wip batch in the state storage
 func (p *PostgresStorage) CloseWIPBatchInStorage(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
-	const closeWIPBatchSQL = `UPDATE state.batch SET batch_resources = $1, closing_reason = $2, wip = FALSE WHERE batch_num = $3`
+	// the CompressedBytes of the wip batch were stored with the last L2 block, the receipt doesn't have them
+	const closeWIPBatchSQL = `UPDATE state.batch
+		SET batch_resources = $1::jsonb || jsonb_build_object('CompressedBytes', COALESCE(batch_resources->'CompressedBytes', '0'::jsonb)),
+			closing_reason = $2, wip = FALSE, closed_at = NOW()
+		WHERE batch_num = $3`
 
 	e := p.getExecQuerier(dbTx)
 	batchResourcesJsonBytes, err := json.Marshal(receipt.BatchResources)
@@ -639,14 +708,13 @@ func (p *PostgresStorage) CloseWIPBatchInStorage(ctx context.Context, receipt st
 		return err
 	}
 	_, err = e.Exec(ctx, closeWIPBatchSQL, string(batchResourcesJsonBytes), receipt.ClosingReason, receipt.BatchNumber)
-
 	return err
 }
 
 // GetWIPBatchInStorage returns the wip batch in the state
 func (p *PostgresStorage) GetWIPBatchInStorage(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	const getWIPBatchByNumberSQL = `
-		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, batch_resources, wip
+		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, raw_txs_data_encoding, forced_batch_num, batch_resources, closing_reason, wip
 		  FROM state.batch 
 		 WHERE batch_num = $1 AND wip = TRUE`
 
@@ -759,8 +827,10 @@ func (p *PostgresStorage) GetVirtualBatchToProve(ctx context.Context, lastVerfie
 			b.timestamp,
 			b.coinbase,
 			b.raw_txs_data,
+			b.raw_txs_data_encoding,
 			b.forced_batch_num,
-			b.batch_resources, 
+			b.batch_resources,
+			b.closing_reason,
 			b.wip
 		FROM
 			state.batch b,
@@ -824,7 +894,7 @@ func (p *PostgresStorage) GetSequences(ctx context.Context, lastVerifiedBatchNum
 // GetLastClosedBatch returns the latest closed batch
 func (p *PostgresStorage) GetLastClosedBatch(ctx context.Context, dbTx pgx.Tx) (*state.Batch, error) {
 	const getLastClosedBatchSQL = `
-		SELECT bt.batch_num, bt.global_exit_root, bt.local_exit_root, bt.acc_input_hash, bt.state_root, bt.timestamp, bt.coinbase, bt.raw_txs_data, bt.batch_resources, bt.wip
+		SELECT bt.batch_num, bt.global_exit_root, bt.local_exit_root, bt.acc_input_hash, bt.state_root, bt.timestamp, bt.coinbase, bt.raw_txs_data, bt.raw_txs_data_encoding, bt.forced_batch_num, bt.batch_resources, bt.closing_reason, bt.wip
 			FROM state.batch bt
 			WHERE wip = FALSE
 			ORDER BY bt.batch_num DESC
@@ -864,7 +934,7 @@ func (p *PostgresStorage) GetLastClosedBatchNumber(ctx context.Context, dbTx pgx
 
 // UpdateBatchL2Data updates data tx data in a batch
 func (p *PostgresStorage) UpdateBatchL2Data(ctx context.Context, batchNumber uint64, batchL2Data []byte, dbTx pgx.Tx) error {
-	const updateL2DataSQL = "UPDATE state.batch SET raw_txs_data = $2 WHERE batch_num = $1"
+	const updateL2DataSQL = "UPDATE state.batch SET raw_txs_data = $2, raw_txs_data_encoding = 'none' WHERE batch_num = $1"
 
 	e := p.getExecQuerier(dbTx)
 	_, err := e.Exec(ctx, updateL2DataSQL, batchNumber, batchL2Data)
@@ -873,7 +943,7 @@ func (p *PostgresStorage) UpdateBatchL2Data(ctx context.Context, batchNumber uin
 
 // UpdateWIPBatch updates the data in a batch
 func (p *PostgresStorage) UpdateWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
-	const updateL2DataSQL = "UPDATE state.batch SET raw_txs_data = $2, state_root = $3, local_exit_root = $4, acc_input_hash = $5, batch_resources = $6 WHERE batch_num = $1"
+	const updateL2DataSQL = "UPDATE state.batch SET raw_txs_data = $2, raw_txs_data_encoding = 'none', state_root = $3, local_exit_root = $4, acc_input_hash = $5, batch_resources = $6 WHERE batch_num = $1"
 
 	e := p.getExecQuerier(dbTx)
 	batchResourcesJsonBytes, err := json.Marshal(receipt.BatchResources)
@@ -956,3 +1026,66 @@ func (p *PostgresStorage) GetRawBatchTimestamps(ctx context.Context, batchNumber
 	}
 	return batchTimestamp, virtualBatchTimestamp, err
 }
+
+// GetBatchTimestamps returns the opening and closing times of the batches in the range, both included
+func (p *PostgresStorage) GetBatchTimestamps(ctx context.Context, fromBatchNumber, toBatchNumber uint64, dbTx pgx.Tx) ([]state.BatchTimestamp, error) {
+	const getBatchTimestampsSQL = "SELECT batch_num, timestamp, closed_at FROM state.batch WHERE batch_num BETWEEN $1 AND $2 ORDER BY batch_num"
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getBatchTimestampsSQL, fromBatchNumber, toBatchNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batchTimestamps := []state.BatchTimestamp{}
+	for rows.Next() {
+		var (
+			batchTimestamp state.BatchTimestamp
+			openedAt       *time.Time
+		)
+		if err := rows.Scan(&batchTimestamp.BatchNumber, &openedAt, &batchTimestamp.ClosedAt); err != nil {
+			return nil, err
+		}
+		if openedAt != nil {
+			batchTimestamp.OpenedAt = *openedAt
+		}
+		batchTimestamps = append(batchTimestamps, batchTimestamp)
+	}
+
+	return batchTimestamps, rows.Err()
+}
+
+// prunableBatchesCondition selects the batches older than $1 that are verified on L1. The genesis
+// batch and the last verified batch are never pruned, the last verified batch is needed to know
+// where the verification continues from
+const prunableBatchesCondition = `batch_num > 0 AND batch_num < $1
+	AND batch_num < (SELECT COALESCE(MAX(batch_num), 0) FROM state.verified_batch)`
+
+// CountPrunableBatches returns the number of batches that PruneBatches would delete
+func (p *PostgresStorage) CountPrunableBatches(ctx context.Context, beforeBatchNumber uint64, dbTx pgx.Tx) (int64, error) {
+	const countPrunableBatchesSQL = "SELECT COUNT(*) FROM state.batch WHERE " + prunableBatchesCondition
+
+	var count int64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, countPrunableBatchesSQL, beforeBatchNumber).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// PruneBatches deletes up to maxBatches of the oldest batches before beforeBatchNumber that are verified
+// on L1, the L2 blocks, transactions, receipts and logs of the batches are deleted in cascade. It returns
+// the number of deleted batches, fewer than maxBatches means there are no more batches to prune
+func (p *PostgresStorage) PruneBatches(ctx context.Context, beforeBatchNumber uint64, maxBatches uint64, dbTx pgx.Tx) (int64, error) {
+	const pruneBatchesSQL = `DELETE FROM state.batch WHERE batch_num IN (
+		SELECT batch_num FROM state.batch WHERE ` + prunableBatchesCondition + ` ORDER BY batch_num LIMIT $2)`
+
+	e := p.getExecQuerier(dbTx)
+	commandTag, err := e.Exec(ctx, pruneBatchesSQL, beforeBatchNumber, maxBatches)
+	if err != nil {
+		return 0, err
+	}
+	return commandTag.RowsAffected(), nil
+}
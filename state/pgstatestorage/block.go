@@ -63,8 +63,8 @@ func (p *PostgresStorage) GetPreviousBlock(ctx context.Context, offset uint64, d
 	return &block, err
 }
 
-// GetBlockByNumber returns the L1 block with the given number.
-func (p *PostgresStorage) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+// GetL1BlockByNumber returns the L1 block with the given number.
+func (p *PostgresStorage) GetL1BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
 	var (
 		blockHash  string
 		parentHash string
@@ -82,3 +82,11 @@ func (p *PostgresStorage) GetBlockByNumber(ctx context.Context, blockNumber uint
 	block.ParentHash = common.HexToHash(parentHash)
 	return &block, err
 }
+
+// GetBlockByNumber returns the L1 block with the given number.
+//
+// Deprecated: use GetL1BlockByNumber, GetBlockByNumber is kept for the callers of the old name
+// and will be removed.
+func (p *PostgresStorage) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	return p.GetL1BlockByNumber(ctx, blockNumber, dbTx)
+}
@@ -139,7 +139,7 @@ func scanDSL2Transaction(row pgx.Row) (*state.DSL2Transaction, error) {
 // GetDSBatches returns the DS batches
 func (p *PostgresStorage) GetDSBatches(ctx context.Context, firstBatchNumber, lastBatchNumber uint64, readWIPBatch bool, dbTx pgx.Tx) ([]*state.DSBatch, error) {
 	var getBatchByNumberSQL = `
-		SELECT b.batch_num, b.global_exit_root, b.local_exit_root, b.acc_input_hash, b.state_root, b.timestamp, b.coinbase, b.raw_txs_data, b.forced_batch_num, f.fork_id
+		SELECT b.batch_num, b.global_exit_root, b.local_exit_root, b.acc_input_hash, b.state_root, b.timestamp, b.coinbase, b.raw_txs_data, b.raw_txs_data_encoding, b.forced_batch_num, f.fork_id
 		  FROM state.batch b, state.fork_id f
 		 WHERE b.batch_num >= $1 AND b.batch_num <= $2 AND batch_num between f.from_batch_num AND f.to_batch_num`
 
@@ -178,6 +178,7 @@ func scanDSBatch(row pgx.Row) (state.DSBatch, error) {
 		aihStr      *string
 		stateStr    *string
 		coinbaseStr string
+		encoding    string
 	)
 	err := row.Scan(
 		&batch.BatchNumber,
@@ -188,12 +189,17 @@ func scanDSBatch(row pgx.Row) (state.DSBatch, error) {
 		&batch.Timestamp,
 		&coinbaseStr,
 		&batch.BatchL2Data,
+		&encoding,
 		&batch.ForcedBatchNum,
 		&batch.ForkID,
 	)
 	if err != nil {
 		return batch, err
 	}
+	batch.BatchL2Data, err = state.GetBatchRawData(batch.BatchL2Data, encoding)
+	if err != nil {
+		return batch, err
+	}
 	batch.GlobalExitRoot = common.HexToHash(gerStr)
 	if lerStr != nil {
 		batch.LocalExitRoot = common.HexToHash(*lerStr)
@@ -23,11 +23,12 @@ func (p *PostgresStorage) GetForcedBatch(ctx context.Context, forcedBatchNumber
 		forcedBatch    state.ForcedBatch
 		globalExitRoot string
 		rawTxs         string
+		rawTxsEncoding string
 		seq            string
 	)
-	const getForcedBatchSQL = "SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, coinbase, block_num FROM state.forced_batch WHERE forced_batch_num = $1"
+	const getForcedBatchSQL = "SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, raw_txs_data_encoding, coinbase, block_num FROM state.forced_batch WHERE forced_batch_num = $1"
 	e := p.getExecQuerier(dbTx)
-	err := e.QueryRow(ctx, getForcedBatchSQL, forcedBatchNumber).Scan(&forcedBatch.ForcedBatchNumber, &globalExitRoot, &forcedBatch.ForcedAt, &rawTxs, &seq, &forcedBatch.BlockNumber)
+	err := e.QueryRow(ctx, getForcedBatchSQL, forcedBatchNumber).Scan(&forcedBatch.ForcedBatchNumber, &globalExitRoot, &forcedBatch.ForcedAt, &rawTxs, &rawTxsEncoding, &seq, &forcedBatch.BlockNumber)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, state.ErrNotFound
 	} else if err != nil {
@@ -37,6 +38,10 @@ func (p *PostgresStorage) GetForcedBatch(ctx context.Context, forcedBatchNumber
 	if err != nil {
 		return nil, err
 	}
+	forcedBatch.RawTxsData, err = state.GetBatchRawData(forcedBatch.RawTxsData, rawTxsEncoding)
+	if err != nil {
+		return nil, err
+	}
 	forcedBatch.Sequencer = common.HexToAddress(seq)
 	forcedBatch.GlobalExitRoot = common.HexToHash(globalExitRoot)
 	return &forcedBatch, nil
@@ -44,7 +49,7 @@ func (p *PostgresStorage) GetForcedBatch(ctx context.Context, forcedBatchNumber
 
 // GetForcedBatchesSince gets L1 forced batches since forcedBatchNumber
 func (p *PostgresStorage) GetForcedBatchesSince(ctx context.Context, forcedBatchNumber, maxBlockNumber uint64, dbTx pgx.Tx) ([]*state.ForcedBatch, error) {
-	const getForcedBatchesSQL = "SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, coinbase, block_num FROM state.forced_batch WHERE forced_batch_num > $1 AND block_num <= $2 ORDER BY forced_batch_num ASC"
+	const getForcedBatchesSQL = "SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, raw_txs_data_encoding, coinbase, block_num FROM state.forced_batch WHERE forced_batch_num > $1 AND block_num <= $2 ORDER BY forced_batch_num ASC"
 	q := p.getExecQuerier(dbTx)
 	rows, err := q.Query(ctx, getForcedBatchesSQL, forcedBatchNumber, maxBlockNumber)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -71,7 +76,7 @@ func (p *PostgresStorage) GetForcedBatchesSince(ctx context.Context, forcedBatch
 // GetNextForcedBatches gets the next forced batches from the queue.
 func (p *PostgresStorage) GetNextForcedBatches(ctx context.Context, nextForcedBatches int, dbTx pgx.Tx) ([]state.ForcedBatch, error) {
 	const getNextForcedBatchesSQL = `
-		SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, coinbase, block_num 
+		SELECT forced_batch_num, global_exit_root, timestamp, raw_txs_data, raw_txs_data_encoding, coinbase, block_num 
 		FROM state.forced_batch
 		WHERE forced_batch_num > (Select coalesce(max(forced_batch_num),0) as forced_batch_num from state.batch INNER JOIN state.virtual_batch ON state.virtual_batch.batch_num = state.batch.batch_num)
 		ORDER BY forced_batch_num ASC LIMIT $1;
@@ -94,9 +99,10 @@ func (p *PostgresStorage) GetNextForcedBatches(ctx context.Context, nextForcedBa
 			forcedBatch    state.ForcedBatch
 			globalExitRoot string
 			rawTxs         string
+			rawTxsEncoding string
 			seq            string
 		)
-		err := rows.Scan(&forcedBatch.ForcedBatchNumber, &globalExitRoot, &forcedBatch.ForcedAt, &rawTxs, &seq, &forcedBatch.BlockNumber)
+		err := rows.Scan(&forcedBatch.ForcedBatchNumber, &globalExitRoot, &forcedBatch.ForcedAt, &rawTxs, &rawTxsEncoding, &seq, &forcedBatch.BlockNumber)
 		if err != nil {
 			return nil, err
 		}
@@ -104,6 +110,10 @@ func (p *PostgresStorage) GetNextForcedBatches(ctx context.Context, nextForcedBa
 		if err != nil {
 			return nil, err
 		}
+		forcedBatch.RawTxsData, err = state.GetBatchRawData(forcedBatch.RawTxsData, rawTxsEncoding)
+		if err != nil {
+			return nil, err
+		}
 		forcedBatch.Sequencer = common.HexToAddress(seq)
 		forcedBatch.GlobalExitRoot = common.HexToHash(globalExitRoot)
 		batches = append(batches, forcedBatch)
@@ -128,7 +138,7 @@ func (p *PostgresStorage) GetLastTrustedForcedBatchNumber(ctx context.Context, d
 // GetBatchByForcedBatchNum returns the batch with the given forced batch number.
 func (p *PostgresStorage) GetBatchByForcedBatchNum(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	const getForcedBatchByNumberSQL = `
-		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num
+		SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, raw_txs_data_encoding, forced_batch_num, batch_resources, closing_reason, wip
 		  FROM state.batch
 		 WHERE forced_batch_num = $1`
 
@@ -144,3 +154,50 @@ func (p *PostgresStorage) GetBatchByForcedBatchNum(ctx context.Context, forcedBa
 
 	return &batch, nil
 }
+
+// StoreForcedTxHashes stores the hashes and senders of the txs of a forced batch being processed,
+// the txs already stored for the forced batch are kept
+func (p *PostgresStorage) StoreForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, hashes map[common.Hash]common.Address, dbTx pgx.Tx) error {
+	const storeForcedTxHashSQL = "INSERT INTO state.forced_tx (forced_batch_num, tx_hash, from_address) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING"
+	e := p.getExecQuerier(dbTx)
+	for hash, from := range hashes {
+		_, err := e.Exec(ctx, storeForcedTxHashSQL, forcedBatchNumber, hash.String(), from.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPendingForcedTxHashes returns the hashes and senders of the txs of the forced batches
+// that are not in the trusted state yet
+func (p *PostgresStorage) GetPendingForcedTxHashes(ctx context.Context, dbTx pgx.Tx) (map[common.Hash]common.Address, error) {
+	const getPendingForcedTxHashesSQL = `
+		SELECT tx_hash, from_address
+		  FROM state.forced_tx
+		 WHERE forced_batch_num > (SELECT COALESCE(MAX(forced_batch_num), 0) FROM state.batch)`
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getPendingForcedTxHashesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := map[common.Hash]common.Address{}
+	for rows.Next() {
+		var hash, from string
+		if err := rows.Scan(&hash, &from); err != nil {
+			return nil, err
+		}
+		hashes[common.HexToHash(hash)] = common.HexToAddress(from)
+	}
+	return hashes, rows.Err()
+}
+
+// DeleteForcedTxHashes deletes the tx hashes stored for the forced batch
+func (p *PostgresStorage) DeleteForcedTxHashes(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) error {
+	const deleteForcedTxHashesSQL = "DELETE FROM state.forced_tx WHERE forced_batch_num = $1"
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, deleteForcedTxHashesSQL, forcedBatchNumber)
+	return err
+}
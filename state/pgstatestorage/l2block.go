@@ -11,9 +11,13 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 )
 
+// uniqueViolationErrCode is the postgres error code of a unique constraint violation
+const uniqueViolationErrCode = "23505"
+
 // GetL2BlockByNumber gets a l2 block by its number
 func (p *PostgresStorage) GetL2BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Block, error) {
 	const query = "SELECT block_hash, header, uncles, received_at FROM state.l2block b WHERE b.block_num = $1"
@@ -193,6 +197,10 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 		l2Block.Number().Uint64(), l2Block.Hash().String(), header, uncles,
 		l2Block.ParentHash().String(), l2Block.Root().String(),
 		l2Block.ReceivedAt, batchNumber, time.Now().UTC()); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationErrCode {
+			return state.ErrL2BlockAlreadyStored
+		}
 		return err
 	}
 
@@ -453,6 +461,33 @@ func (p *PostgresStorage) GetL2BlockHashesSince(ctx context.Context, since time.
 	return blockHashes, nil
 }
 
+// GetL2BlockHashesByBatchNumber gets the hashes of the blocks of the batch ordered by block number
+func (p *PostgresStorage) GetL2BlockHashesByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]common.Hash, error) {
+	const getL2BlockHashesByBatchNumberSQL = "SELECT block_hash FROM state.l2block WHERE batch_num = $1 ORDER BY block_num"
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getL2BlockHashesByBatchNumberSQL, batchNumber)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return []common.Hash{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blockHashes := []common.Hash{}
+	for rows.Next() {
+		var blockHash string
+		err := rows.Scan(&blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		blockHashes = append(blockHashes, common.HexToHash(blockHash))
+	}
+
+	return blockHashes, rows.Err()
+}
+
 // IsL2BlockConsolidated checks if the block ID is consolidated
 func (p *PostgresStorage) IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error) {
 	const isL2BlockConsolidated = "SELECT l2b.block_num FROM state.l2block l2b INNER JOIN state.verified_batch vb ON vb.batch_num = l2b.batch_num WHERE l2b.block_num = $1"
@@ -1,6 +1,7 @@
 package pgstatestorage_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -373,6 +374,66 @@ func TestVerifiedBatch(t *testing.T) {
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestPruneBatches(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	block := &state.Block{
+		BlockNumber: 1,
+		BlockHash:   common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ParentHash:  common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ReceivedAt:  time.Now(),
+	}
+	err = testState.AddBlock(ctx, block, dbTx)
+	require.NoError(t, err)
+
+	// Batches 1 to 4 are virtualized and verified up to batch 3, batch 5 is only trusted
+	for batchNumber := uint64(1); batchNumber <= 5; batchNumber++ {
+		_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+		if batchNumber > 4 {
+			continue
+		}
+		err = testState.AddVirtualBatch(ctx, &state.VirtualBatch{BlockNumber: 1, BatchNumber: batchNumber}, dbTx)
+		require.NoError(t, err)
+	}
+	err = testState.AddVerifiedBatch(ctx, &state.VerifiedBatch{BlockNumber: 1, BatchNumber: 3}, dbTx)
+	require.NoError(t, err)
+
+	// The last verified batch is kept
+	count, err := testState.CountPrunableBatches(ctx, 10, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = testState.CountPrunableBatches(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// The batches are pruned in chunks from the oldest
+	count, err = testState.PruneBatches(ctx, 10, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, err = testState.GetBatchByNumber(ctx, 1, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+	count, err = testState.PruneBatches(ctx, 10, 100, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, err = testState.GetBatchByNumber(ctx, 2, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+	for _, batchNumber := range []uint64{3, 4, 5} {
+		_, err = testState.GetBatchByNumber(ctx, batchNumber, dbTx)
+		require.NoError(t, err)
+	}
+	lastVerifiedBatch, err := testState.GetLastVerifiedBatch(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), lastVerifiedBatch.BatchNumber)
+
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
 func TestAddAccumulatedInputHash(t *testing.T) {
 	initOrResetDB()
 
@@ -437,6 +498,8 @@ func TestForcedBatch(t *testing.T) {
 	require.NoError(t, err)
 	fb, err := testState.GetForcedBatch(ctx, 1, tx)
 	require.NoError(t, err)
+	_, err = testState.GetForcedBatch(ctx, 2, tx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
 	err = tx.Commit(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, forcedBatch.BlockNumber, fb.BlockNumber)
@@ -447,6 +510,80 @@ func TestForcedBatch(t *testing.T) {
 	assert.Equal(t, forcedBatch.ForcedAt.Unix(), fb.ForcedAt.Unix())
 	assert.Equal(t, forcedBatch.GlobalExitRoot, fb.GlobalExitRoot)
 }
+
+func TestForcedTxHashes(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	block := &state.Block{
+		BlockNumber: 1,
+		BlockHash:   common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ParentHash:  common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ReceivedAt:  time.Now(),
+	}
+	err = testState.AddBlock(ctx, block, dbTx)
+	require.NoError(t, err)
+	for forcedBatchNumber := uint64(1); forcedBatchNumber <= 2; forcedBatchNumber++ {
+		err = testState.AddForcedBatch(ctx, &state.ForcedBatch{BlockNumber: 1, ForcedBatchNumber: forcedBatchNumber, ForcedAt: time.Now()}, dbTx)
+		require.NoError(t, err)
+	}
+
+	forcedTxs1 := map[common.Hash]common.Address{common.HexToHash("0x1"): common.HexToAddress("0x10")}
+	forcedTxs2 := map[common.Hash]common.Address{common.HexToHash("0x2"): common.HexToAddress("0x20"), common.HexToHash("0x3"): common.HexToAddress("0x20")}
+	require.NoError(t, testState.StoreForcedTxHashes(ctx, 1, forcedTxs1, dbTx))
+	require.NoError(t, testState.StoreForcedTxHashes(ctx, 2, forcedTxs2, dbTx))
+	// Storing the txs of a forced batch again keeps them
+	require.NoError(t, testState.StoreForcedTxHashes(ctx, 2, forcedTxs2, dbTx))
+
+	pending, err := testState.GetPendingForcedTxHashes(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Len(t, pending, 3)
+
+	// The txs of the forced batches already in the trusted state are not pending
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, forced_batch_num, wip) VALUES (1, 1, FALSE)")
+	require.NoError(t, err)
+	pending, err = testState.GetPendingForcedTxHashes(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, forcedTxs2, pending)
+
+	require.NoError(t, testState.DeleteForcedTxHashes(ctx, 2, dbTx))
+	pending, err = testState.GetPendingForcedTxHashes(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
+func TestOpenBatchForcedBatchNotFromL1(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	// A forced batch not from L1 has no forced batch row, so its number can't be referenced by the batch
+	forcedBatchNumber := uint64(1)
+	processingCtx := state.ProcessingContext{BatchNumber: 1, Timestamp: time.Now(), ForcedBatchNum: &forcedBatchNumber}
+	_, err = dbTx.Exec(ctx, "SAVEPOINT open_batch")
+	require.NoError(t, err)
+	err = testState.OpenBatchInStorage(ctx, processingCtx, dbTx)
+	require.Error(t, err)
+	_, err = dbTx.Exec(ctx, "ROLLBACK TO SAVEPOINT open_batch")
+	require.NoError(t, err)
+
+	// The batch is opened without forced batch number, the L1 forced batch numbers are not used
+	processingCtx.ForcedBatchNum = nil
+	require.NoError(t, testState.OpenBatchInStorage(ctx, processingCtx, dbTx))
+	lastForcedBatchNumber, err := testState.GetLastTrustedForcedBatchNumber(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Zero(t, lastForcedBatchNumber)
+
+	require.NoError(t, dbTx.Rollback(ctx))
+}
+
 func TestCleanupLockedProofs(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
@@ -658,6 +795,41 @@ func TestForkIDs(t *testing.T) {
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestGetL2BlockHashesByBatchNumber(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	for _, batchNumber := range []uint64{1, 2, 3} {
+		_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num,wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+	}
+
+	// the blocks of the batch 1 are added out of order
+	blockHashes := map[uint64]common.Hash{}
+	for _, b := range []struct{ batchNumber, blockNumber uint64 }{{1, 3}, {1, 1}, {2, 4}, {1, 2}} {
+		l2Header := state.NewL2Header(&types.Header{Number: big.NewInt(0).SetUint64(b.blockNumber)})
+		l2Block := state.NewL2BlockWithHeader(l2Header)
+		err = testState.AddL2Block(ctx, b.batchNumber, l2Block, []*types.Receipt{}, []state.StoreTxEGPData{}, dbTx)
+		require.NoError(t, err)
+		blockHashes[b.blockNumber] = l2Block.Hash()
+	}
+
+	hashes, err := testState.GetL2BlockHashesByBatchNumber(ctx, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{blockHashes[1], blockHashes[2], blockHashes[3]}, hashes)
+
+	hashes, err = testState.GetL2BlockHashesByBatchNumber(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{blockHashes[4]}, hashes)
+
+	hashes, err = testState.GetL2BlockHashesByBatchNumber(ctx, 3, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
 func TestGetLastVerifiedL2BlockNumberUntilL1Block(t *testing.T) {
 	initOrResetDB()
 	ctx := context.Background()
@@ -822,15 +994,61 @@ func TestGetBatchByNumber(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, b.BatchNumber, batchNum)
 	assert.Equal(t, b.WIP, true)
+	assert.Equal(t, state.EmptyClosingReason, b.ClosingReason)
+
+	_, err = testState.Exec(ctx, `INSERT INTO state.batch
+	(batch_num, global_exit_root, local_exit_root, state_root, timestamp, coinbase, raw_txs_data, closing_reason, wip)
+	VALUES(2, '0x0000000000000000000000000000000000000000000000000000000000000000', '0x0000000000000000000000000000000000000000000000000000000000000000', '0xbf34f9a52a63229e90d1016011655bc12140bba5b771817b88cbf340d08dcbde', '2022-12-19 08:17:45.000', '0x0000000000000000000000000000000000000000', NULL, $1, FALSE);
+	`, string(state.ForcedBatchClosingReason))
+	require.NoError(t, err)
 
 	batchNum = uint64(2)
 	b, err = testState.GetBatchByNumber(ctx, batchNum, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, b.WIP, false)
+	assert.Equal(t, state.ForcedBatchClosingReason, b.ClosingReason)
+
+	batchNum = uint64(3)
+	b, err = testState.GetBatchByNumber(ctx, batchNum, dbTx)
 	require.Error(t, state.ErrNotFound, err)
 	assert.Nil(t, b)
 
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestGetBatchesByNumbers(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	for batchNum := 1; batchNum <= 3; batchNum++ {
+		_, err = testState.Exec(ctx, `INSERT INTO state.batch
+		(batch_num, global_exit_root, local_exit_root, state_root, timestamp, coinbase, raw_txs_data, wip)
+		VALUES($1, '0x0000000000000000000000000000000000000000000000000000000000000000', '0x0000000000000000000000000000000000000000000000000000000000000000', '0xbf34f9a52a63229e90d1016011655bc12140bba5b771817b88cbf340d08dcbde', '2022-12-19 08:17:45.000', '0x0000000000000000000000000000000000000000', $2, $3);
+		`, batchNum, []byte{byte(batchNum)}, batchNum == 3)
+		require.NoError(t, err)
+	}
+
+	// The batches are sorted by number and the missing ones are skipped
+	batches, err := testState.GetBatchesByNumbers(ctx, []uint64{3, 1, 4}, dbTx)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, uint64(1), batches[0].BatchNumber)
+	assert.Equal(t, []byte{1}, batches[0].BatchL2Data)
+	assert.False(t, batches[0].WIP)
+	assert.Equal(t, uint64(3), batches[1].BatchNumber)
+	assert.Equal(t, []byte{3}, batches[1].BatchL2Data)
+	assert.True(t, batches[1].WIP)
+
+	batches, err = testState.GetBatchesByNumbers(ctx, nil, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
 func TestGetLogs(t *testing.T) {
 	initOrResetDB()
 
@@ -961,6 +1179,31 @@ func TestGetLogs(t *testing.T) {
 			assert.Equal(t, testCase.expectedError, err)
 		})
 	}
+
+	// The receipts of the batch are the same ones returned by tx hash
+	receipts, err := testState.GetTransactionReceiptsByBatchNumber(ctx, batchNumber, dbTx)
+	require.NoError(t, err)
+	require.Len(t, receipts, 3)
+	for _, receipt := range receipts {
+		assert.Len(t, receipt.Logs, 4)
+		txReceipt, err := testState.GetTransactionReceipt(ctx, receipt.TxHash, dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, *txReceipt, receipt)
+	}
+
+	// The receipts of each block are the same ones returned by tx hash
+	for blockNumber := uint64(1); blockNumber <= 3; blockNumber++ {
+		receipts, err := testState.GetTransactionReceiptsByL2BlockNumber(ctx, blockNumber, dbTx)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Len(t, receipts[0].Logs, 4)
+		txReceipt, err := testState.GetTransactionReceipt(ctx, receipts[0].TxHash, dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, *txReceipt, receipts[0])
+	}
+	receipts, err = testState.GetTransactionReceiptsByL2BlockNumber(ctx, 4, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, receipts)
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
@@ -1245,3 +1488,285 @@ func TestGetVirtualBatchWithNoTstamp(t *testing.T) {
 	require.Equal(t, (*time.Time)(nil), read.TimestampBatchEtrog)
 
 }
+
+func BenchmarkGetTransactionWithReceipt(b *testing.B) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(b, err)
+	defer func() { require.NoError(b, dbTx.Rollback(ctx)) }()
+
+	block := &state.Block{
+		BlockNumber: 1,
+		BlockHash:   common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ParentHash:  common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ReceivedAt:  time.Now(),
+	}
+	require.NoError(b, testState.AddBlock(ctx, block, dbTx))
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES (1, FALSE)")
+	require.NoError(b, err)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 0, Value: new(big.Int), GasPrice: big.NewInt(0)})
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		PostState:         state.ZeroHash.Bytes(),
+		EffectiveGasPrice: big.NewInt(0),
+		BlockNumber:       big.NewInt(1),
+		TxHash:            tx.Hash(),
+		Status:            types.ReceiptStatusSuccessful,
+		Logs:              []*types.Log{{TxHash: tx.Hash()}},
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), GasLimit: 10, Time: uint64(time.Now().Unix())})
+	l2Block := state.NewL2Block(header, []*types.Transaction{tx}, []*state.L2Header{}, []*types.Receipt{receipt}, &trie.StackTrie{})
+	receipt.BlockHash = l2Block.Hash()
+	storeTxsEGPData := []state.StoreTxEGPData{{EffectivePercentage: state.MaxEffectivePercentage}}
+	require.NoError(b, testState.AddL2Block(ctx, 1, l2Block, []*types.Receipt{receipt}, storeTxsEGPData, dbTx))
+
+	// Both ways return the same tx and receipt
+	singleTx, singleReceipt, err := testState.GetTransactionWithReceipt(ctx, tx.Hash(), dbTx)
+	require.NoError(b, err)
+	separateReceipt, err := testState.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
+	require.NoError(b, err)
+	assert.Equal(b, tx.Hash(), singleTx.Hash())
+	assert.Equal(b, separateReceipt, singleReceipt)
+
+	b.Run("separate tx and receipt queries", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := testState.GetTransactionByHash(ctx, tx.Hash(), dbTx)
+			require.NoError(b, err)
+			_, err = testState.GetTransactionReceipt(ctx, tx.Hash(), dbTx)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("single query", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, err := testState.GetTransactionWithReceipt(ctx, tx.Hash(), dbTx)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func TestGetTransactionByL2Hash(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	block := &state.Block{
+		BlockNumber: 1,
+		BlockHash:   common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ParentHash:  common.HexToHash("0x29e885edaf8e4b51e1d2e05f9da28161d2fb4f6b1d53827d9b80a23cf2d7d9f1"),
+		ReceivedAt:  time.Now(),
+	}
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES (1, FALSE)")
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 0, Value: new(big.Int), GasPrice: big.NewInt(0)})
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		PostState:         state.ZeroHash.Bytes(),
+		EffectiveGasPrice: big.NewInt(0),
+		BlockNumber:       big.NewInt(1),
+		TxHash:            tx.Hash(),
+		Status:            types.ReceiptStatusSuccessful,
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), GasLimit: 10, Time: uint64(time.Now().Unix())})
+	l2Block := state.NewL2Block(header, []*types.Transaction{tx}, []*state.L2Header{}, []*types.Receipt{receipt}, &trie.StackTrie{})
+	receipt.BlockHash = l2Block.Hash()
+	storeTxsEGPData := []state.StoreTxEGPData{{EffectivePercentage: state.MaxEffectivePercentage}}
+	require.NoError(t, testState.AddL2Block(ctx, 1, l2Block, []*types.Receipt{receipt}, storeTxsEGPData, dbTx))
+
+	l2TxHash, err := state.GetL2Hash(*tx)
+	require.NoError(t, err)
+	require.NotEqual(t, tx.Hash(), l2TxHash)
+
+	readTx, err := testState.GetTransactionByL2Hash(ctx, l2TxHash, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), readTx.Hash())
+
+	// The l1 hash is not an l2 hash
+	_, err = testState.GetTransactionByL2Hash(ctx, tx.Hash(), dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestGetExitRootByGlobalExitRoot(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	require.NoError(t, testState.AddBlock(ctx, state.NewBlock(1), dbTx))
+	exitRoot := state.GlobalExitRoot{
+		BlockNumber:     1,
+		Timestamp:       time.Unix(1700000000, 0).UTC(),
+		MainnetExitRoot: common.HexToHash("0x1"),
+		RollupExitRoot:  common.HexToHash("0x2"),
+		GlobalExitRoot:  common.HexToHash("0x3"),
+	}
+	require.NoError(t, testState.AddGlobalExitRoot(ctx, &exitRoot, dbTx))
+
+	read, err := testState.GetExitRootByGlobalExitRoot(ctx, exitRoot.GlobalExitRoot, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, exitRoot.BlockNumber, read.BlockNumber)
+	assert.Equal(t, exitRoot.Timestamp.Unix(), read.Timestamp.Unix())
+	assert.Equal(t, exitRoot.MainnetExitRoot, read.MainnetExitRoot)
+	assert.Equal(t, exitRoot.RollupExitRoot, read.RollupExitRoot)
+	assert.Equal(t, exitRoot.GlobalExitRoot, read.GlobalExitRoot)
+
+	_, err = testState.GetExitRootByGlobalExitRoot(ctx, common.HexToHash("0x4"), dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestCloseBatchCompressedBytes(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	zstdStorage := pgstatestorage.NewPostgresStorage(state.Config{BatchDataCompression: state.BatchDataCompressionZstd}, stateDb)
+	rawData := bytes.Repeat([]byte{0x01}, 1000)
+	compressedBytes := state.CompressedBatchL2DataSize(rawData)
+	require.Greater(t, compressedBytes, uint64(0))
+	require.Less(t, compressedBytes, uint64(len(rawData)))
+
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	// The compressed size stored with the wip batch is kept when the batch is closed
+	_, err = testState.Exec(ctx, `INSERT INTO state.batch
+	(batch_num, global_exit_root, local_exit_root, state_root, timestamp, coinbase, raw_txs_data, wip)
+	VALUES(1, '0x0000000000000000000000000000000000000000000000000000000000000000', '0x0000000000000000000000000000000000000000000000000000000000000000', '0xbf34f9a52a63229e90d1016011655bc12140bba5b771817b88cbf340d08dcbde', '2022-12-19 08:17:45.000', '0x0000000000000000000000000000000000000000', NULL, TRUE);
+	`)
+	require.NoError(t, err)
+	resources := state.BatchResources{Bytes: uint64(len(rawData)), CompressedBytes: compressedBytes}
+	err = zstdStorage.UpdateWIPBatch(ctx, state.ProcessingReceipt{BatchNumber: 1, BatchL2Data: rawData, BatchResources: resources}, dbTx)
+	require.NoError(t, err)
+	err = zstdStorage.CloseWIPBatchInStorage(ctx, state.ProcessingReceipt{BatchNumber: 1, BatchResources: state.BatchResources{Bytes: uint64(len(rawData))}}, dbTx)
+	require.NoError(t, err)
+
+	// The compressed size of the closed batch is computed from its raw data
+	_, err = testState.Exec(ctx, `INSERT INTO state.batch
+	(batch_num, global_exit_root, local_exit_root, state_root, timestamp, coinbase, raw_txs_data, wip)
+	VALUES(2, '0x0000000000000000000000000000000000000000000000000000000000000000', '0x0000000000000000000000000000000000000000000000000000000000000000', '0xbf34f9a52a63229e90d1016011655bc12140bba5b771817b88cbf340d08dcbde', '2022-12-19 08:17:45.000', '0x0000000000000000000000000000000000000000', NULL, TRUE);
+	`)
+	require.NoError(t, err)
+	err = zstdStorage.CloseBatchInStorage(ctx, state.ProcessingReceipt{BatchNumber: 2, BatchL2Data: rawData, BatchResources: state.BatchResources{Bytes: uint64(len(rawData))}}, dbTx)
+	require.NoError(t, err)
+
+	for _, batchNumber := range []uint64{1, 2} {
+		batch, err := testState.GetBatchByNumber(ctx, batchNumber, dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, rawData, batch.BatchL2Data)
+		assert.Equal(t, uint64(len(rawData)), batch.Resources.Bytes)
+		assert.Equal(t, compressedBytes, batch.Resources.CompressedBytes)
+	}
+
+	// The compressed size doesn't depend on the storage compression
+	err = testState.CloseBatchInStorage(ctx, state.ProcessingReceipt{BatchNumber: 2, BatchL2Data: rawData, BatchResources: state.BatchResources{Bytes: uint64(len(rawData))}}, dbTx)
+	require.NoError(t, err)
+	batch, err := testState.GetBatchByNumber(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, compressedBytes, batch.Resources.CompressedBytes)
+
+	require.NoError(t, dbTx.Rollback(ctx))
+}
+
+func TestGetBatchTimestamps(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	openedAt := time.Date(2022, 12, 19, 8, 17, 45, 0, time.UTC)
+	for _, batchNumber := range []uint64{1, 2, 3, 4} {
+		_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, timestamp, wip) VALUES ($1, $2, TRUE)",
+			batchNumber, openedAt.Add(time.Duration(batchNumber)*time.Minute))
+		require.NoError(t, err)
+	}
+	// The batch 3 is still WIP
+	err = testState.CloseWIPBatchInStorage(ctx, state.ProcessingReceipt{BatchNumber: 1}, dbTx)
+	require.NoError(t, err)
+	err = testState.CloseBatchInStorage(ctx, state.ProcessingReceipt{BatchNumber: 2}, dbTx)
+	require.NoError(t, err)
+
+	batchTimestamps, err := testState.GetBatchTimestamps(ctx, 1, 3, dbTx)
+	require.NoError(t, err)
+	require.Len(t, batchTimestamps, 3)
+	for i, batchTimestamp := range batchTimestamps {
+		batchNumber := uint64(i + 1)
+		assert.Equal(t, batchNumber, batchTimestamp.BatchNumber)
+		assert.True(t, openedAt.Add(time.Duration(batchNumber)*time.Minute).Equal(batchTimestamp.OpenedAt))
+		if batchNumber == 3 {
+			assert.Nil(t, batchTimestamp.ClosedAt)
+		} else {
+			require.NotNil(t, batchTimestamp.ClosedAt)
+		}
+	}
+
+	batchTimestamps, err = testState.GetBatchTimestamps(ctx, 10, 20, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, batchTimestamps)
+}
+
+func TestCountTransactionsByBatch(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	for _, batchNumber := range []uint64{1, 2} {
+		_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+	}
+
+	// the batch 1 has two blocks with 2 and 1 txs, the batch 2 a block without txs
+	nonce := uint64(0)
+	for i, b := range []struct{ batchNumber, txs uint64 }{{1, 2}, {1, 1}, {2, 0}} {
+		transactions := []*types.Transaction{}
+		receipts := []*types.Receipt{}
+		storeTxsEGPData := []state.StoreTxEGPData{}
+		for j := uint64(0); j < b.txs; j++ {
+			tx := types.NewTx(&types.LegacyTx{Nonce: nonce, Value: new(big.Int), GasPrice: big.NewInt(0)})
+			nonce++
+			transactions = append(transactions, tx)
+			receipts = append(receipts, &types.Receipt{
+				Type:              tx.Type(),
+				PostState:         state.ZeroHash.Bytes(),
+				EffectiveGasPrice: big.NewInt(0),
+				BlockNumber:       big.NewInt(int64(i) + 1),
+				TxHash:            tx.Hash(),
+				TransactionIndex:  uint(j),
+				Status:            types.ReceiptStatusSuccessful,
+			})
+			storeTxsEGPData = append(storeTxsEGPData, state.StoreTxEGPData{EGPLog: nil, EffectivePercentage: state.MaxEffectivePercentage})
+		}
+
+		header := state.NewL2Header(&types.Header{Number: big.NewInt(int64(i) + 1)})
+		l2Block := state.NewL2Block(header, transactions, []*state.L2Header{}, receipts, &trie.StackTrie{})
+		for _, receipt := range receipts {
+			receipt.BlockHash = l2Block.Hash()
+		}
+		err = testState.AddL2Block(ctx, b.batchNumber, l2Block, receipts, storeTxsEGPData, dbTx)
+		require.NoError(t, err)
+	}
+
+	count, err := testState.CountTransactionsByBatch(ctx, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	count, err = testState.CountTransactionsByBatch(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	count, err = testState.CountTransactionsByBatch(ctx, 3, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+}
@@ -137,6 +137,19 @@ func (p *PostgresStorage) GetTxsHashesByBatchNumber(ctx context.Context, batchNu
 	return txs, nil
 }
 
+// CountTransactionsByBatch returns the number of transactions in the given batch
+func (p *PostgresStorage) CountTransactionsByBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	const countTransactionsByBatchSQL = "SELECT COUNT(*) FROM state.transaction WHERE l2_block_num IN (SELECT block_num FROM state.l2block WHERE batch_num = $1)"
+
+	var count uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, countTransactionsByBatchSQL, batchNumber).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // GetTransactionByHash gets a transaction accordingly to the provided transaction hash
 func (p *PostgresStorage) GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error) {
 	var encoded string
@@ -159,13 +172,30 @@ func (p *PostgresStorage) GetTransactionByHash(ctx context.Context, transactionH
 	return tx, nil
 }
 
-// GetTransactionReceipt gets a transaction receipt accordingly to the provided transaction hash
-func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error) {
-	var txHash, encodedTx, contractAddress, l2BlockHash string
-	var l2BlockNum uint64
-	var effective_gas_price *uint64
+// GetTransactionByL2Hash gets a transaction accordingly to the provided l2 transaction hash
+func (p *PostgresStorage) GetTransactionByL2Hash(ctx context.Context, l2TxHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error) {
+	var encoded string
+	const getTransactionByL2HashSQL = "SELECT transaction.encoded FROM state.transaction WHERE l2_hash = $1"
+
+	q := p.getExecQuerier(dbTx)
+	err := q.QueryRow(ctx, getTransactionByL2HashSQL, l2TxHash.String()).Scan(&encoded)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
 
-	const getReceiptSQL = `
+	tx, err := state.DecodeTx(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// getReceiptSQL selects the receipt of a tx along with the encoded tx and its l2 block
+const getReceiptSQL = `
 		SELECT 
 			r.tx_index,
 			r.tx_hash,
@@ -186,46 +216,190 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 		    ON b.block_num = t.l2_block_num
 		 WHERE r.tx_hash = $1`
 
-	receipt := types.Receipt{}
+// GetTransactionReceipt gets a transaction receipt accordingly to the provided transaction hash
+func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error) {
 	q := p.getExecQuerier(dbTx)
-	err := q.QueryRow(ctx, getReceiptSQL, transactionHash.String()).
-		Scan(&receipt.TransactionIndex,
-			&txHash,
-			&receipt.Type,
-			&receipt.PostState,
-			&receipt.Status,
-			&receipt.CumulativeGasUsed,
-			&receipt.GasUsed,
-			&contractAddress,
-			&effective_gas_price,
-			&encodedTx,
-			&l2BlockNum,
-			&l2BlockHash,
-		)
-
+	receipt, _, err := scanReceipt(q.QueryRow(ctx, getReceiptSQL, transactionHash.String()))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, state.ErrNotFound
 	} else if err != nil {
 		return nil, err
 	}
 
-	receipt.TxHash = common.HexToHash(txHash)
-	receipt.ContractAddress = common.HexToAddress(contractAddress)
+	logs, err := p.getTransactionLogs(ctx, transactionHash, dbTx)
+	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, err
+	}
+
+	receipt.Logs = logs
+	receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
+
+	return &receipt, nil
+}
+
+// GetTransactionWithReceipt gets a transaction along with its receipt, the tx and the receipt
+// are read with a single query
+func (p *PostgresStorage) GetTransactionWithReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, *types.Receipt, error) {
+	q := p.getExecQuerier(dbTx)
+	receipt, encodedTx, err := scanReceipt(q.QueryRow(ctx, getReceiptSQL, transactionHash.String()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := state.DecodeTx(encodedTx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	logs, err := p.getTransactionLogs(ctx, transactionHash, dbTx)
 	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, nil, err
+	}
+
+	receipt.Logs = logs
+	receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
+
+	return tx, &receipt, nil
+}
+
+// getReceiptsSQL is the query of the receipts of several txs, it must be completed with the
+// WHERE clause that selects the txs
+const getReceiptsSQL = `
+		SELECT 
+			r.tx_index,
+			r.tx_hash,
+		    r.type,
+			r.post_state,
+			r.status,
+			r.cumulative_gas_used,
+			r.gas_used,
+			r.contract_address,
+			r.effective_gas_price,
+			t.encoded,
+			t.l2_block_num,
+			b.block_hash
+	      FROM state.receipt r
+		 INNER JOIN state.transaction t
+		    ON t.hash = r.tx_hash
+		 INNER JOIN state.l2block b
+		    ON b.block_num = t.l2_block_num`
+
+// GetTransactionReceiptsByBatchNumber returns the receipts of all the transactions in the given batch,
+// the receipts are read with a single query and their logs with another one
+func (p *PostgresStorage) GetTransactionReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]types.Receipt, error) {
+	const getReceiptsByBatchNumberSQL = getReceiptsSQL + `
+		 WHERE b.batch_num = $1
+		 ORDER BY t.l2_block_num ASC, r.tx_index ASC`
+
+	receipts, err := p.getTransactionReceipts(ctx, getReceiptsByBatchNumberSQL, batchNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := p.getBatchLogs(ctx, batchNumber, dbTx)
+	if err != nil {
 		return nil, err
 	}
 
+	return setReceiptsLogs(receipts, logs), nil
+}
+
+// GetTransactionReceiptsByL2BlockNumber returns the receipts of all the transactions in the given
+// L2 block ordered by tx index, the receipts are read with a single query and their logs with another one
+func (p *PostgresStorage) GetTransactionReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]types.Receipt, error) {
+	const getReceiptsByL2BlockNumberSQL = getReceiptsSQL + `
+		 WHERE t.l2_block_num = $1
+		 ORDER BY r.tx_index ASC`
+
+	receipts, err := p.getTransactionReceipts(ctx, getReceiptsByL2BlockNumberSQL, blockNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := p.getL2BlockLogs(ctx, blockNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return setReceiptsLogs(receipts, logs), nil
+}
+
+// getTransactionReceipts returns the receipts selected by the query, without their logs
+func (p *PostgresStorage) getTransactionReceipts(ctx context.Context, sql string, arg interface{}, dbTx pgx.Tx) ([]types.Receipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, sql, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]types.Receipt, 0, len(rows.RawValues()))
+	for rows.Next() {
+		receipt, _, err := scanReceipt(rows)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return receipts, nil
+}
+
+// setReceiptsLogs sets the logs and the bloom of the receipts
+func setReceiptsLogs(receipts []types.Receipt, logs []*types.Log) []types.Receipt {
+	logsByTx := make(map[common.Hash][]*types.Log, len(receipts))
+	for _, l := range logs {
+		logsByTx[l.TxHash] = append(logsByTx[l.TxHash], l)
+	}
+
+	for i := range receipts {
+		receipts[i].Logs = logsByTx[receipts[i].TxHash]
+		if receipts[i].Logs == nil {
+			receipts[i].Logs = []*types.Log{}
+		}
+		receipts[i].Bloom = types.CreateBloom(types.Receipts{&receipts[i]})
+	}
+
+	return receipts
+}
+
+// scanReceipt scans a receipt row, it also returns the encoded tx of the receipt
+func scanReceipt(row pgx.Row) (types.Receipt, string, error) {
+	var txHash, encodedTx, contractAddress, l2BlockHash string
+	var l2BlockNum uint64
+	var effective_gas_price *uint64
+
+	receipt := types.Receipt{}
+	err := row.Scan(&receipt.TransactionIndex,
+		&txHash,
+		&receipt.Type,
+		&receipt.PostState,
+		&receipt.Status,
+		&receipt.CumulativeGasUsed,
+		&receipt.GasUsed,
+		&contractAddress,
+		&effective_gas_price,
+		&encodedTx,
+		&l2BlockNum,
+		&l2BlockHash,
+	)
+	if err != nil {
+		return receipt, "", err
+	}
+
+	receipt.TxHash = common.HexToHash(txHash)
+	receipt.ContractAddress = common.HexToAddress(contractAddress)
 	receipt.BlockNumber = big.NewInt(0).SetUint64(l2BlockNum)
 	receipt.BlockHash = common.HexToHash(l2BlockHash)
 	if effective_gas_price != nil {
 		receipt.EffectiveGasPrice = big.NewInt(0).SetUint64(*effective_gas_price)
 	}
-	receipt.Logs = logs
-	receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
 
-	return &receipt, nil
+	return receipt, encodedTx, nil
 }
 
 // GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction index provided.
@@ -297,6 +471,41 @@ func (p *PostgresStorage) getTransactionLogs(ctx context.Context, transactionHas
 	return scanLogs(rows)
 }
 
+// getBatchLogs returns the logs of all the transactions in the given batch
+func (p *PostgresStorage) getBatchLogs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]*types.Log, error) {
+	q := p.getExecQuerier(dbTx)
+
+	const getBatchLogsSQL = `
+	SELECT t.l2_block_num, b.block_hash, l.tx_hash, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
+	FROM state.log l
+	INNER JOIN state.transaction t ON t.hash = l.tx_hash
+	INNER JOIN state.l2block b ON b.block_num = t.l2_block_num 
+	WHERE b.batch_num = $1
+	ORDER BY t.l2_block_num ASC, l.log_index ASC`
+	rows, err := q.Query(ctx, getBatchLogsSQL, batchNumber)
+	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, err
+	}
+	return scanLogs(rows)
+}
+
+func (p *PostgresStorage) getL2BlockLogs(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Log, error) {
+	q := p.getExecQuerier(dbTx)
+
+	const getL2BlockLogsSQL = `
+	SELECT t.l2_block_num, b.block_hash, l.tx_hash, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
+	FROM state.log l
+	INNER JOIN state.transaction t ON t.hash = l.tx_hash
+	INNER JOIN state.l2block b ON b.block_num = t.l2_block_num 
+	WHERE t.l2_block_num = $1
+	ORDER BY l.log_index ASC`
+	rows, err := q.Query(ctx, getL2BlockLogsSQL, blockNumber)
+	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, err
+	}
+	return scanLogs(rows)
+}
+
 func scanLogs(rows pgx.Rows) ([]*types.Log, error) {
 	defer rows.Close()
 
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 // NewExecutorClient is the executor client constructor.
@@ -17,9 +18,20 @@ func NewExecutorClient(ctx context.Context, c Config) (ExecutorServiceClient, *g
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(c.MaxGRPCMessageSize)),
 		grpc.WithBlock(),
 	}
+	if c.ExecutorGRPCKeepaliveTime.Duration > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.ExecutorGRPCKeepaliveTime.Duration,
+			Timeout:             c.ExecutorGRPCKeepaliveTimeout.Duration,
+			PermitWithoutStream: true,
+		}))
+	}
 	const maxWaitSeconds = 120
-	const maxRetries = 5
-	ctx, cancel := context.WithTimeout(ctx, maxWaitSeconds*time.Second)
+	const defaultMaxRetries = 5
+	maxRetries := c.ExecutorGRPCMaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	ctx, cancel := context.WithCancel(ctx)
 
 	connectionRetries := 0
 
@@ -28,7 +40,10 @@ func NewExecutorClient(ctx context.Context, c Config) (ExecutorServiceClient, *g
 	delay := 2
 	for connectionRetries < maxRetries {
 		log.Infof("trying to connect to executor: %v", c.URI)
-		executorConn, err = grpc.DialContext(ctx, c.URI, opts...)
+		// each attempt waits up to maxWaitSeconds for the connection
+		dialCtx, dialCancel := context.WithTimeout(ctx, maxWaitSeconds*time.Second)
+		executorConn, err = grpc.DialContext(dialCtx, c.URI, opts...)
+		dialCancel()
 		if err != nil {
 			log.Infof("Retrying connection to executor #%d", connectionRetries)
 			time.Sleep(time.Duration(delay) * time.Second)
@@ -10,4 +10,17 @@ type Config struct {
 	// WaitOnResourceExhaustion is the time to wait before retrying a transaction because of resource exhaustion
 	WaitOnResourceExhaustion types.Duration `mapstructure:"WaitOnResourceExhaustion"`
 	MaxGRPCMessageSize       int            `mapstructure:"MaxGRPCMessageSize"`
+	// ExecutorGRPCKeepaliveTime is the time without activity after which the client pings the executor to check
+	// the connection is alive, also when there are no calls in progress, so it must be below the idle timeout of the
+	// proxies and firewalls between them (usually 60s to 350s). If zero the keepalive pings are disabled.
+	// The executor must allow the pings without calls at this rate, otherwise it closes the connection. The default
+	// gRPC server policy of the prover doesn't allow them more often than every 2h, it must be run with
+	// GRPC_ARG_KEEPALIVE_PERMIT_WITHOUT_CALLS enabled and GRPC_ARG_HTTP2_MIN_RECV_PING_INTERVAL_WITHOUT_DATA_MS
+	// below this time
+	ExecutorGRPCKeepaliveTime types.Duration `mapstructure:"ExecutorGRPCKeepaliveTime"`
+	// ExecutorGRPCKeepaliveTimeout is the time the client waits for the response to a keepalive ping before
+	// closing the connection
+	ExecutorGRPCKeepaliveTimeout types.Duration `mapstructure:"ExecutorGRPCKeepaliveTimeout"`
+	// ExecutorGRPCMaxRetries is the max number of attempts to connect to the executor on startup, if zero 5 attempts are made
+	ExecutorGRPCMaxRetries int `mapstructure:"ExecutorGRPCMaxRetries"`
 }
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SequencerState is a snapshot of the sequencer internal state, intended for operational visibility
+type SequencerState struct {
+	// BatchNumber is the number of the WIP batch
+	BatchNumber uint64 `json:"batchNumber"`
+	// PendingForcedBatches is the number of forced batches waiting to be processed
+	PendingForcedBatches int `json:"pendingForcedBatches"`
+	// StoredFlushID is the last flush id stored by the executor
+	StoredFlushID uint64 `json:"storedFlushID"`
+	// LastPendingFlushID is the last flush id returned by the executor pending to be stored
+	LastPendingFlushID uint64 `json:"lastPendingFlushID"`
+	// CurrentGERHash is the last GER sent to the data stream
+	CurrentGERHash common.Hash `json:"currentGERHash"`
+	// SequencerAddress is the address of the sequencer
+	SequencerAddress common.Address `json:"sequencerAddress"`
+	// Halted is true when the finalizer has stopped processing due to a critical (executor) error
+	Halted bool `json:"halted"`
+	// HaltError is the error that halted the finalizer
+	HaltError string `json:"haltError,omitempty"`
+}
+
+// PendingTxSummary is a summary of a tx waiting in the sequencer worker, intended for operational visibility
+type PendingTxSummary struct {
+	Hash       common.Hash
+	From       common.Address
+	Nonce      uint64
+	GasPrice   *big.Int
+	GasLimit   uint64
+	ReceivedAt time.Time
+	// To is the recipient of the tx, nil for a contract creation
+	To *common.Address
+	// Value is the amount of wei transferred by the tx
+	Value *big.Int
+	// Queued is true when the tx can't be selected yet because its nonce is ahead of the
+	// next nonce of the address
+	Queued bool
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -36,6 +37,8 @@ type State struct {
 	tree           *merkletree.StateTree
 	eventLog       *event.EventLog
 	l1InfoTree     *l1infotree.L1InfoTree
+	auditLog       *AuditLog
+	batchNumCache  batchNumCache
 
 	newL2BlockEvents        chan NewL2BlockEvent
 	newL2BlockEventHandlers []NewL2BlockEventHandler
@@ -62,12 +65,42 @@ func NewState(cfg Config, storage storage, executorClient executor.ExecutorServi
 	return state
 }
 
-// BeginStateTransaction starts a state transaction
+// BeginStateTransaction starts a state transaction. The context deadline also bounds the wait
+// to acquire the db connection, and a context that is already done returns its error without
+// waiting for a connection. The connection is checked before it's returned, a broken one is
+// given back to the pool and the transaction is started once more with a fresh connection.
+// The in-memory side effects of the mutations done in the transaction happen once it's committed
 func (s *State) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	tx, err := s.beginCheckedTransaction(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Warnf("broken db connection, beginning the state transaction with a fresh connection: %v", err)
+		metrics.DBConnectionRecycled()
+		tx, err = s.beginCheckedTransaction(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stateTx{Tx: tx}, nil
+}
+
+// beginCheckedTransaction begins a transaction and runs a SELECT 1 on it, so a connection
+// silently dropped by the network fails here and not in the first state operation
+func (s *State) beginCheckedTransaction(ctx context.Context) (pgx.Tx, error) {
 	tx, err := s.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	_, err = tx.Exec(ctx, "SELECT 1")
+	if err != nil {
+		// the rollback of a broken connection fails too, pgx closes it so the pool discards it
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			log.Debugf("failed to rollback the state transaction of the broken connection: %v", rollbackErr)
+		}
+		return nil, err
+	}
 	return tx, nil
 }
 
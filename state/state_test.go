@@ -0,0 +1,104 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// beginStorage is a storage whose Begin waits for the context like a pool without free connections,
+// the first brokenConns connections it lends are broken
+type beginStorage struct {
+	storage
+	begins      int
+	brokenConns int
+	dbTxs       []*beginDbTx
+}
+
+func (s *beginStorage) Begin(ctx context.Context) (pgx.Tx, error) {
+	s.begins++
+	if _, ok := ctx.Deadline(); ok {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	dbTx := &beginDbTx{broken: s.begins <= s.brokenConns}
+	s.dbTxs = append(s.dbTxs, dbTx)
+	return dbTx, nil
+}
+
+// beginDbTx is a dbTx whose statements fail when its connection is broken
+type beginDbTx struct {
+	pgx.Tx
+	broken     bool
+	rolledBack bool
+}
+
+func (tx *beginDbTx) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
+	if tx.broken {
+		return nil, errConnectionReset
+	}
+	return pgconn.CommandTag("SELECT 1"), nil
+}
+
+func (tx *beginDbTx) Rollback(ctx context.Context) error {
+	tx.rolledBack = true
+	if tx.broken {
+		return errConnectionReset
+	}
+	return nil
+}
+
+var errConnectionReset = errors.New("read: connection reset by peer")
+
+func TestBeginStateTransactionContext(t *testing.T) {
+	storage := &beginStorage{}
+	s := &State{storage: storage}
+
+	dbTx, err := s.BeginStateTransaction(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, dbTx)
+
+	// The deadline of the context is used while waiting for the connection
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.BeginStateTransaction(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, storage.begins)
+
+	// A done context fails without trying to get a connection
+	_, err = s.BeginStateTransaction(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.BeginStateTransaction(cancelledCtx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, storage.begins)
+}
+
+func TestBeginStateTransactionBrokenConnection(t *testing.T) {
+	ctx := context.Background()
+
+	// A broken connection is replaced once
+	storage := &beginStorage{brokenConns: 1}
+	s := &State{storage: storage}
+	dbTx, err := s.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, storage.begins)
+	assert.True(t, storage.dbTxs[0].rolledBack)
+	require.IsType(t, &stateTx{}, dbTx)
+	assert.Equal(t, storage.dbTxs[1], dbTx.(*stateTx).Tx)
+
+	// The error of the second connection is returned
+	storage = &beginStorage{brokenConns: 2}
+	s = &State{storage: storage}
+	_, err = s.BeginStateTransaction(ctx)
+	assert.ErrorIs(t, err, errConnectionReset)
+	assert.Equal(t, 2, storage.begins)
+	assert.True(t, storage.dbTxs[1].rolledBack)
+}
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// stateTx is a transaction started with BeginStateTransaction, it runs the functions registered
+// with afterCommit once it's committed, so the in-memory side effects of the state mutations done
+// in the transaction, like the audit log entries or the batch number cache invalidation, only
+// happen when the mutations are visible to the other transactions
+type stateTx struct {
+	pgx.Tx
+
+	mux      sync.Mutex
+	onCommit []func()
+}
+
+func (tx *stateTx) afterCommit(f func()) {
+	tx.mux.Lock()
+	defer tx.mux.Unlock()
+	tx.onCommit = append(tx.onCommit, f)
+}
+
+func (tx *stateTx) takeOnCommit() []func() {
+	tx.mux.Lock()
+	defer tx.mux.Unlock()
+	onCommit := tx.onCommit
+	tx.onCommit = nil
+	return onCommit
+}
+
+// Commit commits the transaction and runs the functions registered with afterCommit
+func (tx *stateTx) Commit(ctx context.Context) error {
+	err := tx.Tx.Commit(ctx)
+	onCommit := tx.takeOnCommit()
+	if err != nil {
+		return err
+	}
+	for _, f := range onCommit {
+		f()
+	}
+	return nil
+}
+
+// Rollback rolls back the transaction and drops the functions registered with afterCommit
+func (tx *stateTx) Rollback(ctx context.Context) error {
+	tx.takeOnCommit()
+	return tx.Tx.Rollback(ctx)
+}
+
+// afterCommit runs f once dbTx is committed when dbTx was started with BeginStateTransaction,
+// otherwise f runs right away
+func (s *State) afterCommit(dbTx pgx.Tx, f func()) {
+	if tx, ok := dbTx.(*stateTx); ok {
+		tx.afterCommit(f)
+		return
+	}
+	f()
+}
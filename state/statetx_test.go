@@ -0,0 +1,52 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// committableDbTx is a transaction that can be committed and rolled back
+type committableDbTx struct {
+	pgx.Tx
+	commitErr error
+}
+
+func (tx *committableDbTx) Commit(ctx context.Context) error {
+	return tx.commitErr
+}
+
+func (tx *committableDbTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func TestStateTxAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	s := &State{}
+	calls := []int{}
+
+	// Without a state transaction the function runs right away
+	s.afterCommit(nil, func() { calls = append(calls, 0) })
+	assert.Equal(t, []int{0}, calls)
+
+	// The functions run in order once the transaction is committed
+	dbTx := &stateTx{Tx: &committableDbTx{}}
+	s.afterCommit(dbTx, func() { calls = append(calls, 1) })
+	s.afterCommit(dbTx, func() { calls = append(calls, 2) })
+	assert.Equal(t, []int{0}, calls)
+	require.NoError(t, dbTx.Commit(ctx))
+	assert.Equal(t, []int{0, 1, 2}, calls)
+
+	// The functions are dropped when the transaction is rolled back or fails to commit
+	dbTx = &stateTx{Tx: &committableDbTx{}}
+	s.afterCommit(dbTx, func() { calls = append(calls, 3) })
+	require.NoError(t, dbTx.Rollback(ctx))
+	dbTx = &stateTx{Tx: &committableDbTx{commitErr: errors.New("commit failed")}}
+	s.afterCommit(dbTx, func() { calls = append(calls, 4) })
+	require.Error(t, dbTx.Commit(ctx))
+	assert.Equal(t, []int{0, 1, 2}, calls)
+}
@@ -43,10 +43,11 @@ var (
 	testState *state.State
 	forkID    = uint64(state.FORKID_DRAGONFRUIT)
 	stateCfg  = state.Config{
-		MaxCumulativeGasUsed: 800000,
-		ChainID:              1000,
-		MaxLogsCount:         10000,
-		MaxLogsBlockRange:    10000,
+		MaxCumulativeGasUsed:   800000,
+		ChainID:                1000,
+		MaxLogsCount:           10000,
+		MaxLogsBlockRange:      10000,
+		GasEstimationTolerance: 100,
 		ForkIDIntervals: []state.ForkIDInterval{{
 			FromBatchNumber: 0,
 			ToBatchNumber:   math.MaxUint64,
@@ -336,6 +337,21 @@ func TestExecutorEstimateGas(t *testing.T) {
 	require.NoError(t, err)
 	log.Debugf("Estimated gas = %v", estimatedGas)
 
+	// the estimation must be within the tolerance of the lowest gas that makes the tx succeed
+	testTx := func(gas uint64) bool {
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			Value:    new(big.Int),
+			Gas:      gas,
+			GasPrice: new(big.Int).SetUint64(0),
+			Data:     common.Hex2Bytes(scRevertByteCode),
+		})
+		result, err := testState.ProcessUnsignedTransaction(ctx, tx, sequencerAddress, &blockNumber, true, nil)
+		return err == nil && !result.Failed()
+	}
+	assert.True(t, testTx(estimatedGas))
+	assert.False(t, testTx(estimatedGas-stateCfg.GasEstimationTolerance-1))
+
 	nonce++
 	tx3 := types.NewTransaction(nonce, scAddress, new(big.Int), 40000, new(big.Int).SetUint64(1), common.Hex2Bytes("4abbb40a"))
 	signedTx3, err := auth.Signer(auth.From, tx3)
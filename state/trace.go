@@ -358,6 +358,15 @@ func (s *State) buildTrace(evm *fakevm.FakeEVM, result *runtime.ExecutionResult,
 			}
 		}
 
+		// the self destruct moves the whole contract balance to the beneficiary
+		// on top of the stack, it is traced as an internal tx without steps
+		if step.OpCode == "SELFDESTRUCT" && step.Error == nil && len(step.Stack) > 0 {
+			beneficiary := common.BigToAddress(step.Stack[len(step.Stack)-1])
+			balance := evm.StateDB.GetBalance(step.Contract.Address)
+			tracer.CaptureEnter(fakevm.SELFDESTRUCT, step.Contract.Address, beneficiary, []byte{}, 0, balance)
+			tracer.CaptureExit([]byte{}, 0, nil)
+		}
+
 		previousStepStartedInternalTransaction := previousStep.OpCode == "CREATE" ||
 			previousStep.OpCode == "CREATE2" ||
 			previousStep.OpCode == "DELEGATECALL" ||
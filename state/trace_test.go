@@ -0,0 +1,116 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/fakevm"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers/native"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the tx calls the contract 0x2, which self destructs sending its balance to 0x4
+const selfDestructCallTrace = `{
+	"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x0000000000000000000000000000000000000002",
+	"gas": "0x7530", "gasUsed": "0x6d60", "input": "0x", "value": "0x0",
+	"calls": [
+		{
+			"type": "SELFDESTRUCT", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000004",
+			"gas": "0x0", "gasUsed": "0x0", "input": "0x", "value": "0x5"
+		}
+	]
+}`
+
+// the self destruct runs out of gas, so the balance is not moved
+const failedSelfDestructCallTrace = `{
+	"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x0000000000000000000000000000000000000002",
+	"gas": "0x7530", "gasUsed": "0x7530", "input": "0x", "value": "0x0",
+	"error": "out of gas"
+}`
+
+// traceFakeDB returns the balances of the traced accounts, the rest of the
+// state isn't used when the trace is built
+type traceFakeDB struct {
+	fakevm.FakeDB
+	balances map[common.Address]*big.Int
+}
+
+func (db *traceFakeDB) SetStateRoot(stateRoot []byte) {}
+
+func (db *traceFakeDB) GetBalance(address common.Address) *big.Int {
+	if balance, found := db.balances[address]; found {
+		return balance
+	}
+	return big.NewInt(0)
+}
+
+func TestBuildTraceSelfDestruct(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	contract := common.HexToAddress("0x2")
+	beneficiary := common.HexToAddress("0x4")
+
+	selfDestructResult := func(stepErr error) *runtime.ExecutionResult {
+		gasUsed := uint64(28000)
+		if stepErr != nil {
+			gasUsed = 30000
+		}
+		stepContract := instrumentation.Contract{Address: contract, Caller: from, Value: big.NewInt(0), Gas: 9000}
+		return &runtime.ExecutionResult{
+			Err: stepErr,
+			FullTrace: instrumentation.FullTrace{
+				Context: instrumentation.Context{
+					Type:    "CALL",
+					From:    from.String(),
+					To:      contract.String(),
+					Input:   []byte{},
+					Gas:     30000,
+					Value:   big.NewInt(0),
+					GasUsed: gasUsed,
+				},
+				Steps: []instrumentation.Step{
+					{Depth: 1, Pc: 0, Gas: 9000, GasCost: 3, OpCode: "PUSH20", Op: uint64(fakevm.PUSH20), Contract: stepContract},
+					{Depth: 1, Pc: 21, Gas: 8997, GasCost: 7600, OpCode: "SELFDESTRUCT", Op: uint64(fakevm.SELFDESTRUCT), Contract: stepContract, Stack: []*big.Int{beneficiary.Big()}, Error: stepErr},
+				},
+			},
+		}
+	}
+
+	type testCase struct {
+		name          string
+		result        *runtime.ExecutionResult
+		expectedTrace string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "self destruct is traced as an internal tx",
+			result:        selfDestructResult(nil),
+			expectedTrace: selfDestructCallTrace,
+		},
+		{
+			name:          "failed self destruct is not traced",
+			result:        selfDestructResult(runtime.ErrOutOfGas),
+			expectedTrace: failedSelfDestructCallTrace,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracer, err := native.NewCallTracer(&tracers.Context{}, nil)
+			require.NoError(t, err)
+			fakeDB := &traceFakeDB{balances: map[common.Address]*big.Int{contract: big.NewInt(5)}}
+			evm := fakevm.NewFakeEVM(fakevm.BlockContext{BlockNumber: big.NewInt(1)}, fakevm.TxContext{GasPrice: big.NewInt(1)}, fakeDB, params.TestChainConfig, fakevm.Config{Debug: true, Tracer: tracer})
+
+			s := &State{}
+			trace, err := s.buildTrace(evm, tc.result, tracer)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedTrace, string(trace))
+		})
+	}
+}
@@ -316,13 +316,41 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 		receipt.BlockHash = block.Hash()
 	}
 
-	// Store L2 block and its transactions
-	if err := s.AddL2Block(ctx, batchNumber, block, receipts, storeTxsEGPData, dbTx); err != nil {
+	// Store L2 block and its transactions. The L2 block is stored under a savepoint, so if it's
+	// already stored the unique violation doesn't abort dbTx and the stored L2 block can be read
+	spTx, err := dbTx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.AddL2Block(ctx, batchNumber, block, receipts, storeTxsEGPData, spTx); err != nil {
+		if rollbackErr := spTx.Rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback the L2 block %d savepoint, err: %v, rollback err: %w", l2Block.BlockNumber, err, rollbackErr)
+		}
+		if !errors.Is(err, ErrL2BlockAlreadyStored) {
+			return err
+		}
+
+		// Storing the same L2 block again (e.g. on a retry) succeeds without changes, storing
+		// a different L2 block with the same number is a conflict
+		storedHeader, err := s.GetL2BlockHeaderByNumber(ctx, l2Block.BlockNumber, dbTx)
+		if err != nil {
+			return err
+		}
+		// the header hashes are compared, AddL2Block forces the block hash to the state root since etrog
+		headerHash := block.Header().Hash()
+		if storedHeader.Hash() != headerHash {
+			return fmt.Errorf("%w. Block %d, stored header hash %s, new header hash %s", ErrL2BlockConflict, l2Block.BlockNumber, storedHeader.Hash().String(), headerHash.String())
+		}
+		log.Debugf("l2 block %d with header hash %s is already stored", l2Block.BlockNumber, headerHash.String())
+		return nil
+	}
+	if err := spTx.Commit(ctx); err != nil {
 		return err
 	}
 
 	log.Debugf("stored L2 block %d for batch %d, storing time %v", header.Number, batchNumber, time.Since(start))
 
+	s.audit(dbTx, AuditLogEntry{Event: AuditLogStoreL2Block, BatchNumber: batchNumber, L2BlockNumber: &l2Block.BlockNumber, StateRoot: &header.Root})
 	return nil
 }
 
@@ -832,10 +860,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		}
 
 		// The transaction shouldn't fail, for whatever reason, at highEnd
-		return 0, nil, fmt.Errorf(
-			"gas required exceeds allowance (%d)",
-			highEnd,
-		)
+		return 0, nil, &GasRequiredExceedsAllowanceError{Gas: highEnd, Err: err}
 	}
 
 	// sets
@@ -844,7 +869,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	}
 
 	// Start the binary search for the lowest possible gas price
-	for (lowEnd < highEnd) && (highEnd-lowEnd) > 4096 {
+	for (lowEnd < highEnd) && (highEnd-lowEnd) > s.cfg.GasEstimationTolerance {
 		txExecutionStart := time.Now()
 		mid := (lowEnd + highEnd) / 2 // nolint:gomnd
 		if mid > lowEnd*2 {
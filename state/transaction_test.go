@@ -0,0 +1,101 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storeL2BlockStorage is a storage that only implements the methods used to store an L2 block
+type storeL2BlockStorage struct {
+	storage
+	headers       map[uint64]*L2Header
+	addedBlocks   []common.Hash
+	headerLookups int
+}
+
+func (s *storeL2BlockStorage) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*L2Header, error) {
+	s.headerLookups++
+	header, found := s.headers[blockNumber]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return header, nil
+}
+
+func (s *storeL2BlockStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *L2Block, receipts []*types.Receipt, txsEGPData []StoreTxEGPData, dbTx pgx.Tx) error {
+	if _, found := s.headers[l2Block.NumberU64()]; found {
+		return ErrL2BlockAlreadyStored
+	}
+	s.headers[l2Block.NumberU64()] = l2Block.Header()
+	s.addedBlocks = append(s.addedBlocks, l2Block.Hash())
+	return nil
+}
+
+// savepointDbTx is a dbTx that keeps track of the savepoints released and rolled back
+type savepointDbTx struct {
+	pgx.Tx
+	savepoints []*savepointDbTx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *savepointDbTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	savepoint := &savepointDbTx{}
+	tx.savepoints = append(tx.savepoints, savepoint)
+	return savepoint, nil
+}
+
+func (tx *savepointDbTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *savepointDbTx) Rollback(ctx context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+func TestStoreL2BlockIdempotency(t *testing.T) {
+	st := &storeL2BlockStorage{headers: map[uint64]*L2Header{}}
+	s := &State{storage: st}
+	ctx := context.Background()
+	dbTx := &savepointDbTx{}
+
+	l2Block := &ProcessBlockResponse{
+		BlockNumber: 1,
+		ParentHash:  common.HexToHash("0x1"),
+		BlockHash:   common.HexToHash("0x2"),
+		Timestamp:   1700000000,
+	}
+
+	// First time the L2 block is stored, the stored L2 block is only read on a conflict
+	err := s.StoreL2Block(ctx, 1, l2Block, nil, dbTx)
+	require.NoError(t, err)
+	require.Len(t, st.addedBlocks, 1)
+	assert.Zero(t, st.headerLookups)
+	require.Len(t, dbTx.savepoints, 1)
+	assert.True(t, dbTx.savepoints[0].committed)
+
+	// Storing the same L2 block again succeeds without storing it twice
+	err = s.StoreL2Block(ctx, 1, l2Block, nil, dbTx)
+	require.NoError(t, err)
+	assert.Len(t, st.addedBlocks, 1)
+	assert.Equal(t, 1, st.headerLookups)
+	require.Len(t, dbTx.savepoints, 2)
+	assert.True(t, dbTx.savepoints[1].rolledBack)
+
+	// Storing a different L2 block with the same number is a conflict
+	conflictingL2Block := *l2Block
+	conflictingL2Block.BlockHash = common.HexToHash("0x3")
+	err = s.StoreL2Block(ctx, 1, &conflictingL2Block, nil, dbTx)
+	require.ErrorIs(t, err, ErrL2BlockConflict)
+	assert.Len(t, st.addedBlocks, 1)
+	require.Len(t, dbTx.savepoints, 3)
+	assert.True(t, dbTx.savepoints[2].rolledBack)
+}
@@ -224,20 +224,25 @@ func (z *ZKCounters) Sub(other ZKCounters) error {
 // BatchResources is a struct that contains the ZKEVM resources used by a batch/tx
 type BatchResources struct {
 	ZKCounters ZKCounters
-	Bytes      uint64
+	// Bytes is the size of the raw batch data
+	Bytes uint64
+	// CompressedBytes is the size of the raw batch data compressed with zstd, it's the size of the
+	// batch data in the L1 payload if it's posted compressed, see CompressedBatchL2DataSize
+	CompressedBytes uint64
 }
 
 // Sub subtracts the batch resources from other
 func (r *BatchResources) Sub(other BatchResources) error {
 	// Bytes
-	if other.Bytes > r.Bytes {
+	if other.Bytes > r.Bytes || other.CompressedBytes > r.CompressedBytes {
 		return ErrBatchResourceBytesUnderflow
 	}
-	bytesBackup := r.Bytes
+	bytesBackup, compressedBytesBackup := r.Bytes, r.CompressedBytes
 	r.Bytes -= other.Bytes
+	r.CompressedBytes -= other.CompressedBytes
 	err := r.ZKCounters.Sub(other.ZKCounters)
 	if err != nil {
-		r.Bytes = bytesBackup
+		r.Bytes, r.CompressedBytes = bytesBackup, compressedBytesBackup
 		return NewBatchRemainingResourcesUnderflowError(err, err.Error())
 	}
 
@@ -247,6 +252,7 @@ func (r *BatchResources) Sub(other BatchResources) error {
 // SumUp sum ups the batch resources from other
 func (r *BatchResources) SumUp(other BatchResources) {
 	r.Bytes += other.Bytes
+	r.CompressedBytes += other.CompressedBytes
 	r.ZKCounters.SumUp(other.ZKCounters)
 }
 
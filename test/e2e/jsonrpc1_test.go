@@ -3,6 +3,7 @@ package e2e
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -490,7 +491,7 @@ func Test_Transactions(t *testing.T) {
 
 		log.Infof("Sending Tx %v Nonce (invalid) %v", signedTx.Hash(), signedTx.Nonce())
 		err = ethClient.SendTransaction(context.Background(), signedTx)
-		require.ErrorContains(t, err, "nonce too low")
+		require.ErrorContains(t, err, fmt.Sprintf("nonce too low: next nonce is %d", nonce))
 		// End Test Case
 
 		// Test Case: TX with no signature (which would fail the EIP-155)
@@ -14,6 +14,19 @@ var (
 	BuildDate = "Fri, 17 Jun 1988 01:58:00 +0200"
 )
 
+// gitRevShortLen is the length of the git revision in the client version
+const gitRevShortLen = 8
+
+// ClientVersion returns the node name with the version, the git revision and the platform, in the
+// format of the other ethereum clients, e.g. zkevm-node/v0.5.0-1a2b3c4d/linux-amd64/go1.21.1
+func ClientVersion() string {
+	version := Version
+	if GitRev != "undefined" && len(GitRev) >= gitRevShortLen {
+		version = fmt.Sprintf("%s-%s", version, GitRev[:gitRevShortLen])
+	}
+	return fmt.Sprintf("zkevm-node/%s/%s-%s/%s", version, runtime.GOOS, runtime.GOARCH, runtime.Version())
+}
+
 // PrintVersion prints version info into the provided io.Writer.
 func PrintVersion(w io.Writer) {
 	fmt.Fprintf(w, "Version:      %s\n", Version)